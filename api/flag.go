@@ -0,0 +1,33 @@
+package api
+
+import (
+	"go-api/flags"
+	"go-api/serializer"
+	"go-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlagList 全部功能开关
+func FlagList(c *gin.Context) {
+	Render(c, serializer.Response{
+		Data: flags.Default.All(),
+	})
+}
+
+// FlagUpdate 新增或修改功能开关
+func FlagUpdate(c *gin.Context) {
+	var updateService service.FlagUpdateService
+	if err := c.ShouldBind(&updateService); err == nil {
+		res := updateService.Update(c, CurrentUserID(c), c.Param("name"))
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// FlagAudits 功能开关的修改记录
+func FlagAudits(c *gin.Context) {
+	res := service.ListAudits(c, "flag:"+c.Param("name"), c.Request.URL.Query())
+	Render(c, res)
+}
@@ -0,0 +1,47 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-api/flags"
+	"go-api/serializer"
+	"go-api/testutil"
+)
+
+func TestFlagAdmin(t *testing.T) {
+	env := testutil.New(t)
+	admin := env.User().Admin().Create()
+	adminToken := env.Token(admin)
+	member := env.User().Create()
+
+	body := map[string]interface{}{"enabled": true, "rollout": 0, "allow": []int{member.ID}}
+	if res := env.Call(http.MethodPut, "/api/v1/admin/flags/new_login", body, env.Token(member), nil); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("non admin update: %+v", res)
+	}
+
+	var f flags.Flag
+	if res := env.Call(http.MethodPut, "/api/v1/admin/flags/new_login", body, adminToken, &f); res.Code != 0 {
+		t.Fatalf("update: %+v", res)
+	}
+	if !f.Enabled || f.UpdatedAt != testutil.Epoch.Unix() {
+		t.Fatalf("unexpected flag %+v", f)
+	}
+	if !flags.Default.Enabled("new_login", uint(member.ID)) || flags.Default.Enabled("new_login", uint(admin.ID)) {
+		t.Fatal("flag not applied to allowlist")
+	}
+
+	body["enabled"] = false
+	env.Call(http.MethodPut, "/api/v1/admin/flags/new_login", body, adminToken, nil)
+	if res := env.Call(http.MethodPut, "/api/v1/admin/flags/new_login", map[string]interface{}{"rollout": 120}, adminToken, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("invalid rollout: %+v", res)
+	}
+
+	var audits []serializer.AuditLog
+	if res := env.Call(http.MethodGet, "/api/v1/admin/flags/new_login/audits", nil, adminToken, &audits); res.Code != 0 {
+		t.Fatalf("audits: %+v", res)
+	}
+	if len(audits) != 2 || audits[0].ActorID != admin.ID || audits[1].Before != "" || audits[0].Before == "" {
+		t.Fatalf("unexpected audits %+v", audits)
+	}
+}
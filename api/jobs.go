@@ -0,0 +1,125 @@
+package api
+
+import (
+	"errors"
+	"go-api/jobs"
+	"go-api/serializer"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+)
+
+// inspector 任务队列的 Inspector, 没有初始化时返回 503 并返回 nil
+func inspector(c *gin.Context) *asynq.Inspector {
+	i := jobs.Inspector()
+	if i == nil {
+		RenderStatus(c, 503, serializer.Err(serializer.CodeJobError, "任务队列未初始化", nil))
+	}
+	return i
+}
+
+// JobQueues 队列深度与统计
+func JobQueues(c *gin.Context) {
+	i := inspector(c)
+	if i == nil {
+		return
+	}
+	names, err := i.Queues()
+	if err != nil {
+		RenderStatus(c, 500, serializer.Err(serializer.CodeJobError, "获取队列失败", err))
+		return
+	}
+	queues := make([]*asynq.QueueInfo, 0, len(names))
+	for _, name := range names {
+		q, err := i.GetQueueInfo(name)
+		if err != nil {
+			RenderStatus(c, 500, serializer.Err(serializer.CodeJobError, "获取队列失败", err))
+			return
+		}
+		queues = append(queues, q)
+	}
+	Render(c, serializer.Response{
+		Data: serializer.BuildQueues(queues),
+	})
+}
+
+// JobTasks 按状态列出队列中的任务, state 为 pending|active|scheduled|retry|archived|completed
+// archived 即重试耗尽的死信任务
+func JobTasks(c *gin.Context) {
+	i := inspector(c)
+	if i == nil {
+		return
+	}
+	queue := c.Param("queue")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
+	opts := []asynq.ListOption{asynq.Page(page), asynq.PageSize(size)}
+
+	var (
+		tasks []*asynq.TaskInfo
+		err   error
+	)
+	switch c.DefaultQuery("state", "pending") {
+	case "pending":
+		tasks, err = i.ListPendingTasks(queue, opts...)
+	case "active":
+		tasks, err = i.ListActiveTasks(queue, opts...)
+	case "scheduled":
+		tasks, err = i.ListScheduledTasks(queue, opts...)
+	case "retry":
+		tasks, err = i.ListRetryTasks(queue, opts...)
+	case "archived":
+		tasks, err = i.ListArchivedTasks(queue, opts...)
+	case "completed":
+		tasks, err = i.ListCompletedTasks(queue, opts...)
+	default:
+		RenderStatus(c, 400, serializer.ParamErr("不支持的任务状态", nil))
+		return
+	}
+	if err != nil {
+		jobError(c, err)
+		return
+	}
+	Render(c, serializer.Response{
+		Data: serializer.BuildTasks(tasks),
+	})
+}
+
+// JobTaskRun 立即执行重试中, 定时或死信任务
+func JobTaskRun(c *gin.Context) {
+	i := inspector(c)
+	if i == nil {
+		return
+	}
+	if err := i.RunTask(c.Param("queue"), c.Param("id")); err != nil {
+		jobError(c, err)
+		return
+	}
+	Render(c, serializer.Response{
+		Msg: "任务已重新入队",
+	})
+}
+
+// JobTaskDelete 删除任务
+func JobTaskDelete(c *gin.Context) {
+	i := inspector(c)
+	if i == nil {
+		return
+	}
+	if err := i.DeleteTask(c.Param("queue"), c.Param("id")); err != nil {
+		jobError(c, err)
+		return
+	}
+	Render(c, serializer.Response{
+		Msg: "任务已删除",
+	})
+}
+
+func jobError(c *gin.Context, err error) {
+	if errors.Is(err, asynq.ErrQueueNotFound) || errors.Is(err, asynq.ErrTaskNotFound) {
+		RenderStatus(c, 404, serializer.Err(serializer.CodeNotFound, "任务不存在", err))
+		return
+	}
+	RenderStatus(c, 500, serializer.Err(serializer.CodeJobError, "任务操作失败", err))
+}
@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-api/jobs"
+	"go-api/serializer"
+	"go-api/testutil"
+)
+
+var reportTask = jobs.NewTask[map[string]int]("test:report")
+
+func TestJobAdmin(t *testing.T) {
+	env := testutil.New(t)
+	env.Queue()
+	token := env.Token(env.User().Admin().Create())
+
+	if res := env.Call(http.MethodGet, "/api/v1/admin/jobs/queues", nil, env.Token(env.User().Create()), nil); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("non admin: %+v", res)
+	}
+
+	ctx := context.Background()
+	scheduled, err := reportTask.EnqueueIn(ctx, time.Hour, map[string]int{"day": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reportTask.Enqueue(ctx, map[string]int{"day": 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	var queues []serializer.Queue
+	if res := env.Call(http.MethodGet, "/api/v1/admin/jobs/queues", nil, token, &queues); res.Code != 0 {
+		t.Fatalf("queues: %+v", res)
+	}
+	if len(queues) != 1 || queues[0].Queue != jobs.QueueDefault || queues[0].Pending != 1 || queues[0].Scheduled != 1 {
+		t.Fatalf("unexpected queues %+v", queues)
+	}
+
+	var tasks []serializer.Task
+	if res := env.Call(http.MethodGet, "/api/v1/admin/jobs/queues/default/tasks?state=scheduled", nil, token, &tasks); res.Code != 0 {
+		t.Fatalf("tasks: %+v", res)
+	}
+	if len(tasks) != 1 || tasks[0].ID != scheduled.ID || tasks[0].Type != "test:report" || tasks[0].Payload != `{"day":1}` {
+		t.Fatalf("unexpected tasks %+v", tasks)
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/admin/jobs/queues/default/tasks?state=unknown", nil, token, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("unknown state: %+v", res)
+	}
+
+	// 定时任务立即执行后进入待处理
+	path := "/api/v1/admin/jobs/queues/default/tasks/" + scheduled.ID
+	if res := env.Call(http.MethodPost, path+"/run", nil, token, nil); res.Code != 0 {
+		t.Fatalf("run: %+v", res)
+	}
+	env.Call(http.MethodGet, "/api/v1/admin/jobs/queues/default/tasks?state=pending", nil, token, &tasks)
+	if len(tasks) != 2 {
+		t.Fatalf("pending after run: %+v", tasks)
+	}
+
+	if res := env.Call(http.MethodDelete, path, nil, token, nil); res.Code != 0 {
+		t.Fatalf("delete: %+v", res)
+	}
+	if res := env.Call(http.MethodDelete, path, nil, token, nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("delete again: %+v", res)
+	}
+	if res := env.Call(http.MethodPost, "/api/v1/admin/jobs/queues/missing/tasks/"+scheduled.ID+"/run", nil, token, nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("missing queue: %+v", res)
+	}
+}
+
+func TestJobAdminWithoutQueue(t *testing.T) {
+	env := testutil.New(t)
+	token := env.Token(env.User().Admin().Create())
+	for _, path := range []string{"/api/v1/admin/jobs/queues", "/api/v1/admin/jobs/queues/default/tasks"} {
+		if res := env.Call(http.MethodGet, path, nil, token, nil); res.Code != serializer.CodeJobError {
+			t.Fatalf("%s: %+v", path, res)
+		}
+	}
+}
@@ -28,7 +28,7 @@ import (
 // @Router /api/v1/ping [get]
 // @Success 200 {object} serializer.Response
 func Ping(c *gin.Context) {
-	c.JSON(200, serializer.Response{
+	Render(c, serializer.Response{
 		Code: 0,
 		Msg:  "Pong",
 	})
@@ -43,9 +43,9 @@ func CurrentUser(c *gin.Context) (*ent.User, error) {
 			var buf strings.Builder
 			buf.WriteString("member:")
 			buf.WriteString(strconv.Itoa(int(u.ID)))
-			key := buf.String()
+			key := cache.Key(c, buf.String())
 			var m *ent.User
-			if isExist(key) {
+			if isExist(c, key) {
 				var d ent.User
 				var mj string
 				ml, ok := cache.LocalCacheClient.Get(key)
@@ -53,7 +53,7 @@ func CurrentUser(c *gin.Context) (*ent.User, error) {
 					mj = ml.(string)
 					util.Log().Info("local %s", mj)
 				} else {
-					mj, _ = cache.RedisClient.Get(key).Result()
+					mj, _ = cache.RedisClient.Get(c, key).Result()
 				}
 				json.Unmarshal([]byte(mj), &d)
 				m = &d
@@ -67,8 +67,18 @@ func CurrentUser(c *gin.Context) (*ent.User, error) {
 	return nil, errors.New("无法获取用户信息")
 }
 
-func isExist(key string) bool {
-	if n, err := cache.RedisClient.Exists(key).Result(); err == nil && n == 0 {
+// CurrentUserID 获取当前登录用户 id, 未登录时返回 0
+func CurrentUserID(c *gin.Context) int {
+	if claims, _ := c.Get("claims"); claims != nil {
+		if u, ok := claims.(*middleware.CustomClaims); ok {
+			return int(u.ID)
+		}
+	}
+	return 0
+}
+
+func isExist(ctx context.Context, key string) bool {
+	if n, err := cache.RedisClient.Exists(ctx, key).Result(); err == nil && n == 0 {
 		return false
 	}
 	return true
@@ -79,7 +89,7 @@ func getUser(key string, c context.Context, id int) *ent.User {
 		m, _ := model.Client.User.Get(c, id)
 		mJson, _ := json.Marshal(m)
 		cache.LocalCacheClient.Set(key, string(mJson), 3600*time.Second)
-		cache.RedisClient.Set(key, string(mJson), 3600*time.Second)
+		cache.RedisClient.Set(c, key, string(mJson), 3600*time.Second)
 		return m, nil
 	})
 	return v.(*ent.User)
@@ -119,11 +129,11 @@ func GetOssToken(c *gin.Context) {
 	o := util.NewOss()
 	o, err := o.Info(os.Getenv("OSS_UPDATE_DIR"))
 	if err != nil {
-		c.JSON(400, serializer.Response{
+		RenderStatus(c, 400, serializer.Response{
 			Error: err.Error(),
 		})
 	} else {
-		c.JSON(200, serializer.Response{
+		Render(c, serializer.Response{
 			Code: 0,
 			Msg:  "",
 			Data: o,
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"go-api/auth/oauth"
+	"go-api/service"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthClientCreate 注册第三方应用
+func OAuthClientCreate(c *gin.Context) {
+	var createService service.OAuthClientCreateService
+	if err := c.ShouldBind(&createService); err == nil {
+		res := createService.Create(c, CurrentUserID(c))
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// OAuthClientList 全部第三方应用
+func OAuthClientList(c *gin.Context) {
+	res := service.ListOAuthClients(c)
+	Render(c, res)
+}
+
+// OAuthClientDelete 删除第三方应用
+func OAuthClientDelete(c *gin.Context) {
+	res := service.DeleteOAuthClient(c, CurrentUserID(c), c.Param("client_id"))
+	Render(c, res)
+}
+
+// OAuthConsent 授权确认页需要展示的内容
+func OAuthConsent(c *gin.Context) {
+	var authorizeService service.OAuthAuthorizeService
+	if err := c.ShouldBind(&authorizeService); err == nil {
+		res := authorizeService.Consent(c)
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// OAuthAuthorize 用户同意或拒绝授权
+func OAuthAuthorize(c *gin.Context) {
+	var authorizeService service.OAuthAuthorizeService
+	if err := c.ShouldBind(&authorizeService); err == nil {
+		res := authorizeService.Authorize(c, CurrentUserID(c))
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// OAuthToken token 端点, 按 RFC 6749 的格式返回
+func OAuthToken(c *gin.Context) {
+	var tokenService service.OAuthTokenService
+	if err := c.ShouldBind(&tokenService); err != nil {
+		renderOAuth(c, nil, oauth.Errorf("invalid_request", "%v", err))
+		return
+	}
+	tokenService.ClientID, tokenService.ClientSecret = clientCredentials(c, tokenService.ClientID, tokenService.ClientSecret)
+	token, oerr := tokenService.Token(c)
+	renderOAuth(c, token, oerr)
+}
+
+// OAuthIntrospect RFC 7662 token 内省
+func OAuthIntrospect(c *gin.Context) {
+	var request service.OAuthTokenRequest
+	if err := c.ShouldBind(&request); err != nil {
+		renderOAuth(c, nil, oauth.Errorf("invalid_request", "%v", err))
+		return
+	}
+	request.ClientID, request.ClientSecret = clientCredentials(c, request.ClientID, request.ClientSecret)
+	info, oerr := request.Introspect(c)
+	renderOAuth(c, info, oerr)
+}
+
+// OAuthRevoke RFC 7009 吊销 token
+func OAuthRevoke(c *gin.Context) {
+	var request service.OAuthTokenRequest
+	if err := c.ShouldBind(&request); err != nil {
+		renderOAuth(c, nil, oauth.Errorf("invalid_request", "%v", err))
+		return
+	}
+	request.ClientID, request.ClientSecret = clientCredentials(c, request.ClientID, request.ClientSecret)
+	oerr := request.Revoke(c)
+	renderOAuth(c, struct{}{}, oerr)
+}
+
+// clientCredentials 优先使用 Basic 认证中的客户端 id 与密钥
+func clientCredentials(c *gin.Context, id, secret string) (string, string) {
+	user, pass, ok := c.Request.BasicAuth()
+	if !ok {
+		return id, secret
+	}
+	// RFC 6749 2.3.1 要求对 Basic 认证中的 id 与密钥做 form 编码
+	if v, err := url.QueryUnescape(user); err == nil {
+		user = v
+	}
+	if v, err := url.QueryUnescape(pass); err == nil {
+		pass = v
+	}
+	return user, pass
+}
+
+// renderOAuth 协议端点不使用统一的响应格式, 错误按 RFC 6749 5.2 返回
+func renderOAuth(c *gin.Context, data interface{}, oerr *oauth.Error) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	if oerr != nil {
+		if oerr.Status == http.StatusUnauthorized {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		}
+		c.JSON(oerr.Status, oerr)
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
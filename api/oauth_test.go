@@ -0,0 +1,295 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-api/middleware"
+	"go-api/serializer"
+	"go-api/service"
+	"go-api/tenancy"
+	"go-api/testutil"
+
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/oauth2"
+)
+
+// oauthPost 以表单调用协议端点, id 不为空时使用 Basic 认证
+func oauthPost(t *testing.T, env *testutil.Env, path string, form url.Values, id, secret string, data interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if id != "" {
+		req.SetBasicAuth(id, secret)
+	}
+	w := env.Do(req)
+	if data != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), data); err != nil {
+			t.Fatalf("%s: %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	return w.Code
+}
+
+func TestOAuthAuthorizationCode(t *testing.T) {
+	env := testutil.New(t)
+	admin := env.Token(env.User().Admin().Create())
+	member := env.User().Create()
+	userToken := env.Token(member)
+
+	var client serializer.OAuthClientSecret
+	res := env.Call(http.MethodPost, "/api/v1/admin/oauth/clients", map[string]interface{}{
+		"name":          "partner",
+		"redirect_uris": []string{"https://partner.example.com/cb"},
+		"scopes":        []string{"profile", "pets:read"},
+		"grant_types":   []string{"authorization_code", "client_credentials"},
+	}, admin, &client)
+	if res.Code != 0 || client.ClientSecret == "" || client.Public {
+		t.Fatalf("create client: %+v %+v", res, client)
+	}
+
+	verifier := oauth2.GenerateVerifier()
+	authorize := map[string]interface{}{
+		"response_type":         "code",
+		"client_id":             client.ClientID,
+		"redirect_uri":          "https://partner.example.com/cb",
+		"scope":                 "profile pets:read",
+		"state":                 "xyz",
+		"code_challenge":        oauth2.S256ChallengeFromVerifier(verifier),
+		"code_challenge_method": "S256",
+	}
+	q := url.Values{}
+	for k, v := range authorize {
+		q.Set(k, v.(string))
+	}
+	var consent serializer.OAuthConsent
+	if res := env.Call(http.MethodGet, "/api/v1/oauth2/authorize?"+q.Encode(), nil, userToken, &consent); res.Code != 0 || len(consent.Scopes) != 2 || consent.ClientName != "partner" {
+		t.Fatalf("consent: %+v %+v", res, consent)
+	}
+
+	// 拒绝授权时回调地址带有 access_denied
+	var redirect serializer.OAuthRedirect
+	env.Call(http.MethodPost, "/api/v1/oauth2/authorize", authorize, userToken, &redirect)
+	if u, _ := url.Parse(redirect.RedirectURI); u.Query().Get("error") != "access_denied" || u.Query().Get("state") != "xyz" {
+		t.Fatalf("deny: %s", redirect.RedirectURI)
+	}
+
+	// 未登记的回调地址不跳转
+	authorize["approve"] = true
+	authorize["redirect_uri"] = "https://evil.example.com/cb"
+	if res := env.Call(http.MethodPost, "/api/v1/oauth2/authorize", authorize, userToken, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("unregistered redirect: %+v", res)
+	}
+	authorize["redirect_uri"] = "https://partner.example.com/cb"
+
+	code := func() string {
+		var redirect serializer.OAuthRedirect
+		if res := env.Call(http.MethodPost, "/api/v1/oauth2/authorize", authorize, userToken, &redirect); res.Code != 0 {
+			t.Fatalf("approve: %+v", res)
+		}
+		u, _ := url.Parse(redirect.RedirectURI)
+		return u.Query().Get("code")
+	}
+	exchange := url.Values{
+		"grant_type":   {"authorization_code"},
+		"redirect_uri": {"https://partner.example.com/cb"},
+	}
+
+	// code_verifier 不匹配
+	exchange.Set("code", code())
+	exchange.Set("code_verifier", oauth2.GenerateVerifier())
+	var oerr struct{ Error string }
+	if status := oauthPost(t, env, "/api/v1/oauth2/token", exchange, client.ClientID, client.ClientSecret, &oerr); status != http.StatusBadRequest || oerr.Error != "invalid_grant" {
+		t.Fatalf("wrong verifier: %d %+v", status, oerr)
+	}
+
+	exchange.Set("code", code())
+	exchange.Set("code_verifier", verifier)
+	if status := oauthPost(t, env, "/api/v1/oauth2/token", exchange, client.ClientID, "wrong", &oerr); status != http.StatusUnauthorized || oerr.Error != "invalid_client" {
+		t.Fatalf("wrong secret: %d %+v", status, oerr)
+	}
+	exchange.Set("code", code())
+	var token serializer.OAuthToken
+	if status := oauthPost(t, env, "/api/v1/oauth2/token", exchange, client.ClientID, client.ClientSecret, &token); status != http.StatusOK || token.AccessToken == "" || token.Scope != "pets:read profile" {
+		t.Fatalf("exchange: %d %+v", status, token)
+	}
+	// 授权码只能使用一次
+	if status := oauthPost(t, env, "/api/v1/oauth2/token", exchange, client.ClientID, client.ClientSecret, &oerr); status != http.StatusBadRequest {
+		t.Fatalf("reused code: %d", status)
+	}
+
+	// access token 按授权范围访问接口, 且不影响用户自己的 token
+	bearer := func(method, path string) serializer.Response {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		var res serializer.Response
+		json.Unmarshal(env.Do(req).Body.Bytes(), &res)
+		return res
+	}
+	if res := bearer(http.MethodGet, "/api/v1/user/me"); res.Code != 0 {
+		t.Fatalf("me with access token: %+v", res)
+	}
+	if res := bearer(http.MethodDelete, "/api/v1/pets/1"); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("out of scope: %+v", res)
+	}
+	if res := bearer(http.MethodDelete, "/api/v1/user/logout"); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("first party route: %+v", res)
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, userToken, nil); res.Code != 0 {
+		t.Fatalf("user token invalidated: %+v", res)
+	}
+
+	var info serializer.OAuthIntrospection
+	form := url.Values{"token": {token.AccessToken}}
+	oauthPost(t, env, "/api/v1/oauth2/introspect", form, client.ClientID, client.ClientSecret, &info)
+	if !info.Active || info.Subject != strconv.Itoa(member.ID) || info.Username != member.Username || info.ClientID != client.ClientID {
+		t.Fatalf("introspect: %+v", info)
+	}
+
+	if status := oauthPost(t, env, "/api/v1/oauth2/revoke", form, client.ClientID, client.ClientSecret, nil); status != http.StatusOK {
+		t.Fatalf("revoke: %d", status)
+	}
+	if res := bearer(http.MethodGet, "/api/v1/user/me"); res.Code == 0 {
+		t.Fatal("revoked token accepted")
+	}
+	info = serializer.OAuthIntrospection{}
+	oauthPost(t, env, "/api/v1/oauth2/introspect", form, client.ClientID, client.ClientSecret, &info)
+	if info.Active {
+		t.Fatalf("revoked token active: %+v", info)
+	}
+
+	// 客户端凭证模式, token 不代表用户, 不能申请用户数据的授权范围
+	var app serializer.OAuthToken
+	oauthPost(t, env, "/api/v1/oauth2/token", url.Values{"grant_type": {"client_credentials"}}, client.ClientID, client.ClientSecret, &app)
+	if app.AccessToken == "" || app.Scope != "" {
+		t.Fatalf("client credentials: %+v", app)
+	}
+	info = serializer.OAuthIntrospection{}
+	oauthPost(t, env, "/api/v1/oauth2/introspect", url.Values{"token": {app.AccessToken}}, client.ClientID, client.ClientSecret, &info)
+	if !info.Active || info.Subject != "" {
+		t.Fatalf("introspect client token: %+v", info)
+	}
+	for _, scope := range []string{"pets:read", "pets:write"} {
+		oerr.Error = ""
+		if status := oauthPost(t, env, "/api/v1/oauth2/token", url.Values{"grant_type": {"client_credentials"}, "scope": {scope}}, client.ClientID, client.ClientSecret, &oerr); status != http.StatusBadRequest || oerr.Error != "invalid_scope" {
+			t.Fatalf("client credentials with %s: %d %+v", scope, status, oerr)
+		}
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, app.AccessToken, nil); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("client token on user route: %+v", res)
+	}
+}
+
+func TestOAuthClientTokenOnUserRoutes(t *testing.T) {
+	env := testutil.New(t)
+	// 修复前签发的客户端凭证 token 带有用户数据的授权范围, 但没有用户
+	token, err := middleware.NewJWT().CreateToken(middleware.CustomClaims{
+		Name:     "partner",
+		Tenant:   tenancy.DefaultID,
+		ClientID: "legacy-client",
+		Scope:    "profile pets:read pets:write",
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: testutil.Epoch.Add(time.Hour).Unix(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/user/me"},
+		{http.MethodGet, "/api/v1/pets"},
+		{http.MethodPost, "/api/v1/pets"},
+	} {
+		if res := env.Call(r.method, r.path, map[string]string{"name": "tom", "species": "cat"}, token, nil); res.Code != serializer.CodeNoRightErr {
+			t.Fatalf("%s %s: %+v", r.method, r.path, res)
+		}
+	}
+}
+
+func TestOAuthGrantRevocation(t *testing.T) {
+	env := testutil.New(t)
+	admin := env.Token(env.User().Admin().Create())
+	member := env.User().Create()
+	grant := func(clientID string) string {
+		token, err := middleware.NewJWT().CreateToken(middleware.CustomClaims{
+			ID:       uint(member.ID),
+			Tenant:   tenancy.DefaultID,
+			ClientID: clientID,
+			Scope:    "profile",
+			StandardClaims: jwt.StandardClaims{
+				ExpiresAt: env.Clock.Now().Add(time.Hour).Unix(),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	me := func(token string) int {
+		return env.Call(http.MethodGet, "/api/v1/user/me", nil, token, nil).Code
+	}
+	status := func(status string) {
+		if res := env.Call(http.MethodPut, "/api/v1/admin/users/"+strconv.Itoa(member.ID)+"/status", map[string]string{"status": status}, admin, nil); res.Code != 0 {
+			t.Fatalf("status %s: %+v", status, res)
+		}
+	}
+
+	// 封禁后之前签发的 token 失效, 恢复后也不再有效, 重新授权后可用
+	token := grant("partner")
+	if code := me(token); code != 0 {
+		t.Fatalf("granted token: %d", code)
+	}
+	status("suspend")
+	status("active")
+	if code := me(token); code == 0 {
+		t.Fatal("token issued before suspension accepted")
+	}
+	env.Clock.Advance(time.Second)
+	token = grant("partner")
+	if code := me(token); code != 0 {
+		t.Fatalf("token issued after reactivation: %d", code)
+	}
+
+	// 不经过服务直接修改状态时按用户当前状态拒绝
+	env.DB.User.UpdateOneID(member.ID).SetStatus("inactive").ExecX(env.Context())
+	if code := me(token); code == 0 {
+		t.Fatal("token of inactive user accepted")
+	}
+	env.DB.User.UpdateOneID(member.ID).SetStatus("active").ExecX(env.Context())
+
+	// 重置密码
+	if res := (&service.UserPasswordResetService{Password: "newpassword"}).Reset(env.Context(), member.ID); res.Code != 0 {
+		t.Fatalf("reset password: %+v", res)
+	}
+	if code := me(token); code == 0 {
+		t.Fatal("token issued before password reset accepted")
+	}
+
+	// 删除应用只影响签发给该应用的 token
+	env.Clock.Advance(time.Second)
+	var client serializer.OAuthClientSecret
+	if res := env.Call(http.MethodPost, "/api/v1/admin/oauth/clients", map[string]interface{}{
+		"name":          "partner",
+		"redirect_uris": []string{"https://partner.example.com/cb"},
+		"scopes":        []string{"profile"},
+		"grant_types":   []string{"authorization_code"},
+	}, admin, &client); res.Code != 0 {
+		t.Fatalf("create client: %+v", res)
+	}
+	deleted, other := grant(client.ClientID), grant("other")
+	if res := env.Call(http.MethodDelete, "/api/v1/admin/oauth/clients/"+client.ClientID, nil, admin, nil); res.Code != 0 {
+		t.Fatalf("delete client: %+v", res)
+	}
+	if code := me(deleted); code == 0 {
+		t.Fatal("token of deleted client accepted")
+	}
+	if code := me(other); code != 0 {
+		t.Fatalf("token of other client: %d", code)
+	}
+}
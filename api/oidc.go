@@ -0,0 +1,57 @@
+package api
+
+import (
+	"go-api/auth/oidc"
+	"go-api/serializer"
+	"go-api/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// OIDCProviders 可用的第三方登录方式
+func OIDCProviders(c *gin.Context) {
+	Render(c, serializer.Response{
+		Data: oidc.Default.Names(),
+	})
+}
+
+// OIDCLogin 发起第三方登录, 浏览器访问时直接跳转到授权地址
+func OIDCLogin(c *gin.Context) {
+	res := service.OIDCAuthURL(c, c.Param("provider"), 0)
+	if u, ok := res.Data.(serializer.AuthURL); ok && c.NegotiateFormat(binding.MIMEJSON, binding.MIMEHTML) == binding.MIMEHTML {
+		c.Redirect(http.StatusFound, u.URL)
+		return
+	}
+	Render(c, res)
+}
+
+// OIDCCallback 第三方授权回调, 登录时返回 token, 绑定时返回绑定的账号
+func OIDCCallback(c *gin.Context) {
+	var callbackService service.OIDCCallbackService
+	if err := c.ShouldBind(&callbackService); err == nil {
+		res := callbackService.Callback(c, c.Param("provider"))
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// IdentityList 当前用户绑定的第三方账号
+func IdentityList(c *gin.Context) {
+	res := service.ListIdentities(c, CurrentUserID(c))
+	Render(c, res)
+}
+
+// IdentityLink 当前用户发起绑定第三方账号
+func IdentityLink(c *gin.Context) {
+	res := service.OIDCAuthURL(c, c.Param("provider"), CurrentUserID(c))
+	Render(c, res)
+}
+
+// IdentityUnlink 当前用户解除绑定第三方账号
+func IdentityUnlink(c *gin.Context) {
+	res := service.UnlinkIdentity(c, CurrentUserID(c), c.Param("provider"))
+	Render(c, res)
+}
@@ -0,0 +1,186 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go-api/auth/oidc/oidctest"
+	"go-api/middleware"
+	"go-api/serializer"
+	"go-api/service"
+	"go-api/testutil"
+)
+
+// oidcAuthorize 发起授权并在提供方完成授权, token 不为空时为绑定
+// 返回回调地址与浏览器保存的 state 绑定串
+func oidcAuthorize(t *testing.T, env *testutil.Env, p *oidctest.Provider, token string) (string, string) {
+	t.Helper()
+	var w *httptest.ResponseRecorder
+	if token == "" {
+		w = env.Request(http.MethodGet, "/api/v1/oauth/fake/login", nil, "")
+	} else {
+		w = env.Request(http.MethodPost, "/api/v1/user/identities/fake", nil, token)
+	}
+	var res struct {
+		Data serializer.AuthURL `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || res.Data.URL == "" {
+		t.Fatalf("auth url: %s", w.Body.String())
+	}
+	var binding string
+	for _, c := range w.Result().Cookies() {
+		if c.Name == service.OIDCBindingCookie {
+			binding = c.Value
+		}
+	}
+	code, state, err := p.Authorize(res.Data.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "/api/v1/oauth/fake/callback?" + url.Values{"code": {code}, "state": {state}}.Encode(), binding
+}
+
+// oidcFinish 以持有 binding 的浏览器访问回调地址
+func oidcFinish(env *testutil.Env, callback, binding string, data interface{}) serializer.Response {
+	if binding != "" {
+		env.Header.Set("Cookie", service.OIDCBindingCookie+"="+binding)
+		defer env.Header.Del("Cookie")
+	}
+	return env.Call(http.MethodGet, callback, nil, "", data)
+}
+
+// oidcCallback 发起授权并在同一个浏览器中完成回调, token 不为空时为绑定
+func oidcCallback(t *testing.T, env *testutil.Env, p *oidctest.Provider, token string, data interface{}) serializer.Response {
+	t.Helper()
+	callback, binding := oidcAuthorize(t, env, p, token)
+	return oidcFinish(env, callback, binding, data)
+}
+
+func TestOIDCLogin(t *testing.T) {
+	env := testutil.New(t)
+	p := env.OIDC("fake")
+
+	var providers []string
+	if res := env.Call(http.MethodGet, "/api/v1/oauth/providers", nil, "", &providers); res.Code != 0 || len(providers) != 1 || providers[0] != "fake" {
+		t.Fatalf("providers: %+v %v", res, providers)
+	}
+
+	// 首次登录创建用户, 再次登录得到同一个用户
+	var first, second serializer.UserToken
+	if res := oidcCallback(t, env, p, "", &first); res.Code != 0 || first.Token == "" {
+		t.Fatalf("first login: %+v", res)
+	}
+	if first.Nickname != "oidc user" || !strings.HasPrefix(first.Username, "fake_") {
+		t.Fatalf("created user: %+v", first)
+	}
+	if res := oidcCallback(t, env, p, "", &second); res.Code != 0 || second.ID != first.ID {
+		t.Fatalf("second login: %+v %+v", res, second)
+	}
+	var me serializer.User
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, second.Token, &me); res.Code != 0 || me.ID != first.ID {
+		t.Fatalf("me: %+v", res)
+	}
+
+	// 没有密码的用户不能解除最后一个绑定
+	if res := env.Call(http.MethodDelete, "/api/v1/user/identities/fake", nil, second.Token, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("unlink last identity: %+v", res)
+	}
+
+	// state 只能使用一次
+	callback, binding := oidcAuthorize(t, env, p, "")
+	if res := oidcFinish(env, callback, binding, nil); res.Code != 0 {
+		t.Fatalf("callback: %+v", res)
+	}
+	if res := oidcFinish(env, callback, binding, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("replayed state: %+v", res)
+	}
+
+	// state 绑定发起登录的浏览器
+	callback, _ = oidcAuthorize(t, env, p, "")
+	if res := oidcFinish(env, callback, "", nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("callback without binding: %+v", res)
+	}
+	_, other := oidcAuthorize(t, env, p, "")
+	callback, _ = oidcAuthorize(t, env, p, "")
+	if res := oidcFinish(env, callback, other, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("callback with other binding: %+v", res)
+	}
+
+	// state 绑定发起登录的租户
+	env.Tenant("acme", nil)
+	callback, binding = oidcAuthorize(t, env, p, "")
+	env.Header.Set(middleware.TenantHeader, "acme")
+	if res := oidcFinish(env, callback, binding, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("cross tenant state: %+v", res)
+	}
+	env.Header.Del(middleware.TenantHeader)
+
+	// 绑定的用户已被删除
+	env.DB.User.UpdateOneID(int(first.ID)).SetDeletedAt(env.Clock.Now()).ExecX(env.Context())
+	if res := oidcCallback(t, env, p, "", nil); res.Code != serializer.CodeNoRightErr || res.Msg != "账号已停用" {
+		t.Fatalf("deleted user: %+v", res)
+	}
+
+	if res := env.Call(http.MethodGet, "/api/v1/oauth/missing/login", nil, "", nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("unknown provider: %+v", res)
+	}
+
+	// 浏览器访问时直接跳转
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/oauth/fake/login", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	if w := env.Do(req); w.Code != http.StatusFound || !strings.HasPrefix(w.Header().Get("Location"), p.URL+"/authorize?") {
+		t.Fatalf("redirect: %d %s", w.Code, w.Header().Get("Location"))
+	}
+}
+
+func TestOIDCLink(t *testing.T) {
+	env := testutil.New(t)
+	p := env.OIDC("fake")
+	member := env.User().Create()
+	token := env.Token(member)
+
+	var linked serializer.Identity
+	if res := oidcCallback(t, env, p, token, &linked); res.Code != 0 || linked.Provider != "fake" || linked.Email != "user@example.com" {
+		t.Fatalf("link: %+v %+v", res, linked)
+	}
+	var identities []serializer.Identity
+	if res := env.Call(http.MethodGet, "/api/v1/user/identities", nil, token, &identities); res.Code != 0 || len(identities) != 1 {
+		t.Fatalf("identities: %+v %+v", res, identities)
+	}
+
+	// 绑定后第三方登录得到已有用户, 之前的 token 随即失效
+	var login serializer.UserToken
+	if res := oidcCallback(t, env, p, "", &login); res.Code != 0 || login.ID != uint(member.ID) {
+		t.Fatalf("login linked: %+v %+v", res, login)
+	}
+
+	// 绑定地址交给他人在其浏览器中完成时拒绝
+	callback, _ := oidcAuthorize(t, env, p, env.Token(env.User().Create()))
+	if res := oidcFinish(env, callback, "", nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("link from another browser: %+v", res)
+	}
+
+	// 已绑定的第三方账号不能再绑定给其他用户
+	other := env.Token(env.User().Create())
+	if res := oidcCallback(t, env, p, other, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("link taken identity: %+v", res)
+	}
+
+	// 有密码的用户可以解除全部绑定
+	token = login.Token
+	if res := env.Call(http.MethodDelete, "/api/v1/user/identities/fake", nil, token, nil); res.Code != 0 {
+		t.Fatalf("unlink: %+v", res)
+	}
+	if res := env.Call(http.MethodDelete, "/api/v1/user/identities/fake", nil, token, nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("unlink again: %+v", res)
+	}
+	p.SetUser(oidctest.User{Subject: "1002", Name: "x"})
+	var fresh serializer.UserToken
+	if res := oidcCallback(t, env, p, "", &fresh); res.Code != 0 || fresh.ID == uint(member.ID) {
+		t.Fatalf("login after unlink: %+v %+v", res, fresh)
+	}
+}
@@ -0,0 +1,388 @@
+package api
+
+import (
+	"go-api/flags"
+	"go-api/openapi"
+	"go-api/realtime"
+	"go-api/serializer"
+	"go-api/service"
+	"go-api/util"
+)
+
+// 接口文档, 每个路由的处理函数都需要在这里登记, server 的测试会检查遗漏
+func init() {
+	idParam := openapi.Param{Name: "id", In: "path", Type: "integer"}
+
+	openapi.Describe(Ping, openapi.Operation{
+		Summary: "接口调试",
+		Tags:    []string{"Ping"},
+	})
+
+	// 用户
+	openapi.Describe(UserRegister, openapi.Operation{
+		Summary:  "用户注册",
+		Tags:     []string{"User"},
+		Request:  service.UserRegisterService{},
+		Response: serializer.User{},
+	})
+	openapi.Describe(UserLogin, openapi.Operation{
+		Summary:  "用户登录",
+		Tags:     []string{"User"},
+		Request:  service.UserLoginService{},
+		Response: serializer.UserToken{},
+	})
+	openapi.Describe(UserMe, openapi.Operation{
+		Summary:  "当前用户详情",
+		Tags:     []string{"User"},
+		Auth:     true,
+		Response: serializer.User{},
+	})
+	openapi.Describe(UserLogout, openapi.Operation{
+		Summary:     "用户登出",
+		Description: "通过 session cookie 登录时只退出当前 session",
+		Tags:        []string{"User"},
+		Auth:        true,
+	})
+	openapi.Describe(UserLogoutEverywhere, openapi.Operation{
+		Summary: "退出所有设备",
+		Tags:    []string{"User"},
+		Auth:    true,
+	})
+	openapi.Describe(UserTokenRefresh, openapi.Operation{
+		Summary:  "刷新 token",
+		Tags:     []string{"User"},
+		Auth:     true,
+		Response: "",
+	})
+
+	// 第三方登录
+	providerParam := openapi.Param{Name: "provider", In: "path", Description: "OIDC_PROVIDERS 中配置的提供方名称"}
+	openapi.Describe(OIDCProviders, openapi.Operation{
+		Summary:  "可用的第三方登录方式",
+		Tags:     []string{"User"},
+		Response: []string{},
+	})
+	openapi.Describe(OIDCLogin, openapi.Operation{
+		Summary:     "发起第三方登录",
+		Description: "返回提供方的授权地址, Accept 为 text/html 时直接 302 跳转",
+		Tags:        []string{"User"},
+		Params:      []openapi.Param{providerParam},
+		Response:    serializer.AuthURL{},
+	})
+	openapi.Describe(OIDCCallback, openapi.Operation{
+		Summary:     "第三方授权回调",
+		Description: "登录时返回 token, 首次登录自动创建用户; 绑定时返回绑定的第三方账号",
+		Tags:        []string{"User"},
+		Params:      []openapi.Param{providerParam},
+		Request:     service.OIDCCallbackService{},
+		Response:    serializer.UserToken{},
+	})
+	openapi.Describe(IdentityList, openapi.Operation{
+		Summary:  "绑定的第三方账号",
+		Tags:     []string{"User"},
+		Auth:     true,
+		Response: []serializer.Identity{},
+	})
+	openapi.Describe(IdentityLink, openapi.Operation{
+		Summary:     "绑定第三方账号",
+		Description: "返回提供方的授权地址, 授权完成后回调时绑定到当前用户",
+		Tags:        []string{"User"},
+		Auth:        true,
+		Params:      []openapi.Param{providerParam},
+		Response:    serializer.AuthURL{},
+	})
+	openapi.Describe(IdentityUnlink, openapi.Operation{
+		Summary:     "解除绑定第三方账号",
+		Description: "没有设置密码的用户需要至少保留一个第三方账号",
+		Tags:        []string{"User"},
+		Auth:        true,
+		Params:      []openapi.Param{providerParam},
+	})
+
+	// 个人数据
+	openapi.Describe(UserExport, openapi.Operation{
+		Summary:     "申请导出个人数据",
+		Description: "异步打包资料, 会话, 审计日志, 上传文件等数据, 完成后可在 7 天内下载; 已有处理中的申请时直接返回",
+		Tags:        []string{"Privacy"},
+		Auth:        true,
+		Response:    serializer.DataRequest{},
+	})
+	openapi.Describe(UserErasure, openapi.Operation{
+		Summary:     "申请注销账号",
+		Description: "宽限期(ERASURE_GRACE_DAYS, 默认 14 天)结束后删除文件与关联数据并匿名化账号, 期间可以撤销",
+		Tags:        []string{"Privacy"},
+		Auth:        true,
+		Response:    serializer.DataRequest{},
+	})
+	openapi.Describe(UserErasureCancel, openapi.Operation{
+		Summary: "撤销注销申请",
+		Tags:    []string{"Privacy"},
+		Auth:    true,
+	})
+	openapi.Describe(DataRequestList, openapi.Operation{
+		Summary:  "导出与注销申请列表",
+		Tags:     []string{"Privacy"},
+		Auth:     true,
+		Response: []serializer.DataRequest{},
+	})
+	openapi.Describe(DataRequestDownload, openapi.Operation{
+		Summary: "下载导出的数据",
+		Tags:    []string{"Privacy"},
+		Auth:    true,
+		Params:  []openapi.Param{idParam},
+		Stream:  "application/zip",
+	})
+
+	// OAuth2 授权服务器
+	openapi.Describe(OAuthConsent, openapi.Operation{
+		Summary:     "授权确认页内容",
+		Description: "校验授权请求并返回应用名称与申请的授权范围, 只接受用户自己登录的 token",
+		Tags:        []string{"OAuth2"},
+		Auth:        true,
+		Request:     service.OAuthAuthorizeService{},
+		Response:    serializer.OAuthConsent{},
+	})
+	openapi.Describe(OAuthAuthorize, openapi.Operation{
+		Summary:     "同意或拒绝授权",
+		Description: "返回带有 code 或 error 的回调地址, 由前端跳转; 必须使用 S256 的 PKCE",
+		Tags:        []string{"OAuth2"},
+		Auth:        true,
+		Request:     service.OAuthAuthorizeService{},
+		Response:    serializer.OAuthRedirect{},
+	})
+	openapi.Describe(OAuthToken, openapi.Operation{
+		Summary:     "签发 access token",
+		Description: "RFC 6749, 支持 authorization_code 与 client_credentials, 响应不使用统一格式. 应用密钥放在 Basic 认证或表单中",
+		Tags:        []string{"OAuth2"},
+		Request:     service.OAuthTokenService{},
+	})
+	openapi.Describe(OAuthIntrospect, openapi.Operation{
+		Summary:     "token 内省",
+		Description: "RFC 7662, 需要应用密钥, 响应不使用统一格式",
+		Tags:        []string{"OAuth2"},
+		Request:     service.OAuthTokenRequest{},
+	})
+	openapi.Describe(OAuthRevoke, openapi.Operation{
+		Summary:     "吊销 token",
+		Description: "RFC 7009, 只能吊销签发给本应用的 token",
+		Tags:        []string{"OAuth2"},
+		Request:     service.OAuthTokenRequest{},
+	})
+	openapi.Describe(GetOssToken, openapi.Operation{
+		Summary:  "oss 直传签名",
+		Tags:     []string{"Oss"},
+		Response: util.OssInfo{},
+	})
+
+	// 实时推送
+	openapi.Describe(realtime.ServeWS, openapi.Operation{
+		Summary: "websocket 实时推送",
+		Tags:    []string{"Realtime"},
+		Params: []openapi.Param{
+			{Name: "token", In: "query", Description: "也可以通过请求头 token 传递"},
+			{Name: "topic", In: "query", Description: "订阅的主题, 格式为 <kind>:<id>, 可以重复; 种类需要通过 realtime.Authorize 注册授权, 不允许订阅时返回 403"},
+		},
+		Stream: "application/websocket",
+	})
+	openapi.Describe(realtime.ServeSSE, openapi.Operation{
+		Summary: "SSE 实时推送",
+		Tags:    []string{"Realtime"},
+		Params: []openapi.Param{
+			{Name: "token", In: "query", Description: "也可以通过请求头 token 传递"},
+			{Name: "topic", In: "query", Description: "订阅的主题, 格式为 <kind>:<id>, 可以重复; 种类需要通过 realtime.Authorize 注册授权, 不允许订阅时返回 403"},
+		},
+		Stream: "text/event-stream",
+	})
+
+	// 宠物
+	openapi.Describe(PetCreate, openapi.Operation{
+		Summary:  "创建宠物",
+		Tags:     []string{"Pet"},
+		Auth:     true,
+		Request:  service.PetCreateService{},
+		Response: serializer.Pet{},
+	})
+	openapi.Describe(PetList, openapi.Operation{
+		Summary: "当前用户的宠物列表",
+		Tags:    []string{"Pet"},
+		Auth:    true,
+		Params: []openapi.Param{
+			{Name: "filter", In: "query", Description: "field:op:value, 逗号分隔, 如 species:eq:cat,created_at:gt:2020-01-01T00:00:00Z"},
+			{Name: "sort", In: "query", Description: "排序字段, - 表示倒序, 如 -created_at,name"},
+			{Name: "page[size]", In: "query", Type: "integer"},
+			{Name: "page[after]", In: "query", Description: "上一页返回的 meta.next_cursor"},
+		},
+		Response: serializer.Pet{},
+		List:     true,
+	})
+	openapi.Describe(PetShow, openapi.Operation{
+		Summary:  "宠物详情",
+		Tags:     []string{"Pet"},
+		Auth:     true,
+		Params:   []openapi.Param{idParam},
+		Response: serializer.Pet{},
+	})
+	openapi.Describe(PetUpdate, openapi.Operation{
+		Summary:     "修改宠物",
+		Description: "携带 If-Match 时, 宠物在此期间被修改过则返回 412",
+		Tags:        []string{"Pet"},
+		Auth:        true,
+		Params:      []openapi.Param{idParam, {Name: "If-Match", In: "header", Description: "GET 宠物详情返回的 ETag"}},
+		Request:     service.PetUpdateService{},
+		Response:    serializer.Pet{},
+	})
+	openapi.Describe(PetDelete, openapi.Operation{
+		Summary: "删除宠物",
+		Tags:    []string{"Pet"},
+		Auth:    true,
+		Params:  []openapi.Param{idParam},
+	})
+
+	// 管理后台
+	openapi.Describe(JobQueues, openapi.Operation{
+		Summary:  "任务队列统计",
+		Tags:     []string{"Admin"},
+		Auth:     true,
+		Response: []serializer.Queue{},
+	})
+	openapi.Describe(JobTasks, openapi.Operation{
+		Summary: "按状态列出队列中的任务",
+		Tags:    []string{"Admin"},
+		Auth:    true,
+		Params: []openapi.Param{
+			{Name: "state", In: "query", Enum: []string{"pending", "active", "scheduled", "retry", "archived", "completed"}},
+			{Name: "page", In: "query", Type: "integer"},
+			{Name: "size", In: "query", Type: "integer"},
+		},
+		Response: []serializer.Task{},
+	})
+	openapi.Describe(JobTaskRun, openapi.Operation{
+		Summary: "立即执行任务",
+		Tags:    []string{"Admin"},
+		Auth:    true,
+	})
+	openapi.Describe(JobTaskDelete, openapi.Operation{
+		Summary: "删除任务",
+		Tags:    []string{"Admin"},
+		Auth:    true,
+	})
+	openapi.Describe(UserStatus, openapi.Operation{
+		Summary:  "修改用户状态",
+		Tags:     []string{"Admin"},
+		Auth:     true,
+		Params:   []openapi.Param{idParam},
+		Request:  service.UserStatusService{},
+		Response: serializer.User{},
+	})
+
+	openapi.Describe(OAuthClientCreate, openapi.Operation{
+		Summary:     "注册第三方应用",
+		Description: "client_secret 只在注册时返回一次, public 应用没有密钥",
+		Tags:        []string{"Admin"},
+		Auth:        true,
+		Request:     service.OAuthClientCreateService{},
+		Response:    serializer.OAuthClientSecret{},
+	})
+	openapi.Describe(OAuthClientList, openapi.Operation{
+		Summary:  "全部第三方应用",
+		Tags:     []string{"Admin"},
+		Auth:     true,
+		Response: []serializer.OAuthClient{},
+	})
+	openapi.Describe(OAuthClientDelete, openapi.Operation{
+		Summary: "删除第三方应用",
+		Tags:    []string{"Admin"},
+		Auth:    true,
+		Params:  []openapi.Param{{Name: "client_id", In: "path"}},
+	})
+
+	// webhook
+	openapi.Describe(WebhookEvents, openapi.Operation{
+		Summary:  "可以订阅的 webhook 事件",
+		Tags:     []string{"Admin"},
+		Auth:     true,
+		Response: []serializer.WebhookEvent{},
+	})
+	openapi.Describe(WebhookCreate, openapi.Operation{
+		Summary:     "注册 webhook 地址",
+		Description: "secret 只在注册时返回一次, 用于校验请求头 X-Webhook-Signature",
+		Tags:        []string{"Admin"},
+		Auth:        true,
+		Request:     service.WebhookCreateService{},
+		Response:    serializer.WebhookEndpointSecret{},
+	})
+	openapi.Describe(WebhookList, openapi.Operation{
+		Summary:  "当前用户注册的 webhook 地址",
+		Tags:     []string{"Admin"},
+		Auth:     true,
+		Response: []serializer.WebhookEndpoint{},
+	})
+	openapi.Describe(WebhookUpdate, openapi.Operation{
+		Summary:     "修改 webhook 地址",
+		Description: "未传的字段保持不变, enabled 为 true 时重新启用自动停用的地址",
+		Tags:        []string{"Admin"},
+		Auth:        true,
+		Params:      []openapi.Param{idParam},
+		Request:     service.WebhookUpdateService{},
+		Response:    serializer.WebhookEndpoint{},
+	})
+	openapi.Describe(WebhookDelete, openapi.Operation{
+		Summary: "删除 webhook 地址",
+		Tags:    []string{"Admin"},
+		Auth:    true,
+		Params:  []openapi.Param{idParam},
+	})
+	openapi.Describe(WebhookDeliveries, openapi.Operation{
+		Summary: "webhook 投递记录",
+		Tags:    []string{"Admin"},
+		Auth:    true,
+		Params: []openapi.Param{
+			idParam,
+			{Name: "filter", In: "query", Description: "field:op:value, 逗号分隔, 如 status:eq:failed"},
+			{Name: "sort", In: "query", Description: "如 -created_at"},
+			{Name: "page[size]", In: "query", Type: "integer"},
+			{Name: "page[after]", In: "query", Description: "上一页返回的 meta.next_cursor"},
+		},
+		Response: serializer.WebhookDelivery{},
+		List:     true,
+	})
+	openapi.Describe(WebhookReplay, openapi.Operation{
+		Summary:     "重新投递",
+		Description: "以相同的请求体创建新的投递",
+		Tags:        []string{"Admin"},
+		Auth:        true,
+		Params:      []openapi.Param{idParam, {Name: "delivery_id", In: "path", Type: "integer"}},
+		Response:    serializer.WebhookDelivery{},
+	})
+
+	// 功能开关
+	flagParam := openapi.Param{Name: "name", In: "path", Description: "开关名称, 小写字母, 数字与 _.-"}
+	openapi.Describe(FlagList, openapi.Operation{
+		Summary:  "全部功能开关",
+		Tags:     []string{"Admin"},
+		Auth:     true,
+		Response: []flags.Flag{},
+	})
+	openapi.Describe(FlagUpdate, openapi.Operation{
+		Summary:     "新增或修改功能开关",
+		Description: "enabled 为总开关; 白名单用户总是命中, 其余用户按 id 哈希落在 rollout 百分比内的命中. 修改会写入审计记录",
+		Tags:        []string{"Admin"},
+		Auth:        true,
+		Params:      []openapi.Param{flagParam},
+		Request:     service.FlagUpdateService{},
+		Response:    flags.Flag{},
+	})
+	openapi.Describe(FlagAudits, openapi.Operation{
+		Summary: "功能开关的修改记录",
+		Tags:    []string{"Admin"},
+		Auth:    true,
+		Params: []openapi.Param{
+			flagParam,
+			{Name: "filter", In: "query", Description: "field:op:value, 逗号分隔, 如 actor_id:eq:1"},
+			{Name: "page[size]", In: "query", Type: "integer"},
+			{Name: "page[after]", In: "query", Description: "上一页返回的 meta.next_cursor"},
+		},
+		Response: serializer.AuditLog{},
+		List:     true,
+	})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"go-api/serializer"
+	"go-api/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PetCreate 创建宠物
+// @Summary 创建宠物
+// @Tags Pet
+// @Param token header string true "token"
+// @Param name formData string true "name"
+// @Param species formData string true "species"
+// @Param birthday formData string false "birthday, 2006-01-02"
+// @Router /api/v1/pets [post]
+// @Success 200 {object} serializer.Pet
+func PetCreate(c *gin.Context) {
+	var createService service.PetCreateService
+	if err := c.ShouldBind(&createService); err == nil {
+		res := createService.Create(c, CurrentUserID(c))
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// PetList 宠物列表
+// @Summary 当前用户的宠物列表
+// @Tags Pet
+// @Param token header string true "token"
+// @Param filter query string false "filter, field:op:value[,...] e.g. species:eq:cat,created_at:gt:2020-01-01T00:00:00Z"
+// @Param sort query string false "sort, e.g. -created_at,name"
+// @Param page[size] query int false "page size"
+// @Param page[after] query string false "cursor from meta.next_cursor"
+// @Router /api/v1/pets [get]
+// @Success 200 {object} serializer.ListResponse
+func PetList(c *gin.Context) {
+	res := service.ListPets(c, CurrentUserID(c), c.Request.URL.Query())
+	Render(c, res)
+}
+
+// PetShow 宠物详情
+// @Summary 宠物详情
+// @Tags Pet
+// @Param token header string true "token"
+// @Param id path int true "pet id"
+// @Router /api/v1/pets/{id} [get]
+// @Success 200 {object} serializer.Pet
+func PetShow(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		Render(c, serializer.ParamErr("", err))
+		return
+	}
+	res := service.GetPet(c, CurrentUserID(c), id)
+	Render(c, res)
+}
+
+// PetUpdate 修改宠物
+// @Summary 修改宠物
+// @Tags Pet
+// @Param token header string true "token"
+// @Param id path int true "pet id"
+// @Param name formData string false "name"
+// @Param species formData string false "species"
+// @Param birthday formData string false "birthday, 2006-01-02, 空字符串清除"
+// @Param If-Match header string false "ETag from GET /api/v1/pets/{id}"
+// @Router /api/v1/pets/{id} [put]
+// @Success 200 {object} serializer.Pet
+// @Failure 412 {object} serializer.Response
+func PetUpdate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		Render(c, serializer.ParamErr("", err))
+		return
+	}
+	var updateService service.PetUpdateService
+	if err := c.ShouldBind(&updateService); err == nil {
+		updateService.IfMatch = c.GetHeader("If-Match")
+		res := updateService.Update(c, CurrentUserID(c), id)
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// PetDelete 删除宠物
+// @Summary 删除宠物
+// @Tags Pet
+// @Param token header string true "token"
+// @Param id path int true "pet id"
+// @Router /api/v1/pets/{id} [delete]
+// @Success 200 {object} serializer.Response
+func PetDelete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		Render(c, serializer.ParamErr("", err))
+		return
+	}
+	Render(c, service.DeletePet(c, CurrentUserID(c), id))
+}
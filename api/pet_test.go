@@ -0,0 +1,139 @@
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-api/ent"
+	entpet "go-api/ent/pet"
+	"go-api/entx"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/service"
+	"go-api/testutil"
+)
+
+func TestPetIfMatch(t *testing.T) {
+	env := testutil.New(t)
+	token := env.Token(env.User().Create())
+
+	var pet serializer.Pet
+	res := env.Call(http.MethodPost, "/api/v1/pets", map[string]string{"name": "tom", "species": "cat"}, token, &pet)
+	if res.Code != 0 {
+		t.Fatalf("create: %+v", res)
+	}
+	path := "/api/v1/pets/" + strconv.Itoa(pet.ID)
+
+	etag := env.Request(http.MethodGet, path, nil, token).Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag")
+	}
+	get := httptest.NewRequest(http.MethodGet, path, nil)
+	get.Header.Set("token", token)
+	get.Header.Set("If-None-Match", etag)
+	if w := env.Do(get); w.Code != http.StatusNotModified {
+		t.Fatalf("conditional get: %d", w.Code)
+	}
+
+	update := func(name string) int {
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader("name="+name))
+		req.Header.Set("token", token)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("If-Match", etag)
+		return env.Do(req).Code
+	}
+	if status := update("jerry"); status != http.StatusOK {
+		t.Fatalf("update with current ETag: %d", status)
+	}
+	// 另一个客户端仍持有旧的 ETag
+	if status := update("spike"); status != http.StatusPreconditionFailed {
+		t.Fatalf("update with stale ETag: %d", status)
+	}
+	if res := env.Call(http.MethodGet, path, nil, token, &pet); res.Code != 0 || pet.Name != "jerry" {
+		t.Fatalf("lost update: %+v %+v", res, pet)
+	}
+}
+
+func TestPetSoftDelete(t *testing.T) {
+	env := testutil.New(t)
+	token := env.Token(env.User().Create())
+
+	var pet serializer.Pet
+	env.Call(http.MethodPost, "/api/v1/pets", map[string]string{"name": "tom", "species": "cat"}, token, &pet)
+	path := "/api/v1/pets/" + strconv.Itoa(pet.ID)
+	if res := env.Call(http.MethodDelete, path, nil, token, nil); res.Code != 0 {
+		t.Fatalf("delete: %+v", res)
+	}
+	if res := env.Call(http.MethodGet, path, nil, token, nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("get deleted pet: %+v", res)
+	}
+	var pets []serializer.Pet
+	if env.Call(http.MethodGet, "/api/v1/pets", nil, token, &pets); len(pets) != 0 {
+		t.Fatalf("deleted pet listed: %+v", pets)
+	}
+	deleted := env.DB.Pet.GetX(entx.IncludeDeleted(env.Context()), pet.ID)
+	if deleted.DeletedAt == nil {
+		t.Fatal("pet not soft deleted")
+	}
+	// 修改与删除同样不包含软删除的数据
+	ctx := env.Context()
+	if err := env.DB.Pet.UpdateOneID(pet.ID).SetName("jerry").Exec(ctx); !ent.IsNotFound(err) {
+		t.Fatalf("update deleted pet: %v", err)
+	}
+	if err := env.DB.Pet.UpdateOneID(pet.ID).SetVersion(deleted.Version).SetName("jerry").Exec(ctx); !ent.IsNotFound(err) {
+		t.Fatalf("update deleted pet with version: %v", err)
+	}
+	if n := env.DB.Pet.Update().Where(entpet.ID(pet.ID)).SetName("jerry").SaveX(ctx); n != 0 {
+		t.Fatalf("bulk update changed %d deleted pets", n)
+	}
+	if n := env.DB.Pet.Delete().Where(entpet.ID(pet.ID)).ExecX(ctx); n != 0 {
+		t.Fatalf("bulk delete removed %d deleted pets", n)
+	}
+	env.DB.Pet.UpdateOneID(pet.ID).SetName("jerry").ExecX(entx.IncludeDeleted(ctx))
+	if p := env.DB.Pet.GetX(entx.IncludeDeleted(ctx), pet.ID); p.Name != "jerry" {
+		t.Fatalf("update with deleted included: %+v", p)
+	}
+
+	// 保留期过后物理删除
+	env.Clock.Advance(31 * 24 * time.Hour)
+	service.CleanDeletedUserTask.Enqueue(env.Context(), service.CleanDeletedUserPayload{RetainDays: 30})
+	if _, err := env.DB.Pet.Get(entx.IncludeDeleted(env.Context()), pet.ID); !ent.IsNotFound(err) {
+		t.Fatalf("pet not purged: %v", err)
+	}
+}
+
+func TestPetVersionConflict(t *testing.T) {
+	env := testutil.New(t)
+	token := env.Token(env.User().Create())
+
+	var pet serializer.Pet
+	env.Call(http.MethodPost, "/api/v1/pets", map[string]string{"name": "tom", "species": "cat"}, token, &pet)
+	ctx := env.Context()
+	stale := env.DB.Pet.GetX(ctx, pet.ID)
+
+	if res := env.Call(http.MethodPut, "/api/v1/pets/"+strconv.Itoa(pet.ID), map[string]string{"name": "jerry"}, token, nil); res.Code != 0 {
+		t.Fatalf("update: %+v", res)
+	}
+	if p := env.DB.Pet.GetX(ctx, pet.ID); p.Version != stale.Version+1 {
+		t.Fatalf("version not bumped: %d -> %d", stale.Version, p.Version)
+	}
+
+	// 以读取时的版本更新, 数据已被修改
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		return tx.Pet.UpdateOne(stale).SetVersion(stale.Version).SetName("spike").Exec(ctx)
+	})
+	if !errors.Is(err, entx.ErrVersionConflict) {
+		t.Fatalf("stale update: %v", err)
+	}
+	if res := serializer.DBErr("", err); res.Code != serializer.CodeConflict {
+		t.Fatalf("conflict response: %+v", res)
+	}
+	if p := env.DB.Pet.GetX(ctx, pet.ID); p.Name != "jerry" {
+		t.Fatalf("lost update: %+v", p)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"go-api/serializer"
+	"go-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserExport 申请导出个人数据
+func UserExport(c *gin.Context) {
+	res := service.RequestExport(c, CurrentUserID(c))
+	Render(c, res)
+}
+
+// UserErasure 申请注销账号
+func UserErasure(c *gin.Context) {
+	res := service.RequestErasure(c, CurrentUserID(c))
+	Render(c, res)
+}
+
+// UserErasureCancel 撤销注销申请
+func UserErasureCancel(c *gin.Context) {
+	res := service.CancelErasure(c, CurrentUserID(c))
+	Render(c, res)
+}
+
+// DataRequestList 导出与注销申请列表
+func DataRequestList(c *gin.Context) {
+	res := service.ListDataRequests(c, CurrentUserID(c))
+	Render(c, res)
+}
+
+// DataRequestDownload 下载导出的数据
+func DataRequestDownload(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		Render(c, serializer.ParamErr("", err))
+		return
+	}
+	r, res := service.OpenDataExport(c, CurrentUserID(c), id)
+	if res != nil {
+		Render(c, *res)
+		return
+	}
+	defer r.Close()
+	c.DataFromReader(http.StatusOK, -1, "application/zip", r, map[string]string{
+		"Content-Disposition": `attachment; filename="export-` + strconv.Itoa(id) + `.zip"`,
+		"Cache-Control":       "private, no-store",
+	})
+}
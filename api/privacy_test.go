@@ -0,0 +1,184 @@
+package api_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-api/auth/oauth"
+	"go-api/cache"
+	entoutbox "go-api/ent/outbox"
+	"go-api/ent/pet"
+	"go-api/ent/user"
+	"go-api/ent/webhookdelivery"
+	"go-api/entx"
+	"go-api/model"
+	"go-api/outbox"
+	"go-api/serializer"
+	"go-api/service"
+	"go-api/storage"
+	"go-api/tenancy"
+	"go-api/testutil"
+)
+
+func TestDataExport(t *testing.T) {
+	env := testutil.New(t)
+	u := env.User().Create()
+	token := env.Token(u)
+	other := env.Token(env.User().Create())
+
+	avatar := storage.UserPrefix(u.TenantID, u.ID) + "avatar.png"
+	if err := storage.Default.Put(env.Context(), avatar, strings.NewReader("png"), 3, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	env.Call(http.MethodPost, "/api/v1/pets", map[string]string{"name": "Tom", "species": "cat"}, token, nil)
+
+	var req serializer.DataRequest
+	if res := env.Call(http.MethodPost, "/api/v1/user/me/export", nil, token, &req); res.Code != 0 || req.Kind != "export" || req.Status != "completed" || req.ExpiresAt == 0 {
+		t.Fatalf("export: %+v %+v", res, req)
+	}
+	download := "/api/v1/user/me/data-requests/" + strconv.Itoa(req.ID) + "/download"
+	if res := env.Call(http.MethodGet, download, nil, other, nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("download other's export: %+v", res)
+	}
+
+	w := env.Request(http.MethodGet, download, nil, token)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("download: %d %s", w.Code, w.Body.String())
+	}
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		r, _ := f.Open()
+		files[f.Name], _ = io.ReadAll(r)
+		r.Close()
+	}
+	var profile serializer.User
+	var pets []serializer.Pet
+	var objects []storage.Object
+	json.Unmarshal(files["profile.json"], &profile)
+	json.Unmarshal(files["pets.json"], &pets)
+	json.Unmarshal(files["objects.json"], &objects)
+	if profile.Username != u.Username || strings.Contains(string(files["profile.json"]), "password") {
+		t.Fatalf("profile: %s", files["profile.json"])
+	}
+	if len(pets) != 1 || len(objects) != 1 || objects[0].Key != avatar {
+		t.Fatalf("pets %s objects %s", files["pets.json"], files["objects.json"])
+	}
+	for _, name := range []string{"sessions.json", "audit_logs.json", "identities.json", "data_requests.json"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("missing %s", name)
+		}
+	}
+
+	// 过期后删除导出文件
+	env.Clock.Advance(8 * 24 * time.Hour)
+	token = env.Token(u)
+	service.PrivacySweepTask.Enqueue(env.Context(), service.PrivacySweepPayload{})
+	if res := env.Call(http.MethodGet, download, nil, token, nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("download expired export: %+v", res)
+	}
+	if objects, _ := storage.Default.List(env.Context(), storage.UserPrefix(u.TenantID, u.ID)); len(objects) != 1 {
+		t.Fatalf("expired export not deleted: %+v", objects)
+	}
+}
+
+func TestDataErasure(t *testing.T) {
+	env := testutil.New(t)
+	admin := env.Token(env.User().Admin().Create())
+	srv := httptest.NewServer(&receiver{status: http.StatusOK})
+	defer srv.Close()
+	env.Call(http.MethodPost, "/api/v1/admin/webhooks", map[string]interface{}{
+		"url": srv.URL, "events": []string{"user.registered"},
+	}, admin, nil)
+
+	// 注册事件已推送并留下投递记录, 登录事件尚未发送
+	env.Call(http.MethodPost, "/api/v1/user/register", map[string]string{
+		"nickname": "erin", "username": "erin001", "password": testutil.Password, "password_confirm": testutil.Password,
+	}, "", nil)
+	if _, err := outbox.NewRelay(env.DB, service.WebhookDispatcher(), nil).Flush(env.Context()); err != nil {
+		t.Fatal(err)
+	}
+	sys := entx.IncludeDeleted(tenancy.System(env.Context()))
+	u := env.DB.User.Query().Where(user.Username("erin001")).OnlyX(sys)
+	var login serializer.UserToken
+	env.Call(http.MethodPost, "/api/v1/user/login", map[string]string{"username": u.Username, "password": testutil.Password}, "", &login)
+	if n := env.DB.WebhookDelivery.Query().Where(webhookdelivery.UserID(u.ID)).CountX(sys); n != 1 {
+		t.Fatalf("%d deliveries before erasure", n)
+	}
+	issuedAt := env.Clock.Now().Unix()
+	token := login.Token
+	env.Call(http.MethodPost, "/api/v1/pets", map[string]string{"name": "Tom", "species": "cat"}, token, nil)
+	storage.Default.Put(env.Context(), storage.UserPrefix(u.TenantID, u.ID)+"avatar.png", strings.NewReader("png"), 3, "image/png")
+
+	// 宽限期内可以撤销
+	var req serializer.DataRequest
+	if res := env.Call(http.MethodPost, "/api/v1/user/me/erasure", nil, token, &req); res.Code != 0 || req.Status != "pending" ||
+		req.ScheduledAt != env.Clock.Now().Add(14*24*time.Hour).Unix() {
+		t.Fatalf("erasure: %+v %+v", res, req)
+	}
+	if res := env.Call(http.MethodDelete, "/api/v1/user/me/erasure", nil, token, nil); res.Code != 0 {
+		t.Fatalf("cancel: %+v", res)
+	}
+	if res := env.Call(http.MethodDelete, "/api/v1/user/me/erasure", nil, token, nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("cancel twice: %+v", res)
+	}
+	var again serializer.DataRequest
+	env.Call(http.MethodPost, "/api/v1/user/me/erasure", nil, token, &again)
+	if again.ID == req.ID || again.Status != "pending" {
+		t.Fatalf("request again: %+v", again)
+	}
+
+	// 宽限期结束前不执行
+	service.PrivacySweepTask.Enqueue(env.Context(), service.PrivacySweepPayload{})
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, token, nil); res.Code != 0 {
+		t.Fatalf("erased before grace period: %+v", res)
+	}
+
+	member := cache.TenantKey(u.TenantID, "member:"+strconv.Itoa(u.ID))
+	if !env.Redis.Exists(member) {
+		t.Fatal("member cache not written on login")
+	}
+	env.Clock.Advance(15 * 24 * time.Hour)
+	service.PrivacySweepTask.Enqueue(env.Context(), service.PrivacySweepPayload{})
+
+	erased := env.DB.User.GetX(sys, u.ID)
+	name := "deleted_" + strconv.Itoa(u.ID)
+	if erased.Username != name || erased.Nickname != name || erased.PasswordDigest != "" || erased.Status != model.Inactive || erased.DeletedAt == nil {
+		t.Fatalf("user not anonymized: %+v", erased)
+	}
+	if n := env.DB.Pet.Query().Where(pet.HasOwner()).CountX(tenancy.System(env.Context())); n != 0 {
+		t.Fatalf("%d pets left", n)
+	}
+	for _, d := range env.DB.WebhookDelivery.Query().AllX(sys) {
+		if d.UserID == u.ID || bytes.Contains(d.Payload, []byte(u.Username)) {
+			t.Fatalf("webhook delivery left: %s", d.Payload)
+		}
+	}
+	rows := env.DB.Outbox.Query().Where(entoutbox.AggregateID(strconv.Itoa(u.ID))).AllX(sys)
+	if len(rows) != 1 || rows[0].EventType != "user.erased" {
+		t.Fatalf("outbox rows left: %+v", rows)
+	}
+	if !oauth.RevokedBefore(env.Context(), u.TenantID, u.ID, "partner", issuedAt) {
+		t.Fatal("delegated tokens not revoked")
+	}
+	if env.Redis.Exists(member) {
+		t.Fatal("member cache not purged")
+	}
+	if objects, _ := storage.Default.List(env.Context(), storage.UserPrefix(u.TenantID, u.ID)); len(objects) != 0 {
+		t.Fatalf("objects not deleted: %+v", objects)
+	}
+	if res := env.Call(http.MethodPost, "/api/v1/user/login", map[string]string{"username": u.Username, "password": testutil.Password}, "", nil); res.Code == 0 {
+		t.Fatal("erased user logged in")
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+	"go-api/middleware"
+	"go-api/serializer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"net/http"
+)
+
+// MIMEProtoJSON 以 protobuf 的 JSON 映射输出, Data 为 proto.Message 时按 protojson 规则序列化
+const MIMEProtoJSON = "application/x-protobuf+json"
+
+// offers 支持的响应格式, Accept 为空或无法匹配时使用第一个
+var offers = []string{binding.MIMEJSON, binding.MIMEMSGPACK, binding.MIMEMSGPACK2, MIMEProtoJSON}
+
+// Render 按 Accept 选择格式输出响应, 处理函数统一通过它返回
+// 成功的响应携带实体版本时同时写入 ETag 与 Last-Modified
+func Render(c *gin.Context, res serializer.Responder) {
+	base := res.Base()
+	if v, ok := base.Data.(serializer.Versioned); ok && base.Code == 0 {
+		middleware.SetVersion(c, v)
+	}
+	RenderStatus(c, httpStatus(base), res)
+}
+
+// RenderStatus 以指定的状态码输出响应
+func RenderStatus(c *gin.Context, status int, res serializer.Responder) {
+	c.Writer.Header().Add("Vary", "Accept")
+	switch c.NegotiateFormat(offers...) {
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		c.Render(status, render.MsgPack{Data: res})
+	case MIMEProtoJSON:
+		c.Render(status, protoJSON{Data: res})
+	default:
+		c.JSON(status, res)
+	}
+}
+
+// httpStatus 需要客户端按 HTTP 语义处理的错误码使用对应的状态码, 其余错误仍以 200 返回
+func httpStatus(res serializer.Response) int {
+	switch res.Code {
+	case serializer.CodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case serializer.CodeConflict:
+		return http.StatusConflict
+	}
+	return http.StatusOK
+}
+
+// protoJSON 把响应转换为 google.protobuf.Struct 后按 protojson 输出
+type protoJSON struct {
+	Data serializer.Responder
+}
+
+func (r protoJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	raw, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	if m, ok := r.Data.Base().Data.(proto.Message); ok {
+		data, err := protojson.Marshal(m)
+		if err != nil {
+			return err
+		}
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		fields["data"] = v
+	}
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		return err
+	}
+	out, err := protojson.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+func (r protoJSON) WriteContentType(w http.ResponseWriter) {
+	header := w.Header()
+	if val := header["Content-Type"]; len(val) == 0 {
+		header["Content-Type"] = []string{MIMEProtoJSON + "; charset=utf-8"}
+	}
+}
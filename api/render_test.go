@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+	"go-api/serializer"
+)
+
+func TestRenderNegotiation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) {
+		Render(c, serializer.BuildList([]string{"a"}, serializer.ListMeta{Size: 1}))
+	})
+
+	cases := []struct {
+		accept, contentType string
+	}{
+		{"", "application/json"},
+		{"*/*", "application/json"},
+		{"application/x-msgpack", "application/msgpack"},
+		{"application/msgpack", "application/msgpack"},
+		{MIMEProtoJSON, MIMEProtoJSON},
+		{"text/xml", "application/json"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", tc.accept)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, tc.contentType) {
+			t.Errorf("Accept %q: Content-Type = %q, want %q", tc.accept, got, tc.contentType)
+			continue
+		}
+		if tc.contentType == "application/msgpack" {
+			var res map[string]interface{}
+			if err := codec.NewDecoderBytes(w.Body.Bytes(), new(codec.MsgpackHandle)).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := res["meta"]; !ok {
+				t.Errorf("msgpack body missing meta: %v", res)
+			}
+		} else if !strings.Contains(w.Body.String(), `"has_more"`) {
+			t.Errorf("Accept %q: unexpected body %s", tc.accept, w.Body.String())
+		}
+	}
+}
+
+func TestRenderPreconditionFailed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) {
+		Render(c, serializer.Err(serializer.CodePreconditionFailed, "", nil))
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("status = %d", w.Code)
+	}
+}
@@ -0,0 +1,186 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-api/middleware"
+	"go-api/serializer"
+	"go-api/testutil"
+)
+
+// browser 保存 cookie 的客户端
+type browser struct {
+	t       *testing.T
+	env     *testutil.Env
+	cookies map[string]*http.Cookie
+}
+
+func newBrowser(t *testing.T, env *testutil.Env) *browser {
+	return &browser{t: t, env: env, cookies: make(map[string]*http.Cookie)}
+}
+
+// do 携带 cookie 发送请求, csrf 为 true 时在请求头中带上 csrf_token cookie 的值
+func (b *browser) do(method, path string, body interface{}, csrf bool) serializer.Response {
+	b.t.Helper()
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	for _, c := range b.cookies {
+		req.AddCookie(c)
+	}
+	if c := b.cookies[middleware.CSRFCookie]; csrf && c != nil {
+		req.Header.Set(middleware.CSRFHeader, c.Value)
+	}
+	w := b.env.Do(req)
+	for _, c := range w.Result().Cookies() {
+		if c.MaxAge < 0 {
+			delete(b.cookies, c.Name)
+		} else {
+			b.cookies[c.Name] = c
+		}
+	}
+	var res serializer.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		b.t.Fatalf("%s %s: %d %s", method, path, w.Code, w.Body.String())
+	}
+	return res
+}
+
+// sessionID cookie 中的 session id
+func (b *browser) sessionID() string {
+	c := b.cookies[middleware.SessionCookie]
+	if c == nil {
+		return ""
+	}
+	return c.Value[:strings.LastIndexByte(c.Value, '.')]
+}
+
+func TestSession(t *testing.T) {
+	env := testutil.New(t)
+	member := env.User().Create()
+	login := map[string]string{"username": member.Username, "password": testutil.Password}
+
+	b := newBrowser(t, env)
+	if res := b.do(http.MethodPost, "/api/v1/user/login", login, false); res.Code != 0 {
+		t.Fatalf("login: %+v", res)
+	}
+	first := b.sessionID()
+	if first == "" || b.cookies[middleware.CSRFCookie] == nil {
+		t.Fatalf("session cookies not set: %v", b.cookies)
+	}
+	if res := b.do(http.MethodGet, "/api/v1/user/me", nil, false); res.Code != 0 {
+		t.Fatalf("me with session: %+v", res)
+	}
+
+	// 使用 session 的写请求需要 CSRF token, 使用 token 请求头的不需要
+	pet := map[string]string{"name": "tom", "species": "cat"}
+	if res := b.do(http.MethodPost, "/api/v1/pets", pet, false); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("missing csrf: %+v", res)
+	}
+	if res := b.do(http.MethodPost, "/api/v1/pets", pet, true); res.Code != 0 {
+		t.Fatalf("with csrf: %+v", res)
+	}
+	if res := env.Call(http.MethodPost, "/api/v1/pets", pet, env.Token(member), nil); res.Code != 0 {
+		t.Fatalf("header token: %+v", res)
+	}
+
+	// 闲置过期时间随访问顺延
+	env.Clock.Advance(6 * 24 * time.Hour)
+	b.do(http.MethodGet, "/api/v1/user/me", nil, false)
+	if ttl := env.Redis.TTL("session:" + first); ttl < 6*24*time.Hour {
+		t.Fatalf("session not renewed, ttl = %s", ttl)
+	}
+
+	// 再次登录分配新的 session id, 旧 id 失效
+	stolen := b.cookies[middleware.SessionCookie]
+	b.do(http.MethodPost, "/api/v1/user/login", login, true)
+	if b.sessionID() == first || env.Redis.Exists("session:"+first) {
+		t.Fatal("session id not regenerated on login")
+	}
+	attacker := newBrowser(t, env)
+	attacker.cookies[stolen.Name] = stolen
+	if res := attacker.do(http.MethodGet, "/api/v1/user/me", nil, false); res.Code == 0 {
+		t.Fatal("fixated session still valid")
+	}
+
+	// 退出所有设备
+	other := newBrowser(t, env)
+	other.do(http.MethodPost, "/api/v1/user/login", login, false)
+	if res := b.do(http.MethodDelete, "/api/v1/user/sessions", nil, true); res.Code != 0 {
+		t.Fatalf("logout everywhere: %+v", res)
+	}
+	if res := other.do(http.MethodGet, "/api/v1/user/me", nil, false); res.Code == 0 {
+		t.Fatal("other session still valid")
+	}
+
+	// 登出只结束当前 session
+	b.do(http.MethodPost, "/api/v1/user/login", login, false)
+	other.do(http.MethodPost, "/api/v1/user/login", login, false)
+	if res := b.do(http.MethodDelete, "/api/v1/user/logout", nil, true); res.Code != 0 {
+		t.Fatalf("logout: %+v", res)
+	}
+	if res := b.do(http.MethodGet, "/api/v1/user/me", nil, false); res.Code == 0 {
+		t.Fatal("session valid after logout")
+	}
+	if res := other.do(http.MethodGet, "/api/v1/user/me", nil, false); res.Code != 0 {
+		t.Fatalf("other session ended by logout: %+v", res)
+	}
+}
+
+func TestSessionFollowsUser(t *testing.T) {
+	env := testutil.New(t)
+	member := env.User().Admin().Create()
+	b := newBrowser(t, env)
+	if res := b.do(http.MethodPost, "/api/v1/user/login", map[string]string{"username": member.Username, "password": testutil.Password}, false); res.Code != 0 {
+		t.Fatalf("login: %+v", res)
+	}
+
+	// 普通请求不重新下发 cookie, 距上次下发较久时才下发
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/user/me", nil)
+	for _, c := range b.cookies {
+		req.AddCookie(c)
+	}
+	if w := env.Do(req); len(w.Result().Cookies()) != 0 {
+		t.Fatalf("cookies set on every response: %v", w.Header()["Set-Cookie"])
+	}
+	env.Clock.Advance(24 * time.Hour)
+	if w := env.Do(req); len(w.Result().Cookies()) == 0 {
+		t.Fatal("session cookie not refreshed")
+	}
+
+	// 角色与状态的修改立即生效
+	if res := b.do(http.MethodGet, "/api/v1/admin/webhooks", nil, false); res.Code != 0 {
+		t.Fatalf("admin: %+v", res)
+	}
+	env.DB.User.UpdateOneID(member.ID).SetRole("user").ExecX(env.Context())
+	if res := b.do(http.MethodGet, "/api/v1/admin/webhooks", nil, false); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("demoted admin: %+v", res)
+	}
+	env.DB.User.UpdateOneID(member.ID).SetStatus("suspend").ExecX(env.Context())
+	if res := b.do(http.MethodGet, "/api/v1/user/me", nil, false); res.Code == 0 {
+		t.Fatal("suspended user still logged in")
+	}
+	env.DB.User.UpdateOneID(member.ID).SetStatus("active").ExecX(env.Context())
+
+	// 无效的 token 请求头不能绕过 CSRF 校验
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name":"tom","species":"cat"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("token", "invalid")
+	for _, c := range b.cookies {
+		req.AddCookie(c)
+	}
+	var res serializer.Response
+	json.Unmarshal(env.Do(req).Body.Bytes(), &res)
+	if res.Code != serializer.CodeNoRightErr || res.Msg != "CSRF token 无效" {
+		t.Fatalf("invalid header token: %+v", res)
+	}
+}
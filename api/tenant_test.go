@@ -0,0 +1,117 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"go-api/middleware"
+	"go-api/serializer"
+	"go-api/tenancy"
+	"go-api/testutil"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	env := testutil.New(t)
+	acme := env.Tenant("acme", nil)
+	register := map[string]string{
+		"username":         "sameuser",
+		"nickname":         "same",
+		"password":         "secret123",
+		"password_confirm": "secret123",
+	}
+	login := map[string]string{"username": "sameuser", "password": "secret123"}
+
+	// 用户名在租户内唯一
+	if res := env.Call(http.MethodPost, "/api/v1/user/register", register, "", nil); res.Code != 0 {
+		t.Fatalf("register default: %+v", res)
+	}
+	env.Header.Set(middleware.TenantHeader, "acme")
+	var acmeUser serializer.User
+	if res := env.Call(http.MethodPost, "/api/v1/user/register", register, "", &acmeUser); res.Code != 0 {
+		t.Fatalf("register acme: %+v", res)
+	}
+	var token serializer.UserToken
+	if res := env.Call(http.MethodPost, "/api/v1/user/login", login, "", &token); res.Code != 0 || token.ID != acmeUser.ID {
+		t.Fatalf("login acme: %+v %+v", res, token)
+	}
+	if !env.Redis.Exists("t:" + strconv.Itoa(acme.ID) + ":user:" + strconv.Itoa(int(acmeUser.ID))) {
+		t.Fatal("token key not prefixed with tenant")
+	}
+
+	// 没有指定租户时以 token 中的租户为准, 明确指定其他租户时拒绝
+	env.Header.Del(middleware.TenantHeader)
+	var me serializer.User
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, token.Token, &me); res.Code != 0 || me.ID != acmeUser.ID {
+		t.Fatalf("me: %+v %+v", res, me)
+	}
+	env.Header.Set(middleware.TenantHeader, "default")
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, token.Token, nil); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("cross tenant token: %+v", res)
+	}
+
+	// 其他租户的管理员看不到本租户的数据
+	admin := env.Token(env.User().Admin().Create())
+	res := env.Call(http.MethodPut, "/api/v1/admin/users/"+strconv.Itoa(int(acmeUser.ID))+"/status",
+		map[string]string{"status": "suspend"}, admin, nil)
+	if res.Code != serializer.CodeNotFound {
+		t.Fatalf("cross tenant update: %+v", res)
+	}
+
+	env.Header.Set(middleware.TenantHeader, "missing")
+	if res := env.Call(http.MethodGet, "/api/v1/ping", nil, "", nil); res.Code != serializer.CodeNotFound {
+		t.Fatalf("unknown tenant: %+v", res)
+	}
+}
+
+func TestTenantRateLimit(t *testing.T) {
+	env := testutil.New(t)
+	env.Tenant("limited", map[string]string{"RATE_R": "0", "RATE_B": "0"})
+
+	if res := env.Call(http.MethodGet, "/api/v1/ping", nil, "", nil); res.Code != 0 {
+		t.Fatalf("default tenant: %+v", res)
+	}
+	env.Header.Set(middleware.TenantHeader, "limited")
+	if res := env.Call(http.MethodGet, "/api/v1/ping", nil, "", nil); res.Code != serializer.CodeOverClock {
+		t.Fatalf("limited tenant: %+v", res)
+	}
+}
+
+func TestTenantAdminScope(t *testing.T) {
+	env := testutil.New(t)
+	acme := env.Tenant("acme", nil)
+	acmeAdmin := env.Token(env.User().Tenant(acme).Admin().Create())
+
+	// 全局资源只允许默认租户的管理员
+	for _, path := range []string{"/api/v1/admin/flags", "/api/v1/admin/flags/new_login/audits", "/api/v1/admin/jobs/queues"} {
+		if res := env.Call(http.MethodGet, path, nil, acmeAdmin, nil); res.Code != serializer.CodeNoRightErr {
+			t.Fatalf("tenant admin %s: %+v", path, res)
+		}
+	}
+	if res := env.Call(http.MethodPut, "/api/v1/admin/flags/new_login", map[string]interface{}{"enabled": true}, acmeAdmin, nil); res.Code != serializer.CodeNoRightErr {
+		t.Fatalf("tenant admin flag update: %+v", res)
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/admin/flags", nil, env.Token(env.User().Admin().Create()), nil); res.Code != 0 {
+		t.Fatalf("default admin: %+v", res)
+	}
+
+	// 本租户的资源仍然可以管理
+	if res := env.Call(http.MethodGet, "/api/v1/admin/webhooks", nil, acmeAdmin, nil); res.Code != 0 {
+		t.Fatalf("tenant admin webhooks: %+v", res)
+	}
+
+	// 审计记录属于操作所在的租户
+	if res := env.Call(http.MethodPost, "/api/v1/admin/webhooks", map[string]interface{}{
+		"url": "http://203.0.113.10/hook", "events": []string{"user.registered"},
+	}, acmeAdmin, nil); res.Code != 0 {
+		t.Fatalf("tenant admin webhook create: %+v", res)
+	}
+	acmeCtx := tenancy.NewContext(context.Background(), &tenancy.Tenant{ID: acme.ID})
+	if n := env.DB.AuditLog.Query().CountX(acmeCtx); n != 1 {
+		t.Fatalf("acme audits = %d", n)
+	}
+	if n := env.DB.AuditLog.Query().CountX(env.Context()); n != 0 {
+		t.Fatalf("default tenant sees %d acme audits", n)
+	}
+}
@@ -17,10 +17,10 @@ import (
 func UserRegister(c *gin.Context) {
 	var registerService service.UserRegisterService
 	if err := c.ShouldBind(&registerService); err == nil {
-		res := registerService.Register()
-		c.JSON(200, res)
+		res := registerService.Register(c)
+		Render(c, res)
 	} else {
-		c.JSON(200, ErrorResponse(err))
+		Render(c, ErrorResponse(err))
 	}
 }
 
@@ -30,19 +30,19 @@ func UserRegister(c *gin.Context) {
 // @Param username formData string true "username"
 // @Param password formData string true "password"
 // @Router /api/v1/user/login [post]
-// @Success 200 {object} serializer.BuildUser
+// @Success 200 {object} serializer.UserToken
 func UserLogin(c *gin.Context) {
 	var loginService service.UserLoginService
 	defer func() {
 		if err := recover(); err != nil {
-			c.JSON(400, serializer.Err(40014, "发生错误", nil))
+			RenderStatus(c, 400, serializer.Err(40014, "发生错误", nil))
 		}
 	}()
 	if err := c.ShouldBind(&loginService); err == nil {
 		res := loginService.Login(c)
-		c.JSON(200, res)
+		Render(c, res)
 	} else {
-		c.JSON(200, ErrorResponse(err))
+		Render(c, ErrorResponse(err))
 	}
 }
 
@@ -50,52 +50,56 @@ func UserLogin(c *gin.Context) {
 func UserMe(c *gin.Context) {
 	user, err := CurrentUser(c)
 	if err != nil {
-		c.JSON(200, serializer.Err(40004, "无法获取用户信息", err))
+		Render(c, serializer.Err(40004, "无法获取用户信息", err))
+		return
 	}
 	res := serializer.BuildUserResponse(user)
-	c.JSON(200, res)
+	Render(c, res)
 }
 
-// UserLogout 用户登出
+// UserLogout 用户登出, 通过 session 登录时只退出当前 session, 否则吊销当前 token
 func UserLogout(c *gin.Context) {
 	if claims, _ := c.Get("claims"); claims != nil {
 		if u, ok := claims.(*middleware.CustomClaims); ok {
-			key := strconv.Itoa(int(u.ID))
-			cache.RedisClient.Del("user:" + key).Err()
-			c.JSON(200, serializer.Response{
+			if !middleware.SessionAuthenticated(c) {
+				service.RevokeToken(c, int(u.ID))
+			}
+			middleware.EndSession(c)
+			Render(c, serializer.Response{
 				Code: 0,
 				Msg:  "登出成功",
 			})
 		}
 	} else {
-		c.JSON(200, serializer.Err(40010, "登出失败", nil))
+		Render(c, serializer.Err(40010, "登出失败", nil))
 	}
+}
 
-	//session 销毁
-	/*s := sessions.Default(c)
-	s.Clear()
-	s.Save()
-	c.JSON(200, serializer.Response{
-		Code: 0,
-		Msg:  "~~",
-	})*/
+// UserLogoutEverywhere 退出所有设备, 吊销 token 并删除全部 session
+func UserLogoutEverywhere(c *gin.Context) {
+	if err := service.SignOutEverywhere(c, CurrentUserID(c)); err != nil {
+		Render(c, serializer.Err(serializer.CodeTokenError, "退出失败", err))
+		return
+	}
+	middleware.EndSession(c)
+	Render(c, serializer.Response{Msg: "已退出所有设备"})
 }
 
 func UserTokenRefresh(c *gin.Context) {
 	token, err := TokenRefresh(c)
 	if err != nil {
-		c.JSON(200, serializer.Err(40010, "发生错误", err))
+		Render(c, serializer.Err(40010, "发生错误", err))
 	} else {
 		if claims, _ := c.Get("claims"); claims != nil {
 			if u, ok := claims.(*middleware.CustomClaims); ok {
 				tokenMD5 := util.StringToMD5(token)
 				key := strconv.Itoa(int(u.ID))
 				ttl, _ := strconv.Atoi(os.Getenv("TOKEN_TTL"))
-				cache.RedisClient.Set("user:"+key, tokenMD5, time.Duration(ttl)*time.Second).Err()
+				cache.RedisClient.Set(c, cache.TenantKey(u.Tenant, "user:"+key), tokenMD5, time.Duration(ttl)*time.Second+middleware.RefreshGrace()).Err()
 			}
 		}
 
-		c.JSON(200, serializer.Response{
+		Render(c, serializer.Response{
 			Code: 0,
 			Msg:  "居然刷上了",
 			Data: token,
@@ -103,7 +107,23 @@ func UserTokenRefresh(c *gin.Context) {
 	}
 }
 
+// UserStatus 修改用户状态
+func UserStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		Render(c, serializer.ParamErr("", err))
+		return
+	}
+	var statusService service.UserStatusService
+	if err := c.ShouldBind(&statusService); err == nil {
+		res := statusService.Change(c, id)
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
 //route 或 method 不存在 统一错误信息
 func HandleNotFound(c *gin.Context) {
-	c.JSON(404, serializer.Err(400004, "请求不存在", nil))
+	RenderStatus(c, 404, serializer.Err(400004, "请求不存在", nil))
 }
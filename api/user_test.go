@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/testutil"
+)
+
+func TestUserFlow(t *testing.T) {
+	env := testutil.New(t)
+
+	var user serializer.User
+	res := env.Call(http.MethodPost, "/api/v1/user/register", map[string]string{
+		"nickname":         "alice",
+		"username":         "alice01",
+		"password":         "password",
+		"password_confirm": "password",
+	}, "", &user)
+	if res.Code != 0 || user.Username != "alice01" {
+		t.Fatalf("register: %+v %+v", res, user)
+	}
+
+	res = env.Call(http.MethodPost, "/api/v1/user/login", map[string]string{
+		"username": "alice01",
+		"password": "wrong-password",
+	}, "", nil)
+	if res.Code != serializer.CodeParamErr {
+		t.Fatalf("login with wrong password: %+v", res)
+	}
+
+	var login serializer.UserToken
+	res = env.Call(http.MethodPost, "/api/v1/user/login", map[string]string{
+		"username": "alice01",
+		"password": "password",
+	}, "", &login)
+	if res.Code != 0 || login.Token == "" {
+		t.Fatalf("login: %+v", res)
+	}
+	if want := testutil.Epoch.Add(time.Hour).Unix(); login.ExpiresAt != want {
+		t.Fatalf("expires_at = %d, want %d", login.ExpiresAt, want)
+	}
+
+	var me serializer.User
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, login.Token, &me); res.Code != 0 || me.ID != user.ID {
+		t.Fatalf("me: %+v %+v", res, me)
+	}
+
+	var token string
+	if res := env.Call(http.MethodPut, "/api/v1/user/token/refresh", nil, login.Token, &token); res.Code != 0 || token == login.Token {
+		t.Fatalf("refresh: %+v", res)
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, login.Token, nil); res.Code == 0 {
+		t.Fatal("old token still valid after refresh")
+	}
+
+	if res := env.Call(http.MethodDelete, "/api/v1/user/logout", nil, token, nil); res.Code != 0 {
+		t.Fatalf("logout: %+v", res)
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, token, nil); res.Code == 0 {
+		t.Fatal("token still valid after logout")
+	}
+}
+
+func TestSuspendedUserLogin(t *testing.T) {
+	env := testutil.New(t)
+	for _, status := range []string{model.Suspend, model.Inactive} {
+		u := env.User().Status(status).Create()
+		res := env.Call(http.MethodPost, "/api/v1/user/login", map[string]string{
+			"username": u.Username,
+			"password": testutil.Password,
+		}, "", nil)
+		if res.Code != serializer.CodeNoRightErr {
+			t.Fatalf("%s user login: %+v", status, res)
+		}
+	}
+}
+
+func TestRegisterDeletedUsername(t *testing.T) {
+	env := testutil.New(t)
+	u := env.User().Username("carol01").Create()
+	env.DB.User.UpdateOne(u).SetDeletedAt(env.Clock.Now()).ExecX(env.Context())
+
+	// 软删除的用户在物理删除前仍然占用用户名
+	res := env.Call(http.MethodPost, "/api/v1/user/register", map[string]string{
+		"nickname":         "carol",
+		"username":         "carol01",
+		"password":         "password",
+		"password_confirm": "password",
+	}, "", nil)
+	if res.Code != serializer.CodeParamErr || res.Msg != "用户名已占用" {
+		t.Fatalf("register deleted username: %+v", res)
+	}
+}
+
+func TestExpiredTokenRefresh(t *testing.T) {
+	env := testutil.New(t)
+	token := env.Token(env.User().Create())
+
+	env.Clock.Advance(2 * time.Hour)
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, token, nil); res.Code != serializer.CodeTokenError {
+		t.Fatalf("expired token: %+v", res)
+	}
+	var refreshed string
+	if res := env.Call(http.MethodPut, "/api/v1/user/token/refresh", nil, token, &refreshed); res.Code != 0 {
+		t.Fatalf("refresh within grace: %+v", res)
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, refreshed, nil); res.Code != 0 {
+		t.Fatalf("me with refreshed token: %+v", res)
+	}
+
+	// 超过宽限期后只能重新登录
+	env.Clock.Advance(25 * time.Hour)
+	if res := env.Call(http.MethodPut, "/api/v1/user/token/refresh", nil, refreshed, nil); res.Code == 0 {
+		t.Fatal("refreshed a token past the grace period")
+	}
+}
+
+func TestDeterministicTokens(t *testing.T) {
+	issue := func() string {
+		env := testutil.New(t)
+		return env.Token(env.User().Create())
+	}
+	if a, b := issue(), issue(); a != b {
+		t.Fatalf("tokens differ between runs:\n%s\n%s", a, b)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"go-api/serializer"
+	"go-api/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// webhookID 路径中的地址 id
+func webhookID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		Render(c, serializer.ParamErr("", err))
+		return 0, false
+	}
+	return id, true
+}
+
+// WebhookEvents 可以订阅的事件
+func WebhookEvents(c *gin.Context) {
+	Render(c, service.ListWebhookEvents())
+}
+
+// WebhookCreate 注册 webhook 地址
+func WebhookCreate(c *gin.Context) {
+	var createService service.WebhookCreateService
+	if err := c.ShouldBind(&createService); err == nil {
+		res := createService.Create(c, CurrentUserID(c))
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// WebhookList 当前用户注册的 webhook 地址
+func WebhookList(c *gin.Context) {
+	res := service.ListWebhooks(c, CurrentUserID(c))
+	Render(c, res)
+}
+
+// WebhookUpdate 修改 webhook 地址
+func WebhookUpdate(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+	var updateService service.WebhookUpdateService
+	if err := c.ShouldBind(&updateService); err == nil {
+		res := updateService.Update(c, CurrentUserID(c), id)
+		Render(c, res)
+	} else {
+		Render(c, ErrorResponse(err))
+	}
+}
+
+// WebhookDelete 删除 webhook 地址
+func WebhookDelete(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+	res := service.DeleteWebhook(c, CurrentUserID(c), id)
+	Render(c, res)
+}
+
+// WebhookDeliveries 地址的投递记录
+func WebhookDeliveries(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+	res := service.ListWebhookDeliveries(c, CurrentUserID(c), id, c.Request.URL.Query())
+	Render(c, res)
+}
+
+// WebhookReplay 重新投递
+func WebhookReplay(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		Render(c, serializer.ParamErr("", err))
+		return
+	}
+	res := service.ReplayWebhookDelivery(c, CurrentUserID(c), id, deliveryID)
+	Render(c, res)
+}
@@ -0,0 +1,204 @@
+package api_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-api/outbox"
+	"go-api/serializer"
+	"go-api/service"
+	"go-api/testutil"
+	"go-api/webhook"
+)
+
+// receiver 记录收到的 webhook 请求
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+	w.Write([]byte("received"))
+}
+
+func (r *receiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	env := testutil.New(t)
+	t.Setenv("WEBHOOK_DISABLE_AFTER", "2")
+	admin := env.Token(env.User().Admin().Create())
+	member := env.User().Create()
+
+	recv := &receiver{status: http.StatusOK}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	var endpoint serializer.WebhookEndpointSecret
+	res := env.Call(http.MethodPost, "/api/v1/admin/webhooks", map[string]interface{}{
+		"url":    srv.URL + "/hook",
+		"events": []string{"user.registered", "user.suspended"},
+	}, admin, &endpoint)
+	if res.Code != 0 || endpoint.Secret == "" || len(endpoint.Events) != 2 {
+		t.Fatalf("create: %+v %+v", res, endpoint)
+	}
+	if res := env.Call(http.MethodPost, "/api/v1/admin/webhooks", map[string]interface{}{
+		"url": srv.URL, "events": []string{"user.unknown"},
+	}, admin, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("unknown event: %+v", res)
+	}
+
+	relay := outbox.NewRelay(env.DB, service.WebhookDispatcher(), nil)
+	flush := func() {
+		t.Helper()
+		if _, err := relay.Flush(env.Context()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deliveries := func() []serializer.WebhookDelivery {
+		t.Helper()
+		var items []serializer.WebhookDelivery
+		path := "/api/v1/admin/webhooks/" + strconv.Itoa(endpoint.ID) + "/deliveries"
+		if res := env.Call(http.MethodGet, path, nil, admin, &items); res.Code != 0 {
+			t.Fatalf("deliveries: %+v", res)
+		}
+		return items
+	}
+
+	// 注册事件签名后推送, 未订阅的登录事件不推送
+	env.Call(http.MethodPost, "/api/v1/user/register", map[string]string{
+		"nickname": "bob", "username": "bob001", "password": "password", "password_confirm": "password",
+	}, "", nil)
+	env.Call(http.MethodPost, "/api/v1/user/login", map[string]string{"username": "bob001", "password": "password"}, "", nil)
+	flush()
+	if recv.count() != 1 {
+		t.Fatalf("received %d requests", recv.count())
+	}
+	req, body := recv.requests[0], recv.bodies[0]
+	if req.URL.Path != "/hook" || req.Header.Get(webhook.HeaderEvent) != "user.registered" {
+		t.Fatalf("request: %s %v", req.URL, req.Header)
+	}
+	if err := webhook.Verify(endpoint.Secret, req.Header.Get(webhook.HeaderTimestamp), req.Header.Get(webhook.HeaderSignature), body, 5*time.Minute, env.Clock.Now()); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := webhook.Verify(endpoint.Secret, req.Header.Get(webhook.HeaderTimestamp), req.Header.Get(webhook.HeaderSignature), body, 5*time.Minute, env.Clock.Now().Add(time.Hour)); err == nil {
+		t.Fatal("stale signature accepted")
+	}
+	var envelope webhook.Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Type != "user.registered" || req.Header.Get(webhook.HeaderEventID) != strconv.Itoa(envelope.ID) {
+		t.Fatalf("envelope: %s %v", body, err)
+	}
+	// 重复投递同一事件不会重复推送
+	env.DB.Outbox.Update().ClearPublishedAt().ExecX(env.Context())
+	if flush(); recv.count() != 1 {
+		t.Fatalf("duplicate event pushed again: %d", recv.count())
+	}
+	if items := deliveries(); len(items) != 1 || items[0].Status != "succeeded" || items[0].ResponseStatus != 200 {
+		t.Fatalf("delivery log: %+v", items)
+	}
+
+	// 连续失败后自动停用
+	recv.setStatus(http.StatusInternalServerError)
+	status := "/api/v1/admin/users/" + strconv.Itoa(member.ID) + "/status"
+	for _, s := range []string{"suspend", "active", "suspend", "active", "suspend"} {
+		env.Call(http.MethodPut, status, map[string]string{"status": s}, admin, nil)
+		flush()
+	}
+	items := deliveries()
+	if len(items) != 3 || items[0].Status != "pending" || items[0].Attempts != 1 || items[0].ResponseStatus != 500 {
+		t.Fatalf("failed deliveries: %+v", items)
+	}
+	var endpoints []serializer.WebhookEndpoint
+	env.Call(http.MethodGet, "/api/v1/admin/webhooks", nil, admin, &endpoints)
+	if len(endpoints) != 1 || endpoints[0].Enabled || endpoints[0].Failures != 2 || endpoints[0].DisabledAt == 0 {
+		t.Fatalf("endpoint not disabled: %+v", endpoints)
+	}
+
+	// 重新启用后重放失败的投递
+	replay := "/api/v1/admin/webhooks/" + strconv.Itoa(endpoint.ID) + "/deliveries/" + strconv.Itoa(items[1].ID) + "/replay"
+	if res := env.Call(http.MethodPost, replay, nil, admin, nil); res.Code != serializer.CodeParamErr {
+		t.Fatalf("replay disabled endpoint: %+v", res)
+	}
+	recv.setStatus(http.StatusOK)
+	env.Call(http.MethodPut, "/api/v1/admin/webhooks/"+strconv.Itoa(endpoint.ID), map[string]bool{"enabled": true}, admin, nil)
+	var replayed serializer.WebhookDelivery
+	if res := env.Call(http.MethodPost, replay, nil, admin, &replayed); res.Code != 0 || replayed.Status != "succeeded" || replayed.ReplayOf != items[1].ID || replayed.EventID != items[1].EventID {
+		t.Fatalf("replay: %+v %+v", res, replayed)
+	}
+	// 第一次失败的投递是收到的第二个请求
+	if last := recv.bodies[len(recv.bodies)-1]; len(recv.bodies) != 4 || string(last) != string(recv.bodies[1]) {
+		t.Fatalf("replayed body differs: %s", last)
+	}
+}
+
+func TestWebhookPrivateAddress(t *testing.T) {
+	env := testutil.New(t)
+	webhook.AllowPrivate = false
+	defer func() { webhook.AllowPrivate = true }()
+	admin := env.Token(env.User().Admin().Create())
+
+	for _, raw := range []string{
+		"http://127.0.0.1/hook", "http://10.0.0.1/hook", "http://169.254.169.254/latest/meta-data", "http://[::1]/hook", "http://100.100.100.200/",
+		// ipv4 映射地址与 NAT64 地址
+		"http://[::ffff:127.0.0.1]/hook", "http://[::ffff:a9fe:a9fe]/", "http://[64:ff9b::169.254.169.254]/", "http://[64:ff9b::a00:1]/hook",
+	} {
+		if res := env.Call(http.MethodPost, "/api/v1/admin/webhooks", map[string]interface{}{
+			"url": raw, "events": []string{"user.registered"},
+		}, admin, nil); res.Code != serializer.CodeParamErr {
+			t.Fatalf("%s accepted: %+v", raw, res)
+		}
+	}
+	if res := env.Call(http.MethodPost, "/api/v1/admin/webhooks", map[string]interface{}{
+		"url": "http://[64:ff9b::203.0.113.10]/hook", "events": []string{"user.erased"},
+	}, admin, nil); res.Code != 0 {
+		t.Fatalf("NAT64 address of a public ipv4: %+v", res)
+	}
+
+	// 域名解析到回环地址时在连接时拒绝
+	recv := &receiver{status: http.StatusOK}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	var endpoint serializer.WebhookEndpointSecret
+	if res := env.Call(http.MethodPost, "/api/v1/admin/webhooks", map[string]interface{}{
+		"url": "http://localhost:" + u.Port() + "/hook", "events": []string{"user.registered"},
+	}, admin, &endpoint); res.Code != 0 {
+		t.Fatalf("create: %+v", res)
+	}
+	env.Call(http.MethodPost, "/api/v1/user/register", map[string]string{
+		"nickname": "bob", "username": "bob001", "password": "password", "password_confirm": "password",
+	}, "", nil)
+	if _, err := outbox.NewRelay(env.DB, service.WebhookDispatcher(), nil).Flush(env.Context()); err != nil {
+		t.Fatal(err)
+	}
+	var items []serializer.WebhookDelivery
+	env.Call(http.MethodGet, "/api/v1/admin/webhooks/"+strconv.Itoa(endpoint.ID)+"/deliveries", nil, admin, &items)
+	if recv.count() != 0 || len(items) != 1 || !strings.Contains(items[0].Error, "forbidden address") {
+		t.Fatalf("delivered to loopback: %d %+v", recv.count(), items)
+	}
+}
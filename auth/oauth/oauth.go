@@ -0,0 +1,215 @@
+// Package oauth OAuth2 授权服务器的协议部分, 支持授权码(强制 PKCE)与客户端凭证模式
+//
+// 授权码保存在 redis 中且只能使用一次; 签发的 access token 是带有 client_id 与 scope
+// 的普通 JWT, 由 middleware.JWTAuth 校验, 吊销的 token 按 jti 记录到过期为止;
+// 用户或应用的全部 token 按吊销时间一起吊销, 早于该时间签发的 token 失效
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go-api/cache"
+	"go-api/util"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 授权类型
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantClientCredentials = "client_credentials"
+)
+
+// CodeTTL 授权码有效期
+const CodeTTL = 5 * time.Minute
+
+// Scopes 第三方应用可以申请的授权范围及说明, 说明展示在授权确认页
+// 这些范围都用于访问用户数据, 只能在用户授权后获得, 客户端凭证模式不能申请
+var Scopes = map[string]string{
+	"profile":    "读取昵称与头像",
+	"pets:read":  "读取宠物",
+	"pets:write": "创建, 修改与删除宠物",
+}
+
+// Error RFC 6749 5.2 的错误响应
+type Error struct {
+	Status      int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return "oauth: " + e.Code + ": " + e.Description
+}
+
+// Errorf 返回 400 错误
+func Errorf(code, format string, args ...interface{}) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: code, Description: fmt.Sprintf(format, args...)}
+}
+
+// InvalidClient 客户端认证失败
+func InvalidClient() *Error {
+	return &Error{Status: http.StatusUnauthorized, Code: "invalid_client", Description: "client authentication failed"}
+}
+
+// Code 授权码对应的授权
+type Code struct {
+	ClientID    string `json:"client_id"`
+	UserID      int    `json:"user_id"`
+	RedirectURI string `json:"redirect_uri"`
+	Scope       string `json:"scope"`
+	Challenge   string `json:"challenge"`
+}
+
+// SaveCode 保存授权并返回授权码
+func SaveCode(ctx context.Context, code Code) (string, error) {
+	data, err := json.Marshal(code)
+	if err != nil {
+		return "", err
+	}
+	id := Random(32)
+	if err := cache.RedisClient.Set(ctx, codeKey(ctx, id), data, CodeTTL).Err(); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// TakeCode 取出并删除授权码, 不存在或已使用时返回 nil
+func TakeCode(ctx context.Context, id string) (*Code, error) {
+	data, err := cache.RedisClient.GetDel(ctx, codeKey(ctx, id)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var code Code
+	if err := json.Unmarshal(data, &code); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func codeKey(ctx context.Context, id string) string {
+	return cache.Key(ctx, "oauth:code:"+id)
+}
+
+// VerifyChallenge 校验 PKCE 的 code_verifier, 只支持 S256
+func VerifyChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(sum[:])), []byte(challenge)) == 1
+}
+
+// Revoke 吊销 token, 记录保留到 token 过期
+func Revoke(ctx context.Context, tenantID int, jti string, expiresAt time.Time) error {
+	ttl := expiresAt.Sub(util.Now())
+	if ttl <= 0 {
+		return nil
+	}
+	return cache.RedisClient.Set(ctx, revokedKey(tenantID, jti), 1, ttl).Err()
+}
+
+// Revoked token 是否已被吊销
+func Revoked(ctx context.Context, tenantID int, jti string) bool {
+	n, err := cache.RedisClient.Exists(ctx, revokedKey(tenantID, jti)).Result()
+	// redis 不可用时按已吊销处理
+	return err != nil || n > 0
+}
+
+func revokedKey(tenantID int, jti string) string {
+	return cache.TenantKey(tenantID, "oauth:revoked:"+jti)
+}
+
+// RevokeUser 吊销用户授权给所有应用的 token, 用于封禁, 重置密码与注销
+// 记录吊销时间, 之前签发的 token 都失效, 记录保留 ttl(token 的最长有效期)
+func RevokeUser(ctx context.Context, tenantID, userID int, ttl time.Duration) error {
+	return cache.RedisClient.Set(ctx, epochKey(tenantID, "user:"+strconv.Itoa(userID)), util.Now().Unix(), ttl).Err()
+}
+
+// RevokeClient 吊销签发给应用的全部 token, 用于删除应用
+func RevokeClient(ctx context.Context, tenantID int, clientID string, ttl time.Duration) error {
+	return cache.RedisClient.Set(ctx, epochKey(tenantID, "client:"+clientID), util.Now().Unix(), ttl).Err()
+}
+
+// RevokedBefore token 是否签发于用户或应用的全部 token 被吊销之时或之前
+// 客户端凭证模式的 token 没有用户, userID 为 0
+func RevokedBefore(ctx context.Context, tenantID, userID int, clientID string, issuedAt int64) bool {
+	keys := []string{epochKey(tenantID, "client:"+clientID)}
+	if userID != 0 {
+		keys = append(keys, epochKey(tenantID, "user:"+strconv.Itoa(userID)))
+	}
+	// 键不在同一个 slot, 集群模式下不能使用 MGET
+	for _, key := range keys {
+		epoch, err := cache.RedisClient.Get(ctx, key).Int64()
+		if err == redis.Nil {
+			continue
+		}
+		// redis 不可用时按已吊销处理
+		if err != nil || issuedAt <= epoch {
+			return true
+		}
+	}
+	return false
+}
+
+func epochKey(tenantID int, owner string) string {
+	return cache.TenantKey(tenantID, "oauth:epoch:"+owner)
+}
+
+// ParseScope 解析空格分隔的授权范围, 去重并排序
+func ParseScope(s string) []string {
+	seen := make(map[string]bool)
+	var scopes []string
+	for _, scope := range strings.Fields(s) {
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// Contains 空格分隔的列表中是否包含 item, 用于授权范围, 授权类型与回调地址
+func Contains(list, item string) bool {
+	for _, s := range strings.Fields(list) {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
+
+// HashSecret 客户端密钥的摘要, 密钥为高熵随机串, 不需要慢哈希
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckSecret 比较客户端密钥与摘要
+func CheckSecret(hash, secret string) bool {
+	return hash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(HashSecret(secret))) == 1
+}
+
+// Random n 字节的随机串
+func Random(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
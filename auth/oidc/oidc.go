@@ -0,0 +1,281 @@
+// Package oidc 第三方 OIDC 登录客户端, 支持同时配置多个提供方
+//
+// 使用授权码模式并启用 PKCE, 提供方的端点通过 discovery 文档获取.
+// 发起登录时生成的 state, nonce 与 code_verifier 保存在 redis 中,
+// 回调时一次性取出校验, 同一个 state 不能重复使用;
+// state 同时绑定发起登录的浏览器, 回调时需要带上发起时返回的 binding, 防止把授权地址交给他人完成
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-api/cache"
+	"go-api/tenancy"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/oauth2"
+)
+
+var (
+	// ErrUnknownProvider 提供方没有配置
+	ErrUnknownProvider = errors.New("oidc: unknown provider")
+	// ErrInvalidState state 不存在, 已过期, 已使用或与当前请求不匹配
+	ErrInvalidState = errors.New("oidc: invalid state")
+)
+
+// StateTTL 发起登录到回调之间允许的最长时间
+const StateTTL = 10 * time.Minute
+
+// Config 提供方配置
+type Config struct {
+	// Name 提供方名称, 出现在登录与回调地址中
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL 在提供方登记的回调地址, 即本服务的 /api/v1/oauth/{name}/callback
+	RedirectURL string
+	// Scopes 除 openid 外额外申请的权限, 默认为 profile, email
+	Scopes []string
+}
+
+// Provider 已配置的提供方, discovery 文档在第一次使用时获取
+type Provider struct {
+	Config
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// discover 获取 discovery 文档, 失败时不缓存, 下次使用时重试
+func (p *Provider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth2 != nil {
+		return p.oauth2, p.verifier, nil
+	}
+
+	provider, err := gooidc.NewProvider(context.WithoutCancel(ctx), p.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc: discover %s: %w", p.Name, err)
+	}
+	scopes := p.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"profile", "email"}
+	}
+	p.oauth2 = &oauth2.Config{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  p.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       append([]string{gooidc.ScopeOpenID}, scopes...),
+	}
+	p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.ClientID})
+	return p.oauth2, p.verifier, nil
+}
+
+// State 一次登录请求, 保存在 redis 中直到回调
+type State struct {
+	Provider string `json:"provider"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	TenantID int    `json:"tenant_id"`
+	// UserID 不为 0 时为该用户绑定第三方账号, 否则为登录
+	UserID int `json:"user_id,omitempty"`
+	// Binding 发起登录的浏览器持有的随机串, 回调时必须一致
+	Binding string `json:"binding"`
+}
+
+// Claims id_token 中使用到的用户信息
+type Claims struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// Registry 提供方集合
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]*Provider
+	redis     redis.UniversalClient
+}
+
+// NewRegistry 创建 Registry, state 保存在 rdb 中
+func NewRegistry(rdb redis.UniversalClient) *Registry {
+	return &Registry{
+		providers: make(map[string]*Provider),
+		redis:     rdb,
+	}
+}
+
+// Default 默认 Registry, 由 Init 按环境变量初始化
+var Default = NewRegistry(nil)
+
+// Init 按环境变量初始化默认 Registry
+// OIDC_PROVIDERS 提供方名称, 逗号分隔; 每个提供方读取
+// OIDC_{NAME}_ISSUER, OIDC_{NAME}_CLIENT_ID, OIDC_{NAME}_CLIENT_SECRET,
+// OIDC_{NAME}_REDIRECT_URL 与 OIDC_{NAME}_SCOPES(空格分隔)
+func Init(rdb redis.UniversalClient) {
+	Default = NewRegistry(rdb)
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		Default.Register(Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		})
+	}
+}
+
+// Register 添加或替换提供方
+func (r *Registry) Register(cfg Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[cfg.Name] = &Provider{Config: cfg}
+}
+
+// Get 按名称获取提供方
+func (r *Registry) Get(name string) (*Provider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Names 全部提供方名称
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AuthURL 生成跳转到提供方的授权地址, userID 不为 0 时回调为该用户绑定账号
+// state 绑定 ctx 中的租户, 只能在同一租户下回调;
+// 返回的 binding 由调用方交给发起登录的浏览器保存(如 HttpOnly cookie), 回调时传给 Exchange
+func (r *Registry) AuthURL(ctx context.Context, name string, userID int) (authURL, binding string, err error) {
+	p, ok := r.Get(name)
+	if !ok {
+		return "", "", ErrUnknownProvider
+	}
+	config, _, err := p.discover(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state := State{
+		Provider: name,
+		Nonce:    random(),
+		Verifier: oauth2.GenerateVerifier(),
+		TenantID: tenancy.ID(ctx),
+		UserID:   userID,
+		Binding:  random(),
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", "", err
+	}
+	id := random()
+	if err := r.redis.Set(ctx, stateKey(id), data, StateTTL).Err(); err != nil {
+		return "", "", err
+	}
+	return config.AuthCodeURL(id, gooidc.Nonce(state.Nonce), oauth2.S256ChallengeOption(state.Verifier)), state.Binding, nil
+}
+
+// Exchange 校验回调的 state 与 binding, 用授权码换取并校验 id_token
+func (r *Registry) Exchange(ctx context.Context, name, code, id, binding string) (*Claims, *State, error) {
+	p, ok := r.Get(name)
+	if !ok {
+		return nil, nil, ErrUnknownProvider
+	}
+	state, err := r.takeState(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state.Provider != name || state.TenantID != tenancy.ID(ctx) || state.Binding == "" ||
+		subtle.ConstantTimeCompare([]byte(state.Binding), []byte(binding)) != 1 {
+		return nil, nil, ErrInvalidState
+	}
+
+	config, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc: exchange code: %w", err)
+	}
+	raw, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, nil, errors.New("oidc: no id_token in token response")
+	}
+	idToken, err := verifier.Verify(ctx, raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc: verify id_token: %w", err)
+	}
+	if idToken.Nonce != state.Nonce {
+		return nil, nil, errors.New("oidc: nonce mismatch")
+	}
+
+	var claims Claims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, nil, fmt.Errorf("oidc: parse claims: %w", err)
+	}
+	return &claims, state, nil
+}
+
+// takeState 取出并删除 state, 保证只能使用一次
+func (r *Registry) takeState(ctx context.Context, id string) (*State, error) {
+	if id == "" {
+		return nil, ErrInvalidState
+	}
+	data, err := r.redis.GetDel(ctx, stateKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrInvalidState
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, ErrInvalidState
+	}
+	return &state, nil
+}
+
+func stateKey(id string) string {
+	return cache.Prefixed("oidc:state:" + id)
+}
+
+// random 32 字节随机串
+func random() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -1,38 +1,96 @@
 package cache
 
 import (
-	"os"
-	"go-api/util"
+	"context"
+	"go-api/tenancy"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
-	"github.com/go-redis/redis"
+	"github.com/go-redis/redis/v8"
 	localcache "github.com/patrickmn/go-cache"
 )
 
-// RedisClient Redis缓存客户端单例
-var RedisClient *redis.Client
-
-// Redis 在中间件中初始化redis链接
-func Redis() {
-	db, _ := strconv.ParseUint(os.Getenv("REDIS_DB"), 10, 64)
-	client := redis.NewClient(&redis.Options{
-		Addr:     os.Getenv("REDIS_ADDR"),
-		Password: os.Getenv("REDIS_PW"),
-		DB:       int(db),
-	})
+var LocalCacheClient *localcache.Cache
 
-	_, err := client.Ping().Result()
+func LocalCache() {
+	LocalCacheClient =  localcache.New(5*time.Minute, 10*time.Minute)
+}
 
+// FlushPrefix 删除以 prefix 开头的缓存, 返回删除的 redis 键数量
+// 使用 SCAN 分批遍历, 不会像 KEYS 一样阻塞 redis; 集群模式下遍历每个主节点
+func FlushPrefix(ctx context.Context, prefix string) (int64, error) {
+	var deleted int64
+	flush := func(ctx context.Context, client redis.UniversalClient) error {
+		n, err := scanDelete(ctx, client, prefix)
+		atomic.AddInt64(&deleted, n)
+		return err
+	}
+	var err error
+	if cluster, ok := RedisClient.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, c *redis.Client) error {
+			return flush(ctx, c)
+		})
+	} else {
+		err = flush(ctx, RedisClient)
+	}
 	if err != nil {
-		util.Log().Panic("连接Redis不成功", err)
+		return atomic.LoadInt64(&deleted), err
+	}
+
+	if LocalCacheClient != nil {
+		for key := range LocalCacheClient.Items() {
+			if strings.HasPrefix(key, prefix) {
+				LocalCacheClient.Delete(key)
+			}
+		}
 	}
+	return deleted, nil
+}
 
-	RedisClient = client
+// scanDelete 删除一个节点上以 prefix 开头的键
+// 逐个删除, 集群中同一节点上的键也可能不在同一个 slot
+func scanDelete(ctx context.Context, client redis.UniversalClient, prefix string) (int64, error) {
+	var (
+		cursor  uint64
+		deleted int64
+	)
+	for {
+		keys, next, err := client.Scan(ctx, cursor, prefix+"*", 500).Result()
+		if err != nil {
+			return deleted, err
+		}
+		if len(keys) > 0 {
+			cmds, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				for _, key := range keys {
+					pipe.Del(ctx, key)
+				}
+				return nil
+			})
+			for _, cmd := range cmds {
+				deleted += cmd.(*redis.IntCmd).Val()
+			}
+			if err != nil {
+				return deleted, err
+			}
+		}
+		if next == 0 {
+			return deleted, nil
+		}
+		cursor = next
+	}
 }
 
-var LocalCacheClient *localcache.Cache
+// TenantKey 租户的缓存键, 非默认租户的键加上 t:<id>: 前缀, 默认租户保持原有的键, 都带有环境前缀
+func TenantKey(tenantID int, key string) string {
+	if tenantID == 0 || tenantID == tenancy.DefaultID {
+		return Prefixed(key)
+	}
+	return Prefixed("t:" + strconv.Itoa(tenantID) + ":" + key)
+}
 
-func LocalCache() {
-	LocalCacheClient =  localcache.New(5*time.Minute, 10*time.Minute)
+// Key context 中租户的缓存键
+func Key(ctx context.Context, key string) string {
+	return TenantKey(tenancy.ID(ctx), key)
 }
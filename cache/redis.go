@@ -0,0 +1,193 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/util"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// ModeStandalone 单机
+	ModeStandalone = "standalone"
+	// ModeSentinel 哨兵, 主从切换后自动连接新的主节点
+	ModeSentinel = "sentinel"
+	// ModeCluster 集群
+	ModeCluster = "cluster"
+)
+
+// RedisClient redis 客户端单例, 缓存, 锁, session 与消息推送共用
+var RedisClient redis.UniversalClient
+
+// keyPrefix 键的环境前缀, 多个环境共用一个 redis 时互不影响
+var keyPrefix string
+
+// RedisConfig redis 连接配置
+type RedisConfig struct {
+	// Mode 连接方式, 为空时是单机
+	Mode string
+	// Addrs 单机的地址, 哨兵的地址或集群的种子节点
+	Addrs []string
+	// MasterName 哨兵模式下主节点的名称
+	MasterName string
+	Password   string
+	// SentinelPassword 哨兵本身的密码
+	SentinelPassword string
+	// DB 集群模式只能使用 0
+	DB int
+	// PoolSize 每个节点的最大连接数, 0 为 go-redis 的默认值
+	PoolSize int
+	// MinIdleConns 每个节点保持的最少空闲连接数
+	MinIdleConns int
+}
+
+// RedisConfigFromEnv 从环境变量读取配置
+// REDIS_MODE standalone, sentinel 或 cluster; REDIS_ADDR 逗号分隔的地址; REDIS_MASTER_NAME 哨兵的主节点名称
+func RedisConfigFromEnv() RedisConfig {
+	cfg := RedisConfig{
+		Mode:             os.Getenv("REDIS_MODE"),
+		MasterName:       os.Getenv("REDIS_MASTER_NAME"),
+		Password:         os.Getenv("REDIS_PW"),
+		SentinelPassword: os.Getenv("REDIS_SENTINEL_PW"),
+	}
+	for _, addr := range strings.Split(os.Getenv("REDIS_ADDR"), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			cfg.Addrs = append(cfg.Addrs, addr)
+		}
+	}
+	cfg.DB, _ = strconv.Atoi(os.Getenv("REDIS_DB"))
+	cfg.PoolSize, _ = strconv.Atoi(os.Getenv("REDIS_POOL_SIZE"))
+	cfg.MinIdleConns, _ = strconv.Atoi(os.Getenv("REDIS_MIN_IDLE_CONNS"))
+	return cfg
+}
+
+// NewRedisClient 按连接方式创建客户端, 不检查是否可以连接
+func NewRedisClient(cfg RedisConfig) (redis.UniversalClient, error) {
+	switch cfg.Mode {
+	case "", ModeStandalone:
+		addr := ""
+		if len(cfg.Addrs) > 0 {
+			addr = cfg.Addrs[0]
+		}
+		return redis.NewClient(&redis.Options{
+			Addr:         addr,
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+		}), nil
+	case ModeSentinel:
+		if cfg.MasterName == "" {
+			return nil, errors.New("哨兵模式需要 REDIS_MASTER_NAME")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.Addrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+			PoolSize:         cfg.PoolSize,
+			MinIdleConns:     cfg.MinIdleConns,
+		}), nil
+	case ModeCluster:
+		if cfg.DB != 0 {
+			return nil, fmt.Errorf("集群模式不支持 REDIS_DB=%d", cfg.DB)
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.Addrs,
+			Password:     cfg.Password,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+		}), nil
+	}
+	return nil, fmt.Errorf("未知的 REDIS_MODE %q", cfg.Mode)
+}
+
+// redisRetryDelay 启动时第一次重试的等待时间, 之后每次加倍
+var redisRetryDelay = time.Second
+
+// maxRedisRetryDelay 重试等待时间的上限
+const maxRedisRetryDelay = 30 * time.Second
+
+// pingRedis 检查连接, 失败时指数退避重试 retries 次
+func pingRedis(ctx context.Context, client redis.UniversalClient, retries int) error {
+	delay := redisRetryDelay
+	for i := 0; ; i++ {
+		err := client.Ping(ctx).Err()
+		if err == nil || i >= retries {
+			return err
+		}
+		util.Log().Warning("连接Redis失败, %v 后重试(%d/%d): %v", delay, i+1, retries, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxRedisRetryDelay {
+			delay = maxRedisRetryDelay
+		}
+	}
+}
+
+// Redis 初始化 redis 客户端, 重试 REDIS_CONNECT_RETRIES 次(默认 5 次)后仍无法连接时退出
+// REDIS_KEY_PREFIX 为全部键加上环境前缀
+func Redis() {
+	keyPrefix = os.Getenv("REDIS_KEY_PREFIX")
+	if keyPrefix != "" && !strings.HasSuffix(keyPrefix, ":") {
+		keyPrefix += ":"
+	}
+
+	client, err := NewRedisClient(RedisConfigFromEnv())
+	if err != nil {
+		util.Log().Panic("Redis 配置错误: %v", err)
+	}
+	retries, err := strconv.Atoi(os.Getenv("REDIS_CONNECT_RETRIES"))
+	if err != nil || retries < 0 {
+		retries = 5
+	}
+	if err := pingRedis(context.Background(), client, retries); err != nil {
+		client.Close()
+		util.Log().Panic("连接Redis不成功: %v", err)
+	}
+	RedisClient = client
+}
+
+// Prefixed 加上环境前缀的键, TenantKey 与 Key 返回的键已经带有前缀
+func Prefixed(key string) string {
+	return keyPrefix + key
+}
+
+// RedisStats 连接池统计, 集群模式为全部节点之和
+type RedisStats struct {
+	// Hits 从池中取到空闲连接的次数
+	Hits uint32 `json:"hits"`
+	// Misses 需要新建连接的次数
+	Misses uint32 `json:"misses"`
+	// Timeouts 等待空闲连接超时的次数, 持续增长时需要调大 REDIS_POOL_SIZE
+	Timeouts   uint32 `json:"timeouts"`
+	TotalConns uint32 `json:"total_conns"`
+	IdleConns  uint32 `json:"idle_conns"`
+	StaleConns uint32 `json:"stale_conns"`
+}
+
+// PoolStats 当前的连接池统计
+func PoolStats() RedisStats {
+	if RedisClient == nil {
+		return RedisStats{}
+	}
+	s := RedisClient.PoolStats()
+	return RedisStats{
+		Hits:       s.Hits,
+		Misses:     s.Misses,
+		Timeouts:   s.Timeouts,
+		TotalConns: s.TotalConns,
+		IdleConns:  s.IdleConns,
+		StaleConns: s.StaleConns,
+	}
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// useRedis 连接 miniredis, 测试结束时恢复全局变量
+func useRedis(t *testing.T, env map[string]string) *miniredis.Miniredis {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	t.Setenv("REDIS_ADDR", mr.Addr())
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+	client, prefix := RedisClient, keyPrefix
+	Redis()
+	t.Cleanup(func() {
+		RedisClient.Close()
+		RedisClient, keyPrefix = client, prefix
+	})
+	return mr
+}
+
+func TestRedisModes(t *testing.T) {
+	for _, mode := range []string{"", ModeStandalone, ModeCluster} {
+		useRedis(t, map[string]string{"REDIS_MODE": mode, "REDIS_KEY_PREFIX": "staging"})
+		ctx := context.Background()
+		key := TenantKey(2, "member:1")
+		if key != "staging:t:2:member:1" || Prefixed("session:1") != "staging:session:1" {
+			t.Fatalf("%q: key %s", mode, key)
+		}
+		RedisClient.Set(ctx, key, "1", 0)
+		RedisClient.Set(ctx, TenantKey(2, "member:2"), "2", 0)
+		RedisClient.Set(ctx, TenantKey(3, "member:1"), "3", 0)
+		if n, err := FlushPrefix(ctx, TenantKey(2, "member:")); err != nil || n != 2 {
+			t.Fatalf("%q: flush %d %v", mode, n, err)
+		}
+		if n := RedisClient.Exists(ctx, TenantKey(3, "member:1")).Val(); n != 1 {
+			t.Fatalf("%q: other tenant flushed", mode)
+		}
+		if s := PoolStats(); s.TotalConns == 0 {
+			t.Fatalf("%q: pool stats %+v", mode, s)
+		}
+	}
+
+	if _, err := NewRedisClient(RedisConfig{Mode: ModeSentinel}); err == nil {
+		t.Fatal("sentinel without master name")
+	}
+	if _, err := NewRedisClient(RedisConfig{Mode: ModeCluster, DB: 1}); err == nil {
+		t.Fatal("cluster with db")
+	}
+}
+
+func TestPingRetry(t *testing.T) {
+	defer func(d time.Duration) { redisRetryDelay = d }(redisRetryDelay)
+	redisRetryDelay = 20 * time.Millisecond
+
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	mr.Close()
+	client, _ := NewRedisClient(RedisConfig{Addrs: []string{addr}})
+	defer client.Close()
+
+	if err := pingRedis(context.Background(), client, 1); err == nil {
+		t.Fatal("ping closed redis")
+	}
+	// 重试期间 redis 启动
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		mr.StartAddr(addr)
+	}()
+	if err := pingRedis(context.Background(), client, 5); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"context"
+	"go-api/cache"
+	"strconv"
+)
+
+func runCacheFlush(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("cache flush")
+	tenantName := tenantFlag(fs)
+	prefix := fs.String("prefix", "", "缓存键前缀, 如 member:")
+	if err := a.parse(fs, out, args); err != nil {
+		return err
+	}
+	// 不允许空前缀, 避免误删全部数据
+	if *prefix == "" {
+		return usagef("缺少参数 --prefix")
+	}
+	ctx, err := a.withTenant(ctx, *tenantName)
+	if err != nil {
+		return err
+	}
+
+	n, err := cache.FlushPrefix(ctx, cache.Key(ctx, *prefix))
+	if err != nil {
+		return err
+	}
+	return out.print(map[string]interface{}{"prefix": *prefix, "deleted": n},
+		[]string{"PREFIX", "DELETED"}, [][]string{{*prefix, strconv.FormatInt(n, 10)}})
+}
@@ -0,0 +1,219 @@
+// Package cli 命令行入口, 子命令与 API 共用 conf 配置和 service 服务层
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"go-api/conf"
+	"go-api/ent"
+	"go-api/middleware"
+	"go-api/serializer"
+	"go-api/tenancy"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// 退出码
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+)
+
+// App 命令行程序
+type App struct {
+	// Init 初始化配置与数据库, redis 等连接, 默认为 conf.Init
+	Init   func()
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// New 使用 conf.Init 初始化, 输出到标准输出的命令行程序
+func New() *App {
+	return &App{
+		Init:   conf.Init,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+// command 子命令
+type command struct {
+	name  string
+	args  string
+	usage string
+	run   func(a *App, ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{"serve", "", "启动 API 服务(默认)", runServe},
+	{"worker", "", "启动后台任务 worker 并投递领域事件", runWorker},
+	{"migrate", "", "新增表和字段并创建默认租户, 部署时在启动服务之前执行", runMigrate},
+	{"user create", "--username NAME --nickname NAME [--password PW] [--admin]", "创建用户, 未指定密码时随机生成", runUserCreate},
+	{"user list", "[--filter EXPR] [--sort FIELD] [--limit N] [--after CURSOR]", "用户列表, 过滤与排序语法同列表接口", runUserList},
+	{"user suspend", "--id ID", "封禁用户并吊销 token", runUserStatus("user suspend", "suspend")},
+	{"user activate", "--id ID", "解封用户", runUserStatus("user activate", "active")},
+	{"user reset-password", "--id ID [--password PW]", "重置密码并吊销 token, 未指定密码时随机生成", runUserResetPassword},
+	{"token revoke", "--user ID", "吊销用户当前的 token", runTokenRevoke},
+	{"cache flush", "--prefix PREFIX", "删除指定前缀的缓存, 如 member:", runCacheFlush},
+	{"tenant create", "--name NAME [--set KEY=VALUE]...", "创建租户", runTenantCreate},
+	{"tenant config", "--name NAME [--set KEY=VALUE]... [--unset KEY]...", "修改租户配置, 如 RATE_R, RATE_B", runTenantConfig},
+	{"tenant list", "", "租户列表", runTenantList},
+}
+
+// usageError 参数错误, 以 ExitUsage 退出
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, v ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, v...)}
+}
+
+// responseError 服务层返回的错误响应
+type responseError struct {
+	res serializer.Response
+}
+
+func (e *responseError) Error() string {
+	if e.res.Error != "" {
+		return fmt.Sprintf("%s (code %d): %s", e.res.Msg, e.res.Code, e.res.Error)
+	}
+	return fmt.Sprintf("%s (code %d)", e.res.Msg, e.res.Code)
+}
+
+// check 服务层响应失败时转换为错误
+func check(res serializer.Response) error {
+	if res.Code != 0 {
+		return &responseError{res: res}
+	}
+	return nil
+}
+
+// Run 执行 args 指定的子命令, 返回退出码, 没有参数时启动 API 服务
+func (a *App) Run(args []string) int {
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		a.usage(a.Stdout)
+		return ExitOK
+	}
+
+	cmd, rest := lookup(args)
+	if cmd == nil {
+		fmt.Fprintf(a.Stderr, "未知命令: %s\n\n", strings.Join(args, " "))
+		a.usage(a.Stderr)
+		return ExitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := cmd.run(a, ctx, rest)
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(a.Stderr, "%s\n用法: go-api %s %s\n", err, cmd.name, cmd.args)
+		return ExitUsage
+	default:
+		fmt.Fprintf(a.Stderr, "错误: %s\n", err)
+		return ExitFailure
+	}
+}
+
+// lookup 按最长匹配查找子命令, 如 "user create"
+func lookup(args []string) (*command, []string) {
+	if len(args) > 1 {
+		name := args[0] + " " + args[1]
+		for i := range commands {
+			if commands[i].name == name {
+				return &commands[i], args[2:]
+			}
+		}
+	}
+	for i := range commands {
+		if commands[i].name == args[0] {
+			return &commands[i], args[1:]
+		}
+	}
+	return nil, nil
+}
+
+func (a *App) usage(w io.Writer) {
+	fmt.Fprintln(w, "用法: go-api <命令> [参数] [-o table|json] [--tenant NAME]")
+	fmt.Fprintln(w)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-20s %s\n", cmd.name, cmd.usage)
+		if cmd.args != "" {
+			fmt.Fprintf(w, "  %-20s   %s\n", "", cmd.args)
+		}
+	}
+}
+
+// flags 子命令参数, 所有子命令都支持 -o 指定输出格式
+func (a *App) flags(name string) (*flag.FlagSet, *output) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.Stderr)
+	out := &output{w: a.Stdout}
+	fs.StringVar(&out.format, "o", formatTable, "输出格式 table|json")
+	return fs, out
+}
+
+// tenantFlag 租户数据相关的子命令通过 --tenant 指定租户
+func tenantFlag(fs *flag.FlagSet) *string {
+	return fs.String("tenant", tenancy.DefaultName, "租户名称")
+}
+
+// withTenant 初始化配置, 返回指定租户的 context
+func (a *App) withTenant(ctx context.Context, name string) (context.Context, error) {
+	a.Init()
+	t, err := middleware.TenantByName(ctx, name)
+	if ent.IsNotFound(err) {
+		return nil, fmt.Errorf("租户 %s 不存在", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("租户 %s 已停用", name)
+	}
+	return tenancy.NewContext(ctx, t), nil
+}
+
+// parse 解析参数, required 为必填参数
+func (a *App) parse(fs *flag.FlagSet, out *output, args []string, required ...string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{msg: err.Error()}
+	}
+	if fs.NArg() > 0 {
+		return usagef("多余的参数: %s", strings.Join(fs.Args(), " "))
+	}
+	if out.format != formatTable && out.format != formatJSON {
+		return usagef("不支持的输出格式 %q", out.format)
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range required {
+		if !set[name] {
+			return usagef("缺少参数 --%s", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-api/cli"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/testutil"
+)
+
+// run 在测试环境中执行命令, 配置由 testutil 初始化
+func run(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	app := &cli.App{Init: func() {}, Stdout: &out, Stderr: &errOut}
+	code = app.Run(args)
+	return code, out.String(), errOut.String()
+}
+
+func login(env *testutil.Env, username, password string) serializer.Response {
+	return env.Call(http.MethodPost, "/api/v1/user/login", map[string]string{
+		"username": username,
+		"password": password,
+	}, "", nil)
+}
+
+func TestUserCreateAdmin(t *testing.T) {
+	env := testutil.New(t)
+
+	code, stdout, stderr := run("user", "create", "--username", "operator", "--nickname", "ops", "--admin", "-o", "json")
+	if code != cli.ExitOK {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	var created struct {
+		User     serializer.User `json:"user"`
+		Password string          `json:"password"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &created); err != nil {
+		t.Fatalf("%v: %s", err, stdout)
+	}
+	if created.User.Role != model.RoleAdmin || created.Password == "" {
+		t.Fatalf("unexpected output %s", stdout)
+	}
+
+	// 生成的密码可以登录, token 带有管理员角色
+	var token serializer.UserToken
+	res := env.Call(http.MethodPost, "/api/v1/user/login", map[string]string{
+		"username": "operator",
+		"password": created.Password,
+	}, "", &token)
+	if res.Code != 0 {
+		t.Fatalf("login: %+v", res)
+	}
+	member := env.User().Create()
+	res = env.Call(http.MethodPut, "/api/v1/admin/users/"+strconv.Itoa(member.ID)+"/status",
+		map[string]string{"status": model.Suspend}, token.Token, nil)
+	if res.Code != 0 {
+		t.Fatalf("admin request: %+v", res)
+	}
+
+	// 用户名重复
+	if code, _, stderr := run("user", "create", "--username", "operator", "--nickname", "ops2", "--password", "secret1"); code != cli.ExitFailure {
+		t.Fatalf("duplicate create exit %d: %s", code, stderr)
+	}
+}
+
+func TestUserListTable(t *testing.T) {
+	env := testutil.New(t)
+	env.User().Create()
+	env.User().Status(model.Suspend).Create()
+
+	code, stdout, stderr := run("user", "list", "--filter", "status:eq:suspend")
+	if code != cli.ExitOK {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "user0002") {
+		t.Fatalf("unexpected table:\n%s", stdout)
+	}
+
+	if code, _, _ := run("user", "list", "--filter", "password_digest:eq:x"); code != cli.ExitFailure {
+		t.Fatalf("invalid filter exit %d", code)
+	}
+}
+
+func TestSuspendAndResetPassword(t *testing.T) {
+	env := testutil.New(t)
+	member := env.User().Create()
+	id := strconv.Itoa(member.ID)
+	token := env.Token(member)
+
+	if code, _, stderr := run("user", "reset-password", "--id", id, "--password", "changed"); code != cli.ExitOK {
+		t.Fatalf("reset exit %d: %s", code, stderr)
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, token, nil); res.Code == 0 {
+		t.Fatal("token still valid after password reset")
+	}
+	if res := login(env, member.Username, testutil.Password); res.Code == 0 {
+		t.Fatal("old password still valid")
+	}
+	if res := login(env, member.Username, "changed"); res.Code != 0 {
+		t.Fatalf("login with new password: %+v", res)
+	}
+
+	if code, _, stderr := run("user", "suspend", "--id", id); code != cli.ExitOK {
+		t.Fatalf("suspend exit %d: %s", code, stderr)
+	}
+	if u, _ := env.DB.User.Get(env.Context(), member.ID); u.Status != model.Suspend {
+		t.Fatalf("status = %s", u.Status)
+	}
+
+	if code, _, _ := run("user", "suspend", "--id", "9999"); code != cli.ExitFailure {
+		t.Fatalf("missing user exit %d", code)
+	}
+}
+
+func TestTokenRevokeAndCacheFlush(t *testing.T) {
+	env := testutil.New(t)
+	member := env.User().Create()
+	token := env.Token(member)
+	env.Redis.Set("member:1", "{}")
+	env.Redis.Set("member:2", "{}")
+	env.Redis.Set("other", "x")
+
+	if code, _, stderr := run("token", "revoke", "--user", strconv.Itoa(member.ID)); code != cli.ExitOK {
+		t.Fatalf("revoke exit %d: %s", code, stderr)
+	}
+	if res := env.Call(http.MethodGet, "/api/v1/user/me", nil, token, nil); res.Code == 0 {
+		t.Fatal("token still valid after revoke")
+	}
+
+	code, stdout, stderr := run("cache", "flush", "--prefix", "member:", "-o", "json")
+	if code != cli.ExitOK {
+		t.Fatalf("flush exit %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, `"deleted": 2`) || env.Redis.Exists("member:1") || !env.Redis.Exists("other") {
+		t.Fatalf("unexpected flush result %s", stdout)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"nope"},
+		{"user", "suspend"},
+		{"cache", "flush"},
+		{"token", "revoke", "--user", "1", "-o", "yaml"},
+		{"user", "create", "--username", "ab", "--nickname", "n"},
+	} {
+		if code, _, _ := run(args...); code != cli.ExitUsage {
+			t.Errorf("%v: exit %d, want %d", args, code, cli.ExitUsage)
+		}
+	}
+}
+
+func TestTenantCommands(t *testing.T) {
+	env := testutil.New(t)
+
+	if code, _, stderr := run("tenant", "create", "--name", "acme", "--set", "RATE_R=5"); code != cli.ExitOK {
+		t.Fatalf("tenant create exit %d: %s", code, stderr)
+	}
+	code, stdout, stderr := run("tenant", "config", "--name", "acme", "--set", "RATE_B=9", "--unset", "RATE_R", "-o", "json")
+	if code != cli.ExitOK || !strings.Contains(stdout, `"RATE_B": "9"`) || strings.Contains(stdout, "RATE_R") {
+		t.Fatalf("tenant config exit %d: %s %s", code, stdout, stderr)
+	}
+
+	// 不同租户可以使用相同的用户名
+	for _, tenant := range []string{"default", "acme"} {
+		if code, _, stderr := run("user", "create", "--tenant", tenant, "--username", "operator", "--nickname", "ops", "--password", "secret1"); code != cli.ExitOK {
+			t.Fatalf("create in %s exit %d: %s", tenant, code, stderr)
+		}
+	}
+	if n := env.DB.User.Query().CountX(env.Context()); n != 1 {
+		t.Fatalf("default tenant users = %d", n)
+	}
+	if code, _, _ := run("user", "list", "--tenant", "missing"); code != cli.ExitFailure {
+		t.Fatalf("unknown tenant exit %d", code)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	testutil.New(t)
+	code, stdout, stderr := run("migrate", "-o", "json")
+	if code != cli.ExitOK || !strings.Contains(stdout, `"migrated"`) {
+		t.Fatalf("migrate exit %d: %s %s", code, stdout, stderr)
+	}
+	// 重复执行不会出错
+	if code, _, stderr := run("migrate"); code != cli.ExitOK {
+		t.Fatalf("migrate again exit %d: %s", code, stderr)
+	}
+}
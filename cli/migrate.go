@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"context"
+	"go-api/model"
+)
+
+// runMigrate 新增表和字段并创建默认租户, 部署时在启动服务之前执行一次
+func runMigrate(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("migrate")
+	if err := a.parse(fs, out, args); err != nil {
+		return err
+	}
+	a.Init()
+	if err := model.Migrate(ctx); err != nil {
+		return err
+	}
+	return out.print(map[string]string{"status": "migrated"}, []string{"STATUS"}, [][]string{{"migrated"}})
+}
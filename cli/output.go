@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// 输出格式
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// output 按格式输出命令结果
+type output struct {
+	format string
+	w      io.Writer
+}
+
+// print json 格式输出 v, table 格式输出 header 与 rows
+func (o *output) print(v interface{}, header []string, rows [][]string) error {
+	if o.format == formatJSON {
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+	writeRow(tw, header)
+	for _, row := range rows {
+		writeRow(tw, row)
+	}
+	return tw.Flush()
+}
+
+func writeRow(w io.Writer, cols []string) {
+	for i, col := range cols {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		fmt.Fprint(w, col)
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"go-api/cache"
+	"go-api/dbresolver"
+	"go-api/jobs"
+	"go-api/model"
+	"go-api/ops"
+	"go-api/outbox"
+	"go-api/server"
+	"go-api/service"
+)
+
+// runServe 启动 API 服务
+func runServe(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("serve")
+	addr := fs.String("addr", ":3000", "监听地址")
+	if err := a.parse(fs, out, args); err != nil {
+		return err
+	}
+	a.Init()
+	// pprof 等挂在单独的运维端口上, 不能从 API 端口访问
+	admin, err := ops.Start(ops.ConfigFromEnv())
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	// 装载路由
+	r := server.NewRouter()
+	return r.Run(*addr)
+}
+
+// runWorker worker 模式只处理后台任务和投递领域事件
+func runWorker(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("worker")
+	if err := a.parse(fs, out, args); err != nil {
+		return err
+	}
+	a.Init()
+	admin, err := ops.Start(ops.ConfigFromEnv())
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	broker, err := outbox.NewBrokerFromEnv()
+	if err != nil {
+		return err
+	}
+	// 事件同时投递给消息中间件与 webhook
+	broker = outbox.Fanout(broker, service.WebhookDispatcher())
+	defer broker.Close()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// relay 需要读到最新的发布状态, 使用主库
+	go outbox.NewRelay(model.Client, broker, cache.RedisClient).Run(dbresolver.Primary(ctx))
+
+	return jobs.Run()
+}
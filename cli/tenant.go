@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"go-api/serializer"
+	"go-api/service"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// configFlag 可重复的 KEY=VALUE 参数
+type configFlag map[string]string
+
+func (f configFlag) String() string {
+	return ""
+}
+
+func (f configFlag) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("格式应为 KEY=VALUE: %s", s)
+	}
+	f[k] = v
+	return nil
+}
+
+// stringsFlag 可重复的字符串参数
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+var tenantHeader = []string{"ID", "NAME", "STATUS", "CONFIG", "CREATED_AT"}
+
+func tenantRow(t serializer.Tenant) []string {
+	keys := make([]string, 0, len(t.Config))
+	for k := range t.Config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	config := make([]string, 0, len(keys))
+	for _, k := range keys {
+		config = append(config, k+"="+t.Config[k])
+	}
+	return []string{
+		strconv.Itoa(t.ID),
+		t.Name,
+		t.Status,
+		strings.Join(config, ","),
+		time.Unix(t.CreatedAt, 0).Format(time.RFC3339),
+	}
+}
+
+func printTenant(out *output, res serializer.Response) error {
+	t := res.Data.(serializer.Tenant)
+	return out.print(t, tenantHeader, [][]string{tenantRow(t)})
+}
+
+func runTenantCreate(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("tenant create")
+	svc := service.TenantCreateService{Config: configFlag{}}
+	fs.StringVar(&svc.Name, "name", "", "租户名称, 同时作为子域名")
+	fs.Var(configFlag(svc.Config), "set", "租户配置 KEY=VALUE, 覆盖同名环境变量, 可重复")
+	if err := a.parse(fs, out, args, "name"); err != nil {
+		return err
+	}
+	a.Init()
+
+	res := svc.Create(ctx)
+	if err := check(res); err != nil {
+		return err
+	}
+	return printTenant(out, res)
+}
+
+func runTenantConfig(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("tenant config")
+	name := fs.String("name", "", "租户名称")
+	svc := service.TenantConfigService{Set: configFlag{}}
+	fs.Var(configFlag(svc.Set), "set", "新增或覆盖配置 KEY=VALUE, 可重复")
+	fs.Var((*stringsFlag)(&svc.Unset), "unset", "删除配置 KEY, 可重复")
+	if err := a.parse(fs, out, args, "name"); err != nil {
+		return err
+	}
+	a.Init()
+
+	res := svc.Update(ctx, *name)
+	if err := check(res); err != nil {
+		return err
+	}
+	return printTenant(out, res)
+}
+
+func runTenantList(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("tenant list")
+	if err := a.parse(fs, out, args); err != nil {
+		return err
+	}
+	a.Init()
+
+	res := service.ListTenants(ctx)
+	if err := check(res); err != nil {
+		return err
+	}
+	tenants := res.Data.([]serializer.Tenant)
+	rows := make([][]string, 0, len(tenants))
+	for _, t := range tenants {
+		rows = append(rows, tenantRow(t))
+	}
+	return out.print(tenants, tenantHeader, rows)
+}
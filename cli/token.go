@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"context"
+	"go-api/service"
+	"strconv"
+)
+
+func runTokenRevoke(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("token revoke")
+	tenantName := tenantFlag(fs)
+	id := fs.Int("user", 0, "用户 id")
+	if err := a.parse(fs, out, args, "user"); err != nil {
+		return err
+	}
+	ctx, err := a.withTenant(ctx, *tenantName)
+	if err != nil {
+		return err
+	}
+
+	if err := service.RevokeToken(ctx, *id); err != nil {
+		return err
+	}
+	return out.print(map[string]int{"revoked": *id}, []string{"REVOKED"}, [][]string{{strconv.Itoa(*id)}})
+}
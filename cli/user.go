@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/service"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// userResult 用户操作结果, 随机生成的密码只在这里输出一次
+type userResult struct {
+	User     serializer.User `json:"user"`
+	Password string          `json:"password,omitempty"`
+}
+
+var userHeader = []string{"ID", "USERNAME", "NICKNAME", "STATUS", "ROLE", "CREATED_AT"}
+
+func userRow(u serializer.User) []string {
+	return []string{
+		strconv.Itoa(int(u.ID)),
+		u.Username,
+		u.Nickname,
+		u.Status,
+		u.Role,
+		time.Unix(u.CreatedAt, 0).Format(time.RFC3339),
+	}
+}
+
+// printUser 输出单个用户, password 不为空时一并输出
+func printUser(out *output, res serializer.Response, password string) error {
+	u := res.Data.(serializer.User)
+	header, row := userHeader, userRow(u)
+	if password != "" {
+		header = append(append([]string{}, header...), "PASSWORD")
+		row = append(row, password)
+	}
+	return out.print(userResult{User: u, Password: password}, header, [][]string{row})
+}
+
+// generatePassword 随机生成 16 位密码
+func generatePassword() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func runUserCreate(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("user create")
+	tenantName := tenantFlag(fs)
+	svc := service.UserRegisterService{}
+	fs.StringVar(&svc.Username, "username", "", "用户名")
+	fs.StringVar(&svc.Nickname, "nickname", "", "昵称")
+	fs.StringVar(&svc.Password, "password", "", "密码, 为空时随机生成")
+	admin := fs.Bool("admin", false, "创建管理员")
+	if err := a.parse(fs, out, args); err != nil {
+		return err
+	}
+
+	var generated string
+	if svc.Password == "" {
+		p, err := generatePassword()
+		if err != nil {
+			return err
+		}
+		svc.Password, generated = p, p
+	}
+	svc.PasswordConfirm = svc.Password
+	if *admin {
+		svc.Role = model.RoleAdmin
+	}
+	// 与接口使用相同的参数校验规则
+	if err := binding.Validator.ValidateStruct(&svc); err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	ctx, err := a.withTenant(ctx, *tenantName)
+	if err != nil {
+		return err
+	}
+
+	res := svc.Register(ctx)
+	if err := check(res); err != nil {
+		return err
+	}
+	return printUser(out, res, generated)
+}
+
+func runUserList(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("user list")
+	tenantName := tenantFlag(fs)
+	filter := fs.String("filter", "", "过滤条件, 如 status:eq:active,role:eq:admin")
+	sort := fs.String("sort", "", "排序字段, - 前缀表示倒序, 如 -created_at")
+	limit := fs.Int("limit", 100, "返回数量")
+	after := fs.String("after", "", "上一页返回的游标")
+	if err := a.parse(fs, out, args); err != nil {
+		return err
+	}
+
+	values := url.Values{}
+	if *filter != "" {
+		values.Set("filter", *filter)
+	}
+	if *sort != "" {
+		values.Set("sort", *sort)
+	}
+	if *after != "" {
+		values.Set("page[after]", *after)
+	}
+	values.Set("page[size]", strconv.Itoa(*limit))
+	ctx, err := a.withTenant(ctx, *tenantName)
+	if err != nil {
+		return err
+	}
+
+	res := service.ListUsers(ctx, values)
+	if err := check(res.Response); err != nil {
+		return err
+	}
+	users := res.Data.([]serializer.User)
+	rows := make([][]string, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, userRow(u))
+	}
+	if err := out.print(res, userHeader, rows); err != nil {
+		return err
+	}
+	if out.format == formatTable && res.Meta.HasMore {
+		fmt.Fprintf(a.Stderr, "还有更多, 下一页: --after %s\n", res.Meta.NextCursor)
+	}
+	return nil
+}
+
+// runUserStatus 修改用户状态, 非激活状态的用户 token 随即失效
+func runUserStatus(name, status string) func(a *App, ctx context.Context, args []string) error {
+	return func(a *App, ctx context.Context, args []string) error {
+		fs, out := a.flags(name)
+		tenantName := tenantFlag(fs)
+		id := fs.Int("id", 0, "用户 id")
+		if err := a.parse(fs, out, args, "id"); err != nil {
+			return err
+		}
+		ctx, err := a.withTenant(ctx, *tenantName)
+		if err != nil {
+			return err
+		}
+
+		svc := service.UserStatusService{Status: status}
+		res := svc.Change(ctx, *id)
+		if err := check(res); err != nil {
+			return err
+		}
+		return printUser(out, res, "")
+	}
+}
+
+func runUserResetPassword(a *App, ctx context.Context, args []string) error {
+	fs, out := a.flags("user reset-password")
+	tenantName := tenantFlag(fs)
+	id := fs.Int("id", 0, "用户 id")
+	svc := service.UserPasswordResetService{}
+	fs.StringVar(&svc.Password, "password", "", "新密码, 为空时随机生成")
+	if err := a.parse(fs, out, args, "id"); err != nil {
+		return err
+	}
+
+	var generated string
+	if svc.Password == "" {
+		p, err := generatePassword()
+		if err != nil {
+			return err
+		}
+		svc.Password, generated = p, p
+	}
+	if err := binding.Validator.ValidateStruct(&svc); err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	ctx, err := a.withTenant(ctx, *tenantName)
+	if err != nil {
+		return err
+	}
+
+	res := svc.Reset(ctx, *id)
+	if err := check(res); err != nil {
+		return err
+	}
+	return printUser(out, res, generated)
+}
@@ -0,0 +1,269 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client go-api 的 HTTP 客户端, 可以在多个协程中使用
+type Client struct {
+	baseURL    string
+	http       *http.Client
+	maxRetries int
+	backoff    time.Duration
+	onToken    func(token string)
+
+	mu    sync.Mutex
+	token string
+	// refreshing 正在进行的刷新, 并发请求共用同一次刷新
+	refreshing chan struct{}
+	refreshErr error
+}
+
+// Option 客户端选项
+type Option func(*Client)
+
+// WithHTTPClient 使用自定义的 http.Client
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithToken 使用已有的 token
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetry 幂等请求的最大重试次数与初始退避间隔, 默认 3 次, 100ms
+func WithRetry(max int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = max
+		c.backoff = backoff
+	}
+}
+
+// WithTokenHook 登录或自动刷新得到新 token 时回调, 用于持久化
+func WithTokenHook(fn func(token string)) Option {
+	return func(c *Client) {
+		c.onToken = fn
+	}
+}
+
+// New 创建客户端, baseURL 如 http://localhost:3000
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		http:       &http.Client{Timeout: 30 * time.Second},
+		maxRetries: 3,
+		backoff:    100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token 当前使用的 token
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// SetToken 替换当前使用的 token
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+	if c.onToken != nil {
+		c.onToken(token)
+	}
+}
+
+// request 一次接口调用
+type request struct {
+	method string
+	path   string
+	body   interface{}
+	auth   bool
+	// idempotencyKey 非空时 POST 请求也可以安全重试
+	idempotencyKey string
+	// noRetry 不能重复执行的 PUT/DELETE, 如刷新 token 与登出后当前 token 立即失效, 不能重试
+	noRetry bool
+}
+
+// envelope 对应 serializer.Response
+type envelope struct {
+	Code  int             `json:"code"`
+	Data  json.RawMessage `json:"data"`
+	Msg   string          `json:"msg"`
+	Error string          `json:"error"`
+}
+
+// do 发送请求并把 data 解析到 out
+// token 过期时自动刷新一次后重放请求
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	token := c.Token()
+	err := c.send(ctx, req, token, out)
+	if !req.auth || !IsCode(err, CodeTokenError) || token == "" {
+		return err
+	}
+	if err := c.refresh(ctx, token); err != nil {
+		return err
+	}
+	return c.send(ctx, req, c.Token(), out)
+}
+
+// send 发送请求, 幂等请求在网络错误, 5xx 与限流时按指数退避重试
+func (c *Client) send(ctx context.Context, req request, token string, out interface{}) error {
+	var payload []byte
+	if req.body != nil {
+		var err error
+		if payload, err = json.Marshal(req.body); err != nil {
+			return err
+		}
+	}
+
+	retries := 0
+	if idempotent(req) {
+		retries = c.maxRetries
+	}
+	for attempt := 0; ; attempt++ {
+		err := c.roundTrip(ctx, req, token, payload, out)
+		if err == nil || attempt >= retries || !retryable(err) {
+			return err
+		}
+		delay := c.backoff * time.Duration(1<<attempt)
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay)/2+1))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *Client) roundTrip(ctx context.Context, req request, token string, payload []byte, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, c.baseURL+req.path, body)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if payload != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if req.auth && token != "" {
+		httpReq.Header.Set("token", token)
+	}
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", req.idempotencyKey)
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return &Error{Status: resp.StatusCode, Msg: fmt.Sprintf("unexpected response: %s", truncate(raw))}
+	}
+	if env.Code != 0 {
+		return &Error{Status: resp.StatusCode, Code: env.Code, Msg: env.Msg, Detail: env.Error}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return &Error{Status: resp.StatusCode, Msg: env.Msg}
+	}
+	if out != nil && len(env.Data) > 0 {
+		return json.Unmarshal(env.Data, out)
+	}
+	return nil
+}
+
+// refresh 用过期的 token 换取新 token, 并发调用只刷新一次
+func (c *Client) refresh(ctx context.Context, expired string) error {
+	c.mu.Lock()
+	if c.token != expired {
+		// 其他请求已经刷新过
+		c.mu.Unlock()
+		return nil
+	}
+	if wait := c.refreshing; wait != nil {
+		c.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.refreshErr
+	}
+	done := make(chan struct{})
+	c.refreshing = done
+	c.mu.Unlock()
+
+	_, err := c.RefreshToken(ctx)
+
+	c.mu.Lock()
+	c.refreshing = nil
+	c.refreshErr = err
+	c.mu.Unlock()
+	close(done)
+	return err
+}
+
+// idempotent GET/PUT/DELETE 与携带幂等键的 POST 可以重试
+func idempotent(req request) bool {
+	if req.noRetry {
+		return false
+	}
+	switch req.method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.idempotencyKey != ""
+}
+
+// retryable 网络错误, 服务端错误与限流可以重试, 业务错误直接返回
+func retryable(err error) bool {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Status >= http.StatusInternalServerError || e.Code == CodeOverClock
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+func truncate(b []byte) string {
+	if len(b) > 200 {
+		return string(b[:200]) + "..."
+	}
+	return string(b)
+}
+
+func newIdempotencyKey() string {
+	return uuid.NewString()
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-api/testutil"
+)
+
+func register(t *testing.T, c *Client, username string) *User {
+	t.Helper()
+	u, err := c.Register(context.Background(), RegisterRequest{
+		Nickname:        username,
+		Username:        username,
+		Password:        "password",
+		PasswordConfirm: "password",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestUserFlow(t *testing.T) {
+	srv := testutil.New(t).Server
+	ctx := context.Background()
+	c := New(srv.URL)
+
+	u := register(t, c, "alice01")
+	if u.ID == 0 || u.Username != "alice01" {
+		t.Fatalf("unexpected user %+v", u)
+	}
+
+	if _, err := c.Login(ctx, "alice01", "wrong-password"); !IsCode(err, CodeParamErr) {
+		t.Fatalf("expected param error, got %v", err)
+	}
+	token, err := c.Login(ctx, "alice01", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Token() != token.Token || token.ID != u.ID {
+		t.Fatalf("unexpected token %+v", token)
+	}
+
+	me, err := c.Me(ctx)
+	if err != nil || me.ID != u.ID {
+		t.Fatalf("me: %+v %v", me, err)
+	}
+
+	refreshed, err := c.RefreshToken(ctx)
+	if err != nil || refreshed == token.Token {
+		t.Fatalf("refresh: %q %v", refreshed, err)
+	}
+	// 旧 token 刷新后失效
+	if _, err := New(srv.URL, WithToken(token.Token)).Me(ctx); err == nil {
+		t.Fatal("old token still valid after refresh")
+	}
+	if _, err := c.Me(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Logout(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(srv.URL, WithToken(refreshed)).Me(ctx); err == nil {
+		t.Fatal("token still valid after logout")
+	}
+}
+
+func TestAutoRefreshExpiredToken(t *testing.T) {
+	env := testutil.New(t)
+	ctx := context.Background()
+	u := env.User().Create()
+	expired := env.Token(u)
+	// 过期但仍在宽限期内
+	env.Clock.Advance(2 * time.Hour)
+
+	var saved atomic.Value
+	c := New(env.Server.URL, WithToken(expired), WithTokenHook(func(token string) { saved.Store(token) }))
+
+	// 并发请求只刷新一次, 否则后刷新的会因旧 token 已失效而失败
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			me, err := c.Me(ctx)
+			if err == nil && int(me.ID) != u.ID {
+				t.Errorf("unexpected user %+v", me)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if c.Token() == expired || saved.Load() != c.Token() {
+		t.Fatalf("token not refreshed: %q", c.Token())
+	}
+}
+
+func TestRetryIdempotentCalls(t *testing.T) {
+	var gets, posts, logouts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/user/me":
+			if atomic.AddInt32(&gets, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"code":50001,"msg":"unavailable"}`))
+				return
+			}
+			w.Write([]byte(`{"code":0,"msg":"","data":{"id":7,"username":"carol"}}`))
+		case "/api/v1/user/login":
+			atomic.AddInt32(&posts, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":50001,"msg":"unavailable"}`))
+		case "/api/v1/user/logout":
+			atomic.AddInt32(&logouts, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":50001,"msg":"unavailable"}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := New(srv.URL, WithToken("t"), WithRetry(3, time.Millisecond))
+	me, err := c.Me(ctx)
+	if err != nil || me.ID != 7 || gets != 3 {
+		t.Fatalf("me: %+v %v after %d attempts", me, err, gets)
+	}
+
+	_, err = c.Login(ctx, "carol", "password")
+	if e, ok := err.(*Error); !ok || e.Status != http.StatusServiceUnavailable || posts != 1 {
+		t.Fatalf("login: %v after %d attempts", err, posts)
+	}
+
+	// 登出可能已经在服务端生效, 不重试
+	if err := c.Logout(ctx); err == nil || logouts != 1 {
+		t.Fatalf("logout: %v after %d attempts", err, logouts)
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import "fmt"
+
+// 与 serializer 中的错误码一致, SDK 不依赖服务端的包
+const (
+	CodeCheckLogin         = 401
+	CodeNoRightErr         = 403
+	CodeNotFound           = 404
+	CodePreconditionFailed = 412
+	CodeParamErr           = 40001
+	CodeTokenError         = 40002
+	CodeOverClock          = 40003
+)
+
+// User 用户
+type User struct {
+	ID        uint   `json:"id"`
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	Status    string `json:"status"`
+	Avatar    string `json:"avatar"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserToken 登录返回的用户与 token
+type UserToken struct {
+	User
+	Token     string `json:"token"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// RegisterRequest 注册参数
+type RegisterRequest struct {
+	Nickname        string `json:"nickname"`
+	Username        string `json:"username"`
+	Password        string `json:"password"`
+	PasswordConfirm string `json:"password_confirm"`
+}
+
+// Error 接口返回的错误, Code 为 serializer 中的错误码
+type Error struct {
+	// Status HTTP 状态码, 大部分业务错误仍为 200
+	Status int
+	Code   int
+	Msg    string
+	// Detail 非 release 模式下服务端返回的底层错误
+	Detail string
+}
+
+func (e *Error) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("go-api: %d %s: %s", e.Code, e.Msg, e.Detail)
+	}
+	return fmt.Sprintf("go-api: %d %s", e.Code, e.Msg)
+}
+
+// IsCode 判断 err 是否为指定错误码的接口错误
+func IsCode(err error, code int) bool {
+	e, ok := err.(*Error)
+	return ok && e.Code == code
+}
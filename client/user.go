@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Register 注册用户, 携带幂等键, 网络错误时重试不会重复注册
+func (c *Client) Register(ctx context.Context, req RegisterRequest) (*User, error) {
+	var u User
+	err := c.do(ctx, request{
+		method:         http.MethodPost,
+		path:           "/api/v1/user/register",
+		body:           req,
+		idempotencyKey: newIdempotencyKey(),
+	}, &u)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// Login 登录, 成功后后续请求自动携带 token
+func (c *Client) Login(ctx context.Context, username, password string) (*UserToken, error) {
+	var t UserToken
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/api/v1/user/login",
+		body:   map[string]string{"username": username, "password": password},
+	}, &t)
+	if err != nil {
+		return nil, err
+	}
+	c.SetToken(t.Token)
+	return &t, nil
+}
+
+// Me 当前登录用户
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var u User
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/user/me", auth: true}, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// RefreshToken 换取新 token 并替换当前 token, 旧 token 随即失效
+func (c *Client) RefreshToken(ctx context.Context) (string, error) {
+	var token string
+	err := c.send(ctx, request{method: http.MethodPut, path: "/api/v1/user/token/refresh", auth: true, noRetry: true}, c.Token(), &token)
+	if err != nil {
+		return "", err
+	}
+	c.SetToken(token)
+	return token, nil
+}
+
+// Logout 登出, 当前 token 失效; 第一次请求可能已经生效, 重试只会得到 token 已失效, 所以不重试
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.do(ctx, request{method: http.MethodDelete, path: "/api/v1/user/logout", auth: true, noRetry: true}, nil); err != nil {
+		return err
+	}
+	c.SetToken("")
+	return nil
+}
@@ -1,8 +1,14 @@
 package conf
 
 import (
+	"go-api/auth/oidc"
 	"go-api/cache"
+	"go-api/flags"
+	"go-api/jobs"
 	"go-api/model"
+	"go-api/ops"
+	"go-api/realtime"
+	"go-api/storage"
 	"go-api/util"
 	"io"
 	"os"
@@ -20,6 +26,9 @@ func Init() {
 	// 设置日志级别
 	util.BuildLogger(os.Getenv("LOG_LEVEL"))
 
+	// 内存软上限
+	ops.ApplyMemoryLimit()
+
 	// 读取翻译文件
 	if err := LoadLocales("conf/locales/zh-cn.yaml"); err != nil {
 		util.Log().Panic("翻译文件加载失败", err)
@@ -46,4 +55,19 @@ func Init() {
 	model.DatabaseEnt(os.Getenv("MYSQL_DSN"))
 	cache.Redis()
 	cache.LocalCache()
+
+	// 后台任务队列
+	jobs.Init()
+
+	// 实时推送
+	realtime.Init()
+
+	// 功能开关
+	flags.Init(cache.RedisClient)
+
+	// 第三方登录
+	oidc.Init(cache.RedisClient)
+
+	// 对象存储
+	storage.Init()
 }
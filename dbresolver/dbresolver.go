@@ -0,0 +1,259 @@
+// Package dbresolver ent 的读写分离驱动
+//
+// 写入与事务使用主库, 查询轮流分配到健康的从库, 没有健康的从库时回退到主库;
+// context 带有会话(WithSession)时, 会话中发生写入后的查询都使用主库, 保证读到自己的写入
+package dbresolver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"go-api/util"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/facebook/ent/dialect"
+	entsql "github.com/facebook/ent/dialect/sql"
+)
+
+// pingTimeout 健康检查的超时时间
+const pingTimeout = 2 * time.Second
+
+// Config 驱动配置
+type Config struct {
+	// QueryTimeout 单条语句的超时时间, 请求的 context 先结束时以请求为准, 0 表示不限制
+	QueryTimeout time.Duration
+	// CheckInterval 从库健康检查间隔, 0 表示不检查
+	CheckInterval time.Duration
+}
+
+// replica 从库及其健康状态
+type replica struct {
+	*entsql.Driver
+	healthy atomic.Bool
+}
+
+// Driver 读写分离的 dialect.Driver
+type Driver struct {
+	primary  *entsql.Driver
+	replicas []*replica
+	next     atomic.Uint32
+	cfg      Config
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+var _ dialect.Driver = (*Driver)(nil)
+
+// New 创建驱动, 从库初始视为健康, CheckInterval 大于 0 时在后台定期检查
+func New(primary *entsql.Driver, replicas []*entsql.Driver, cfg Config) *Driver {
+	d := &Driver{primary: primary, cfg: cfg, done: make(chan struct{})}
+	for _, r := range replicas {
+		rep := &replica{Driver: r}
+		rep.healthy.Store(true)
+		d.replicas = append(d.replicas, rep)
+	}
+	if len(d.replicas) > 0 && cfg.CheckInterval > 0 {
+		d.wg.Add(1)
+		go d.healthCheck()
+	}
+	return d
+}
+
+type sessionKey struct{}
+
+type primaryKey struct{}
+
+// session 记录会话中是否发生过写入
+type session struct {
+	wrote atomic.Bool
+}
+
+// WithSession 返回带有会话的 context, 会话中写入之后的查询都使用主库
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+// Primary 返回只使用主库的 context, 用于不能容忍复制延迟的查询
+func Primary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// markWrite 记录会话中发生了写入
+func markWrite(ctx context.Context) {
+	if s, ok := ctx.Value(sessionKey{}).(*session); ok {
+		s.wrote.Store(true)
+	}
+}
+
+// usePrimary 查询是否需要使用主库
+func usePrimary(ctx context.Context) bool {
+	if p, _ := ctx.Value(primaryKey{}).(bool); p {
+		return true
+	}
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	return ok && s.wrote.Load()
+}
+
+// replica 轮流选择健康的从库, 都不健康时返回 nil
+func (d *Driver) replica() *replica {
+	n := len(d.replicas)
+	start := int(d.next.Add(1))
+	for i := 0; i < n; i++ {
+		r := d.replicas[(start+i)%n]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// timeout 为语句加上超时
+func (d *Driver) timeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.cfg.QueryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d.cfg.QueryTimeout)
+}
+
+// Exec 在主库执行写入
+func (d *Driver) Exec(ctx context.Context, query string, args, v interface{}) error {
+	markWrite(ctx)
+	ctx, cancel := d.timeout(ctx)
+	defer cancel()
+	return d.primary.Exec(ctx, query, args, v)
+}
+
+// Query 在从库执行查询, 从库连接失败时标记为不健康并改用主库
+func (d *Driver) Query(ctx context.Context, query string, args, v interface{}) error {
+	// 返回的 rows 由调用方读取, 不能在这里取消, 超时或请求结束时自动释放
+	ctx, cancel := d.timeout(ctx)
+	_ = cancel
+	if !usePrimary(ctx) {
+		if r := d.replica(); r != nil {
+			err := r.Query(ctx, query, args, v)
+			if err == nil || !connError(err) || ctx.Err() != nil {
+				return err
+			}
+			r.healthy.Store(false)
+			util.Log().Warning("从库查询失败, 改用主库: %v", err)
+		}
+	}
+	return d.primary.Query(ctx, query, args, v)
+}
+
+// connError 是否为连接错误, 语句本身的错误在主库上也会失败
+func connError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netErr)
+}
+
+// Tx 在主库开始事务
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	markWrite(ctx)
+	t, err := d.primary.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tx{Tx: t, d: d}, nil
+}
+
+// Close 停止健康检查并关闭全部连接
+func (d *Driver) Close() error {
+	close(d.done)
+	d.wg.Wait()
+	err := d.primary.Close()
+	for _, r := range d.replicas {
+		if cerr := r.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Dialect 主库的方言
+func (d *Driver) Dialect() string {
+	return d.primary.Dialect()
+}
+
+// NodeStats 一个数据库节点的连接池统计
+type NodeStats struct {
+	// Node primary 或 replica<序号>
+	Node    string `json:"node"`
+	Healthy bool   `json:"healthy"`
+	sql.DBStats
+}
+
+// Stats 主库与从库的连接池统计, 从库的健康状态为最近一次检查的结果
+func (d *Driver) Stats() []NodeStats {
+	stats := []NodeStats{{Node: "primary", Healthy: true, DBStats: d.primary.DB().Stats()}}
+	for i, r := range d.replicas {
+		stats = append(stats, NodeStats{
+			Node:    "replica" + strconv.Itoa(i),
+			Healthy: r.healthy.Load(),
+			DBStats: r.DB().Stats(),
+		})
+	}
+	return stats
+}
+
+// Ping 检查主库是否可用
+func (d *Driver) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	return d.primary.DB().PingContext(ctx)
+}
+
+// healthCheck 定期 ping 从库, 恢复后重新参与查询
+func (d *Driver) healthCheck() {
+	defer d.wg.Done()
+	ticker := time.NewTicker(d.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		d.check()
+		select {
+		case <-ticker.C:
+		case <-d.done:
+			return
+		}
+	}
+}
+
+// check 检查一次全部从库
+func (d *Driver) check() {
+	for i, r := range d.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+		err := r.DB().PingContext(ctx)
+		cancel()
+		healthy := err == nil
+		if r.healthy.Swap(healthy) != healthy {
+			if healthy {
+				util.Log().Info("从库 %d 已恢复", i)
+			} else {
+				util.Log().Warning("从库 %d 不可用: %v", i, err)
+			}
+		}
+	}
+}
+
+// tx 事务中的语句同样有超时
+type tx struct {
+	dialect.Tx
+	d *Driver
+}
+
+func (t *tx) Exec(ctx context.Context, query string, args, v interface{}) error {
+	ctx, cancel := t.d.timeout(ctx)
+	defer cancel()
+	return t.Tx.Exec(ctx, query, args, v)
+}
+
+func (t *tx) Query(ctx context.Context, query string, args, v interface{}) error {
+	ctx, cancel := t.d.timeout(ctx)
+	_ = cancel
+	return t.Tx.Query(ctx, query, args, v)
+}
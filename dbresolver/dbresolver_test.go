@@ -0,0 +1,119 @@
+package dbresolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entsql "github.com/facebook/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// open 打开一个内存数据库, 表 t 中有一行 name
+func open(t *testing.T, name string) *entsql.Driver {
+	drv, err := entsql.Open("sqlite3", "file:"+t.Name()+name+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := drv.Exec(ctx, "CREATE TABLE t (v TEXT)", []interface{}{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := drv.Exec(ctx, "INSERT INTO t (v) VALUES (?)", []interface{}{name}, nil); err != nil {
+		t.Fatal(err)
+	}
+	return drv
+}
+
+// source 查询落在哪个库
+func source(t *testing.T, d *Driver, ctx context.Context) string {
+	t.Helper()
+	rows := &entsql.Rows{}
+	if err := d.Query(ctx, "SELECT v FROM t LIMIT 1", []interface{}{}, rows); err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var v string
+	for rows.Next() {
+		rows.Scan(&v)
+	}
+	return v
+}
+
+func TestRouting(t *testing.T) {
+	d := New(open(t, "primary"), []*entsql.Driver{open(t, "replica")}, Config{QueryTimeout: time.Second})
+	defer d.Close()
+	ctx := context.Background()
+
+	if got := source(t, d, ctx); got != "replica" {
+		t.Fatalf("query went to %s", got)
+	}
+	if got := source(t, d, Primary(ctx)); got != "primary" {
+		t.Fatalf("forced query went to %s", got)
+	}
+
+	// 会话中写入之后读主库
+	session := WithSession(ctx)
+	if got := source(t, d, session); got != "replica" {
+		t.Fatalf("query before write went to %s", got)
+	}
+	if err := d.Exec(session, "UPDATE t SET v = v", []interface{}{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := source(t, d, session); got != "primary" {
+		t.Fatalf("query after write went to %s", got)
+	}
+	if got := source(t, d, ctx); got != "replica" {
+		t.Fatalf("query outside session went to %s", got)
+	}
+
+	tx, err := d.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := &entsql.Rows{}
+	if err := tx.Query(ctx, "SELECT v FROM t", []interface{}{}, rows); err != nil {
+		t.Fatal(err)
+	}
+	var v string
+	for rows.Next() {
+		rows.Scan(&v)
+	}
+	rows.Close()
+	tx.Rollback()
+	if v != "primary" {
+		t.Fatalf("transaction went to %s", v)
+	}
+}
+
+func TestReplicaHealth(t *testing.T) {
+	replica := open(t, "replica")
+	d := New(open(t, "primary"), []*entsql.Driver{replica}, Config{})
+	defer d.primary.Close()
+	ctx := context.Background()
+
+	// 从库不健康时回退到主库, 检查通过后重新使用
+	d.replicas[0].healthy.Store(false)
+	if got := source(t, d, ctx); got != "primary" {
+		t.Fatalf("query went to unhealthy %s", got)
+	}
+	d.check()
+	if got := source(t, d, ctx); got != "replica" {
+		t.Fatalf("recovered replica not used: %s", got)
+	}
+
+	replica.DB().Close()
+	d.check()
+	if got := source(t, d, ctx); got != "primary" {
+		t.Fatalf("query went to closed %s", got)
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	d := New(open(t, "primary"), nil, Config{QueryTimeout: time.Nanosecond})
+	defer d.Close()
+	time.Sleep(time.Millisecond)
+	if err := d.Exec(context.Background(), "UPDATE t SET v = v", []interface{}{}, nil); err == nil {
+		t.Fatal("statement not timed out")
+	}
+}
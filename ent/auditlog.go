@@ -0,0 +1,147 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-api/ent/auditlog"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// AuditLog is the model entity for the AuditLog schema.
+type AuditLog struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id"`
+	// ActorID holds the value of the "actor_id" field.
+	ActorID int `json:"actor_id"`
+	// Action holds the value of the "action" field.
+	Action string `json:"action"`
+	// Target holds the value of the "target" field.
+	Target string `json:"target"`
+	// Before holds the value of the "before" field.
+	Before string `json:"before"`
+	// After holds the value of the "after" field.
+	After string `json:"after"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AuditLog) scanValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{},  // id
+		&sql.NullInt64{},  // tenant_id
+		&sql.NullInt64{},  // actor_id
+		&sql.NullString{}, // action
+		&sql.NullString{}, // target
+		&sql.NullString{}, // before
+		&sql.NullString{}, // after
+		&sql.NullTime{},   // created_at
+	}
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AuditLog fields.
+func (al *AuditLog) assignValues(values ...interface{}) error {
+	if m, n := len(values), len(auditlog.Columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	value, ok := values[0].(*sql.NullInt64)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for field id", value)
+	}
+	al.ID = int(value.Int64)
+	values = values[1:]
+	if value, ok := values[0].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field tenant_id", values[0])
+	} else if value.Valid {
+		al.TenantID = int(value.Int64)
+	}
+	if value, ok := values[1].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field actor_id", values[1])
+	} else if value.Valid {
+		al.ActorID = int(value.Int64)
+	}
+	if value, ok := values[2].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field action", values[2])
+	} else if value.Valid {
+		al.Action = value.String
+	}
+	if value, ok := values[3].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field target", values[3])
+	} else if value.Valid {
+		al.Target = value.String
+	}
+	if value, ok := values[4].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field before", values[4])
+	} else if value.Valid {
+		al.Before = value.String
+	}
+	if value, ok := values[5].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field after", values[5])
+	} else if value.Valid {
+		al.After = value.String
+	}
+	if value, ok := values[6].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[6])
+	} else if value.Valid {
+		al.CreatedAt = value.Time
+	}
+	return nil
+}
+
+// Update returns a builder for updating this AuditLog.
+// Note that, you need to call AuditLog.Unwrap() before calling this method, if this AuditLog
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (al *AuditLog) Update() *AuditLogUpdateOne {
+	return (&AuditLogClient{config: al.config}).UpdateOne(al)
+}
+
+// Unwrap unwraps the entity that was returned from a transaction after it was closed,
+// so that all next queries will be executed through the driver which created the transaction.
+func (al *AuditLog) Unwrap() *AuditLog {
+	tx, ok := al.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AuditLog is not a transactional entity")
+	}
+	al.config.driver = tx.drv
+	return al
+}
+
+// String implements the fmt.Stringer.
+func (al *AuditLog) String() string {
+	var builder strings.Builder
+	builder.WriteString("AuditLog(")
+	builder.WriteString(fmt.Sprintf("id=%v", al.ID))
+	builder.WriteString(", tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", al.TenantID))
+	builder.WriteString(", actor_id=")
+	builder.WriteString(fmt.Sprintf("%v", al.ActorID))
+	builder.WriteString(", action=")
+	builder.WriteString(al.Action)
+	builder.WriteString(", target=")
+	builder.WriteString(al.Target)
+	builder.WriteString(", before=")
+	builder.WriteString(al.Before)
+	builder.WriteString(", after=")
+	builder.WriteString(al.After)
+	builder.WriteString(", created_at=")
+	builder.WriteString(al.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AuditLogs is a parsable slice of AuditLog.
+type AuditLogs []*AuditLog
+
+func (al AuditLogs) config(cfg config) {
+	for _i := range al {
+		al[_i].config = cfg
+	}
+}
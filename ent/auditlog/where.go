@@ -0,0 +1,874 @@
+// Code generated by entc, DO NOT EDIT.
+
+package auditlog
+
+import (
+	"go-api/ent/predicate"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// ID filters vertices based on their identifier.
+func ID(id int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// TenantID applies equality check predicate on the "tenant_id" field. It's identical to TenantIDEQ.
+func TenantID(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTenantID), v))
+	})
+}
+
+// ActorID applies equality check predicate on the "actor_id" field. It's identical to ActorIDEQ.
+func ActorID(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldActorID), v))
+	})
+}
+
+// Action applies equality check predicate on the "action" field. It's identical to ActionEQ.
+func Action(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAction), v))
+	})
+}
+
+// Target applies equality check predicate on the "target" field. It's identical to TargetEQ.
+func Target(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTarget), v))
+	})
+}
+
+// Before applies equality check predicate on the "before" field. It's identical to BeforeEQ.
+func Before(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldBefore), v))
+	})
+}
+
+// After applies equality check predicate on the "after" field. It's identical to AfterEQ.
+func After(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAfter), v))
+	})
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenant_id" field.
+func TenantIDEQ(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenant_id" field.
+func TenantIDNEQ(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDIn applies the In predicate on the "tenant_id" field.
+func TenantIDIn(vs ...int) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldTenantID), v...))
+	})
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenant_id" field.
+func TenantIDNotIn(vs ...int) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldTenantID), v...))
+	})
+}
+
+// TenantIDGT applies the GT predicate on the "tenant_id" field.
+func TenantIDGT(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenant_id" field.
+func TenantIDGTE(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDLT applies the LT predicate on the "tenant_id" field.
+func TenantIDLT(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenant_id" field.
+func TenantIDLTE(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldTenantID), v))
+	})
+}
+
+// ActorIDEQ applies the EQ predicate on the "actor_id" field.
+func ActorIDEQ(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldActorID), v))
+	})
+}
+
+// ActorIDNEQ applies the NEQ predicate on the "actor_id" field.
+func ActorIDNEQ(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldActorID), v))
+	})
+}
+
+// ActorIDIn applies the In predicate on the "actor_id" field.
+func ActorIDIn(vs ...int) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldActorID), v...))
+	})
+}
+
+// ActorIDNotIn applies the NotIn predicate on the "actor_id" field.
+func ActorIDNotIn(vs ...int) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldActorID), v...))
+	})
+}
+
+// ActorIDGT applies the GT predicate on the "actor_id" field.
+func ActorIDGT(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldActorID), v))
+	})
+}
+
+// ActorIDGTE applies the GTE predicate on the "actor_id" field.
+func ActorIDGTE(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldActorID), v))
+	})
+}
+
+// ActorIDLT applies the LT predicate on the "actor_id" field.
+func ActorIDLT(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldActorID), v))
+	})
+}
+
+// ActorIDLTE applies the LTE predicate on the "actor_id" field.
+func ActorIDLTE(v int) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldActorID), v))
+	})
+}
+
+// ActionEQ applies the EQ predicate on the "action" field.
+func ActionEQ(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAction), v))
+	})
+}
+
+// ActionNEQ applies the NEQ predicate on the "action" field.
+func ActionNEQ(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAction), v))
+	})
+}
+
+// ActionIn applies the In predicate on the "action" field.
+func ActionIn(vs ...string) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAction), v...))
+	})
+}
+
+// ActionNotIn applies the NotIn predicate on the "action" field.
+func ActionNotIn(vs ...string) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAction), v...))
+	})
+}
+
+// ActionGT applies the GT predicate on the "action" field.
+func ActionGT(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAction), v))
+	})
+}
+
+// ActionGTE applies the GTE predicate on the "action" field.
+func ActionGTE(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAction), v))
+	})
+}
+
+// ActionLT applies the LT predicate on the "action" field.
+func ActionLT(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAction), v))
+	})
+}
+
+// ActionLTE applies the LTE predicate on the "action" field.
+func ActionLTE(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAction), v))
+	})
+}
+
+// ActionContains applies the Contains predicate on the "action" field.
+func ActionContains(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAction), v))
+	})
+}
+
+// ActionHasPrefix applies the HasPrefix predicate on the "action" field.
+func ActionHasPrefix(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAction), v))
+	})
+}
+
+// ActionHasSuffix applies the HasSuffix predicate on the "action" field.
+func ActionHasSuffix(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAction), v))
+	})
+}
+
+// ActionEqualFold applies the EqualFold predicate on the "action" field.
+func ActionEqualFold(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAction), v))
+	})
+}
+
+// ActionContainsFold applies the ContainsFold predicate on the "action" field.
+func ActionContainsFold(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAction), v))
+	})
+}
+
+// TargetEQ applies the EQ predicate on the "target" field.
+func TargetEQ(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTarget), v))
+	})
+}
+
+// TargetNEQ applies the NEQ predicate on the "target" field.
+func TargetNEQ(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldTarget), v))
+	})
+}
+
+// TargetIn applies the In predicate on the "target" field.
+func TargetIn(vs ...string) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldTarget), v...))
+	})
+}
+
+// TargetNotIn applies the NotIn predicate on the "target" field.
+func TargetNotIn(vs ...string) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldTarget), v...))
+	})
+}
+
+// TargetGT applies the GT predicate on the "target" field.
+func TargetGT(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldTarget), v))
+	})
+}
+
+// TargetGTE applies the GTE predicate on the "target" field.
+func TargetGTE(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldTarget), v))
+	})
+}
+
+// TargetLT applies the LT predicate on the "target" field.
+func TargetLT(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldTarget), v))
+	})
+}
+
+// TargetLTE applies the LTE predicate on the "target" field.
+func TargetLTE(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldTarget), v))
+	})
+}
+
+// TargetContains applies the Contains predicate on the "target" field.
+func TargetContains(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldTarget), v))
+	})
+}
+
+// TargetHasPrefix applies the HasPrefix predicate on the "target" field.
+func TargetHasPrefix(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldTarget), v))
+	})
+}
+
+// TargetHasSuffix applies the HasSuffix predicate on the "target" field.
+func TargetHasSuffix(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldTarget), v))
+	})
+}
+
+// TargetEqualFold applies the EqualFold predicate on the "target" field.
+func TargetEqualFold(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldTarget), v))
+	})
+}
+
+// TargetContainsFold applies the ContainsFold predicate on the "target" field.
+func TargetContainsFold(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldTarget), v))
+	})
+}
+
+// BeforeEQ applies the EQ predicate on the "before" field.
+func BeforeEQ(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeNEQ applies the NEQ predicate on the "before" field.
+func BeforeNEQ(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeIn applies the In predicate on the "before" field.
+func BeforeIn(vs ...string) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldBefore), v...))
+	})
+}
+
+// BeforeNotIn applies the NotIn predicate on the "before" field.
+func BeforeNotIn(vs ...string) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldBefore), v...))
+	})
+}
+
+// BeforeGT applies the GT predicate on the "before" field.
+func BeforeGT(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeGTE applies the GTE predicate on the "before" field.
+func BeforeGTE(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeLT applies the LT predicate on the "before" field.
+func BeforeLT(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeLTE applies the LTE predicate on the "before" field.
+func BeforeLTE(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeContains applies the Contains predicate on the "before" field.
+func BeforeContains(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeHasPrefix applies the HasPrefix predicate on the "before" field.
+func BeforeHasPrefix(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeHasSuffix applies the HasSuffix predicate on the "before" field.
+func BeforeHasSuffix(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeIsNil applies the IsNil predicate on the "before" field.
+func BeforeIsNil() predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldBefore)))
+	})
+}
+
+// BeforeNotNil applies the NotNil predicate on the "before" field.
+func BeforeNotNil() predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldBefore)))
+	})
+}
+
+// BeforeEqualFold applies the EqualFold predicate on the "before" field.
+func BeforeEqualFold(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldBefore), v))
+	})
+}
+
+// BeforeContainsFold applies the ContainsFold predicate on the "before" field.
+func BeforeContainsFold(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldBefore), v))
+	})
+}
+
+// AfterEQ applies the EQ predicate on the "after" field.
+func AfterEQ(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAfter), v))
+	})
+}
+
+// AfterNEQ applies the NEQ predicate on the "after" field.
+func AfterNEQ(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAfter), v))
+	})
+}
+
+// AfterIn applies the In predicate on the "after" field.
+func AfterIn(vs ...string) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAfter), v...))
+	})
+}
+
+// AfterNotIn applies the NotIn predicate on the "after" field.
+func AfterNotIn(vs ...string) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAfter), v...))
+	})
+}
+
+// AfterGT applies the GT predicate on the "after" field.
+func AfterGT(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAfter), v))
+	})
+}
+
+// AfterGTE applies the GTE predicate on the "after" field.
+func AfterGTE(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAfter), v))
+	})
+}
+
+// AfterLT applies the LT predicate on the "after" field.
+func AfterLT(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAfter), v))
+	})
+}
+
+// AfterLTE applies the LTE predicate on the "after" field.
+func AfterLTE(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAfter), v))
+	})
+}
+
+// AfterContains applies the Contains predicate on the "after" field.
+func AfterContains(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAfter), v))
+	})
+}
+
+// AfterHasPrefix applies the HasPrefix predicate on the "after" field.
+func AfterHasPrefix(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAfter), v))
+	})
+}
+
+// AfterHasSuffix applies the HasSuffix predicate on the "after" field.
+func AfterHasSuffix(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAfter), v))
+	})
+}
+
+// AfterIsNil applies the IsNil predicate on the "after" field.
+func AfterIsNil() predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldAfter)))
+	})
+}
+
+// AfterNotNil applies the NotNil predicate on the "after" field.
+func AfterNotNil() predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldAfter)))
+	})
+}
+
+// AfterEqualFold applies the EqualFold predicate on the "after" field.
+func AfterEqualFold(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAfter), v))
+	})
+}
+
+// AfterContainsFold applies the ContainsFold predicate on the "after" field.
+func AfterContainsFold(v string) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAfter), v))
+	})
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.AuditLog {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditLog(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// And groups list of predicates with the AND operator between them.
+func And(predicates ...predicate.AuditLog) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups list of predicates with the OR operator between them.
+func Or(predicates ...predicate.AuditLog) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.AuditLog) predicate.AuditLog {
+	return predicate.AuditLog(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,327 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/auditlog"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// AuditLogCreate is the builder for creating a AuditLog entity.
+type AuditLogCreate struct {
+	config
+	mutation *AuditLogMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the tenant_id field.
+func (alc *AuditLogCreate) SetTenantID(i int) *AuditLogCreate {
+	alc.mutation.SetTenantID(i)
+	return alc
+}
+
+// SetNillableTenantID sets the tenant_id field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableTenantID(i *int) *AuditLogCreate {
+	if i != nil {
+		alc.SetTenantID(*i)
+	}
+	return alc
+}
+
+// SetActorID sets the actor_id field.
+func (alc *AuditLogCreate) SetActorID(i int) *AuditLogCreate {
+	alc.mutation.SetActorID(i)
+	return alc
+}
+
+// SetAction sets the action field.
+func (alc *AuditLogCreate) SetAction(s string) *AuditLogCreate {
+	alc.mutation.SetAction(s)
+	return alc
+}
+
+// SetTarget sets the target field.
+func (alc *AuditLogCreate) SetTarget(s string) *AuditLogCreate {
+	alc.mutation.SetTarget(s)
+	return alc
+}
+
+// SetBefore sets the before field.
+func (alc *AuditLogCreate) SetBefore(s string) *AuditLogCreate {
+	alc.mutation.SetBefore(s)
+	return alc
+}
+
+// SetNillableBefore sets the before field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableBefore(s *string) *AuditLogCreate {
+	if s != nil {
+		alc.SetBefore(*s)
+	}
+	return alc
+}
+
+// SetAfter sets the after field.
+func (alc *AuditLogCreate) SetAfter(s string) *AuditLogCreate {
+	alc.mutation.SetAfter(s)
+	return alc
+}
+
+// SetNillableAfter sets the after field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableAfter(s *string) *AuditLogCreate {
+	if s != nil {
+		alc.SetAfter(*s)
+	}
+	return alc
+}
+
+// SetCreatedAt sets the created_at field.
+func (alc *AuditLogCreate) SetCreatedAt(t time.Time) *AuditLogCreate {
+	alc.mutation.SetCreatedAt(t)
+	return alc
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableCreatedAt(t *time.Time) *AuditLogCreate {
+	if t != nil {
+		alc.SetCreatedAt(*t)
+	}
+	return alc
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (alc *AuditLogCreate) Mutation() *AuditLogMutation {
+	return alc.mutation
+}
+
+// Save creates the AuditLog in the database.
+func (alc *AuditLogCreate) Save(ctx context.Context) (*AuditLog, error) {
+	var (
+		err  error
+		node *AuditLog
+	)
+	alc.defaults()
+	if len(alc.hooks) == 0 {
+		if err = alc.check(); err != nil {
+			return nil, err
+		}
+		node, err = alc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*AuditLogMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = alc.check(); err != nil {
+				return nil, err
+			}
+			alc.mutation = mutation
+			node, err = alc.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(alc.hooks) - 1; i >= 0; i-- {
+			mut = alc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, alc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (alc *AuditLogCreate) SaveX(ctx context.Context) *AuditLog {
+	v, err := alc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// defaults sets the default values of the builder before save.
+func (alc *AuditLogCreate) defaults() {
+	if _, ok := alc.mutation.TenantID(); !ok {
+		v := auditlog.DefaultTenantID
+		alc.mutation.SetTenantID(v)
+	}
+	if _, ok := alc.mutation.CreatedAt(); !ok {
+		v := auditlog.DefaultCreatedAt()
+		alc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (alc *AuditLogCreate) check() error {
+	if _, ok := alc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New("ent: missing required field \"tenant_id\"")}
+	}
+	if _, ok := alc.mutation.ActorID(); !ok {
+		return &ValidationError{Name: "actor_id", err: errors.New("ent: missing required field \"actor_id\"")}
+	}
+	if _, ok := alc.mutation.Action(); !ok {
+		return &ValidationError{Name: "action", err: errors.New("ent: missing required field \"action\"")}
+	}
+	if _, ok := alc.mutation.Target(); !ok {
+		return &ValidationError{Name: "target", err: errors.New("ent: missing required field \"target\"")}
+	}
+	if _, ok := alc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New("ent: missing required field \"created_at\"")}
+	}
+	return nil
+}
+
+func (alc *AuditLogCreate) sqlSave(ctx context.Context) (*AuditLog, error) {
+	_node, _spec := alc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, alc.driver, _spec); err != nil {
+		if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (alc *AuditLogCreate) createSpec() (*AuditLog, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuditLog{config: alc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: auditlog.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: auditlog.FieldID,
+			},
+		}
+	)
+	if value, ok := alc.mutation.TenantID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditlog.FieldTenantID,
+		})
+		_node.TenantID = value
+	}
+	if value, ok := alc.mutation.ActorID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditlog.FieldActorID,
+		})
+		_node.ActorID = value
+	}
+	if value, ok := alc.mutation.Action(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldAction,
+		})
+		_node.Action = value
+	}
+	if value, ok := alc.mutation.Target(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldTarget,
+		})
+		_node.Target = value
+	}
+	if value, ok := alc.mutation.Before(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldBefore,
+		})
+		_node.Before = value
+	}
+	if value, ok := alc.mutation.After(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldAfter,
+		})
+		_node.After = value
+	}
+	if value, ok := alc.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: auditlog.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// AuditLogCreateBulk is the builder for creating a bulk of AuditLog entities.
+type AuditLogCreateBulk struct {
+	config
+	builders []*AuditLogCreate
+}
+
+// Save creates the AuditLog entities in the database.
+func (alcb *AuditLogCreateBulk) Save(ctx context.Context) ([]*AuditLog, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(alcb.builders))
+	nodes := make([]*AuditLog, len(alcb.builders))
+	mutators := make([]Mutator, len(alcb.builders))
+	for i := range alcb.builders {
+		func(i int, root context.Context) {
+			builder := alcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuditLogMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, alcb.builders[i+1].mutation)
+				} else {
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, alcb.driver, &sqlgraph.BatchCreateSpec{Nodes: specs}); err != nil {
+						if cerr, ok := isSQLConstraintError(err); ok {
+							err = cerr
+						}
+					}
+				}
+				mutation.done = true
+				if err != nil {
+					return nil, err
+				}
+				id := specs[i].ID.Value.(int64)
+				nodes[i].ID = int(id)
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, alcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (alcb *AuditLogCreateBulk) SaveX(ctx context.Context) []*AuditLog {
+	v, err := alcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,486 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/auditlog"
+	"go-api/ent/predicate"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// AuditLogUpdate is the builder for updating AuditLog entities.
+type AuditLogUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuditLogMutation
+}
+
+// Where adds a new predicate for the builder.
+func (alu *AuditLogUpdate) Where(ps ...predicate.AuditLog) *AuditLogUpdate {
+	alu.mutation.predicates = append(alu.mutation.predicates, ps...)
+	return alu
+}
+
+// SetActorID sets the actor_id field.
+func (alu *AuditLogUpdate) SetActorID(i int) *AuditLogUpdate {
+	alu.mutation.ResetActorID()
+	alu.mutation.SetActorID(i)
+	return alu
+}
+
+// AddActorID adds i to actor_id.
+func (alu *AuditLogUpdate) AddActorID(i int) *AuditLogUpdate {
+	alu.mutation.AddActorID(i)
+	return alu
+}
+
+// SetAction sets the action field.
+func (alu *AuditLogUpdate) SetAction(s string) *AuditLogUpdate {
+	alu.mutation.SetAction(s)
+	return alu
+}
+
+// SetTarget sets the target field.
+func (alu *AuditLogUpdate) SetTarget(s string) *AuditLogUpdate {
+	alu.mutation.SetTarget(s)
+	return alu
+}
+
+// SetBefore sets the before field.
+func (alu *AuditLogUpdate) SetBefore(s string) *AuditLogUpdate {
+	alu.mutation.SetBefore(s)
+	return alu
+}
+
+// SetNillableBefore sets the before field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableBefore(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetBefore(*s)
+	}
+	return alu
+}
+
+// ClearBefore clears the value of before.
+func (alu *AuditLogUpdate) ClearBefore() *AuditLogUpdate {
+	alu.mutation.ClearBefore()
+	return alu
+}
+
+// SetAfter sets the after field.
+func (alu *AuditLogUpdate) SetAfter(s string) *AuditLogUpdate {
+	alu.mutation.SetAfter(s)
+	return alu
+}
+
+// SetNillableAfter sets the after field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableAfter(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetAfter(*s)
+	}
+	return alu
+}
+
+// ClearAfter clears the value of after.
+func (alu *AuditLogUpdate) ClearAfter() *AuditLogUpdate {
+	alu.mutation.ClearAfter()
+	return alu
+}
+
+// SetCreatedAt sets the created_at field.
+func (alu *AuditLogUpdate) SetCreatedAt(t time.Time) *AuditLogUpdate {
+	alu.mutation.SetCreatedAt(t)
+	return alu
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableCreatedAt(t *time.Time) *AuditLogUpdate {
+	if t != nil {
+		alu.SetCreatedAt(*t)
+	}
+	return alu
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (alu *AuditLogUpdate) Mutation() *AuditLogMutation {
+	return alu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (alu *AuditLogUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(alu.hooks) == 0 {
+		affected, err = alu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*AuditLogMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			alu.mutation = mutation
+			affected, err = alu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(alu.hooks) - 1; i >= 0; i-- {
+			mut = alu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, alu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (alu *AuditLogUpdate) SaveX(ctx context.Context) int {
+	affected, err := alu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (alu *AuditLogUpdate) Exec(ctx context.Context) error {
+	_, err := alu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (alu *AuditLogUpdate) ExecX(ctx context.Context) {
+	if err := alu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (alu *AuditLogUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   auditlog.Table,
+			Columns: auditlog.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: auditlog.FieldID,
+			},
+		},
+	}
+	if ps := alu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := alu.mutation.ActorID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditlog.FieldActorID,
+		})
+	}
+	if value, ok := alu.mutation.AddedActorID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditlog.FieldActorID,
+		})
+	}
+	if value, ok := alu.mutation.Action(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldAction,
+		})
+	}
+	if value, ok := alu.mutation.Target(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldTarget,
+		})
+	}
+	if value, ok := alu.mutation.Before(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldBefore,
+		})
+	}
+	if alu.mutation.BeforeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: auditlog.FieldBefore,
+		})
+	}
+	if value, ok := alu.mutation.After(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldAfter,
+		})
+	}
+	if alu.mutation.AfterCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: auditlog.FieldAfter,
+		})
+	}
+	if value, ok := alu.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: auditlog.FieldCreatedAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, alu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditlog.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// AuditLogUpdateOne is the builder for updating a single AuditLog entity.
+type AuditLogUpdateOne struct {
+	config
+	hooks    []Hook
+	mutation *AuditLogMutation
+}
+
+// SetActorID sets the actor_id field.
+func (aluo *AuditLogUpdateOne) SetActorID(i int) *AuditLogUpdateOne {
+	aluo.mutation.ResetActorID()
+	aluo.mutation.SetActorID(i)
+	return aluo
+}
+
+// AddActorID adds i to actor_id.
+func (aluo *AuditLogUpdateOne) AddActorID(i int) *AuditLogUpdateOne {
+	aluo.mutation.AddActorID(i)
+	return aluo
+}
+
+// SetAction sets the action field.
+func (aluo *AuditLogUpdateOne) SetAction(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetAction(s)
+	return aluo
+}
+
+// SetTarget sets the target field.
+func (aluo *AuditLogUpdateOne) SetTarget(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetTarget(s)
+	return aluo
+}
+
+// SetBefore sets the before field.
+func (aluo *AuditLogUpdateOne) SetBefore(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetBefore(s)
+	return aluo
+}
+
+// SetNillableBefore sets the before field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableBefore(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetBefore(*s)
+	}
+	return aluo
+}
+
+// ClearBefore clears the value of before.
+func (aluo *AuditLogUpdateOne) ClearBefore() *AuditLogUpdateOne {
+	aluo.mutation.ClearBefore()
+	return aluo
+}
+
+// SetAfter sets the after field.
+func (aluo *AuditLogUpdateOne) SetAfter(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetAfter(s)
+	return aluo
+}
+
+// SetNillableAfter sets the after field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableAfter(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetAfter(*s)
+	}
+	return aluo
+}
+
+// ClearAfter clears the value of after.
+func (aluo *AuditLogUpdateOne) ClearAfter() *AuditLogUpdateOne {
+	aluo.mutation.ClearAfter()
+	return aluo
+}
+
+// SetCreatedAt sets the created_at field.
+func (aluo *AuditLogUpdateOne) SetCreatedAt(t time.Time) *AuditLogUpdateOne {
+	aluo.mutation.SetCreatedAt(t)
+	return aluo
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableCreatedAt(t *time.Time) *AuditLogUpdateOne {
+	if t != nil {
+		aluo.SetCreatedAt(*t)
+	}
+	return aluo
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (aluo *AuditLogUpdateOne) Mutation() *AuditLogMutation {
+	return aluo.mutation
+}
+
+// Save executes the query and returns the updated entity.
+func (aluo *AuditLogUpdateOne) Save(ctx context.Context) (*AuditLog, error) {
+	var (
+		err  error
+		node *AuditLog
+	)
+	if len(aluo.hooks) == 0 {
+		node, err = aluo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*AuditLogMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			aluo.mutation = mutation
+			node, err = aluo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(aluo.hooks) - 1; i >= 0; i-- {
+			mut = aluo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, aluo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aluo *AuditLogUpdateOne) SaveX(ctx context.Context) *AuditLog {
+	node, err := aluo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (aluo *AuditLogUpdateOne) Exec(ctx context.Context) error {
+	_, err := aluo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aluo *AuditLogUpdateOne) ExecX(ctx context.Context) {
+	if err := aluo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (aluo *AuditLogUpdateOne) sqlSave(ctx context.Context) (_node *AuditLog, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   auditlog.Table,
+			Columns: auditlog.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: auditlog.FieldID,
+			},
+		},
+	}
+	id, ok := aluo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "ID", err: fmt.Errorf("missing AuditLog.ID for update")}
+	}
+	_spec.Node.ID.Value = id
+	if value, ok := aluo.mutation.ActorID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditlog.FieldActorID,
+		})
+	}
+	if value, ok := aluo.mutation.AddedActorID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditlog.FieldActorID,
+		})
+	}
+	if value, ok := aluo.mutation.Action(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldAction,
+		})
+	}
+	if value, ok := aluo.mutation.Target(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldTarget,
+		})
+	}
+	if value, ok := aluo.mutation.Before(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldBefore,
+		})
+	}
+	if aluo.mutation.BeforeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: auditlog.FieldBefore,
+		})
+	}
+	if value, ok := aluo.mutation.After(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditlog.FieldAfter,
+		})
+	}
+	if aluo.mutation.AfterCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: auditlog.FieldAfter,
+		})
+	}
+	if value, ok := aluo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: auditlog.FieldCreatedAt,
+		})
+	}
+	_node = &AuditLog{config: aluo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues()
+	if err = sqlgraph.UpdateNode(ctx, aluo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditlog.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -24,8 +24,16 @@ type config struct {
 
 // hooks per client, for fast access.
 type hooks struct {
-	Pet  []ent.Hook
-	User []ent.Hook
+	AuditLog        []ent.Hook
+	DataRequest     []ent.Hook
+	Identity        []ent.Hook
+	OAuthClient     []ent.Hook
+	Outbox          []ent.Hook
+	Pet             []ent.Hook
+	Tenant          []ent.Hook
+	User            []ent.Hook
+	WebhookDelivery []ent.Hook
+	WebhookEndpoint []ent.Hook
 }
 
 // Options applies the options on the config object.
@@ -0,0 +1,186 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-api/ent/datarequest"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// DataRequest is the model entity for the DataRequest schema.
+type DataRequest struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id"`
+	// UserID holds the value of the "user_id" field.
+	UserID int `json:"user_id"`
+	// Kind holds the value of the "kind" field.
+	Kind datarequest.Kind `json:"kind"`
+	// Status holds the value of the "status" field.
+	Status datarequest.Status `json:"status"`
+	// ObjectKey holds the value of the "object_key" field.
+	ObjectKey string `json:"object_key"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error"`
+	// ScheduledAt holds the value of the "scheduled_at" field.
+	ScheduledAt *time.Time `json:"scheduled_at"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt *time.Time `json:"expires_at"`
+	// CompletedAt holds the value of the "completed_at" field.
+	CompletedAt *time.Time `json:"completed_at"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*DataRequest) scanValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{},  // id
+		&sql.NullInt64{},  // tenant_id
+		&sql.NullInt64{},  // user_id
+		&sql.NullString{}, // kind
+		&sql.NullString{}, // status
+		&sql.NullString{}, // object_key
+		&sql.NullString{}, // error
+		&sql.NullTime{},   // scheduled_at
+		&sql.NullTime{},   // expires_at
+		&sql.NullTime{},   // completed_at
+		&sql.NullTime{},   // created_at
+	}
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the DataRequest fields.
+func (dr *DataRequest) assignValues(values ...interface{}) error {
+	if m, n := len(values), len(datarequest.Columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	value, ok := values[0].(*sql.NullInt64)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for field id", value)
+	}
+	dr.ID = int(value.Int64)
+	values = values[1:]
+	if value, ok := values[0].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field tenant_id", values[0])
+	} else if value.Valid {
+		dr.TenantID = int(value.Int64)
+	}
+	if value, ok := values[1].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field user_id", values[1])
+	} else if value.Valid {
+		dr.UserID = int(value.Int64)
+	}
+	if value, ok := values[2].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field kind", values[2])
+	} else if value.Valid {
+		dr.Kind = datarequest.Kind(value.String)
+	}
+	if value, ok := values[3].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field status", values[3])
+	} else if value.Valid {
+		dr.Status = datarequest.Status(value.String)
+	}
+	if value, ok := values[4].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field object_key", values[4])
+	} else if value.Valid {
+		dr.ObjectKey = value.String
+	}
+	if value, ok := values[5].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field error", values[5])
+	} else if value.Valid {
+		dr.Error = value.String
+	}
+	if value, ok := values[6].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field scheduled_at", values[6])
+	} else if value.Valid {
+		dr.ScheduledAt = new(time.Time)
+		*dr.ScheduledAt = value.Time
+	}
+	if value, ok := values[7].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field expires_at", values[7])
+	} else if value.Valid {
+		dr.ExpiresAt = new(time.Time)
+		*dr.ExpiresAt = value.Time
+	}
+	if value, ok := values[8].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field completed_at", values[8])
+	} else if value.Valid {
+		dr.CompletedAt = new(time.Time)
+		*dr.CompletedAt = value.Time
+	}
+	if value, ok := values[9].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[9])
+	} else if value.Valid {
+		dr.CreatedAt = value.Time
+	}
+	return nil
+}
+
+// Update returns a builder for updating this DataRequest.
+// Note that, you need to call DataRequest.Unwrap() before calling this method, if this DataRequest
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (dr *DataRequest) Update() *DataRequestUpdateOne {
+	return (&DataRequestClient{config: dr.config}).UpdateOne(dr)
+}
+
+// Unwrap unwraps the entity that was returned from a transaction after it was closed,
+// so that all next queries will be executed through the driver which created the transaction.
+func (dr *DataRequest) Unwrap() *DataRequest {
+	tx, ok := dr.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: DataRequest is not a transactional entity")
+	}
+	dr.config.driver = tx.drv
+	return dr
+}
+
+// String implements the fmt.Stringer.
+func (dr *DataRequest) String() string {
+	var builder strings.Builder
+	builder.WriteString("DataRequest(")
+	builder.WriteString(fmt.Sprintf("id=%v", dr.ID))
+	builder.WriteString(", tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", dr.TenantID))
+	builder.WriteString(", user_id=")
+	builder.WriteString(fmt.Sprintf("%v", dr.UserID))
+	builder.WriteString(", kind=")
+	builder.WriteString(fmt.Sprintf("%v", dr.Kind))
+	builder.WriteString(", status=")
+	builder.WriteString(fmt.Sprintf("%v", dr.Status))
+	builder.WriteString(", object_key=")
+	builder.WriteString(dr.ObjectKey)
+	builder.WriteString(", error=")
+	builder.WriteString(dr.Error)
+	if v := dr.ScheduledAt; v != nil {
+		builder.WriteString(", scheduled_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	if v := dr.ExpiresAt; v != nil {
+		builder.WriteString(", expires_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	if v := dr.CompletedAt; v != nil {
+		builder.WriteString(", completed_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", created_at=")
+	builder.WriteString(dr.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// DataRequests is a parsable slice of DataRequest.
+type DataRequests []*DataRequest
+
+func (dr DataRequests) config(cfg config) {
+	for _i := range dr {
+		dr[_i].config = cfg
+	}
+}
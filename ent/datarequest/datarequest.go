@@ -0,0 +1,135 @@
+// Code generated by entc, DO NOT EDIT.
+
+package datarequest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/facebook/ent"
+)
+
+const (
+	// Label holds the string label denoting the datarequest type in the database.
+	Label = "data_request"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTenantID holds the string denoting the tenant_id field in the database.
+	FieldTenantID = "tenant_id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldKind holds the string denoting the kind field in the database.
+	FieldKind = "kind"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldObjectKey holds the string denoting the object_key field in the database.
+	FieldObjectKey = "object_key"
+	// FieldError holds the string denoting the error field in the database.
+	FieldError = "error"
+	// FieldScheduledAt holds the string denoting the scheduled_at field in the database.
+	FieldScheduledAt = "scheduled_at"
+	// FieldExpiresAt holds the string denoting the expires_at field in the database.
+	FieldExpiresAt = "expires_at"
+	// FieldCompletedAt holds the string denoting the completed_at field in the database.
+	FieldCompletedAt = "completed_at"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+
+	// Table holds the table name of the datarequest in the database.
+	Table = "data_requests"
+)
+
+// Columns holds all SQL columns for datarequest fields.
+var Columns = []string{
+	FieldID,
+	FieldTenantID,
+	FieldUserID,
+	FieldKind,
+	FieldStatus,
+	FieldObjectKey,
+	FieldError,
+	FieldScheduledAt,
+	FieldExpiresAt,
+	FieldCompletedAt,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "go-api/ent/runtime"
+var (
+	Hooks  [2]ent.Hook
+	Policy ent.Policy
+	// DefaultTenantID holds the default value on creation for the tenant_id field.
+	DefaultTenantID int
+	// DefaultObjectKey holds the default value on creation for the object_key field.
+	DefaultObjectKey string
+	// DefaultError holds the default value on creation for the error field.
+	DefaultError string
+	// DefaultCreatedAt holds the default value on creation for the created_at field.
+	DefaultCreatedAt func() time.Time
+)
+
+// Kind defines the type for the kind enum field.
+type Kind string
+
+// Kind values.
+const (
+	KindExport  Kind = "export"
+	KindErasure Kind = "erasure"
+)
+
+func (k Kind) String() string {
+	return string(k)
+}
+
+// KindValidator is a validator for the "kind" field enum values. It is called by the builders before save.
+func KindValidator(k Kind) error {
+	switch k {
+	case KindExport, KindErasure:
+		return nil
+	default:
+		return fmt.Errorf("datarequest: invalid enum value for kind field: %q", k)
+	}
+}
+
+// Status defines the type for the status enum field.
+type Status string
+
+// StatusPending is the default Status.
+const DefaultStatus = StatusPending
+
+// Status values.
+const (
+	StatusPending   Status = "pending"
+	StatusCompleted Status = "completed"
+	StatusCancelled Status = "cancelled"
+	StatusFailed    Status = "failed"
+	StatusExpired   Status = "expired"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// StatusValidator is a validator for the "status" field enum values. It is called by the builders before save.
+func StatusValidator(s Status) error {
+	switch s {
+	case StatusPending, StatusCompleted, StatusCancelled, StatusFailed, StatusExpired:
+		return nil
+	default:
+		return fmt.Errorf("datarequest: invalid enum value for status field: %q", s)
+	}
+}
@@ -0,0 +1,429 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/datarequest"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// DataRequestCreate is the builder for creating a DataRequest entity.
+type DataRequestCreate struct {
+	config
+	mutation *DataRequestMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the tenant_id field.
+func (drc *DataRequestCreate) SetTenantID(i int) *DataRequestCreate {
+	drc.mutation.SetTenantID(i)
+	return drc
+}
+
+// SetNillableTenantID sets the tenant_id field if the given value is not nil.
+func (drc *DataRequestCreate) SetNillableTenantID(i *int) *DataRequestCreate {
+	if i != nil {
+		drc.SetTenantID(*i)
+	}
+	return drc
+}
+
+// SetUserID sets the user_id field.
+func (drc *DataRequestCreate) SetUserID(i int) *DataRequestCreate {
+	drc.mutation.SetUserID(i)
+	return drc
+}
+
+// SetKind sets the kind field.
+func (drc *DataRequestCreate) SetKind(d datarequest.Kind) *DataRequestCreate {
+	drc.mutation.SetKind(d)
+	return drc
+}
+
+// SetStatus sets the status field.
+func (drc *DataRequestCreate) SetStatus(d datarequest.Status) *DataRequestCreate {
+	drc.mutation.SetStatus(d)
+	return drc
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (drc *DataRequestCreate) SetNillableStatus(d *datarequest.Status) *DataRequestCreate {
+	if d != nil {
+		drc.SetStatus(*d)
+	}
+	return drc
+}
+
+// SetObjectKey sets the object_key field.
+func (drc *DataRequestCreate) SetObjectKey(s string) *DataRequestCreate {
+	drc.mutation.SetObjectKey(s)
+	return drc
+}
+
+// SetNillableObjectKey sets the object_key field if the given value is not nil.
+func (drc *DataRequestCreate) SetNillableObjectKey(s *string) *DataRequestCreate {
+	if s != nil {
+		drc.SetObjectKey(*s)
+	}
+	return drc
+}
+
+// SetError sets the error field.
+func (drc *DataRequestCreate) SetError(s string) *DataRequestCreate {
+	drc.mutation.SetError(s)
+	return drc
+}
+
+// SetNillableError sets the error field if the given value is not nil.
+func (drc *DataRequestCreate) SetNillableError(s *string) *DataRequestCreate {
+	if s != nil {
+		drc.SetError(*s)
+	}
+	return drc
+}
+
+// SetScheduledAt sets the scheduled_at field.
+func (drc *DataRequestCreate) SetScheduledAt(t time.Time) *DataRequestCreate {
+	drc.mutation.SetScheduledAt(t)
+	return drc
+}
+
+// SetNillableScheduledAt sets the scheduled_at field if the given value is not nil.
+func (drc *DataRequestCreate) SetNillableScheduledAt(t *time.Time) *DataRequestCreate {
+	if t != nil {
+		drc.SetScheduledAt(*t)
+	}
+	return drc
+}
+
+// SetExpiresAt sets the expires_at field.
+func (drc *DataRequestCreate) SetExpiresAt(t time.Time) *DataRequestCreate {
+	drc.mutation.SetExpiresAt(t)
+	return drc
+}
+
+// SetNillableExpiresAt sets the expires_at field if the given value is not nil.
+func (drc *DataRequestCreate) SetNillableExpiresAt(t *time.Time) *DataRequestCreate {
+	if t != nil {
+		drc.SetExpiresAt(*t)
+	}
+	return drc
+}
+
+// SetCompletedAt sets the completed_at field.
+func (drc *DataRequestCreate) SetCompletedAt(t time.Time) *DataRequestCreate {
+	drc.mutation.SetCompletedAt(t)
+	return drc
+}
+
+// SetNillableCompletedAt sets the completed_at field if the given value is not nil.
+func (drc *DataRequestCreate) SetNillableCompletedAt(t *time.Time) *DataRequestCreate {
+	if t != nil {
+		drc.SetCompletedAt(*t)
+	}
+	return drc
+}
+
+// SetCreatedAt sets the created_at field.
+func (drc *DataRequestCreate) SetCreatedAt(t time.Time) *DataRequestCreate {
+	drc.mutation.SetCreatedAt(t)
+	return drc
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (drc *DataRequestCreate) SetNillableCreatedAt(t *time.Time) *DataRequestCreate {
+	if t != nil {
+		drc.SetCreatedAt(*t)
+	}
+	return drc
+}
+
+// Mutation returns the DataRequestMutation object of the builder.
+func (drc *DataRequestCreate) Mutation() *DataRequestMutation {
+	return drc.mutation
+}
+
+// Save creates the DataRequest in the database.
+func (drc *DataRequestCreate) Save(ctx context.Context) (*DataRequest, error) {
+	var (
+		err  error
+		node *DataRequest
+	)
+	drc.defaults()
+	if len(drc.hooks) == 0 {
+		if err = drc.check(); err != nil {
+			return nil, err
+		}
+		node, err = drc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*DataRequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = drc.check(); err != nil {
+				return nil, err
+			}
+			drc.mutation = mutation
+			node, err = drc.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(drc.hooks) - 1; i >= 0; i-- {
+			mut = drc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, drc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (drc *DataRequestCreate) SaveX(ctx context.Context) *DataRequest {
+	v, err := drc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// defaults sets the default values of the builder before save.
+func (drc *DataRequestCreate) defaults() {
+	if _, ok := drc.mutation.TenantID(); !ok {
+		v := datarequest.DefaultTenantID
+		drc.mutation.SetTenantID(v)
+	}
+	if _, ok := drc.mutation.Status(); !ok {
+		v := datarequest.DefaultStatus
+		drc.mutation.SetStatus(v)
+	}
+	if _, ok := drc.mutation.ObjectKey(); !ok {
+		v := datarequest.DefaultObjectKey
+		drc.mutation.SetObjectKey(v)
+	}
+	if _, ok := drc.mutation.Error(); !ok {
+		v := datarequest.DefaultError
+		drc.mutation.SetError(v)
+	}
+	if _, ok := drc.mutation.CreatedAt(); !ok {
+		v := datarequest.DefaultCreatedAt()
+		drc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (drc *DataRequestCreate) check() error {
+	if _, ok := drc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New("ent: missing required field \"tenant_id\"")}
+	}
+	if _, ok := drc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New("ent: missing required field \"user_id\"")}
+	}
+	if _, ok := drc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New("ent: missing required field \"kind\"")}
+	}
+	if v, ok := drc.mutation.Kind(); ok {
+		if err := datarequest.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf("ent: validator failed for field \"kind\": %w", err)}
+		}
+	}
+	if _, ok := drc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New("ent: missing required field \"status\"")}
+	}
+	if v, ok := drc.mutation.Status(); ok {
+		if err := datarequest.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf("ent: validator failed for field \"status\": %w", err)}
+		}
+	}
+	if _, ok := drc.mutation.ObjectKey(); !ok {
+		return &ValidationError{Name: "object_key", err: errors.New("ent: missing required field \"object_key\"")}
+	}
+	if _, ok := drc.mutation.Error(); !ok {
+		return &ValidationError{Name: "error", err: errors.New("ent: missing required field \"error\"")}
+	}
+	if _, ok := drc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New("ent: missing required field \"created_at\"")}
+	}
+	return nil
+}
+
+func (drc *DataRequestCreate) sqlSave(ctx context.Context) (*DataRequest, error) {
+	_node, _spec := drc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, drc.driver, _spec); err != nil {
+		if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (drc *DataRequestCreate) createSpec() (*DataRequest, *sqlgraph.CreateSpec) {
+	var (
+		_node = &DataRequest{config: drc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: datarequest.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: datarequest.FieldID,
+			},
+		}
+	)
+	if value, ok := drc.mutation.TenantID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: datarequest.FieldTenantID,
+		})
+		_node.TenantID = value
+	}
+	if value, ok := drc.mutation.UserID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: datarequest.FieldUserID,
+		})
+		_node.UserID = value
+	}
+	if value, ok := drc.mutation.Kind(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: datarequest.FieldKind,
+		})
+		_node.Kind = value
+	}
+	if value, ok := drc.mutation.Status(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: datarequest.FieldStatus,
+		})
+		_node.Status = value
+	}
+	if value, ok := drc.mutation.ObjectKey(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: datarequest.FieldObjectKey,
+		})
+		_node.ObjectKey = value
+	}
+	if value, ok := drc.mutation.Error(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: datarequest.FieldError,
+		})
+		_node.Error = value
+	}
+	if value, ok := drc.mutation.ScheduledAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldScheduledAt,
+		})
+		_node.ScheduledAt = &value
+	}
+	if value, ok := drc.mutation.ExpiresAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldExpiresAt,
+		})
+		_node.ExpiresAt = &value
+	}
+	if value, ok := drc.mutation.CompletedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldCompletedAt,
+		})
+		_node.CompletedAt = &value
+	}
+	if value, ok := drc.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// DataRequestCreateBulk is the builder for creating a bulk of DataRequest entities.
+type DataRequestCreateBulk struct {
+	config
+	builders []*DataRequestCreate
+}
+
+// Save creates the DataRequest entities in the database.
+func (drcb *DataRequestCreateBulk) Save(ctx context.Context) ([]*DataRequest, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(drcb.builders))
+	nodes := make([]*DataRequest, len(drcb.builders))
+	mutators := make([]Mutator, len(drcb.builders))
+	for i := range drcb.builders {
+		func(i int, root context.Context) {
+			builder := drcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DataRequestMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, drcb.builders[i+1].mutation)
+				} else {
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, drcb.driver, &sqlgraph.BatchCreateSpec{Nodes: specs}); err != nil {
+						if cerr, ok := isSQLConstraintError(err); ok {
+							err = cerr
+						}
+					}
+				}
+				mutation.done = true
+				if err != nil {
+					return nil, err
+				}
+				id := specs[i].ID.Value.(int64)
+				nodes[i].ID = int(id)
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, drcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (drcb *DataRequestCreateBulk) SaveX(ctx context.Context) []*DataRequest {
+	v, err := drcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,108 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/datarequest"
+	"go-api/ent/predicate"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// DataRequestDelete is the builder for deleting a DataRequest entity.
+type DataRequestDelete struct {
+	config
+	hooks    []Hook
+	mutation *DataRequestMutation
+}
+
+// Where adds a new predicate to the delete builder.
+func (drd *DataRequestDelete) Where(ps ...predicate.DataRequest) *DataRequestDelete {
+	drd.mutation.predicates = append(drd.mutation.predicates, ps...)
+	return drd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (drd *DataRequestDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(drd.hooks) == 0 {
+		affected, err = drd.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*DataRequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			drd.mutation = mutation
+			affected, err = drd.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(drd.hooks) - 1; i >= 0; i-- {
+			mut = drd.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, drd.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (drd *DataRequestDelete) ExecX(ctx context.Context) int {
+	n, err := drd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (drd *DataRequestDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: datarequest.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: datarequest.FieldID,
+			},
+		},
+	}
+	if ps := drd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return sqlgraph.DeleteNodes(ctx, drd.driver, _spec)
+}
+
+// DataRequestDeleteOne is the builder for deleting a single DataRequest entity.
+type DataRequestDeleteOne struct {
+	drd *DataRequestDelete
+}
+
+// Exec executes the deletion query.
+func (drdo *DataRequestDeleteOne) Exec(ctx context.Context) error {
+	n, err := drdo.drd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{datarequest.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (drdo *DataRequestDeleteOne) ExecX(ctx context.Context) {
+	drdo.drd.ExecX(ctx)
+}
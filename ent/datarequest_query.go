@@ -0,0 +1,884 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/datarequest"
+	"go-api/ent/predicate"
+	"math"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// DataRequestQuery is the builder for querying DataRequest entities.
+type DataRequestQuery struct {
+	config
+	limit      *int
+	offset     *int
+	order      []OrderFunc
+	unique     []string
+	predicates []predicate.DataRequest
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the builder.
+func (drq *DataRequestQuery) Where(ps ...predicate.DataRequest) *DataRequestQuery {
+	drq.predicates = append(drq.predicates, ps...)
+	return drq
+}
+
+// Limit adds a limit step to the query.
+func (drq *DataRequestQuery) Limit(limit int) *DataRequestQuery {
+	drq.limit = &limit
+	return drq
+}
+
+// Offset adds an offset step to the query.
+func (drq *DataRequestQuery) Offset(offset int) *DataRequestQuery {
+	drq.offset = &offset
+	return drq
+}
+
+// Order adds an order step to the query.
+func (drq *DataRequestQuery) Order(o ...OrderFunc) *DataRequestQuery {
+	drq.order = append(drq.order, o...)
+	return drq
+}
+
+// First returns the first DataRequest entity in the query. Returns *NotFoundError when no datarequest was found.
+func (drq *DataRequestQuery) First(ctx context.Context) (*DataRequest, error) {
+	nodes, err := drq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{datarequest.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (drq *DataRequestQuery) FirstX(ctx context.Context) *DataRequest {
+	node, err := drq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first DataRequest id in the query. Returns *NotFoundError when no id was found.
+func (drq *DataRequestQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = drq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{datarequest.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (drq *DataRequestQuery) FirstIDX(ctx context.Context) int {
+	id, err := drq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns the only DataRequest entity in the query, returns an error if not exactly one entity was returned.
+func (drq *DataRequestQuery) Only(ctx context.Context) (*DataRequest, error) {
+	nodes, err := drq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{datarequest.Label}
+	default:
+		return nil, &NotSingularError{datarequest.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (drq *DataRequestQuery) OnlyX(ctx context.Context) *DataRequest {
+	node, err := drq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID returns the only DataRequest id in the query, returns an error if not exactly one id was returned.
+func (drq *DataRequestQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = drq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = &NotSingularError{datarequest.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (drq *DataRequestQuery) OnlyIDX(ctx context.Context) int {
+	id, err := drq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of DataRequests.
+func (drq *DataRequestQuery) All(ctx context.Context) ([]*DataRequest, error) {
+	if err := drq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return drq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (drq *DataRequestQuery) AllX(ctx context.Context) []*DataRequest {
+	nodes, err := drq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of DataRequest ids.
+func (drq *DataRequestQuery) IDs(ctx context.Context) ([]int, error) {
+	var ids []int
+	if err := drq.Select(datarequest.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (drq *DataRequestQuery) IDsX(ctx context.Context) []int {
+	ids, err := drq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (drq *DataRequestQuery) Count(ctx context.Context) (int, error) {
+	if err := drq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return drq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (drq *DataRequestQuery) CountX(ctx context.Context) int {
+	count, err := drq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (drq *DataRequestQuery) Exist(ctx context.Context) (bool, error) {
+	if err := drq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return drq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (drq *DataRequestQuery) ExistX(ctx context.Context) bool {
+	exist, err := drq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the query builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (drq *DataRequestQuery) Clone() *DataRequestQuery {
+	if drq == nil {
+		return nil
+	}
+	return &DataRequestQuery{
+		config:     drq.config,
+		limit:      drq.limit,
+		offset:     drq.offset,
+		order:      append([]OrderFunc{}, drq.order...),
+		unique:     append([]string{}, drq.unique...),
+		predicates: append([]predicate.DataRequest{}, drq.predicates...),
+		// clone intermediate query.
+		sql:  drq.sql.Clone(),
+		path: drq.path,
+	}
+}
+
+// GroupBy used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID int `json:"tenant_id"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.DataRequest.Query().
+//		GroupBy(datarequest.FieldTenantID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (drq *DataRequestQuery) GroupBy(field string, fields ...string) *DataRequestGroupBy {
+	group := &DataRequestGroupBy{config: drq.config}
+	group.fields = append([]string{field}, fields...)
+	group.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := drq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return drq.sqlQuery(), nil
+	}
+	return group
+}
+
+// Select one or more fields from the given query.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID int `json:"tenant_id"`
+//	}
+//
+//	client.DataRequest.Query().
+//		Select(datarequest.FieldTenantID).
+//		Scan(ctx, &v)
+func (drq *DataRequestQuery) Select(field string, fields ...string) *DataRequestSelect {
+	selector := &DataRequestSelect{config: drq.config}
+	selector.fields = append([]string{field}, fields...)
+	selector.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := drq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return drq.sqlQuery(), nil
+	}
+	return selector
+}
+
+func (drq *DataRequestQuery) prepareQuery(ctx context.Context) error {
+	if drq.path != nil {
+		prev, err := drq.path(ctx)
+		if err != nil {
+			return err
+		}
+		drq.sql = prev
+	}
+	if err := datarequest.Policy.EvalQuery(ctx, drq); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (drq *DataRequestQuery) sqlAll(ctx context.Context) ([]*DataRequest, error) {
+	var (
+		nodes = []*DataRequest{}
+		_spec = drq.querySpec()
+	)
+	_spec.ScanValues = func() []interface{} {
+		node := &DataRequest{config: drq.config}
+		nodes = append(nodes, node)
+		values := node.scanValues()
+		return values
+	}
+	_spec.Assign = func(values ...interface{}) error {
+		if len(nodes) == 0 {
+			return fmt.Errorf("ent: Assign called without calling ScanValues")
+		}
+		node := nodes[len(nodes)-1]
+		return node.assignValues(values...)
+	}
+	if err := sqlgraph.QueryNodes(ctx, drq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (drq *DataRequestQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := drq.querySpec()
+	return sqlgraph.CountNodes(ctx, drq.driver, _spec)
+}
+
+func (drq *DataRequestQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := drq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ent: check existence: %v", err)
+	}
+	return n > 0, nil
+}
+
+func (drq *DataRequestQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   datarequest.Table,
+			Columns: datarequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: datarequest.FieldID,
+			},
+		},
+		From:   drq.sql,
+		Unique: true,
+	}
+	if ps := drq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := drq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := drq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := drq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector, datarequest.ValidColumn)
+			}
+		}
+	}
+	return _spec
+}
+
+func (drq *DataRequestQuery) sqlQuery() *sql.Selector {
+	builder := sql.Dialect(drq.driver.Dialect())
+	t1 := builder.Table(datarequest.Table)
+	selector := builder.Select(t1.Columns(datarequest.Columns...)...).From(t1)
+	if drq.sql != nil {
+		selector = drq.sql
+		selector.Select(selector.Columns(datarequest.Columns...)...)
+	}
+	for _, p := range drq.predicates {
+		p(selector)
+	}
+	for _, p := range drq.order {
+		p(selector, datarequest.ValidColumn)
+	}
+	if offset := drq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := drq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// DataRequestGroupBy is the builder for group-by DataRequest entities.
+type DataRequestGroupBy struct {
+	config
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (drgb *DataRequestGroupBy) Aggregate(fns ...AggregateFunc) *DataRequestGroupBy {
+	drgb.fns = append(drgb.fns, fns...)
+	return drgb
+}
+
+// Scan applies the group-by query and scan the result into the given value.
+func (drgb *DataRequestGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := drgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	drgb.sql = query
+	return drgb.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) ScanX(ctx context.Context, v interface{}) {
+	if err := drgb.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from group-by. It is only allowed when querying group-by with one field.
+func (drgb *DataRequestGroupBy) Strings(ctx context.Context) ([]string, error) {
+	if len(drgb.fields) > 1 {
+		return nil, errors.New("ent: DataRequestGroupBy.Strings is not achievable when grouping more than 1 field")
+	}
+	var v []string
+	if err := drgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) StringsX(ctx context.Context) []string {
+	v, err := drgb.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from group-by. It is only allowed when querying group-by with one field.
+func (drgb *DataRequestGroupBy) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = drgb.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = fmt.Errorf("ent: DataRequestGroupBy.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) StringX(ctx context.Context) string {
+	v, err := drgb.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from group-by. It is only allowed when querying group-by with one field.
+func (drgb *DataRequestGroupBy) Ints(ctx context.Context) ([]int, error) {
+	if len(drgb.fields) > 1 {
+		return nil, errors.New("ent: DataRequestGroupBy.Ints is not achievable when grouping more than 1 field")
+	}
+	var v []int
+	if err := drgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) IntsX(ctx context.Context) []int {
+	v, err := drgb.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from group-by. It is only allowed when querying group-by with one field.
+func (drgb *DataRequestGroupBy) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = drgb.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = fmt.Errorf("ent: DataRequestGroupBy.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) IntX(ctx context.Context) int {
+	v, err := drgb.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from group-by. It is only allowed when querying group-by with one field.
+func (drgb *DataRequestGroupBy) Float64s(ctx context.Context) ([]float64, error) {
+	if len(drgb.fields) > 1 {
+		return nil, errors.New("ent: DataRequestGroupBy.Float64s is not achievable when grouping more than 1 field")
+	}
+	var v []float64
+	if err := drgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) Float64sX(ctx context.Context) []float64 {
+	v, err := drgb.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from group-by. It is only allowed when querying group-by with one field.
+func (drgb *DataRequestGroupBy) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = drgb.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = fmt.Errorf("ent: DataRequestGroupBy.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) Float64X(ctx context.Context) float64 {
+	v, err := drgb.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from group-by. It is only allowed when querying group-by with one field.
+func (drgb *DataRequestGroupBy) Bools(ctx context.Context) ([]bool, error) {
+	if len(drgb.fields) > 1 {
+		return nil, errors.New("ent: DataRequestGroupBy.Bools is not achievable when grouping more than 1 field")
+	}
+	var v []bool
+	if err := drgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) BoolsX(ctx context.Context) []bool {
+	v, err := drgb.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from group-by. It is only allowed when querying group-by with one field.
+func (drgb *DataRequestGroupBy) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = drgb.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = fmt.Errorf("ent: DataRequestGroupBy.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (drgb *DataRequestGroupBy) BoolX(ctx context.Context) bool {
+	v, err := drgb.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (drgb *DataRequestGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range drgb.fields {
+		if !datarequest.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := drgb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := drgb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (drgb *DataRequestGroupBy) sqlQuery() *sql.Selector {
+	selector := drgb.sql
+	columns := make([]string, 0, len(drgb.fields)+len(drgb.fns))
+	columns = append(columns, drgb.fields...)
+	for _, fn := range drgb.fns {
+		columns = append(columns, fn(selector, datarequest.ValidColumn))
+	}
+	return selector.Select(columns...).GroupBy(drgb.fields...)
+}
+
+// DataRequestSelect is the builder for select fields of DataRequest entities.
+type DataRequestSelect struct {
+	config
+	fields []string
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Scan applies the selector query and scan the result into the given value.
+func (drs *DataRequestSelect) Scan(ctx context.Context, v interface{}) error {
+	query, err := drs.path(ctx)
+	if err != nil {
+		return err
+	}
+	drs.sql = query
+	return drs.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (drs *DataRequestSelect) ScanX(ctx context.Context, v interface{}) {
+	if err := drs.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from selector. It is only allowed when selecting one field.
+func (drs *DataRequestSelect) Strings(ctx context.Context) ([]string, error) {
+	if len(drs.fields) > 1 {
+		return nil, errors.New("ent: DataRequestSelect.Strings is not achievable when selecting more than 1 field")
+	}
+	var v []string
+	if err := drs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (drs *DataRequestSelect) StringsX(ctx context.Context) []string {
+	v, err := drs.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from selector. It is only allowed when selecting one field.
+func (drs *DataRequestSelect) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = drs.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = fmt.Errorf("ent: DataRequestSelect.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (drs *DataRequestSelect) StringX(ctx context.Context) string {
+	v, err := drs.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from selector. It is only allowed when selecting one field.
+func (drs *DataRequestSelect) Ints(ctx context.Context) ([]int, error) {
+	if len(drs.fields) > 1 {
+		return nil, errors.New("ent: DataRequestSelect.Ints is not achievable when selecting more than 1 field")
+	}
+	var v []int
+	if err := drs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (drs *DataRequestSelect) IntsX(ctx context.Context) []int {
+	v, err := drs.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from selector. It is only allowed when selecting one field.
+func (drs *DataRequestSelect) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = drs.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = fmt.Errorf("ent: DataRequestSelect.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (drs *DataRequestSelect) IntX(ctx context.Context) int {
+	v, err := drs.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from selector. It is only allowed when selecting one field.
+func (drs *DataRequestSelect) Float64s(ctx context.Context) ([]float64, error) {
+	if len(drs.fields) > 1 {
+		return nil, errors.New("ent: DataRequestSelect.Float64s is not achievable when selecting more than 1 field")
+	}
+	var v []float64
+	if err := drs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (drs *DataRequestSelect) Float64sX(ctx context.Context) []float64 {
+	v, err := drs.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from selector. It is only allowed when selecting one field.
+func (drs *DataRequestSelect) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = drs.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = fmt.Errorf("ent: DataRequestSelect.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (drs *DataRequestSelect) Float64X(ctx context.Context) float64 {
+	v, err := drs.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from selector. It is only allowed when selecting one field.
+func (drs *DataRequestSelect) Bools(ctx context.Context) ([]bool, error) {
+	if len(drs.fields) > 1 {
+		return nil, errors.New("ent: DataRequestSelect.Bools is not achievable when selecting more than 1 field")
+	}
+	var v []bool
+	if err := drs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (drs *DataRequestSelect) BoolsX(ctx context.Context) []bool {
+	v, err := drs.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from selector. It is only allowed when selecting one field.
+func (drs *DataRequestSelect) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = drs.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{datarequest.Label}
+	default:
+		err = fmt.Errorf("ent: DataRequestSelect.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (drs *DataRequestSelect) BoolX(ctx context.Context) bool {
+	v, err := drs.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (drs *DataRequestSelect) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range drs.fields {
+		if !datarequest.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for selection", f)}
+		}
+	}
+	rows := &sql.Rows{}
+	query, args := drs.sqlQuery().Query()
+	if err := drs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (drs *DataRequestSelect) sqlQuery() sql.Querier {
+	selector := drs.sql
+	selector.Select(selector.Columns(drs.fields...)...)
+	return selector
+}
@@ -0,0 +1,604 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/datarequest"
+	"go-api/ent/predicate"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// DataRequestUpdate is the builder for updating DataRequest entities.
+type DataRequestUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DataRequestMutation
+}
+
+// Where adds a new predicate for the builder.
+func (dru *DataRequestUpdate) Where(ps ...predicate.DataRequest) *DataRequestUpdate {
+	dru.mutation.predicates = append(dru.mutation.predicates, ps...)
+	return dru
+}
+
+// SetStatus sets the status field.
+func (dru *DataRequestUpdate) SetStatus(d datarequest.Status) *DataRequestUpdate {
+	dru.mutation.SetStatus(d)
+	return dru
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (dru *DataRequestUpdate) SetNillableStatus(d *datarequest.Status) *DataRequestUpdate {
+	if d != nil {
+		dru.SetStatus(*d)
+	}
+	return dru
+}
+
+// SetObjectKey sets the object_key field.
+func (dru *DataRequestUpdate) SetObjectKey(s string) *DataRequestUpdate {
+	dru.mutation.SetObjectKey(s)
+	return dru
+}
+
+// SetNillableObjectKey sets the object_key field if the given value is not nil.
+func (dru *DataRequestUpdate) SetNillableObjectKey(s *string) *DataRequestUpdate {
+	if s != nil {
+		dru.SetObjectKey(*s)
+	}
+	return dru
+}
+
+// SetError sets the error field.
+func (dru *DataRequestUpdate) SetError(s string) *DataRequestUpdate {
+	dru.mutation.SetError(s)
+	return dru
+}
+
+// SetNillableError sets the error field if the given value is not nil.
+func (dru *DataRequestUpdate) SetNillableError(s *string) *DataRequestUpdate {
+	if s != nil {
+		dru.SetError(*s)
+	}
+	return dru
+}
+
+// SetScheduledAt sets the scheduled_at field.
+func (dru *DataRequestUpdate) SetScheduledAt(t time.Time) *DataRequestUpdate {
+	dru.mutation.SetScheduledAt(t)
+	return dru
+}
+
+// SetNillableScheduledAt sets the scheduled_at field if the given value is not nil.
+func (dru *DataRequestUpdate) SetNillableScheduledAt(t *time.Time) *DataRequestUpdate {
+	if t != nil {
+		dru.SetScheduledAt(*t)
+	}
+	return dru
+}
+
+// ClearScheduledAt clears the value of scheduled_at.
+func (dru *DataRequestUpdate) ClearScheduledAt() *DataRequestUpdate {
+	dru.mutation.ClearScheduledAt()
+	return dru
+}
+
+// SetExpiresAt sets the expires_at field.
+func (dru *DataRequestUpdate) SetExpiresAt(t time.Time) *DataRequestUpdate {
+	dru.mutation.SetExpiresAt(t)
+	return dru
+}
+
+// SetNillableExpiresAt sets the expires_at field if the given value is not nil.
+func (dru *DataRequestUpdate) SetNillableExpiresAt(t *time.Time) *DataRequestUpdate {
+	if t != nil {
+		dru.SetExpiresAt(*t)
+	}
+	return dru
+}
+
+// ClearExpiresAt clears the value of expires_at.
+func (dru *DataRequestUpdate) ClearExpiresAt() *DataRequestUpdate {
+	dru.mutation.ClearExpiresAt()
+	return dru
+}
+
+// SetCompletedAt sets the completed_at field.
+func (dru *DataRequestUpdate) SetCompletedAt(t time.Time) *DataRequestUpdate {
+	dru.mutation.SetCompletedAt(t)
+	return dru
+}
+
+// SetNillableCompletedAt sets the completed_at field if the given value is not nil.
+func (dru *DataRequestUpdate) SetNillableCompletedAt(t *time.Time) *DataRequestUpdate {
+	if t != nil {
+		dru.SetCompletedAt(*t)
+	}
+	return dru
+}
+
+// ClearCompletedAt clears the value of completed_at.
+func (dru *DataRequestUpdate) ClearCompletedAt() *DataRequestUpdate {
+	dru.mutation.ClearCompletedAt()
+	return dru
+}
+
+// SetCreatedAt sets the created_at field.
+func (dru *DataRequestUpdate) SetCreatedAt(t time.Time) *DataRequestUpdate {
+	dru.mutation.SetCreatedAt(t)
+	return dru
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (dru *DataRequestUpdate) SetNillableCreatedAt(t *time.Time) *DataRequestUpdate {
+	if t != nil {
+		dru.SetCreatedAt(*t)
+	}
+	return dru
+}
+
+// Mutation returns the DataRequestMutation object of the builder.
+func (dru *DataRequestUpdate) Mutation() *DataRequestMutation {
+	return dru.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (dru *DataRequestUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(dru.hooks) == 0 {
+		if err = dru.check(); err != nil {
+			return 0, err
+		}
+		affected, err = dru.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*DataRequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = dru.check(); err != nil {
+				return 0, err
+			}
+			dru.mutation = mutation
+			affected, err = dru.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(dru.hooks) - 1; i >= 0; i-- {
+			mut = dru.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, dru.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (dru *DataRequestUpdate) SaveX(ctx context.Context) int {
+	affected, err := dru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (dru *DataRequestUpdate) Exec(ctx context.Context) error {
+	_, err := dru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (dru *DataRequestUpdate) ExecX(ctx context.Context) {
+	if err := dru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (dru *DataRequestUpdate) check() error {
+	if v, ok := dru.mutation.Status(); ok {
+		if err := datarequest.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf("ent: validator failed for field \"status\": %w", err)}
+		}
+	}
+	return nil
+}
+
+func (dru *DataRequestUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   datarequest.Table,
+			Columns: datarequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: datarequest.FieldID,
+			},
+		},
+	}
+	if ps := dru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := dru.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: datarequest.FieldStatus,
+		})
+	}
+	if value, ok := dru.mutation.ObjectKey(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: datarequest.FieldObjectKey,
+		})
+	}
+	if value, ok := dru.mutation.Error(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: datarequest.FieldError,
+		})
+	}
+	if value, ok := dru.mutation.ScheduledAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldScheduledAt,
+		})
+	}
+	if dru.mutation.ScheduledAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: datarequest.FieldScheduledAt,
+		})
+	}
+	if value, ok := dru.mutation.ExpiresAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldExpiresAt,
+		})
+	}
+	if dru.mutation.ExpiresAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: datarequest.FieldExpiresAt,
+		})
+	}
+	if value, ok := dru.mutation.CompletedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldCompletedAt,
+		})
+	}
+	if dru.mutation.CompletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: datarequest.FieldCompletedAt,
+		})
+	}
+	if value, ok := dru.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldCreatedAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, dru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{datarequest.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// DataRequestUpdateOne is the builder for updating a single DataRequest entity.
+type DataRequestUpdateOne struct {
+	config
+	hooks    []Hook
+	mutation *DataRequestMutation
+}
+
+// SetStatus sets the status field.
+func (druo *DataRequestUpdateOne) SetStatus(d datarequest.Status) *DataRequestUpdateOne {
+	druo.mutation.SetStatus(d)
+	return druo
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (druo *DataRequestUpdateOne) SetNillableStatus(d *datarequest.Status) *DataRequestUpdateOne {
+	if d != nil {
+		druo.SetStatus(*d)
+	}
+	return druo
+}
+
+// SetObjectKey sets the object_key field.
+func (druo *DataRequestUpdateOne) SetObjectKey(s string) *DataRequestUpdateOne {
+	druo.mutation.SetObjectKey(s)
+	return druo
+}
+
+// SetNillableObjectKey sets the object_key field if the given value is not nil.
+func (druo *DataRequestUpdateOne) SetNillableObjectKey(s *string) *DataRequestUpdateOne {
+	if s != nil {
+		druo.SetObjectKey(*s)
+	}
+	return druo
+}
+
+// SetError sets the error field.
+func (druo *DataRequestUpdateOne) SetError(s string) *DataRequestUpdateOne {
+	druo.mutation.SetError(s)
+	return druo
+}
+
+// SetNillableError sets the error field if the given value is not nil.
+func (druo *DataRequestUpdateOne) SetNillableError(s *string) *DataRequestUpdateOne {
+	if s != nil {
+		druo.SetError(*s)
+	}
+	return druo
+}
+
+// SetScheduledAt sets the scheduled_at field.
+func (druo *DataRequestUpdateOne) SetScheduledAt(t time.Time) *DataRequestUpdateOne {
+	druo.mutation.SetScheduledAt(t)
+	return druo
+}
+
+// SetNillableScheduledAt sets the scheduled_at field if the given value is not nil.
+func (druo *DataRequestUpdateOne) SetNillableScheduledAt(t *time.Time) *DataRequestUpdateOne {
+	if t != nil {
+		druo.SetScheduledAt(*t)
+	}
+	return druo
+}
+
+// ClearScheduledAt clears the value of scheduled_at.
+func (druo *DataRequestUpdateOne) ClearScheduledAt() *DataRequestUpdateOne {
+	druo.mutation.ClearScheduledAt()
+	return druo
+}
+
+// SetExpiresAt sets the expires_at field.
+func (druo *DataRequestUpdateOne) SetExpiresAt(t time.Time) *DataRequestUpdateOne {
+	druo.mutation.SetExpiresAt(t)
+	return druo
+}
+
+// SetNillableExpiresAt sets the expires_at field if the given value is not nil.
+func (druo *DataRequestUpdateOne) SetNillableExpiresAt(t *time.Time) *DataRequestUpdateOne {
+	if t != nil {
+		druo.SetExpiresAt(*t)
+	}
+	return druo
+}
+
+// ClearExpiresAt clears the value of expires_at.
+func (druo *DataRequestUpdateOne) ClearExpiresAt() *DataRequestUpdateOne {
+	druo.mutation.ClearExpiresAt()
+	return druo
+}
+
+// SetCompletedAt sets the completed_at field.
+func (druo *DataRequestUpdateOne) SetCompletedAt(t time.Time) *DataRequestUpdateOne {
+	druo.mutation.SetCompletedAt(t)
+	return druo
+}
+
+// SetNillableCompletedAt sets the completed_at field if the given value is not nil.
+func (druo *DataRequestUpdateOne) SetNillableCompletedAt(t *time.Time) *DataRequestUpdateOne {
+	if t != nil {
+		druo.SetCompletedAt(*t)
+	}
+	return druo
+}
+
+// ClearCompletedAt clears the value of completed_at.
+func (druo *DataRequestUpdateOne) ClearCompletedAt() *DataRequestUpdateOne {
+	druo.mutation.ClearCompletedAt()
+	return druo
+}
+
+// SetCreatedAt sets the created_at field.
+func (druo *DataRequestUpdateOne) SetCreatedAt(t time.Time) *DataRequestUpdateOne {
+	druo.mutation.SetCreatedAt(t)
+	return druo
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (druo *DataRequestUpdateOne) SetNillableCreatedAt(t *time.Time) *DataRequestUpdateOne {
+	if t != nil {
+		druo.SetCreatedAt(*t)
+	}
+	return druo
+}
+
+// Mutation returns the DataRequestMutation object of the builder.
+func (druo *DataRequestUpdateOne) Mutation() *DataRequestMutation {
+	return druo.mutation
+}
+
+// Save executes the query and returns the updated entity.
+func (druo *DataRequestUpdateOne) Save(ctx context.Context) (*DataRequest, error) {
+	var (
+		err  error
+		node *DataRequest
+	)
+	if len(druo.hooks) == 0 {
+		if err = druo.check(); err != nil {
+			return nil, err
+		}
+		node, err = druo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*DataRequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = druo.check(); err != nil {
+				return nil, err
+			}
+			druo.mutation = mutation
+			node, err = druo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(druo.hooks) - 1; i >= 0; i-- {
+			mut = druo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, druo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (druo *DataRequestUpdateOne) SaveX(ctx context.Context) *DataRequest {
+	node, err := druo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (druo *DataRequestUpdateOne) Exec(ctx context.Context) error {
+	_, err := druo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (druo *DataRequestUpdateOne) ExecX(ctx context.Context) {
+	if err := druo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (druo *DataRequestUpdateOne) check() error {
+	if v, ok := druo.mutation.Status(); ok {
+		if err := datarequest.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf("ent: validator failed for field \"status\": %w", err)}
+		}
+	}
+	return nil
+}
+
+func (druo *DataRequestUpdateOne) sqlSave(ctx context.Context) (_node *DataRequest, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   datarequest.Table,
+			Columns: datarequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: datarequest.FieldID,
+			},
+		},
+	}
+	id, ok := druo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "ID", err: fmt.Errorf("missing DataRequest.ID for update")}
+	}
+	_spec.Node.ID.Value = id
+	if value, ok := druo.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: datarequest.FieldStatus,
+		})
+	}
+	if value, ok := druo.mutation.ObjectKey(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: datarequest.FieldObjectKey,
+		})
+	}
+	if value, ok := druo.mutation.Error(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: datarequest.FieldError,
+		})
+	}
+	if value, ok := druo.mutation.ScheduledAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldScheduledAt,
+		})
+	}
+	if druo.mutation.ScheduledAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: datarequest.FieldScheduledAt,
+		})
+	}
+	if value, ok := druo.mutation.ExpiresAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldExpiresAt,
+		})
+	}
+	if druo.mutation.ExpiresAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: datarequest.FieldExpiresAt,
+		})
+	}
+	if value, ok := druo.mutation.CompletedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldCompletedAt,
+		})
+	}
+	if druo.mutation.CompletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: datarequest.FieldCompletedAt,
+		})
+	}
+	if value, ok := druo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: datarequest.FieldCreatedAt,
+		})
+	}
+	_node = &DataRequest{config: druo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues()
+	if err = sqlgraph.UpdateNode(ctx, druo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{datarequest.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	return _node, nil
+}
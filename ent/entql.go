@@ -0,0 +1,1250 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"go-api/ent/auditlog"
+	"go-api/ent/datarequest"
+	"go-api/ent/identity"
+	"go-api/ent/oauthclient"
+	"go-api/ent/outbox"
+	"go-api/ent/pet"
+	"go-api/ent/predicate"
+	"go-api/ent/tenant"
+	"go-api/ent/user"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/entql"
+	"github.com/facebook/ent/schema/field"
+)
+
+// schemaGraph holds a representation of ent/schema at runtime.
+var schemaGraph = func() *sqlgraph.Schema {
+	graph := &sqlgraph.Schema{Nodes: make([]*sqlgraph.Node, 10)}
+	graph.Nodes[0] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   auditlog.Table,
+			Columns: auditlog.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: auditlog.FieldID,
+			},
+		},
+		Type: "AuditLog",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			auditlog.FieldTenantID:  {Type: field.TypeInt, Column: auditlog.FieldTenantID},
+			auditlog.FieldActorID:   {Type: field.TypeInt, Column: auditlog.FieldActorID},
+			auditlog.FieldAction:    {Type: field.TypeString, Column: auditlog.FieldAction},
+			auditlog.FieldTarget:    {Type: field.TypeString, Column: auditlog.FieldTarget},
+			auditlog.FieldBefore:    {Type: field.TypeString, Column: auditlog.FieldBefore},
+			auditlog.FieldAfter:     {Type: field.TypeString, Column: auditlog.FieldAfter},
+			auditlog.FieldCreatedAt: {Type: field.TypeTime, Column: auditlog.FieldCreatedAt},
+		},
+	}
+	graph.Nodes[1] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   datarequest.Table,
+			Columns: datarequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: datarequest.FieldID,
+			},
+		},
+		Type: "DataRequest",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			datarequest.FieldTenantID:    {Type: field.TypeInt, Column: datarequest.FieldTenantID},
+			datarequest.FieldUserID:      {Type: field.TypeInt, Column: datarequest.FieldUserID},
+			datarequest.FieldKind:        {Type: field.TypeEnum, Column: datarequest.FieldKind},
+			datarequest.FieldStatus:      {Type: field.TypeEnum, Column: datarequest.FieldStatus},
+			datarequest.FieldObjectKey:   {Type: field.TypeString, Column: datarequest.FieldObjectKey},
+			datarequest.FieldError:       {Type: field.TypeString, Column: datarequest.FieldError},
+			datarequest.FieldScheduledAt: {Type: field.TypeTime, Column: datarequest.FieldScheduledAt},
+			datarequest.FieldExpiresAt:   {Type: field.TypeTime, Column: datarequest.FieldExpiresAt},
+			datarequest.FieldCompletedAt: {Type: field.TypeTime, Column: datarequest.FieldCompletedAt},
+			datarequest.FieldCreatedAt:   {Type: field.TypeTime, Column: datarequest.FieldCreatedAt},
+		},
+	}
+	graph.Nodes[2] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   identity.Table,
+			Columns: identity.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: identity.FieldID,
+			},
+		},
+		Type: "Identity",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			identity.FieldTenantID:  {Type: field.TypeInt, Column: identity.FieldTenantID},
+			identity.FieldProvider:  {Type: field.TypeString, Column: identity.FieldProvider},
+			identity.FieldSubject:   {Type: field.TypeString, Column: identity.FieldSubject},
+			identity.FieldEmail:     {Type: field.TypeString, Column: identity.FieldEmail},
+			identity.FieldCreatedAt: {Type: field.TypeTime, Column: identity.FieldCreatedAt},
+		},
+	}
+	graph.Nodes[3] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   oauthclient.Table,
+			Columns: oauthclient.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: oauthclient.FieldID,
+			},
+		},
+		Type: "OAuthClient",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			oauthclient.FieldTenantID:     {Type: field.TypeInt, Column: oauthclient.FieldTenantID},
+			oauthclient.FieldClientID:     {Type: field.TypeString, Column: oauthclient.FieldClientID},
+			oauthclient.FieldSecretHash:   {Type: field.TypeString, Column: oauthclient.FieldSecretHash},
+			oauthclient.FieldName:         {Type: field.TypeString, Column: oauthclient.FieldName},
+			oauthclient.FieldRedirectUris: {Type: field.TypeString, Column: oauthclient.FieldRedirectUris},
+			oauthclient.FieldScopes:       {Type: field.TypeString, Column: oauthclient.FieldScopes},
+			oauthclient.FieldGrantTypes:   {Type: field.TypeString, Column: oauthclient.FieldGrantTypes},
+			oauthclient.FieldCreatedBy:    {Type: field.TypeInt, Column: oauthclient.FieldCreatedBy},
+			oauthclient.FieldCreatedAt:    {Type: field.TypeTime, Column: oauthclient.FieldCreatedAt},
+		},
+	}
+	graph.Nodes[4] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   outbox.Table,
+			Columns: outbox.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outbox.FieldID,
+			},
+		},
+		Type: "Outbox",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			outbox.FieldAggregateType: {Type: field.TypeString, Column: outbox.FieldAggregateType},
+			outbox.FieldAggregateID:   {Type: field.TypeString, Column: outbox.FieldAggregateID},
+			outbox.FieldEventType:     {Type: field.TypeString, Column: outbox.FieldEventType},
+			outbox.FieldPayload:       {Type: field.TypeBytes, Column: outbox.FieldPayload},
+			outbox.FieldCreatedAt:     {Type: field.TypeTime, Column: outbox.FieldCreatedAt},
+			outbox.FieldPublishedAt:   {Type: field.TypeTime, Column: outbox.FieldPublishedAt},
+			outbox.FieldAttempts:      {Type: field.TypeInt, Column: outbox.FieldAttempts},
+			outbox.FieldLastError:     {Type: field.TypeString, Column: outbox.FieldLastError},
+			outbox.FieldDeadAt:        {Type: field.TypeTime, Column: outbox.FieldDeadAt},
+		},
+	}
+	graph.Nodes[5] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   pet.Table,
+			Columns: pet.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: pet.FieldID,
+			},
+		},
+		Type: "Pet",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			pet.FieldDeletedAt: {Type: field.TypeTime, Column: pet.FieldDeletedAt},
+			pet.FieldTenantID:  {Type: field.TypeInt, Column: pet.FieldTenantID},
+			pet.FieldCreatedAt: {Type: field.TypeTime, Column: pet.FieldCreatedAt},
+			pet.FieldUpdatedAt: {Type: field.TypeTime, Column: pet.FieldUpdatedAt},
+			pet.FieldVersion:   {Type: field.TypeInt, Column: pet.FieldVersion},
+			pet.FieldName:      {Type: field.TypeString, Column: pet.FieldName},
+			pet.FieldSpecies:   {Type: field.TypeString, Column: pet.FieldSpecies},
+			pet.FieldBirthday:  {Type: field.TypeTime, Column: pet.FieldBirthday},
+		},
+	}
+	graph.Nodes[6] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   tenant.Table,
+			Columns: tenant.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: tenant.FieldID,
+			},
+		},
+		Type: "Tenant",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			tenant.FieldName:      {Type: field.TypeString, Column: tenant.FieldName},
+			tenant.FieldStatus:    {Type: field.TypeString, Column: tenant.FieldStatus},
+			tenant.FieldConfig:    {Type: field.TypeString, Column: tenant.FieldConfig},
+			tenant.FieldCreatedAt: {Type: field.TypeTime, Column: tenant.FieldCreatedAt},
+			tenant.FieldUpdatedAt: {Type: field.TypeTime, Column: tenant.FieldUpdatedAt},
+		},
+	}
+	graph.Nodes[7] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   user.Table,
+			Columns: user.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: user.FieldID,
+			},
+		},
+		Type: "User",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			user.FieldDeletedAt:      {Type: field.TypeTime, Column: user.FieldDeletedAt},
+			user.FieldTenantID:       {Type: field.TypeInt, Column: user.FieldTenantID},
+			user.FieldCreatedAt:      {Type: field.TypeTime, Column: user.FieldCreatedAt},
+			user.FieldUpdatedAt:      {Type: field.TypeTime, Column: user.FieldUpdatedAt},
+			user.FieldVersion:        {Type: field.TypeInt, Column: user.FieldVersion},
+			user.FieldUsername:       {Type: field.TypeString, Column: user.FieldUsername},
+			user.FieldPasswordDigest: {Type: field.TypeString, Column: user.FieldPasswordDigest},
+			user.FieldNickname:       {Type: field.TypeString, Column: user.FieldNickname},
+			user.FieldStatus:         {Type: field.TypeString, Column: user.FieldStatus},
+			user.FieldAvatar:         {Type: field.TypeString, Column: user.FieldAvatar},
+			user.FieldRole:           {Type: field.TypeString, Column: user.FieldRole},
+		},
+	}
+	graph.Nodes[8] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   webhookdelivery.Table,
+			Columns: webhookdelivery.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookdelivery.FieldID,
+			},
+		},
+		Type: "WebhookDelivery",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			webhookdelivery.FieldTenantID:       {Type: field.TypeInt, Column: webhookdelivery.FieldTenantID},
+			webhookdelivery.FieldEventID:        {Type: field.TypeInt, Column: webhookdelivery.FieldEventID},
+			webhookdelivery.FieldEventType:      {Type: field.TypeString, Column: webhookdelivery.FieldEventType},
+			webhookdelivery.FieldUserID:         {Type: field.TypeInt, Column: webhookdelivery.FieldUserID},
+			webhookdelivery.FieldPayload:        {Type: field.TypeBytes, Column: webhookdelivery.FieldPayload},
+			webhookdelivery.FieldStatus:         {Type: field.TypeEnum, Column: webhookdelivery.FieldStatus},
+			webhookdelivery.FieldAttempts:       {Type: field.TypeInt, Column: webhookdelivery.FieldAttempts},
+			webhookdelivery.FieldResponseStatus: {Type: field.TypeInt, Column: webhookdelivery.FieldResponseStatus},
+			webhookdelivery.FieldResponseBody:   {Type: field.TypeString, Column: webhookdelivery.FieldResponseBody},
+			webhookdelivery.FieldError:          {Type: field.TypeString, Column: webhookdelivery.FieldError},
+			webhookdelivery.FieldDurationMs:     {Type: field.TypeInt64, Column: webhookdelivery.FieldDurationMs},
+			webhookdelivery.FieldReplayOf:       {Type: field.TypeInt, Column: webhookdelivery.FieldReplayOf},
+			webhookdelivery.FieldCreatedAt:      {Type: field.TypeTime, Column: webhookdelivery.FieldCreatedAt},
+			webhookdelivery.FieldLastAttemptAt:  {Type: field.TypeTime, Column: webhookdelivery.FieldLastAttemptAt},
+			webhookdelivery.FieldDeliveredAt:    {Type: field.TypeTime, Column: webhookdelivery.FieldDeliveredAt},
+		},
+	}
+	graph.Nodes[9] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   webhookendpoint.Table,
+			Columns: webhookendpoint.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookendpoint.FieldID,
+			},
+		},
+		Type: "WebhookEndpoint",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			webhookendpoint.FieldTenantID:    {Type: field.TypeInt, Column: webhookendpoint.FieldTenantID},
+			webhookendpoint.FieldOwnerID:     {Type: field.TypeInt, Column: webhookendpoint.FieldOwnerID},
+			webhookendpoint.FieldURL:         {Type: field.TypeString, Column: webhookendpoint.FieldURL},
+			webhookendpoint.FieldSecret:      {Type: field.TypeString, Column: webhookendpoint.FieldSecret},
+			webhookendpoint.FieldEvents:      {Type: field.TypeString, Column: webhookendpoint.FieldEvents},
+			webhookendpoint.FieldDescription: {Type: field.TypeString, Column: webhookendpoint.FieldDescription},
+			webhookendpoint.FieldEnabled:     {Type: field.TypeBool, Column: webhookendpoint.FieldEnabled},
+			webhookendpoint.FieldFailures:    {Type: field.TypeInt, Column: webhookendpoint.FieldFailures},
+			webhookendpoint.FieldDisabledAt:  {Type: field.TypeTime, Column: webhookendpoint.FieldDisabledAt},
+			webhookendpoint.FieldCreatedAt:   {Type: field.TypeTime, Column: webhookendpoint.FieldCreatedAt},
+			webhookendpoint.FieldUpdatedAt:   {Type: field.TypeTime, Column: webhookendpoint.FieldUpdatedAt},
+		},
+	}
+	graph.MustAddE(
+		"user",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   identity.UserTable,
+			Columns: []string{identity.UserColumn},
+			Bidi:    false,
+		},
+		"Identity",
+		"User",
+	)
+	graph.MustAddE(
+		"owner",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pet.OwnerTable,
+			Columns: []string{pet.OwnerColumn},
+			Bidi:    false,
+		},
+		"Pet",
+		"User",
+	)
+	graph.MustAddE(
+		"pets",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.PetsTable,
+			Columns: []string{user.PetsColumn},
+			Bidi:    false,
+		},
+		"User",
+		"Pet",
+	)
+	graph.MustAddE(
+		"identities",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.IdentitiesTable,
+			Columns: []string{user.IdentitiesColumn},
+			Bidi:    false,
+		},
+		"User",
+		"Identity",
+	)
+	graph.MustAddE(
+		"endpoint",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   webhookdelivery.EndpointTable,
+			Columns: []string{webhookdelivery.EndpointColumn},
+			Bidi:    false,
+		},
+		"WebhookDelivery",
+		"WebhookEndpoint",
+	)
+	graph.MustAddE(
+		"deliveries",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+		},
+		"WebhookEndpoint",
+		"WebhookDelivery",
+	)
+	return graph
+}()
+
+// predicateAdder wraps the addPredicate method.
+// All update, update-one and query builders implement this interface.
+type predicateAdder interface {
+	addPredicate(func(s *sql.Selector))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (alq *AuditLogQuery) addPredicate(pred func(s *sql.Selector)) {
+	alq.predicates = append(alq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the AuditLogQuery builder.
+func (alq *AuditLogQuery) Filter() *AuditLogFilter {
+	return &AuditLogFilter{alq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *AuditLogMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the AuditLogMutation builder.
+func (m *AuditLogMutation) Filter() *AuditLogFilter {
+	return &AuditLogFilter{m}
+}
+
+// AuditLogFilter provides a generic filtering capability at runtime for AuditLogQuery.
+type AuditLogFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *AuditLogFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[0].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *AuditLogFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(auditlog.FieldID))
+}
+
+// WhereTenantID applies the entql int predicate on the tenant_id field.
+func (f *AuditLogFilter) WhereTenantID(p entql.IntP) {
+	f.Where(p.Field(auditlog.FieldTenantID))
+}
+
+// WhereActorID applies the entql int predicate on the actor_id field.
+func (f *AuditLogFilter) WhereActorID(p entql.IntP) {
+	f.Where(p.Field(auditlog.FieldActorID))
+}
+
+// WhereAction applies the entql string predicate on the action field.
+func (f *AuditLogFilter) WhereAction(p entql.StringP) {
+	f.Where(p.Field(auditlog.FieldAction))
+}
+
+// WhereTarget applies the entql string predicate on the target field.
+func (f *AuditLogFilter) WhereTarget(p entql.StringP) {
+	f.Where(p.Field(auditlog.FieldTarget))
+}
+
+// WhereBefore applies the entql string predicate on the before field.
+func (f *AuditLogFilter) WhereBefore(p entql.StringP) {
+	f.Where(p.Field(auditlog.FieldBefore))
+}
+
+// WhereAfter applies the entql string predicate on the after field.
+func (f *AuditLogFilter) WhereAfter(p entql.StringP) {
+	f.Where(p.Field(auditlog.FieldAfter))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *AuditLogFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(auditlog.FieldCreatedAt))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (drq *DataRequestQuery) addPredicate(pred func(s *sql.Selector)) {
+	drq.predicates = append(drq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the DataRequestQuery builder.
+func (drq *DataRequestQuery) Filter() *DataRequestFilter {
+	return &DataRequestFilter{drq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *DataRequestMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the DataRequestMutation builder.
+func (m *DataRequestMutation) Filter() *DataRequestFilter {
+	return &DataRequestFilter{m}
+}
+
+// DataRequestFilter provides a generic filtering capability at runtime for DataRequestQuery.
+type DataRequestFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *DataRequestFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[1].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *DataRequestFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(datarequest.FieldID))
+}
+
+// WhereTenantID applies the entql int predicate on the tenant_id field.
+func (f *DataRequestFilter) WhereTenantID(p entql.IntP) {
+	f.Where(p.Field(datarequest.FieldTenantID))
+}
+
+// WhereUserID applies the entql int predicate on the user_id field.
+func (f *DataRequestFilter) WhereUserID(p entql.IntP) {
+	f.Where(p.Field(datarequest.FieldUserID))
+}
+
+// WhereKind applies the entql string predicate on the kind field.
+func (f *DataRequestFilter) WhereKind(p entql.StringP) {
+	f.Where(p.Field(datarequest.FieldKind))
+}
+
+// WhereStatus applies the entql string predicate on the status field.
+func (f *DataRequestFilter) WhereStatus(p entql.StringP) {
+	f.Where(p.Field(datarequest.FieldStatus))
+}
+
+// WhereObjectKey applies the entql string predicate on the object_key field.
+func (f *DataRequestFilter) WhereObjectKey(p entql.StringP) {
+	f.Where(p.Field(datarequest.FieldObjectKey))
+}
+
+// WhereError applies the entql string predicate on the error field.
+func (f *DataRequestFilter) WhereError(p entql.StringP) {
+	f.Where(p.Field(datarequest.FieldError))
+}
+
+// WhereScheduledAt applies the entql time.Time predicate on the scheduled_at field.
+func (f *DataRequestFilter) WhereScheduledAt(p entql.TimeP) {
+	f.Where(p.Field(datarequest.FieldScheduledAt))
+}
+
+// WhereExpiresAt applies the entql time.Time predicate on the expires_at field.
+func (f *DataRequestFilter) WhereExpiresAt(p entql.TimeP) {
+	f.Where(p.Field(datarequest.FieldExpiresAt))
+}
+
+// WhereCompletedAt applies the entql time.Time predicate on the completed_at field.
+func (f *DataRequestFilter) WhereCompletedAt(p entql.TimeP) {
+	f.Where(p.Field(datarequest.FieldCompletedAt))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *DataRequestFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(datarequest.FieldCreatedAt))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (iq *IdentityQuery) addPredicate(pred func(s *sql.Selector)) {
+	iq.predicates = append(iq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the IdentityQuery builder.
+func (iq *IdentityQuery) Filter() *IdentityFilter {
+	return &IdentityFilter{iq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *IdentityMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the IdentityMutation builder.
+func (m *IdentityMutation) Filter() *IdentityFilter {
+	return &IdentityFilter{m}
+}
+
+// IdentityFilter provides a generic filtering capability at runtime for IdentityQuery.
+type IdentityFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *IdentityFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[2].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *IdentityFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(identity.FieldID))
+}
+
+// WhereTenantID applies the entql int predicate on the tenant_id field.
+func (f *IdentityFilter) WhereTenantID(p entql.IntP) {
+	f.Where(p.Field(identity.FieldTenantID))
+}
+
+// WhereProvider applies the entql string predicate on the provider field.
+func (f *IdentityFilter) WhereProvider(p entql.StringP) {
+	f.Where(p.Field(identity.FieldProvider))
+}
+
+// WhereSubject applies the entql string predicate on the subject field.
+func (f *IdentityFilter) WhereSubject(p entql.StringP) {
+	f.Where(p.Field(identity.FieldSubject))
+}
+
+// WhereEmail applies the entql string predicate on the email field.
+func (f *IdentityFilter) WhereEmail(p entql.StringP) {
+	f.Where(p.Field(identity.FieldEmail))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *IdentityFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(identity.FieldCreatedAt))
+}
+
+// WhereHasUser applies a predicate to check if query has an edge user.
+func (f *IdentityFilter) WhereHasUser() {
+	f.Where(entql.HasEdge("user"))
+}
+
+// WhereHasUserWith applies a predicate to check if query has an edge user with a given conditions (other predicates).
+func (f *IdentityFilter) WhereHasUserWith(preds ...predicate.User) {
+	f.Where(entql.HasEdgeWith("user", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (ocq *OAuthClientQuery) addPredicate(pred func(s *sql.Selector)) {
+	ocq.predicates = append(ocq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the OAuthClientQuery builder.
+func (ocq *OAuthClientQuery) Filter() *OAuthClientFilter {
+	return &OAuthClientFilter{ocq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *OAuthClientMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the OAuthClientMutation builder.
+func (m *OAuthClientMutation) Filter() *OAuthClientFilter {
+	return &OAuthClientFilter{m}
+}
+
+// OAuthClientFilter provides a generic filtering capability at runtime for OAuthClientQuery.
+type OAuthClientFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *OAuthClientFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[3].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *OAuthClientFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(oauthclient.FieldID))
+}
+
+// WhereTenantID applies the entql int predicate on the tenant_id field.
+func (f *OAuthClientFilter) WhereTenantID(p entql.IntP) {
+	f.Where(p.Field(oauthclient.FieldTenantID))
+}
+
+// WhereClientID applies the entql string predicate on the client_id field.
+func (f *OAuthClientFilter) WhereClientID(p entql.StringP) {
+	f.Where(p.Field(oauthclient.FieldClientID))
+}
+
+// WhereSecretHash applies the entql string predicate on the secret_hash field.
+func (f *OAuthClientFilter) WhereSecretHash(p entql.StringP) {
+	f.Where(p.Field(oauthclient.FieldSecretHash))
+}
+
+// WhereName applies the entql string predicate on the name field.
+func (f *OAuthClientFilter) WhereName(p entql.StringP) {
+	f.Where(p.Field(oauthclient.FieldName))
+}
+
+// WhereRedirectUris applies the entql string predicate on the redirect_uris field.
+func (f *OAuthClientFilter) WhereRedirectUris(p entql.StringP) {
+	f.Where(p.Field(oauthclient.FieldRedirectUris))
+}
+
+// WhereScopes applies the entql string predicate on the scopes field.
+func (f *OAuthClientFilter) WhereScopes(p entql.StringP) {
+	f.Where(p.Field(oauthclient.FieldScopes))
+}
+
+// WhereGrantTypes applies the entql string predicate on the grant_types field.
+func (f *OAuthClientFilter) WhereGrantTypes(p entql.StringP) {
+	f.Where(p.Field(oauthclient.FieldGrantTypes))
+}
+
+// WhereCreatedBy applies the entql int predicate on the created_by field.
+func (f *OAuthClientFilter) WhereCreatedBy(p entql.IntP) {
+	f.Where(p.Field(oauthclient.FieldCreatedBy))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *OAuthClientFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(oauthclient.FieldCreatedAt))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (oq *OutboxQuery) addPredicate(pred func(s *sql.Selector)) {
+	oq.predicates = append(oq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the OutboxQuery builder.
+func (oq *OutboxQuery) Filter() *OutboxFilter {
+	return &OutboxFilter{oq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *OutboxMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the OutboxMutation builder.
+func (m *OutboxMutation) Filter() *OutboxFilter {
+	return &OutboxFilter{m}
+}
+
+// OutboxFilter provides a generic filtering capability at runtime for OutboxQuery.
+type OutboxFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *OutboxFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[4].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *OutboxFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(outbox.FieldID))
+}
+
+// WhereAggregateType applies the entql string predicate on the aggregate_type field.
+func (f *OutboxFilter) WhereAggregateType(p entql.StringP) {
+	f.Where(p.Field(outbox.FieldAggregateType))
+}
+
+// WhereAggregateID applies the entql string predicate on the aggregate_id field.
+func (f *OutboxFilter) WhereAggregateID(p entql.StringP) {
+	f.Where(p.Field(outbox.FieldAggregateID))
+}
+
+// WhereEventType applies the entql string predicate on the event_type field.
+func (f *OutboxFilter) WhereEventType(p entql.StringP) {
+	f.Where(p.Field(outbox.FieldEventType))
+}
+
+// WherePayload applies the entql []byte predicate on the payload field.
+func (f *OutboxFilter) WherePayload(p entql.BytesP) {
+	f.Where(p.Field(outbox.FieldPayload))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *OutboxFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(outbox.FieldCreatedAt))
+}
+
+// WherePublishedAt applies the entql time.Time predicate on the published_at field.
+func (f *OutboxFilter) WherePublishedAt(p entql.TimeP) {
+	f.Where(p.Field(outbox.FieldPublishedAt))
+}
+
+// WhereAttempts applies the entql int predicate on the attempts field.
+func (f *OutboxFilter) WhereAttempts(p entql.IntP) {
+	f.Where(p.Field(outbox.FieldAttempts))
+}
+
+// WhereLastError applies the entql string predicate on the last_error field.
+func (f *OutboxFilter) WhereLastError(p entql.StringP) {
+	f.Where(p.Field(outbox.FieldLastError))
+}
+
+// WhereDeadAt applies the entql time.Time predicate on the dead_at field.
+func (f *OutboxFilter) WhereDeadAt(p entql.TimeP) {
+	f.Where(p.Field(outbox.FieldDeadAt))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (pq *PetQuery) addPredicate(pred func(s *sql.Selector)) {
+	pq.predicates = append(pq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the PetQuery builder.
+func (pq *PetQuery) Filter() *PetFilter {
+	return &PetFilter{pq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *PetMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the PetMutation builder.
+func (m *PetMutation) Filter() *PetFilter {
+	return &PetFilter{m}
+}
+
+// PetFilter provides a generic filtering capability at runtime for PetQuery.
+type PetFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *PetFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[5].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *PetFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(pet.FieldID))
+}
+
+// WhereDeletedAt applies the entql time.Time predicate on the deleted_at field.
+func (f *PetFilter) WhereDeletedAt(p entql.TimeP) {
+	f.Where(p.Field(pet.FieldDeletedAt))
+}
+
+// WhereTenantID applies the entql int predicate on the tenant_id field.
+func (f *PetFilter) WhereTenantID(p entql.IntP) {
+	f.Where(p.Field(pet.FieldTenantID))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *PetFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(pet.FieldCreatedAt))
+}
+
+// WhereUpdatedAt applies the entql time.Time predicate on the updated_at field.
+func (f *PetFilter) WhereUpdatedAt(p entql.TimeP) {
+	f.Where(p.Field(pet.FieldUpdatedAt))
+}
+
+// WhereVersion applies the entql int predicate on the version field.
+func (f *PetFilter) WhereVersion(p entql.IntP) {
+	f.Where(p.Field(pet.FieldVersion))
+}
+
+// WhereName applies the entql string predicate on the name field.
+func (f *PetFilter) WhereName(p entql.StringP) {
+	f.Where(p.Field(pet.FieldName))
+}
+
+// WhereSpecies applies the entql string predicate on the species field.
+func (f *PetFilter) WhereSpecies(p entql.StringP) {
+	f.Where(p.Field(pet.FieldSpecies))
+}
+
+// WhereBirthday applies the entql time.Time predicate on the birthday field.
+func (f *PetFilter) WhereBirthday(p entql.TimeP) {
+	f.Where(p.Field(pet.FieldBirthday))
+}
+
+// WhereHasOwner applies a predicate to check if query has an edge owner.
+func (f *PetFilter) WhereHasOwner() {
+	f.Where(entql.HasEdge("owner"))
+}
+
+// WhereHasOwnerWith applies a predicate to check if query has an edge owner with a given conditions (other predicates).
+func (f *PetFilter) WhereHasOwnerWith(preds ...predicate.User) {
+	f.Where(entql.HasEdgeWith("owner", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (tq *TenantQuery) addPredicate(pred func(s *sql.Selector)) {
+	tq.predicates = append(tq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the TenantQuery builder.
+func (tq *TenantQuery) Filter() *TenantFilter {
+	return &TenantFilter{tq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *TenantMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the TenantMutation builder.
+func (m *TenantMutation) Filter() *TenantFilter {
+	return &TenantFilter{m}
+}
+
+// TenantFilter provides a generic filtering capability at runtime for TenantQuery.
+type TenantFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *TenantFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[6].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *TenantFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(tenant.FieldID))
+}
+
+// WhereName applies the entql string predicate on the name field.
+func (f *TenantFilter) WhereName(p entql.StringP) {
+	f.Where(p.Field(tenant.FieldName))
+}
+
+// WhereStatus applies the entql string predicate on the status field.
+func (f *TenantFilter) WhereStatus(p entql.StringP) {
+	f.Where(p.Field(tenant.FieldStatus))
+}
+
+// WhereConfig applies the entql string predicate on the config field.
+func (f *TenantFilter) WhereConfig(p entql.StringP) {
+	f.Where(p.Field(tenant.FieldConfig))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *TenantFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(tenant.FieldCreatedAt))
+}
+
+// WhereUpdatedAt applies the entql time.Time predicate on the updated_at field.
+func (f *TenantFilter) WhereUpdatedAt(p entql.TimeP) {
+	f.Where(p.Field(tenant.FieldUpdatedAt))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (uq *UserQuery) addPredicate(pred func(s *sql.Selector)) {
+	uq.predicates = append(uq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the UserQuery builder.
+func (uq *UserQuery) Filter() *UserFilter {
+	return &UserFilter{uq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *UserMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the UserMutation builder.
+func (m *UserMutation) Filter() *UserFilter {
+	return &UserFilter{m}
+}
+
+// UserFilter provides a generic filtering capability at runtime for UserQuery.
+type UserFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *UserFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[7].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *UserFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(user.FieldID))
+}
+
+// WhereDeletedAt applies the entql time.Time predicate on the deleted_at field.
+func (f *UserFilter) WhereDeletedAt(p entql.TimeP) {
+	f.Where(p.Field(user.FieldDeletedAt))
+}
+
+// WhereTenantID applies the entql int predicate on the tenant_id field.
+func (f *UserFilter) WhereTenantID(p entql.IntP) {
+	f.Where(p.Field(user.FieldTenantID))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *UserFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(user.FieldCreatedAt))
+}
+
+// WhereUpdatedAt applies the entql time.Time predicate on the updated_at field.
+func (f *UserFilter) WhereUpdatedAt(p entql.TimeP) {
+	f.Where(p.Field(user.FieldUpdatedAt))
+}
+
+// WhereVersion applies the entql int predicate on the version field.
+func (f *UserFilter) WhereVersion(p entql.IntP) {
+	f.Where(p.Field(user.FieldVersion))
+}
+
+// WhereUsername applies the entql string predicate on the username field.
+func (f *UserFilter) WhereUsername(p entql.StringP) {
+	f.Where(p.Field(user.FieldUsername))
+}
+
+// WherePasswordDigest applies the entql string predicate on the password_digest field.
+func (f *UserFilter) WherePasswordDigest(p entql.StringP) {
+	f.Where(p.Field(user.FieldPasswordDigest))
+}
+
+// WhereNickname applies the entql string predicate on the nickname field.
+func (f *UserFilter) WhereNickname(p entql.StringP) {
+	f.Where(p.Field(user.FieldNickname))
+}
+
+// WhereStatus applies the entql string predicate on the status field.
+func (f *UserFilter) WhereStatus(p entql.StringP) {
+	f.Where(p.Field(user.FieldStatus))
+}
+
+// WhereAvatar applies the entql string predicate on the avatar field.
+func (f *UserFilter) WhereAvatar(p entql.StringP) {
+	f.Where(p.Field(user.FieldAvatar))
+}
+
+// WhereRole applies the entql string predicate on the role field.
+func (f *UserFilter) WhereRole(p entql.StringP) {
+	f.Where(p.Field(user.FieldRole))
+}
+
+// WhereHasPets applies a predicate to check if query has an edge pets.
+func (f *UserFilter) WhereHasPets() {
+	f.Where(entql.HasEdge("pets"))
+}
+
+// WhereHasPetsWith applies a predicate to check if query has an edge pets with a given conditions (other predicates).
+func (f *UserFilter) WhereHasPetsWith(preds ...predicate.Pet) {
+	f.Where(entql.HasEdgeWith("pets", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// WhereHasIdentities applies a predicate to check if query has an edge identities.
+func (f *UserFilter) WhereHasIdentities() {
+	f.Where(entql.HasEdge("identities"))
+}
+
+// WhereHasIdentitiesWith applies a predicate to check if query has an edge identities with a given conditions (other predicates).
+func (f *UserFilter) WhereHasIdentitiesWith(preds ...predicate.Identity) {
+	f.Where(entql.HasEdgeWith("identities", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (wdq *WebhookDeliveryQuery) addPredicate(pred func(s *sql.Selector)) {
+	wdq.predicates = append(wdq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the WebhookDeliveryQuery builder.
+func (wdq *WebhookDeliveryQuery) Filter() *WebhookDeliveryFilter {
+	return &WebhookDeliveryFilter{wdq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *WebhookDeliveryMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the WebhookDeliveryMutation builder.
+func (m *WebhookDeliveryMutation) Filter() *WebhookDeliveryFilter {
+	return &WebhookDeliveryFilter{m}
+}
+
+// WebhookDeliveryFilter provides a generic filtering capability at runtime for WebhookDeliveryQuery.
+type WebhookDeliveryFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *WebhookDeliveryFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[8].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *WebhookDeliveryFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(webhookdelivery.FieldID))
+}
+
+// WhereTenantID applies the entql int predicate on the tenant_id field.
+func (f *WebhookDeliveryFilter) WhereTenantID(p entql.IntP) {
+	f.Where(p.Field(webhookdelivery.FieldTenantID))
+}
+
+// WhereEventID applies the entql int predicate on the event_id field.
+func (f *WebhookDeliveryFilter) WhereEventID(p entql.IntP) {
+	f.Where(p.Field(webhookdelivery.FieldEventID))
+}
+
+// WhereEventType applies the entql string predicate on the event_type field.
+func (f *WebhookDeliveryFilter) WhereEventType(p entql.StringP) {
+	f.Where(p.Field(webhookdelivery.FieldEventType))
+}
+
+// WhereUserID applies the entql int predicate on the user_id field.
+func (f *WebhookDeliveryFilter) WhereUserID(p entql.IntP) {
+	f.Where(p.Field(webhookdelivery.FieldUserID))
+}
+
+// WherePayload applies the entql []byte predicate on the payload field.
+func (f *WebhookDeliveryFilter) WherePayload(p entql.BytesP) {
+	f.Where(p.Field(webhookdelivery.FieldPayload))
+}
+
+// WhereStatus applies the entql string predicate on the status field.
+func (f *WebhookDeliveryFilter) WhereStatus(p entql.StringP) {
+	f.Where(p.Field(webhookdelivery.FieldStatus))
+}
+
+// WhereAttempts applies the entql int predicate on the attempts field.
+func (f *WebhookDeliveryFilter) WhereAttempts(p entql.IntP) {
+	f.Where(p.Field(webhookdelivery.FieldAttempts))
+}
+
+// WhereResponseStatus applies the entql int predicate on the response_status field.
+func (f *WebhookDeliveryFilter) WhereResponseStatus(p entql.IntP) {
+	f.Where(p.Field(webhookdelivery.FieldResponseStatus))
+}
+
+// WhereResponseBody applies the entql string predicate on the response_body field.
+func (f *WebhookDeliveryFilter) WhereResponseBody(p entql.StringP) {
+	f.Where(p.Field(webhookdelivery.FieldResponseBody))
+}
+
+// WhereError applies the entql string predicate on the error field.
+func (f *WebhookDeliveryFilter) WhereError(p entql.StringP) {
+	f.Where(p.Field(webhookdelivery.FieldError))
+}
+
+// WhereDurationMs applies the entql int64 predicate on the duration_ms field.
+func (f *WebhookDeliveryFilter) WhereDurationMs(p entql.Int64P) {
+	f.Where(p.Field(webhookdelivery.FieldDurationMs))
+}
+
+// WhereReplayOf applies the entql int predicate on the replay_of field.
+func (f *WebhookDeliveryFilter) WhereReplayOf(p entql.IntP) {
+	f.Where(p.Field(webhookdelivery.FieldReplayOf))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *WebhookDeliveryFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(webhookdelivery.FieldCreatedAt))
+}
+
+// WhereLastAttemptAt applies the entql time.Time predicate on the last_attempt_at field.
+func (f *WebhookDeliveryFilter) WhereLastAttemptAt(p entql.TimeP) {
+	f.Where(p.Field(webhookdelivery.FieldLastAttemptAt))
+}
+
+// WhereDeliveredAt applies the entql time.Time predicate on the delivered_at field.
+func (f *WebhookDeliveryFilter) WhereDeliveredAt(p entql.TimeP) {
+	f.Where(p.Field(webhookdelivery.FieldDeliveredAt))
+}
+
+// WhereHasEndpoint applies a predicate to check if query has an edge endpoint.
+func (f *WebhookDeliveryFilter) WhereHasEndpoint() {
+	f.Where(entql.HasEdge("endpoint"))
+}
+
+// WhereHasEndpointWith applies a predicate to check if query has an edge endpoint with a given conditions (other predicates).
+func (f *WebhookDeliveryFilter) WhereHasEndpointWith(preds ...predicate.WebhookEndpoint) {
+	f.Where(entql.HasEdgeWith("endpoint", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (weq *WebhookEndpointQuery) addPredicate(pred func(s *sql.Selector)) {
+	weq.predicates = append(weq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the WebhookEndpointQuery builder.
+func (weq *WebhookEndpointQuery) Filter() *WebhookEndpointFilter {
+	return &WebhookEndpointFilter{weq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *WebhookEndpointMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the WebhookEndpointMutation builder.
+func (m *WebhookEndpointMutation) Filter() *WebhookEndpointFilter {
+	return &WebhookEndpointFilter{m}
+}
+
+// WebhookEndpointFilter provides a generic filtering capability at runtime for WebhookEndpointQuery.
+type WebhookEndpointFilter struct {
+	predicateAdder
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *WebhookEndpointFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[9].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql int predicate on the id field.
+func (f *WebhookEndpointFilter) WhereID(p entql.IntP) {
+	f.Where(p.Field(webhookendpoint.FieldID))
+}
+
+// WhereTenantID applies the entql int predicate on the tenant_id field.
+func (f *WebhookEndpointFilter) WhereTenantID(p entql.IntP) {
+	f.Where(p.Field(webhookendpoint.FieldTenantID))
+}
+
+// WhereOwnerID applies the entql int predicate on the owner_id field.
+func (f *WebhookEndpointFilter) WhereOwnerID(p entql.IntP) {
+	f.Where(p.Field(webhookendpoint.FieldOwnerID))
+}
+
+// WhereURL applies the entql string predicate on the url field.
+func (f *WebhookEndpointFilter) WhereURL(p entql.StringP) {
+	f.Where(p.Field(webhookendpoint.FieldURL))
+}
+
+// WhereSecret applies the entql string predicate on the secret field.
+func (f *WebhookEndpointFilter) WhereSecret(p entql.StringP) {
+	f.Where(p.Field(webhookendpoint.FieldSecret))
+}
+
+// WhereEvents applies the entql string predicate on the events field.
+func (f *WebhookEndpointFilter) WhereEvents(p entql.StringP) {
+	f.Where(p.Field(webhookendpoint.FieldEvents))
+}
+
+// WhereDescription applies the entql string predicate on the description field.
+func (f *WebhookEndpointFilter) WhereDescription(p entql.StringP) {
+	f.Where(p.Field(webhookendpoint.FieldDescription))
+}
+
+// WhereEnabled applies the entql bool predicate on the enabled field.
+func (f *WebhookEndpointFilter) WhereEnabled(p entql.BoolP) {
+	f.Where(p.Field(webhookendpoint.FieldEnabled))
+}
+
+// WhereFailures applies the entql int predicate on the failures field.
+func (f *WebhookEndpointFilter) WhereFailures(p entql.IntP) {
+	f.Where(p.Field(webhookendpoint.FieldFailures))
+}
+
+// WhereDisabledAt applies the entql time.Time predicate on the disabled_at field.
+func (f *WebhookEndpointFilter) WhereDisabledAt(p entql.TimeP) {
+	f.Where(p.Field(webhookendpoint.FieldDisabledAt))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *WebhookEndpointFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(webhookendpoint.FieldCreatedAt))
+}
+
+// WhereUpdatedAt applies the entql time.Time predicate on the updated_at field.
+func (f *WebhookEndpointFilter) WhereUpdatedAt(p entql.TimeP) {
+	f.Where(p.Field(webhookendpoint.FieldUpdatedAt))
+}
+
+// WhereHasDeliveries applies a predicate to check if query has an edge deliveries.
+func (f *WebhookEndpointFilter) WhereHasDeliveries() {
+	f.Where(entql.HasEdge("deliveries"))
+}
+
+// WhereHasDeliveriesWith applies a predicate to check if query has an edge deliveries with a given conditions (other predicates).
+func (f *WebhookEndpointFilter) WhereHasDeliveriesWith(preds ...predicate.WebhookDelivery) {
+	f.Where(entql.HasEdgeWith("deliveries", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
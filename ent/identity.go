@@ -0,0 +1,176 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-api/ent/identity"
+	"go-api/ent/user"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// Identity is the model entity for the Identity schema.
+type Identity struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id"`
+	// Provider holds the value of the "provider" field.
+	Provider string `json:"provider"`
+	// Subject holds the value of the "subject" field.
+	Subject string `json:"subject"`
+	// Email holds the value of the "email" field.
+	Email string `json:"email"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the IdentityQuery when eager-loading is set.
+	Edges           IdentityEdges `json:"edges"`
+	user_identities *int
+}
+
+// IdentityEdges holds the relations/edges for other nodes in the graph.
+type IdentityEdges struct {
+	// User holds the value of the user edge.
+	User *User
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e IdentityEdges) UserOrErr() (*User, error) {
+	if e.loadedTypes[0] {
+		if e.User == nil {
+			// The edge user was loaded in eager-loading,
+			// but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.User, nil
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Identity) scanValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{},  // id
+		&sql.NullInt64{},  // tenant_id
+		&sql.NullString{}, // provider
+		&sql.NullString{}, // subject
+		&sql.NullString{}, // email
+		&sql.NullTime{},   // created_at
+	}
+}
+
+// fkValues returns the types for scanning foreign-keys values from sql.Rows.
+func (*Identity) fkValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{}, // user_identities
+	}
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Identity fields.
+func (i *Identity) assignValues(values ...interface{}) error {
+	if m, n := len(values), len(identity.Columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	value, ok := values[0].(*sql.NullInt64)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for field id", value)
+	}
+	i.ID = int(value.Int64)
+	values = values[1:]
+	if value, ok := values[0].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field tenant_id", values[0])
+	} else if value.Valid {
+		i.TenantID = int(value.Int64)
+	}
+	if value, ok := values[1].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field provider", values[1])
+	} else if value.Valid {
+		i.Provider = value.String
+	}
+	if value, ok := values[2].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field subject", values[2])
+	} else if value.Valid {
+		i.Subject = value.String
+	}
+	if value, ok := values[3].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field email", values[3])
+	} else if value.Valid {
+		i.Email = value.String
+	}
+	if value, ok := values[4].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[4])
+	} else if value.Valid {
+		i.CreatedAt = value.Time
+	}
+	values = values[5:]
+	if len(values) == len(identity.ForeignKeys) {
+		if value, ok := values[0].(*sql.NullInt64); !ok {
+			return fmt.Errorf("unexpected type %T for edge-field user_identities", value)
+		} else if value.Valid {
+			i.user_identities = new(int)
+			*i.user_identities = int(value.Int64)
+		}
+	}
+	return nil
+}
+
+// QueryUser queries the user edge of the Identity.
+func (i *Identity) QueryUser() *UserQuery {
+	return (&IdentityClient{config: i.config}).QueryUser(i)
+}
+
+// Update returns a builder for updating this Identity.
+// Note that, you need to call Identity.Unwrap() before calling this method, if this Identity
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (i *Identity) Update() *IdentityUpdateOne {
+	return (&IdentityClient{config: i.config}).UpdateOne(i)
+}
+
+// Unwrap unwraps the entity that was returned from a transaction after it was closed,
+// so that all next queries will be executed through the driver which created the transaction.
+func (i *Identity) Unwrap() *Identity {
+	tx, ok := i.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Identity is not a transactional entity")
+	}
+	i.config.driver = tx.drv
+	return i
+}
+
+// String implements the fmt.Stringer.
+func (i *Identity) String() string {
+	var builder strings.Builder
+	builder.WriteString("Identity(")
+	builder.WriteString(fmt.Sprintf("id=%v", i.ID))
+	builder.WriteString(", tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", i.TenantID))
+	builder.WriteString(", provider=")
+	builder.WriteString(i.Provider)
+	builder.WriteString(", subject=")
+	builder.WriteString(i.Subject)
+	builder.WriteString(", email=")
+	builder.WriteString(i.Email)
+	builder.WriteString(", created_at=")
+	builder.WriteString(i.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Identities is a parsable slice of Identity.
+type Identities []*Identity
+
+func (i Identities) config(cfg config) {
+	for _i := range i {
+		i[_i].config = cfg
+	}
+}
@@ -0,0 +1,166 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-api/ent/oauthclient"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// OAuthClient is the model entity for the OAuthClient schema.
+type OAuthClient struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id"`
+	// ClientID holds the value of the "client_id" field.
+	ClientID string `json:"client_id"`
+	// SecretHash holds the value of the "secret_hash" field.
+	SecretHash string `json:"-"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name"`
+	// RedirectUris holds the value of the "redirect_uris" field.
+	RedirectUris string `json:"redirect_uris"`
+	// Scopes holds the value of the "scopes" field.
+	Scopes string `json:"scopes"`
+	// GrantTypes holds the value of the "grant_types" field.
+	GrantTypes string `json:"grant_types"`
+	// CreatedBy holds the value of the "created_by" field.
+	CreatedBy int `json:"created_by"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*OAuthClient) scanValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{},  // id
+		&sql.NullInt64{},  // tenant_id
+		&sql.NullString{}, // client_id
+		&sql.NullString{}, // secret_hash
+		&sql.NullString{}, // name
+		&sql.NullString{}, // redirect_uris
+		&sql.NullString{}, // scopes
+		&sql.NullString{}, // grant_types
+		&sql.NullInt64{},  // created_by
+		&sql.NullTime{},   // created_at
+	}
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the OAuthClient fields.
+func (oc *OAuthClient) assignValues(values ...interface{}) error {
+	if m, n := len(values), len(oauthclient.Columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	value, ok := values[0].(*sql.NullInt64)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for field id", value)
+	}
+	oc.ID = int(value.Int64)
+	values = values[1:]
+	if value, ok := values[0].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field tenant_id", values[0])
+	} else if value.Valid {
+		oc.TenantID = int(value.Int64)
+	}
+	if value, ok := values[1].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field client_id", values[1])
+	} else if value.Valid {
+		oc.ClientID = value.String
+	}
+	if value, ok := values[2].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field secret_hash", values[2])
+	} else if value.Valid {
+		oc.SecretHash = value.String
+	}
+	if value, ok := values[3].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field name", values[3])
+	} else if value.Valid {
+		oc.Name = value.String
+	}
+	if value, ok := values[4].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field redirect_uris", values[4])
+	} else if value.Valid {
+		oc.RedirectUris = value.String
+	}
+	if value, ok := values[5].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field scopes", values[5])
+	} else if value.Valid {
+		oc.Scopes = value.String
+	}
+	if value, ok := values[6].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field grant_types", values[6])
+	} else if value.Valid {
+		oc.GrantTypes = value.String
+	}
+	if value, ok := values[7].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field created_by", values[7])
+	} else if value.Valid {
+		oc.CreatedBy = int(value.Int64)
+	}
+	if value, ok := values[8].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[8])
+	} else if value.Valid {
+		oc.CreatedAt = value.Time
+	}
+	return nil
+}
+
+// Update returns a builder for updating this OAuthClient.
+// Note that, you need to call OAuthClient.Unwrap() before calling this method, if this OAuthClient
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (oc *OAuthClient) Update() *OAuthClientUpdateOne {
+	return (&OAuthClientClient{config: oc.config}).UpdateOne(oc)
+}
+
+// Unwrap unwraps the entity that was returned from a transaction after it was closed,
+// so that all next queries will be executed through the driver which created the transaction.
+func (oc *OAuthClient) Unwrap() *OAuthClient {
+	tx, ok := oc.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: OAuthClient is not a transactional entity")
+	}
+	oc.config.driver = tx.drv
+	return oc
+}
+
+// String implements the fmt.Stringer.
+func (oc *OAuthClient) String() string {
+	var builder strings.Builder
+	builder.WriteString("OAuthClient(")
+	builder.WriteString(fmt.Sprintf("id=%v", oc.ID))
+	builder.WriteString(", tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", oc.TenantID))
+	builder.WriteString(", client_id=")
+	builder.WriteString(oc.ClientID)
+	builder.WriteString(", secret_hash=<sensitive>")
+	builder.WriteString(", name=")
+	builder.WriteString(oc.Name)
+	builder.WriteString(", redirect_uris=")
+	builder.WriteString(oc.RedirectUris)
+	builder.WriteString(", scopes=")
+	builder.WriteString(oc.Scopes)
+	builder.WriteString(", grant_types=")
+	builder.WriteString(oc.GrantTypes)
+	builder.WriteString(", created_by=")
+	builder.WriteString(fmt.Sprintf("%v", oc.CreatedBy))
+	builder.WriteString(", created_at=")
+	builder.WriteString(oc.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// OAuthClients is a parsable slice of OAuthClient.
+type OAuthClients []*OAuthClient
+
+func (oc OAuthClients) config(cfg config) {
+	for _i := range oc {
+		oc[_i].config = cfg
+	}
+}
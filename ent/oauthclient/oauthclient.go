@@ -0,0 +1,87 @@
+// Code generated by entc, DO NOT EDIT.
+
+package oauthclient
+
+import (
+	"time"
+
+	"github.com/facebook/ent"
+)
+
+const (
+	// Label holds the string label denoting the oauthclient type in the database.
+	Label = "oauth_client"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTenantID holds the string denoting the tenant_id field in the database.
+	FieldTenantID = "tenant_id"
+	// FieldClientID holds the string denoting the client_id field in the database.
+	FieldClientID = "client_id"
+	// FieldSecretHash holds the string denoting the secret_hash field in the database.
+	FieldSecretHash = "secret_hash"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldRedirectUris holds the string denoting the redirect_uris field in the database.
+	FieldRedirectUris = "redirect_uris"
+	// FieldScopes holds the string denoting the scopes field in the database.
+	FieldScopes = "scopes"
+	// FieldGrantTypes holds the string denoting the grant_types field in the database.
+	FieldGrantTypes = "grant_types"
+	// FieldCreatedBy holds the string denoting the created_by field in the database.
+	FieldCreatedBy = "created_by"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+
+	// Table holds the table name of the oauthclient in the database.
+	Table = "oauth_clients"
+)
+
+// Columns holds all SQL columns for oauthclient fields.
+var Columns = []string{
+	FieldID,
+	FieldTenantID,
+	FieldClientID,
+	FieldSecretHash,
+	FieldName,
+	FieldRedirectUris,
+	FieldScopes,
+	FieldGrantTypes,
+	FieldCreatedBy,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "go-api/ent/runtime"
+var (
+	Hooks  [2]ent.Hook
+	Policy ent.Policy
+	// DefaultTenantID holds the default value on creation for the tenant_id field.
+	DefaultTenantID int
+	// DefaultSecretHash holds the default value on creation for the secret_hash field.
+	DefaultSecretHash string
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// DefaultRedirectUris holds the default value on creation for the redirect_uris field.
+	DefaultRedirectUris string
+	// DefaultScopes holds the default value on creation for the scopes field.
+	DefaultScopes string
+	// DefaultGrantTypes holds the default value on creation for the grant_types field.
+	DefaultGrantTypes string
+	// DefaultCreatedBy holds the default value on creation for the created_by field.
+	DefaultCreatedBy int
+	// DefaultCreatedAt holds the default value on creation for the created_at field.
+	DefaultCreatedAt func() time.Time
+)
@@ -0,0 +1,1082 @@
+// Code generated by entc, DO NOT EDIT.
+
+package oauthclient
+
+import (
+	"go-api/ent/predicate"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// ID filters vertices based on their identifier.
+func ID(id int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// TenantID applies equality check predicate on the "tenant_id" field. It's identical to TenantIDEQ.
+func TenantID(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTenantID), v))
+	})
+}
+
+// ClientID applies equality check predicate on the "client_id" field. It's identical to ClientIDEQ.
+func ClientID(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClientID), v))
+	})
+}
+
+// SecretHash applies equality check predicate on the "secret_hash" field. It's identical to SecretHashEQ.
+func SecretHash(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldSecretHash), v))
+	})
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldName), v))
+	})
+}
+
+// RedirectUris applies equality check predicate on the "redirect_uris" field. It's identical to RedirectUrisEQ.
+func RedirectUris(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRedirectUris), v))
+	})
+}
+
+// Scopes applies equality check predicate on the "scopes" field. It's identical to ScopesEQ.
+func Scopes(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldScopes), v))
+	})
+}
+
+// GrantTypes applies equality check predicate on the "grant_types" field. It's identical to GrantTypesEQ.
+func GrantTypes(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldGrantTypes), v))
+	})
+}
+
+// CreatedBy applies equality check predicate on the "created_by" field. It's identical to CreatedByEQ.
+func CreatedBy(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedBy), v))
+	})
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenant_id" field.
+func TenantIDEQ(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenant_id" field.
+func TenantIDNEQ(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDIn applies the In predicate on the "tenant_id" field.
+func TenantIDIn(vs ...int) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldTenantID), v...))
+	})
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenant_id" field.
+func TenantIDNotIn(vs ...int) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldTenantID), v...))
+	})
+}
+
+// TenantIDGT applies the GT predicate on the "tenant_id" field.
+func TenantIDGT(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenant_id" field.
+func TenantIDGTE(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDLT applies the LT predicate on the "tenant_id" field.
+func TenantIDLT(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldTenantID), v))
+	})
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenant_id" field.
+func TenantIDLTE(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldTenantID), v))
+	})
+}
+
+// ClientIDEQ applies the EQ predicate on the "client_id" field.
+func ClientIDEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDNEQ applies the NEQ predicate on the "client_id" field.
+func ClientIDNEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDIn applies the In predicate on the "client_id" field.
+func ClientIDIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldClientID), v...))
+	})
+}
+
+// ClientIDNotIn applies the NotIn predicate on the "client_id" field.
+func ClientIDNotIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldClientID), v...))
+	})
+}
+
+// ClientIDGT applies the GT predicate on the "client_id" field.
+func ClientIDGT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDGTE applies the GTE predicate on the "client_id" field.
+func ClientIDGTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDLT applies the LT predicate on the "client_id" field.
+func ClientIDLT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDLTE applies the LTE predicate on the "client_id" field.
+func ClientIDLTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDContains applies the Contains predicate on the "client_id" field.
+func ClientIDContains(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDHasPrefix applies the HasPrefix predicate on the "client_id" field.
+func ClientIDHasPrefix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDHasSuffix applies the HasSuffix predicate on the "client_id" field.
+func ClientIDHasSuffix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDEqualFold applies the EqualFold predicate on the "client_id" field.
+func ClientIDEqualFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDContainsFold applies the ContainsFold predicate on the "client_id" field.
+func ClientIDContainsFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldClientID), v))
+	})
+}
+
+// SecretHashEQ applies the EQ predicate on the "secret_hash" field.
+func SecretHashEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashNEQ applies the NEQ predicate on the "secret_hash" field.
+func SecretHashNEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashIn applies the In predicate on the "secret_hash" field.
+func SecretHashIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldSecretHash), v...))
+	})
+}
+
+// SecretHashNotIn applies the NotIn predicate on the "secret_hash" field.
+func SecretHashNotIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldSecretHash), v...))
+	})
+}
+
+// SecretHashGT applies the GT predicate on the "secret_hash" field.
+func SecretHashGT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashGTE applies the GTE predicate on the "secret_hash" field.
+func SecretHashGTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashLT applies the LT predicate on the "secret_hash" field.
+func SecretHashLT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashLTE applies the LTE predicate on the "secret_hash" field.
+func SecretHashLTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashContains applies the Contains predicate on the "secret_hash" field.
+func SecretHashContains(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashHasPrefix applies the HasPrefix predicate on the "secret_hash" field.
+func SecretHashHasPrefix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashHasSuffix applies the HasSuffix predicate on the "secret_hash" field.
+func SecretHashHasSuffix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashEqualFold applies the EqualFold predicate on the "secret_hash" field.
+func SecretHashEqualFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldSecretHash), v))
+	})
+}
+
+// SecretHashContainsFold applies the ContainsFold predicate on the "secret_hash" field.
+func SecretHashContainsFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldSecretHash), v))
+	})
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldName), v))
+	})
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldName), v))
+	})
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldName), v...))
+	})
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldName), v...))
+	})
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldName), v))
+	})
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldName), v))
+	})
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldName), v))
+	})
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldName), v))
+	})
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldName), v))
+	})
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldName), v))
+	})
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldName), v))
+	})
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldName), v))
+	})
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldName), v))
+	})
+}
+
+// RedirectUrisEQ applies the EQ predicate on the "redirect_uris" field.
+func RedirectUrisEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisNEQ applies the NEQ predicate on the "redirect_uris" field.
+func RedirectUrisNEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisIn applies the In predicate on the "redirect_uris" field.
+func RedirectUrisIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldRedirectUris), v...))
+	})
+}
+
+// RedirectUrisNotIn applies the NotIn predicate on the "redirect_uris" field.
+func RedirectUrisNotIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldRedirectUris), v...))
+	})
+}
+
+// RedirectUrisGT applies the GT predicate on the "redirect_uris" field.
+func RedirectUrisGT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisGTE applies the GTE predicate on the "redirect_uris" field.
+func RedirectUrisGTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisLT applies the LT predicate on the "redirect_uris" field.
+func RedirectUrisLT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisLTE applies the LTE predicate on the "redirect_uris" field.
+func RedirectUrisLTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisContains applies the Contains predicate on the "redirect_uris" field.
+func RedirectUrisContains(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisHasPrefix applies the HasPrefix predicate on the "redirect_uris" field.
+func RedirectUrisHasPrefix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisHasSuffix applies the HasSuffix predicate on the "redirect_uris" field.
+func RedirectUrisHasSuffix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisEqualFold applies the EqualFold predicate on the "redirect_uris" field.
+func RedirectUrisEqualFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldRedirectUris), v))
+	})
+}
+
+// RedirectUrisContainsFold applies the ContainsFold predicate on the "redirect_uris" field.
+func RedirectUrisContainsFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldRedirectUris), v))
+	})
+}
+
+// ScopesEQ applies the EQ predicate on the "scopes" field.
+func ScopesEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesNEQ applies the NEQ predicate on the "scopes" field.
+func ScopesNEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesIn applies the In predicate on the "scopes" field.
+func ScopesIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldScopes), v...))
+	})
+}
+
+// ScopesNotIn applies the NotIn predicate on the "scopes" field.
+func ScopesNotIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldScopes), v...))
+	})
+}
+
+// ScopesGT applies the GT predicate on the "scopes" field.
+func ScopesGT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesGTE applies the GTE predicate on the "scopes" field.
+func ScopesGTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesLT applies the LT predicate on the "scopes" field.
+func ScopesLT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesLTE applies the LTE predicate on the "scopes" field.
+func ScopesLTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesContains applies the Contains predicate on the "scopes" field.
+func ScopesContains(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesHasPrefix applies the HasPrefix predicate on the "scopes" field.
+func ScopesHasPrefix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesHasSuffix applies the HasSuffix predicate on the "scopes" field.
+func ScopesHasSuffix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesEqualFold applies the EqualFold predicate on the "scopes" field.
+func ScopesEqualFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldScopes), v))
+	})
+}
+
+// ScopesContainsFold applies the ContainsFold predicate on the "scopes" field.
+func ScopesContainsFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldScopes), v))
+	})
+}
+
+// GrantTypesEQ applies the EQ predicate on the "grant_types" field.
+func GrantTypesEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesNEQ applies the NEQ predicate on the "grant_types" field.
+func GrantTypesNEQ(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesIn applies the In predicate on the "grant_types" field.
+func GrantTypesIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldGrantTypes), v...))
+	})
+}
+
+// GrantTypesNotIn applies the NotIn predicate on the "grant_types" field.
+func GrantTypesNotIn(vs ...string) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldGrantTypes), v...))
+	})
+}
+
+// GrantTypesGT applies the GT predicate on the "grant_types" field.
+func GrantTypesGT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesGTE applies the GTE predicate on the "grant_types" field.
+func GrantTypesGTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesLT applies the LT predicate on the "grant_types" field.
+func GrantTypesLT(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesLTE applies the LTE predicate on the "grant_types" field.
+func GrantTypesLTE(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesContains applies the Contains predicate on the "grant_types" field.
+func GrantTypesContains(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesHasPrefix applies the HasPrefix predicate on the "grant_types" field.
+func GrantTypesHasPrefix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesHasSuffix applies the HasSuffix predicate on the "grant_types" field.
+func GrantTypesHasSuffix(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesEqualFold applies the EqualFold predicate on the "grant_types" field.
+func GrantTypesEqualFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldGrantTypes), v))
+	})
+}
+
+// GrantTypesContainsFold applies the ContainsFold predicate on the "grant_types" field.
+func GrantTypesContainsFold(v string) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldGrantTypes), v))
+	})
+}
+
+// CreatedByEQ applies the EQ predicate on the "created_by" field.
+func CreatedByEQ(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedBy), v))
+	})
+}
+
+// CreatedByNEQ applies the NEQ predicate on the "created_by" field.
+func CreatedByNEQ(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldCreatedBy), v))
+	})
+}
+
+// CreatedByIn applies the In predicate on the "created_by" field.
+func CreatedByIn(vs ...int) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldCreatedBy), v...))
+	})
+}
+
+// CreatedByNotIn applies the NotIn predicate on the "created_by" field.
+func CreatedByNotIn(vs ...int) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldCreatedBy), v...))
+	})
+}
+
+// CreatedByGT applies the GT predicate on the "created_by" field.
+func CreatedByGT(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldCreatedBy), v))
+	})
+}
+
+// CreatedByGTE applies the GTE predicate on the "created_by" field.
+func CreatedByGTE(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldCreatedBy), v))
+	})
+}
+
+// CreatedByLT applies the LT predicate on the "created_by" field.
+func CreatedByLT(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldCreatedBy), v))
+	})
+}
+
+// CreatedByLTE applies the LTE predicate on the "created_by" field.
+func CreatedByLTE(v int) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldCreatedBy), v))
+	})
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.OAuthClient {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// And groups list of predicates with the AND operator between them.
+func And(predicates ...predicate.OAuthClient) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups list of predicates with the OR operator between them.
+func Or(predicates ...predicate.OAuthClient) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.OAuthClient) predicate.OAuthClient {
+	return predicate.OAuthClient(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,416 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/oauthclient"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// OAuthClientCreate is the builder for creating a OAuthClient entity.
+type OAuthClientCreate struct {
+	config
+	mutation *OAuthClientMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the tenant_id field.
+func (occ *OAuthClientCreate) SetTenantID(i int) *OAuthClientCreate {
+	occ.mutation.SetTenantID(i)
+	return occ
+}
+
+// SetNillableTenantID sets the tenant_id field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableTenantID(i *int) *OAuthClientCreate {
+	if i != nil {
+		occ.SetTenantID(*i)
+	}
+	return occ
+}
+
+// SetClientID sets the client_id field.
+func (occ *OAuthClientCreate) SetClientID(s string) *OAuthClientCreate {
+	occ.mutation.SetClientID(s)
+	return occ
+}
+
+// SetSecretHash sets the secret_hash field.
+func (occ *OAuthClientCreate) SetSecretHash(s string) *OAuthClientCreate {
+	occ.mutation.SetSecretHash(s)
+	return occ
+}
+
+// SetNillableSecretHash sets the secret_hash field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableSecretHash(s *string) *OAuthClientCreate {
+	if s != nil {
+		occ.SetSecretHash(*s)
+	}
+	return occ
+}
+
+// SetName sets the name field.
+func (occ *OAuthClientCreate) SetName(s string) *OAuthClientCreate {
+	occ.mutation.SetName(s)
+	return occ
+}
+
+// SetRedirectUris sets the redirect_uris field.
+func (occ *OAuthClientCreate) SetRedirectUris(s string) *OAuthClientCreate {
+	occ.mutation.SetRedirectUris(s)
+	return occ
+}
+
+// SetNillableRedirectUris sets the redirect_uris field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableRedirectUris(s *string) *OAuthClientCreate {
+	if s != nil {
+		occ.SetRedirectUris(*s)
+	}
+	return occ
+}
+
+// SetScopes sets the scopes field.
+func (occ *OAuthClientCreate) SetScopes(s string) *OAuthClientCreate {
+	occ.mutation.SetScopes(s)
+	return occ
+}
+
+// SetNillableScopes sets the scopes field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableScopes(s *string) *OAuthClientCreate {
+	if s != nil {
+		occ.SetScopes(*s)
+	}
+	return occ
+}
+
+// SetGrantTypes sets the grant_types field.
+func (occ *OAuthClientCreate) SetGrantTypes(s string) *OAuthClientCreate {
+	occ.mutation.SetGrantTypes(s)
+	return occ
+}
+
+// SetNillableGrantTypes sets the grant_types field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableGrantTypes(s *string) *OAuthClientCreate {
+	if s != nil {
+		occ.SetGrantTypes(*s)
+	}
+	return occ
+}
+
+// SetCreatedBy sets the created_by field.
+func (occ *OAuthClientCreate) SetCreatedBy(i int) *OAuthClientCreate {
+	occ.mutation.SetCreatedBy(i)
+	return occ
+}
+
+// SetNillableCreatedBy sets the created_by field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableCreatedBy(i *int) *OAuthClientCreate {
+	if i != nil {
+		occ.SetCreatedBy(*i)
+	}
+	return occ
+}
+
+// SetCreatedAt sets the created_at field.
+func (occ *OAuthClientCreate) SetCreatedAt(t time.Time) *OAuthClientCreate {
+	occ.mutation.SetCreatedAt(t)
+	return occ
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableCreatedAt(t *time.Time) *OAuthClientCreate {
+	if t != nil {
+		occ.SetCreatedAt(*t)
+	}
+	return occ
+}
+
+// Mutation returns the OAuthClientMutation object of the builder.
+func (occ *OAuthClientCreate) Mutation() *OAuthClientMutation {
+	return occ.mutation
+}
+
+// Save creates the OAuthClient in the database.
+func (occ *OAuthClientCreate) Save(ctx context.Context) (*OAuthClient, error) {
+	var (
+		err  error
+		node *OAuthClient
+	)
+	occ.defaults()
+	if len(occ.hooks) == 0 {
+		if err = occ.check(); err != nil {
+			return nil, err
+		}
+		node, err = occ.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OAuthClientMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = occ.check(); err != nil {
+				return nil, err
+			}
+			occ.mutation = mutation
+			node, err = occ.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(occ.hooks) - 1; i >= 0; i-- {
+			mut = occ.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, occ.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (occ *OAuthClientCreate) SaveX(ctx context.Context) *OAuthClient {
+	v, err := occ.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// defaults sets the default values of the builder before save.
+func (occ *OAuthClientCreate) defaults() {
+	if _, ok := occ.mutation.TenantID(); !ok {
+		v := oauthclient.DefaultTenantID
+		occ.mutation.SetTenantID(v)
+	}
+	if _, ok := occ.mutation.SecretHash(); !ok {
+		v := oauthclient.DefaultSecretHash
+		occ.mutation.SetSecretHash(v)
+	}
+	if _, ok := occ.mutation.RedirectUris(); !ok {
+		v := oauthclient.DefaultRedirectUris
+		occ.mutation.SetRedirectUris(v)
+	}
+	if _, ok := occ.mutation.Scopes(); !ok {
+		v := oauthclient.DefaultScopes
+		occ.mutation.SetScopes(v)
+	}
+	if _, ok := occ.mutation.GrantTypes(); !ok {
+		v := oauthclient.DefaultGrantTypes
+		occ.mutation.SetGrantTypes(v)
+	}
+	if _, ok := occ.mutation.CreatedBy(); !ok {
+		v := oauthclient.DefaultCreatedBy
+		occ.mutation.SetCreatedBy(v)
+	}
+	if _, ok := occ.mutation.CreatedAt(); !ok {
+		v := oauthclient.DefaultCreatedAt()
+		occ.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (occ *OAuthClientCreate) check() error {
+	if _, ok := occ.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New("ent: missing required field \"tenant_id\"")}
+	}
+	if _, ok := occ.mutation.ClientID(); !ok {
+		return &ValidationError{Name: "client_id", err: errors.New("ent: missing required field \"client_id\"")}
+	}
+	if _, ok := occ.mutation.SecretHash(); !ok {
+		return &ValidationError{Name: "secret_hash", err: errors.New("ent: missing required field \"secret_hash\"")}
+	}
+	if _, ok := occ.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New("ent: missing required field \"name\"")}
+	}
+	if v, ok := occ.mutation.Name(); ok {
+		if err := oauthclient.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	if _, ok := occ.mutation.RedirectUris(); !ok {
+		return &ValidationError{Name: "redirect_uris", err: errors.New("ent: missing required field \"redirect_uris\"")}
+	}
+	if _, ok := occ.mutation.Scopes(); !ok {
+		return &ValidationError{Name: "scopes", err: errors.New("ent: missing required field \"scopes\"")}
+	}
+	if _, ok := occ.mutation.GrantTypes(); !ok {
+		return &ValidationError{Name: "grant_types", err: errors.New("ent: missing required field \"grant_types\"")}
+	}
+	if _, ok := occ.mutation.CreatedBy(); !ok {
+		return &ValidationError{Name: "created_by", err: errors.New("ent: missing required field \"created_by\"")}
+	}
+	if _, ok := occ.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New("ent: missing required field \"created_at\"")}
+	}
+	return nil
+}
+
+func (occ *OAuthClientCreate) sqlSave(ctx context.Context) (*OAuthClient, error) {
+	_node, _spec := occ.createSpec()
+	if err := sqlgraph.CreateNode(ctx, occ.driver, _spec); err != nil {
+		if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (occ *OAuthClientCreate) createSpec() (*OAuthClient, *sqlgraph.CreateSpec) {
+	var (
+		_node = &OAuthClient{config: occ.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: oauthclient.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: oauthclient.FieldID,
+			},
+		}
+	)
+	if value, ok := occ.mutation.TenantID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: oauthclient.FieldTenantID,
+		})
+		_node.TenantID = value
+	}
+	if value, ok := occ.mutation.ClientID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldClientID,
+		})
+		_node.ClientID = value
+	}
+	if value, ok := occ.mutation.SecretHash(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldSecretHash,
+		})
+		_node.SecretHash = value
+	}
+	if value, ok := occ.mutation.Name(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldName,
+		})
+		_node.Name = value
+	}
+	if value, ok := occ.mutation.RedirectUris(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldRedirectUris,
+		})
+		_node.RedirectUris = value
+	}
+	if value, ok := occ.mutation.Scopes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldScopes,
+		})
+		_node.Scopes = value
+	}
+	if value, ok := occ.mutation.GrantTypes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldGrantTypes,
+		})
+		_node.GrantTypes = value
+	}
+	if value, ok := occ.mutation.CreatedBy(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: oauthclient.FieldCreatedBy,
+		})
+		_node.CreatedBy = value
+	}
+	if value, ok := occ.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: oauthclient.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OAuthClientCreateBulk is the builder for creating a bulk of OAuthClient entities.
+type OAuthClientCreateBulk struct {
+	config
+	builders []*OAuthClientCreate
+}
+
+// Save creates the OAuthClient entities in the database.
+func (occb *OAuthClientCreateBulk) Save(ctx context.Context) ([]*OAuthClient, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(occb.builders))
+	nodes := make([]*OAuthClient, len(occb.builders))
+	mutators := make([]Mutator, len(occb.builders))
+	for i := range occb.builders {
+		func(i int, root context.Context) {
+			builder := occb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OAuthClientMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, occb.builders[i+1].mutation)
+				} else {
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, occb.driver, &sqlgraph.BatchCreateSpec{Nodes: specs}); err != nil {
+						if cerr, ok := isSQLConstraintError(err); ok {
+							err = cerr
+						}
+					}
+				}
+				mutation.done = true
+				if err != nil {
+					return nil, err
+				}
+				id := specs[i].ID.Value.(int64)
+				nodes[i].ID = int(id)
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, occb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (occb *OAuthClientCreateBulk) SaveX(ctx context.Context) []*OAuthClient {
+	v, err := occb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,884 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/oauthclient"
+	"go-api/ent/predicate"
+	"math"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// OAuthClientQuery is the builder for querying OAuthClient entities.
+type OAuthClientQuery struct {
+	config
+	limit      *int
+	offset     *int
+	order      []OrderFunc
+	unique     []string
+	predicates []predicate.OAuthClient
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the builder.
+func (ocq *OAuthClientQuery) Where(ps ...predicate.OAuthClient) *OAuthClientQuery {
+	ocq.predicates = append(ocq.predicates, ps...)
+	return ocq
+}
+
+// Limit adds a limit step to the query.
+func (ocq *OAuthClientQuery) Limit(limit int) *OAuthClientQuery {
+	ocq.limit = &limit
+	return ocq
+}
+
+// Offset adds an offset step to the query.
+func (ocq *OAuthClientQuery) Offset(offset int) *OAuthClientQuery {
+	ocq.offset = &offset
+	return ocq
+}
+
+// Order adds an order step to the query.
+func (ocq *OAuthClientQuery) Order(o ...OrderFunc) *OAuthClientQuery {
+	ocq.order = append(ocq.order, o...)
+	return ocq
+}
+
+// First returns the first OAuthClient entity in the query. Returns *NotFoundError when no oauthclient was found.
+func (ocq *OAuthClientQuery) First(ctx context.Context) (*OAuthClient, error) {
+	nodes, err := ocq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{oauthclient.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (ocq *OAuthClientQuery) FirstX(ctx context.Context) *OAuthClient {
+	node, err := ocq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first OAuthClient id in the query. Returns *NotFoundError when no id was found.
+func (ocq *OAuthClientQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = ocq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{oauthclient.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (ocq *OAuthClientQuery) FirstIDX(ctx context.Context) int {
+	id, err := ocq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns the only OAuthClient entity in the query, returns an error if not exactly one entity was returned.
+func (ocq *OAuthClientQuery) Only(ctx context.Context) (*OAuthClient, error) {
+	nodes, err := ocq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{oauthclient.Label}
+	default:
+		return nil, &NotSingularError{oauthclient.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (ocq *OAuthClientQuery) OnlyX(ctx context.Context) *OAuthClient {
+	node, err := ocq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID returns the only OAuthClient id in the query, returns an error if not exactly one id was returned.
+func (ocq *OAuthClientQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = ocq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = &NotSingularError{oauthclient.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (ocq *OAuthClientQuery) OnlyIDX(ctx context.Context) int {
+	id, err := ocq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of OAuthClients.
+func (ocq *OAuthClientQuery) All(ctx context.Context) ([]*OAuthClient, error) {
+	if err := ocq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return ocq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (ocq *OAuthClientQuery) AllX(ctx context.Context) []*OAuthClient {
+	nodes, err := ocq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of OAuthClient ids.
+func (ocq *OAuthClientQuery) IDs(ctx context.Context) ([]int, error) {
+	var ids []int
+	if err := ocq.Select(oauthclient.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (ocq *OAuthClientQuery) IDsX(ctx context.Context) []int {
+	ids, err := ocq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (ocq *OAuthClientQuery) Count(ctx context.Context) (int, error) {
+	if err := ocq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return ocq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (ocq *OAuthClientQuery) CountX(ctx context.Context) int {
+	count, err := ocq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (ocq *OAuthClientQuery) Exist(ctx context.Context) (bool, error) {
+	if err := ocq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return ocq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (ocq *OAuthClientQuery) ExistX(ctx context.Context) bool {
+	exist, err := ocq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the query builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (ocq *OAuthClientQuery) Clone() *OAuthClientQuery {
+	if ocq == nil {
+		return nil
+	}
+	return &OAuthClientQuery{
+		config:     ocq.config,
+		limit:      ocq.limit,
+		offset:     ocq.offset,
+		order:      append([]OrderFunc{}, ocq.order...),
+		unique:     append([]string{}, ocq.unique...),
+		predicates: append([]predicate.OAuthClient{}, ocq.predicates...),
+		// clone intermediate query.
+		sql:  ocq.sql.Clone(),
+		path: ocq.path,
+	}
+}
+
+// GroupBy used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID int `json:"tenant_id"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.OAuthClient.Query().
+//		GroupBy(oauthclient.FieldTenantID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (ocq *OAuthClientQuery) GroupBy(field string, fields ...string) *OAuthClientGroupBy {
+	group := &OAuthClientGroupBy{config: ocq.config}
+	group.fields = append([]string{field}, fields...)
+	group.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := ocq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return ocq.sqlQuery(), nil
+	}
+	return group
+}
+
+// Select one or more fields from the given query.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID int `json:"tenant_id"`
+//	}
+//
+//	client.OAuthClient.Query().
+//		Select(oauthclient.FieldTenantID).
+//		Scan(ctx, &v)
+func (ocq *OAuthClientQuery) Select(field string, fields ...string) *OAuthClientSelect {
+	selector := &OAuthClientSelect{config: ocq.config}
+	selector.fields = append([]string{field}, fields...)
+	selector.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := ocq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return ocq.sqlQuery(), nil
+	}
+	return selector
+}
+
+func (ocq *OAuthClientQuery) prepareQuery(ctx context.Context) error {
+	if ocq.path != nil {
+		prev, err := ocq.path(ctx)
+		if err != nil {
+			return err
+		}
+		ocq.sql = prev
+	}
+	if err := oauthclient.Policy.EvalQuery(ctx, ocq); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ocq *OAuthClientQuery) sqlAll(ctx context.Context) ([]*OAuthClient, error) {
+	var (
+		nodes = []*OAuthClient{}
+		_spec = ocq.querySpec()
+	)
+	_spec.ScanValues = func() []interface{} {
+		node := &OAuthClient{config: ocq.config}
+		nodes = append(nodes, node)
+		values := node.scanValues()
+		return values
+	}
+	_spec.Assign = func(values ...interface{}) error {
+		if len(nodes) == 0 {
+			return fmt.Errorf("ent: Assign called without calling ScanValues")
+		}
+		node := nodes[len(nodes)-1]
+		return node.assignValues(values...)
+	}
+	if err := sqlgraph.QueryNodes(ctx, ocq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (ocq *OAuthClientQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := ocq.querySpec()
+	return sqlgraph.CountNodes(ctx, ocq.driver, _spec)
+}
+
+func (ocq *OAuthClientQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := ocq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ent: check existence: %v", err)
+	}
+	return n > 0, nil
+}
+
+func (ocq *OAuthClientQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   oauthclient.Table,
+			Columns: oauthclient.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: oauthclient.FieldID,
+			},
+		},
+		From:   ocq.sql,
+		Unique: true,
+	}
+	if ps := ocq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := ocq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := ocq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := ocq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector, oauthclient.ValidColumn)
+			}
+		}
+	}
+	return _spec
+}
+
+func (ocq *OAuthClientQuery) sqlQuery() *sql.Selector {
+	builder := sql.Dialect(ocq.driver.Dialect())
+	t1 := builder.Table(oauthclient.Table)
+	selector := builder.Select(t1.Columns(oauthclient.Columns...)...).From(t1)
+	if ocq.sql != nil {
+		selector = ocq.sql
+		selector.Select(selector.Columns(oauthclient.Columns...)...)
+	}
+	for _, p := range ocq.predicates {
+		p(selector)
+	}
+	for _, p := range ocq.order {
+		p(selector, oauthclient.ValidColumn)
+	}
+	if offset := ocq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := ocq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// OAuthClientGroupBy is the builder for group-by OAuthClient entities.
+type OAuthClientGroupBy struct {
+	config
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (ocgb *OAuthClientGroupBy) Aggregate(fns ...AggregateFunc) *OAuthClientGroupBy {
+	ocgb.fns = append(ocgb.fns, fns...)
+	return ocgb
+}
+
+// Scan applies the group-by query and scan the result into the given value.
+func (ocgb *OAuthClientGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := ocgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	ocgb.sql = query
+	return ocgb.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) ScanX(ctx context.Context, v interface{}) {
+	if err := ocgb.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from group-by. It is only allowed when querying group-by with one field.
+func (ocgb *OAuthClientGroupBy) Strings(ctx context.Context) ([]string, error) {
+	if len(ocgb.fields) > 1 {
+		return nil, errors.New("ent: OAuthClientGroupBy.Strings is not achievable when grouping more than 1 field")
+	}
+	var v []string
+	if err := ocgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) StringsX(ctx context.Context) []string {
+	v, err := ocgb.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from group-by. It is only allowed when querying group-by with one field.
+func (ocgb *OAuthClientGroupBy) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = ocgb.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = fmt.Errorf("ent: OAuthClientGroupBy.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) StringX(ctx context.Context) string {
+	v, err := ocgb.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from group-by. It is only allowed when querying group-by with one field.
+func (ocgb *OAuthClientGroupBy) Ints(ctx context.Context) ([]int, error) {
+	if len(ocgb.fields) > 1 {
+		return nil, errors.New("ent: OAuthClientGroupBy.Ints is not achievable when grouping more than 1 field")
+	}
+	var v []int
+	if err := ocgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) IntsX(ctx context.Context) []int {
+	v, err := ocgb.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from group-by. It is only allowed when querying group-by with one field.
+func (ocgb *OAuthClientGroupBy) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = ocgb.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = fmt.Errorf("ent: OAuthClientGroupBy.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) IntX(ctx context.Context) int {
+	v, err := ocgb.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from group-by. It is only allowed when querying group-by with one field.
+func (ocgb *OAuthClientGroupBy) Float64s(ctx context.Context) ([]float64, error) {
+	if len(ocgb.fields) > 1 {
+		return nil, errors.New("ent: OAuthClientGroupBy.Float64s is not achievable when grouping more than 1 field")
+	}
+	var v []float64
+	if err := ocgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) Float64sX(ctx context.Context) []float64 {
+	v, err := ocgb.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from group-by. It is only allowed when querying group-by with one field.
+func (ocgb *OAuthClientGroupBy) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = ocgb.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = fmt.Errorf("ent: OAuthClientGroupBy.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) Float64X(ctx context.Context) float64 {
+	v, err := ocgb.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from group-by. It is only allowed when querying group-by with one field.
+func (ocgb *OAuthClientGroupBy) Bools(ctx context.Context) ([]bool, error) {
+	if len(ocgb.fields) > 1 {
+		return nil, errors.New("ent: OAuthClientGroupBy.Bools is not achievable when grouping more than 1 field")
+	}
+	var v []bool
+	if err := ocgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) BoolsX(ctx context.Context) []bool {
+	v, err := ocgb.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from group-by. It is only allowed when querying group-by with one field.
+func (ocgb *OAuthClientGroupBy) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = ocgb.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = fmt.Errorf("ent: OAuthClientGroupBy.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (ocgb *OAuthClientGroupBy) BoolX(ctx context.Context) bool {
+	v, err := ocgb.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (ocgb *OAuthClientGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range ocgb.fields {
+		if !oauthclient.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := ocgb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ocgb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (ocgb *OAuthClientGroupBy) sqlQuery() *sql.Selector {
+	selector := ocgb.sql
+	columns := make([]string, 0, len(ocgb.fields)+len(ocgb.fns))
+	columns = append(columns, ocgb.fields...)
+	for _, fn := range ocgb.fns {
+		columns = append(columns, fn(selector, oauthclient.ValidColumn))
+	}
+	return selector.Select(columns...).GroupBy(ocgb.fields...)
+}
+
+// OAuthClientSelect is the builder for select fields of OAuthClient entities.
+type OAuthClientSelect struct {
+	config
+	fields []string
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Scan applies the selector query and scan the result into the given value.
+func (ocs *OAuthClientSelect) Scan(ctx context.Context, v interface{}) error {
+	query, err := ocs.path(ctx)
+	if err != nil {
+		return err
+	}
+	ocs.sql = query
+	return ocs.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (ocs *OAuthClientSelect) ScanX(ctx context.Context, v interface{}) {
+	if err := ocs.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from selector. It is only allowed when selecting one field.
+func (ocs *OAuthClientSelect) Strings(ctx context.Context) ([]string, error) {
+	if len(ocs.fields) > 1 {
+		return nil, errors.New("ent: OAuthClientSelect.Strings is not achievable when selecting more than 1 field")
+	}
+	var v []string
+	if err := ocs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (ocs *OAuthClientSelect) StringsX(ctx context.Context) []string {
+	v, err := ocs.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from selector. It is only allowed when selecting one field.
+func (ocs *OAuthClientSelect) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = ocs.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = fmt.Errorf("ent: OAuthClientSelect.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (ocs *OAuthClientSelect) StringX(ctx context.Context) string {
+	v, err := ocs.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from selector. It is only allowed when selecting one field.
+func (ocs *OAuthClientSelect) Ints(ctx context.Context) ([]int, error) {
+	if len(ocs.fields) > 1 {
+		return nil, errors.New("ent: OAuthClientSelect.Ints is not achievable when selecting more than 1 field")
+	}
+	var v []int
+	if err := ocs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (ocs *OAuthClientSelect) IntsX(ctx context.Context) []int {
+	v, err := ocs.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from selector. It is only allowed when selecting one field.
+func (ocs *OAuthClientSelect) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = ocs.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = fmt.Errorf("ent: OAuthClientSelect.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (ocs *OAuthClientSelect) IntX(ctx context.Context) int {
+	v, err := ocs.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from selector. It is only allowed when selecting one field.
+func (ocs *OAuthClientSelect) Float64s(ctx context.Context) ([]float64, error) {
+	if len(ocs.fields) > 1 {
+		return nil, errors.New("ent: OAuthClientSelect.Float64s is not achievable when selecting more than 1 field")
+	}
+	var v []float64
+	if err := ocs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (ocs *OAuthClientSelect) Float64sX(ctx context.Context) []float64 {
+	v, err := ocs.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from selector. It is only allowed when selecting one field.
+func (ocs *OAuthClientSelect) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = ocs.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = fmt.Errorf("ent: OAuthClientSelect.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (ocs *OAuthClientSelect) Float64X(ctx context.Context) float64 {
+	v, err := ocs.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from selector. It is only allowed when selecting one field.
+func (ocs *OAuthClientSelect) Bools(ctx context.Context) ([]bool, error) {
+	if len(ocs.fields) > 1 {
+		return nil, errors.New("ent: OAuthClientSelect.Bools is not achievable when selecting more than 1 field")
+	}
+	var v []bool
+	if err := ocs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (ocs *OAuthClientSelect) BoolsX(ctx context.Context) []bool {
+	v, err := ocs.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from selector. It is only allowed when selecting one field.
+func (ocs *OAuthClientSelect) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = ocs.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{oauthclient.Label}
+	default:
+		err = fmt.Errorf("ent: OAuthClientSelect.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (ocs *OAuthClientSelect) BoolX(ctx context.Context) bool {
+	v, err := ocs.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (ocs *OAuthClientSelect) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range ocs.fields {
+		if !oauthclient.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for selection", f)}
+		}
+	}
+	rows := &sql.Rows{}
+	query, args := ocs.sqlQuery().Query()
+	if err := ocs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (ocs *OAuthClientSelect) sqlQuery() sql.Querier {
+	selector := ocs.sql
+	selector.Select(selector.Columns(ocs.fields...)...)
+	return selector
+}
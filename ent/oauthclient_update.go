@@ -0,0 +1,544 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/oauthclient"
+	"go-api/ent/predicate"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// OAuthClientUpdate is the builder for updating OAuthClient entities.
+type OAuthClientUpdate struct {
+	config
+	hooks    []Hook
+	mutation *OAuthClientMutation
+}
+
+// Where adds a new predicate for the builder.
+func (ocu *OAuthClientUpdate) Where(ps ...predicate.OAuthClient) *OAuthClientUpdate {
+	ocu.mutation.predicates = append(ocu.mutation.predicates, ps...)
+	return ocu
+}
+
+// SetSecretHash sets the secret_hash field.
+func (ocu *OAuthClientUpdate) SetSecretHash(s string) *OAuthClientUpdate {
+	ocu.mutation.SetSecretHash(s)
+	return ocu
+}
+
+// SetNillableSecretHash sets the secret_hash field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableSecretHash(s *string) *OAuthClientUpdate {
+	if s != nil {
+		ocu.SetSecretHash(*s)
+	}
+	return ocu
+}
+
+// SetName sets the name field.
+func (ocu *OAuthClientUpdate) SetName(s string) *OAuthClientUpdate {
+	ocu.mutation.SetName(s)
+	return ocu
+}
+
+// SetRedirectUris sets the redirect_uris field.
+func (ocu *OAuthClientUpdate) SetRedirectUris(s string) *OAuthClientUpdate {
+	ocu.mutation.SetRedirectUris(s)
+	return ocu
+}
+
+// SetNillableRedirectUris sets the redirect_uris field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableRedirectUris(s *string) *OAuthClientUpdate {
+	if s != nil {
+		ocu.SetRedirectUris(*s)
+	}
+	return ocu
+}
+
+// SetScopes sets the scopes field.
+func (ocu *OAuthClientUpdate) SetScopes(s string) *OAuthClientUpdate {
+	ocu.mutation.SetScopes(s)
+	return ocu
+}
+
+// SetNillableScopes sets the scopes field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableScopes(s *string) *OAuthClientUpdate {
+	if s != nil {
+		ocu.SetScopes(*s)
+	}
+	return ocu
+}
+
+// SetGrantTypes sets the grant_types field.
+func (ocu *OAuthClientUpdate) SetGrantTypes(s string) *OAuthClientUpdate {
+	ocu.mutation.SetGrantTypes(s)
+	return ocu
+}
+
+// SetNillableGrantTypes sets the grant_types field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableGrantTypes(s *string) *OAuthClientUpdate {
+	if s != nil {
+		ocu.SetGrantTypes(*s)
+	}
+	return ocu
+}
+
+// SetCreatedBy sets the created_by field.
+func (ocu *OAuthClientUpdate) SetCreatedBy(i int) *OAuthClientUpdate {
+	ocu.mutation.ResetCreatedBy()
+	ocu.mutation.SetCreatedBy(i)
+	return ocu
+}
+
+// SetNillableCreatedBy sets the created_by field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableCreatedBy(i *int) *OAuthClientUpdate {
+	if i != nil {
+		ocu.SetCreatedBy(*i)
+	}
+	return ocu
+}
+
+// AddCreatedBy adds i to created_by.
+func (ocu *OAuthClientUpdate) AddCreatedBy(i int) *OAuthClientUpdate {
+	ocu.mutation.AddCreatedBy(i)
+	return ocu
+}
+
+// SetCreatedAt sets the created_at field.
+func (ocu *OAuthClientUpdate) SetCreatedAt(t time.Time) *OAuthClientUpdate {
+	ocu.mutation.SetCreatedAt(t)
+	return ocu
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableCreatedAt(t *time.Time) *OAuthClientUpdate {
+	if t != nil {
+		ocu.SetCreatedAt(*t)
+	}
+	return ocu
+}
+
+// Mutation returns the OAuthClientMutation object of the builder.
+func (ocu *OAuthClientUpdate) Mutation() *OAuthClientMutation {
+	return ocu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ocu *OAuthClientUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(ocu.hooks) == 0 {
+		if err = ocu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = ocu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OAuthClientMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ocu.check(); err != nil {
+				return 0, err
+			}
+			ocu.mutation = mutation
+			affected, err = ocu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(ocu.hooks) - 1; i >= 0; i-- {
+			mut = ocu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ocu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ocu *OAuthClientUpdate) SaveX(ctx context.Context) int {
+	affected, err := ocu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ocu *OAuthClientUpdate) Exec(ctx context.Context) error {
+	_, err := ocu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ocu *OAuthClientUpdate) ExecX(ctx context.Context) {
+	if err := ocu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ocu *OAuthClientUpdate) check() error {
+	if v, ok := ocu.mutation.Name(); ok {
+		if err := oauthclient.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	return nil
+}
+
+func (ocu *OAuthClientUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   oauthclient.Table,
+			Columns: oauthclient.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: oauthclient.FieldID,
+			},
+		},
+	}
+	if ps := ocu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ocu.mutation.SecretHash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldSecretHash,
+		})
+	}
+	if value, ok := ocu.mutation.Name(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldName,
+		})
+	}
+	if value, ok := ocu.mutation.RedirectUris(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldRedirectUris,
+		})
+	}
+	if value, ok := ocu.mutation.Scopes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldScopes,
+		})
+	}
+	if value, ok := ocu.mutation.GrantTypes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldGrantTypes,
+		})
+	}
+	if value, ok := ocu.mutation.CreatedBy(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: oauthclient.FieldCreatedBy,
+		})
+	}
+	if value, ok := ocu.mutation.AddedCreatedBy(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: oauthclient.FieldCreatedBy,
+		})
+	}
+	if value, ok := ocu.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: oauthclient.FieldCreatedAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ocu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{oauthclient.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// OAuthClientUpdateOne is the builder for updating a single OAuthClient entity.
+type OAuthClientUpdateOne struct {
+	config
+	hooks    []Hook
+	mutation *OAuthClientMutation
+}
+
+// SetSecretHash sets the secret_hash field.
+func (ocuo *OAuthClientUpdateOne) SetSecretHash(s string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetSecretHash(s)
+	return ocuo
+}
+
+// SetNillableSecretHash sets the secret_hash field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableSecretHash(s *string) *OAuthClientUpdateOne {
+	if s != nil {
+		ocuo.SetSecretHash(*s)
+	}
+	return ocuo
+}
+
+// SetName sets the name field.
+func (ocuo *OAuthClientUpdateOne) SetName(s string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetName(s)
+	return ocuo
+}
+
+// SetRedirectUris sets the redirect_uris field.
+func (ocuo *OAuthClientUpdateOne) SetRedirectUris(s string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetRedirectUris(s)
+	return ocuo
+}
+
+// SetNillableRedirectUris sets the redirect_uris field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableRedirectUris(s *string) *OAuthClientUpdateOne {
+	if s != nil {
+		ocuo.SetRedirectUris(*s)
+	}
+	return ocuo
+}
+
+// SetScopes sets the scopes field.
+func (ocuo *OAuthClientUpdateOne) SetScopes(s string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetScopes(s)
+	return ocuo
+}
+
+// SetNillableScopes sets the scopes field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableScopes(s *string) *OAuthClientUpdateOne {
+	if s != nil {
+		ocuo.SetScopes(*s)
+	}
+	return ocuo
+}
+
+// SetGrantTypes sets the grant_types field.
+func (ocuo *OAuthClientUpdateOne) SetGrantTypes(s string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetGrantTypes(s)
+	return ocuo
+}
+
+// SetNillableGrantTypes sets the grant_types field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableGrantTypes(s *string) *OAuthClientUpdateOne {
+	if s != nil {
+		ocuo.SetGrantTypes(*s)
+	}
+	return ocuo
+}
+
+// SetCreatedBy sets the created_by field.
+func (ocuo *OAuthClientUpdateOne) SetCreatedBy(i int) *OAuthClientUpdateOne {
+	ocuo.mutation.ResetCreatedBy()
+	ocuo.mutation.SetCreatedBy(i)
+	return ocuo
+}
+
+// SetNillableCreatedBy sets the created_by field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableCreatedBy(i *int) *OAuthClientUpdateOne {
+	if i != nil {
+		ocuo.SetCreatedBy(*i)
+	}
+	return ocuo
+}
+
+// AddCreatedBy adds i to created_by.
+func (ocuo *OAuthClientUpdateOne) AddCreatedBy(i int) *OAuthClientUpdateOne {
+	ocuo.mutation.AddCreatedBy(i)
+	return ocuo
+}
+
+// SetCreatedAt sets the created_at field.
+func (ocuo *OAuthClientUpdateOne) SetCreatedAt(t time.Time) *OAuthClientUpdateOne {
+	ocuo.mutation.SetCreatedAt(t)
+	return ocuo
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableCreatedAt(t *time.Time) *OAuthClientUpdateOne {
+	if t != nil {
+		ocuo.SetCreatedAt(*t)
+	}
+	return ocuo
+}
+
+// Mutation returns the OAuthClientMutation object of the builder.
+func (ocuo *OAuthClientUpdateOne) Mutation() *OAuthClientMutation {
+	return ocuo.mutation
+}
+
+// Save executes the query and returns the updated entity.
+func (ocuo *OAuthClientUpdateOne) Save(ctx context.Context) (*OAuthClient, error) {
+	var (
+		err  error
+		node *OAuthClient
+	)
+	if len(ocuo.hooks) == 0 {
+		if err = ocuo.check(); err != nil {
+			return nil, err
+		}
+		node, err = ocuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OAuthClientMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ocuo.check(); err != nil {
+				return nil, err
+			}
+			ocuo.mutation = mutation
+			node, err = ocuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(ocuo.hooks) - 1; i >= 0; i-- {
+			mut = ocuo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ocuo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ocuo *OAuthClientUpdateOne) SaveX(ctx context.Context) *OAuthClient {
+	node, err := ocuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ocuo *OAuthClientUpdateOne) Exec(ctx context.Context) error {
+	_, err := ocuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ocuo *OAuthClientUpdateOne) ExecX(ctx context.Context) {
+	if err := ocuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ocuo *OAuthClientUpdateOne) check() error {
+	if v, ok := ocuo.mutation.Name(); ok {
+		if err := oauthclient.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	return nil
+}
+
+func (ocuo *OAuthClientUpdateOne) sqlSave(ctx context.Context) (_node *OAuthClient, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   oauthclient.Table,
+			Columns: oauthclient.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: oauthclient.FieldID,
+			},
+		},
+	}
+	id, ok := ocuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "ID", err: fmt.Errorf("missing OAuthClient.ID for update")}
+	}
+	_spec.Node.ID.Value = id
+	if value, ok := ocuo.mutation.SecretHash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldSecretHash,
+		})
+	}
+	if value, ok := ocuo.mutation.Name(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldName,
+		})
+	}
+	if value, ok := ocuo.mutation.RedirectUris(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldRedirectUris,
+		})
+	}
+	if value, ok := ocuo.mutation.Scopes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldScopes,
+		})
+	}
+	if value, ok := ocuo.mutation.GrantTypes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauthclient.FieldGrantTypes,
+		})
+	}
+	if value, ok := ocuo.mutation.CreatedBy(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: oauthclient.FieldCreatedBy,
+		})
+	}
+	if value, ok := ocuo.mutation.AddedCreatedBy(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: oauthclient.FieldCreatedBy,
+		})
+	}
+	if value, ok := ocuo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: oauthclient.FieldCreatedAt,
+		})
+	}
+	_node = &OAuthClient{config: ocuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues()
+	if err = sqlgraph.UpdateNode(ctx, ocuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{oauthclient.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	return _node, nil
+}
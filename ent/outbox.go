@@ -0,0 +1,173 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-api/ent/outbox"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// Outbox is the model entity for the Outbox schema.
+type Outbox struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// AggregateType holds the value of the "aggregate_type" field.
+	AggregateType string `json:"aggregate_type"`
+	// AggregateID holds the value of the "aggregate_id" field.
+	AggregateID string `json:"aggregate_id"`
+	// EventType holds the value of the "event_type" field.
+	EventType string `json:"event_type"`
+	// Payload holds the value of the "payload" field.
+	Payload []byte `json:"payload"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+	// PublishedAt holds the value of the "published_at" field.
+	PublishedAt *time.Time `json:"published_at"`
+	// Attempts holds the value of the "attempts" field.
+	Attempts int `json:"attempts"`
+	// LastError holds the value of the "last_error" field.
+	LastError string `json:"last_error"`
+	// DeadAt holds the value of the "dead_at" field.
+	DeadAt *time.Time `json:"dead_at"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Outbox) scanValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{},  // id
+		&sql.NullString{}, // aggregate_type
+		&sql.NullString{}, // aggregate_id
+		&sql.NullString{}, // event_type
+		&[]byte{},         // payload
+		&sql.NullTime{},   // created_at
+		&sql.NullTime{},   // published_at
+		&sql.NullInt64{},  // attempts
+		&sql.NullString{}, // last_error
+		&sql.NullTime{},   // dead_at
+	}
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Outbox fields.
+func (o *Outbox) assignValues(values ...interface{}) error {
+	if m, n := len(values), len(outbox.Columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	value, ok := values[0].(*sql.NullInt64)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for field id", value)
+	}
+	o.ID = int(value.Int64)
+	values = values[1:]
+	if value, ok := values[0].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field aggregate_type", values[0])
+	} else if value.Valid {
+		o.AggregateType = value.String
+	}
+	if value, ok := values[1].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field aggregate_id", values[1])
+	} else if value.Valid {
+		o.AggregateID = value.String
+	}
+	if value, ok := values[2].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field event_type", values[2])
+	} else if value.Valid {
+		o.EventType = value.String
+	}
+	if value, ok := values[3].(*[]byte); !ok {
+		return fmt.Errorf("unexpected type %T for field payload", values[3])
+	} else if value != nil {
+		o.Payload = *value
+	}
+	if value, ok := values[4].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[4])
+	} else if value.Valid {
+		o.CreatedAt = value.Time
+	}
+	if value, ok := values[5].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field published_at", values[5])
+	} else if value.Valid {
+		o.PublishedAt = new(time.Time)
+		*o.PublishedAt = value.Time
+	}
+	if value, ok := values[6].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field attempts", values[6])
+	} else if value.Valid {
+		o.Attempts = int(value.Int64)
+	}
+	if value, ok := values[7].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field last_error", values[7])
+	} else if value.Valid {
+		o.LastError = value.String
+	}
+	if value, ok := values[8].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field dead_at", values[8])
+	} else if value.Valid {
+		o.DeadAt = new(time.Time)
+		*o.DeadAt = value.Time
+	}
+	return nil
+}
+
+// Update returns a builder for updating this Outbox.
+// Note that, you need to call Outbox.Unwrap() before calling this method, if this Outbox
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (o *Outbox) Update() *OutboxUpdateOne {
+	return (&OutboxClient{config: o.config}).UpdateOne(o)
+}
+
+// Unwrap unwraps the entity that was returned from a transaction after it was closed,
+// so that all next queries will be executed through the driver which created the transaction.
+func (o *Outbox) Unwrap() *Outbox {
+	tx, ok := o.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Outbox is not a transactional entity")
+	}
+	o.config.driver = tx.drv
+	return o
+}
+
+// String implements the fmt.Stringer.
+func (o *Outbox) String() string {
+	var builder strings.Builder
+	builder.WriteString("Outbox(")
+	builder.WriteString(fmt.Sprintf("id=%v", o.ID))
+	builder.WriteString(", aggregate_type=")
+	builder.WriteString(o.AggregateType)
+	builder.WriteString(", aggregate_id=")
+	builder.WriteString(o.AggregateID)
+	builder.WriteString(", event_type=")
+	builder.WriteString(o.EventType)
+	builder.WriteString(", payload=")
+	builder.WriteString(fmt.Sprintf("%v", o.Payload))
+	builder.WriteString(", created_at=")
+	builder.WriteString(o.CreatedAt.Format(time.ANSIC))
+	if v := o.PublishedAt; v != nil {
+		builder.WriteString(", published_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", attempts=")
+	builder.WriteString(fmt.Sprintf("%v", o.Attempts))
+	builder.WriteString(", last_error=")
+	builder.WriteString(o.LastError)
+	if v := o.DeadAt; v != nil {
+		builder.WriteString(", dead_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Outboxes is a parsable slice of Outbox.
+type Outboxes []*Outbox
+
+func (o Outboxes) config(cfg config) {
+	for _i := range o {
+		o[_i].config = cfg
+	}
+}
@@ -0,0 +1,66 @@
+// Code generated by entc, DO NOT EDIT.
+
+package outbox
+
+import (
+	"time"
+)
+
+const (
+	// Label holds the string label denoting the outbox type in the database.
+	Label = "outbox"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldAggregateType holds the string denoting the aggregate_type field in the database.
+	FieldAggregateType = "aggregate_type"
+	// FieldAggregateID holds the string denoting the aggregate_id field in the database.
+	FieldAggregateID = "aggregate_id"
+	// FieldEventType holds the string denoting the event_type field in the database.
+	FieldEventType = "event_type"
+	// FieldPayload holds the string denoting the payload field in the database.
+	FieldPayload = "payload"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldPublishedAt holds the string denoting the published_at field in the database.
+	FieldPublishedAt = "published_at"
+	// FieldAttempts holds the string denoting the attempts field in the database.
+	FieldAttempts = "attempts"
+	// FieldLastError holds the string denoting the last_error field in the database.
+	FieldLastError = "last_error"
+	// FieldDeadAt holds the string denoting the dead_at field in the database.
+	FieldDeadAt = "dead_at"
+
+	// Table holds the table name of the outbox in the database.
+	Table = "outboxes"
+)
+
+// Columns holds all SQL columns for outbox fields.
+var Columns = []string{
+	FieldID,
+	FieldAggregateType,
+	FieldAggregateID,
+	FieldEventType,
+	FieldPayload,
+	FieldCreatedAt,
+	FieldPublishedAt,
+	FieldAttempts,
+	FieldLastError,
+	FieldDeadAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultCreatedAt holds the default value on creation for the created_at field.
+	DefaultCreatedAt func() time.Time
+	// DefaultAttempts holds the default value on creation for the attempts field.
+	DefaultAttempts int
+)
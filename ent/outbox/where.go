@@ -0,0 +1,1054 @@
+// Code generated by entc, DO NOT EDIT.
+
+package outbox
+
+import (
+	"go-api/ent/predicate"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// ID filters vertices based on their identifier.
+func ID(id int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// AggregateType applies equality check predicate on the "aggregate_type" field. It's identical to AggregateTypeEQ.
+func AggregateType(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateID applies equality check predicate on the "aggregate_id" field. It's identical to AggregateIDEQ.
+func AggregateID(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAggregateID), v))
+	})
+}
+
+// EventType applies equality check predicate on the "event_type" field. It's identical to EventTypeEQ.
+func EventType(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldEventType), v))
+	})
+}
+
+// Payload applies equality check predicate on the "payload" field. It's identical to PayloadEQ.
+func Payload(v []byte) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPayload), v))
+	})
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// PublishedAt applies equality check predicate on the "published_at" field. It's identical to PublishedAtEQ.
+func PublishedAt(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPublishedAt), v))
+	})
+}
+
+// Attempts applies equality check predicate on the "attempts" field. It's identical to AttemptsEQ.
+func Attempts(v int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAttempts), v))
+	})
+}
+
+// LastError applies equality check predicate on the "last_error" field. It's identical to LastErrorEQ.
+func LastError(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastError), v))
+	})
+}
+
+// DeadAt applies equality check predicate on the "dead_at" field. It's identical to DeadAtEQ.
+func DeadAt(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDeadAt), v))
+	})
+}
+
+// AggregateTypeEQ applies the EQ predicate on the "aggregate_type" field.
+func AggregateTypeEQ(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeNEQ applies the NEQ predicate on the "aggregate_type" field.
+func AggregateTypeNEQ(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeIn applies the In predicate on the "aggregate_type" field.
+func AggregateTypeIn(vs ...string) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAggregateType), v...))
+	})
+}
+
+// AggregateTypeNotIn applies the NotIn predicate on the "aggregate_type" field.
+func AggregateTypeNotIn(vs ...string) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAggregateType), v...))
+	})
+}
+
+// AggregateTypeGT applies the GT predicate on the "aggregate_type" field.
+func AggregateTypeGT(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeGTE applies the GTE predicate on the "aggregate_type" field.
+func AggregateTypeGTE(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeLT applies the LT predicate on the "aggregate_type" field.
+func AggregateTypeLT(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeLTE applies the LTE predicate on the "aggregate_type" field.
+func AggregateTypeLTE(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeContains applies the Contains predicate on the "aggregate_type" field.
+func AggregateTypeContains(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeHasPrefix applies the HasPrefix predicate on the "aggregate_type" field.
+func AggregateTypeHasPrefix(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeHasSuffix applies the HasSuffix predicate on the "aggregate_type" field.
+func AggregateTypeHasSuffix(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeEqualFold applies the EqualFold predicate on the "aggregate_type" field.
+func AggregateTypeEqualFold(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateTypeContainsFold applies the ContainsFold predicate on the "aggregate_type" field.
+func AggregateTypeContainsFold(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAggregateType), v))
+	})
+}
+
+// AggregateIDEQ applies the EQ predicate on the "aggregate_id" field.
+func AggregateIDEQ(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDNEQ applies the NEQ predicate on the "aggregate_id" field.
+func AggregateIDNEQ(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDIn applies the In predicate on the "aggregate_id" field.
+func AggregateIDIn(vs ...string) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAggregateID), v...))
+	})
+}
+
+// AggregateIDNotIn applies the NotIn predicate on the "aggregate_id" field.
+func AggregateIDNotIn(vs ...string) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAggregateID), v...))
+	})
+}
+
+// AggregateIDGT applies the GT predicate on the "aggregate_id" field.
+func AggregateIDGT(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDGTE applies the GTE predicate on the "aggregate_id" field.
+func AggregateIDGTE(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDLT applies the LT predicate on the "aggregate_id" field.
+func AggregateIDLT(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDLTE applies the LTE predicate on the "aggregate_id" field.
+func AggregateIDLTE(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDContains applies the Contains predicate on the "aggregate_id" field.
+func AggregateIDContains(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDHasPrefix applies the HasPrefix predicate on the "aggregate_id" field.
+func AggregateIDHasPrefix(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDHasSuffix applies the HasSuffix predicate on the "aggregate_id" field.
+func AggregateIDHasSuffix(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDEqualFold applies the EqualFold predicate on the "aggregate_id" field.
+func AggregateIDEqualFold(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAggregateID), v))
+	})
+}
+
+// AggregateIDContainsFold applies the ContainsFold predicate on the "aggregate_id" field.
+func AggregateIDContainsFold(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAggregateID), v))
+	})
+}
+
+// EventTypeEQ applies the EQ predicate on the "event_type" field.
+func EventTypeEQ(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeNEQ applies the NEQ predicate on the "event_type" field.
+func EventTypeNEQ(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeIn applies the In predicate on the "event_type" field.
+func EventTypeIn(vs ...string) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldEventType), v...))
+	})
+}
+
+// EventTypeNotIn applies the NotIn predicate on the "event_type" field.
+func EventTypeNotIn(vs ...string) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldEventType), v...))
+	})
+}
+
+// EventTypeGT applies the GT predicate on the "event_type" field.
+func EventTypeGT(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeGTE applies the GTE predicate on the "event_type" field.
+func EventTypeGTE(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeLT applies the LT predicate on the "event_type" field.
+func EventTypeLT(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeLTE applies the LTE predicate on the "event_type" field.
+func EventTypeLTE(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeContains applies the Contains predicate on the "event_type" field.
+func EventTypeContains(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeHasPrefix applies the HasPrefix predicate on the "event_type" field.
+func EventTypeHasPrefix(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeHasSuffix applies the HasSuffix predicate on the "event_type" field.
+func EventTypeHasSuffix(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeEqualFold applies the EqualFold predicate on the "event_type" field.
+func EventTypeEqualFold(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldEventType), v))
+	})
+}
+
+// EventTypeContainsFold applies the ContainsFold predicate on the "event_type" field.
+func EventTypeContainsFold(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldEventType), v))
+	})
+}
+
+// PayloadEQ applies the EQ predicate on the "payload" field.
+func PayloadEQ(v []byte) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadNEQ applies the NEQ predicate on the "payload" field.
+func PayloadNEQ(v []byte) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadIn applies the In predicate on the "payload" field.
+func PayloadIn(vs ...[]byte) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldPayload), v...))
+	})
+}
+
+// PayloadNotIn applies the NotIn predicate on the "payload" field.
+func PayloadNotIn(vs ...[]byte) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldPayload), v...))
+	})
+}
+
+// PayloadGT applies the GT predicate on the "payload" field.
+func PayloadGT(v []byte) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadGTE applies the GTE predicate on the "payload" field.
+func PayloadGTE(v []byte) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadLT applies the LT predicate on the "payload" field.
+func PayloadLT(v []byte) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadLTE applies the LTE predicate on the "payload" field.
+func PayloadLTE(v []byte) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldPayload), v))
+	})
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// PublishedAtEQ applies the EQ predicate on the "published_at" field.
+func PublishedAtEQ(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPublishedAt), v))
+	})
+}
+
+// PublishedAtNEQ applies the NEQ predicate on the "published_at" field.
+func PublishedAtNEQ(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldPublishedAt), v))
+	})
+}
+
+// PublishedAtIn applies the In predicate on the "published_at" field.
+func PublishedAtIn(vs ...time.Time) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldPublishedAt), v...))
+	})
+}
+
+// PublishedAtNotIn applies the NotIn predicate on the "published_at" field.
+func PublishedAtNotIn(vs ...time.Time) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldPublishedAt), v...))
+	})
+}
+
+// PublishedAtGT applies the GT predicate on the "published_at" field.
+func PublishedAtGT(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldPublishedAt), v))
+	})
+}
+
+// PublishedAtGTE applies the GTE predicate on the "published_at" field.
+func PublishedAtGTE(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldPublishedAt), v))
+	})
+}
+
+// PublishedAtLT applies the LT predicate on the "published_at" field.
+func PublishedAtLT(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldPublishedAt), v))
+	})
+}
+
+// PublishedAtLTE applies the LTE predicate on the "published_at" field.
+func PublishedAtLTE(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldPublishedAt), v))
+	})
+}
+
+// PublishedAtIsNil applies the IsNil predicate on the "published_at" field.
+func PublishedAtIsNil() predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldPublishedAt)))
+	})
+}
+
+// PublishedAtNotNil applies the NotNil predicate on the "published_at" field.
+func PublishedAtNotNil() predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldPublishedAt)))
+	})
+}
+
+// AttemptsEQ applies the EQ predicate on the "attempts" field.
+func AttemptsEQ(v int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAttempts), v))
+	})
+}
+
+// AttemptsNEQ applies the NEQ predicate on the "attempts" field.
+func AttemptsNEQ(v int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAttempts), v))
+	})
+}
+
+// AttemptsIn applies the In predicate on the "attempts" field.
+func AttemptsIn(vs ...int) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAttempts), v...))
+	})
+}
+
+// AttemptsNotIn applies the NotIn predicate on the "attempts" field.
+func AttemptsNotIn(vs ...int) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAttempts), v...))
+	})
+}
+
+// AttemptsGT applies the GT predicate on the "attempts" field.
+func AttemptsGT(v int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAttempts), v))
+	})
+}
+
+// AttemptsGTE applies the GTE predicate on the "attempts" field.
+func AttemptsGTE(v int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAttempts), v))
+	})
+}
+
+// AttemptsLT applies the LT predicate on the "attempts" field.
+func AttemptsLT(v int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAttempts), v))
+	})
+}
+
+// AttemptsLTE applies the LTE predicate on the "attempts" field.
+func AttemptsLTE(v int) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAttempts), v))
+	})
+}
+
+// LastErrorEQ applies the EQ predicate on the "last_error" field.
+func LastErrorEQ(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorNEQ applies the NEQ predicate on the "last_error" field.
+func LastErrorNEQ(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorIn applies the In predicate on the "last_error" field.
+func LastErrorIn(vs ...string) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldLastError), v...))
+	})
+}
+
+// LastErrorNotIn applies the NotIn predicate on the "last_error" field.
+func LastErrorNotIn(vs ...string) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldLastError), v...))
+	})
+}
+
+// LastErrorGT applies the GT predicate on the "last_error" field.
+func LastErrorGT(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorGTE applies the GTE predicate on the "last_error" field.
+func LastErrorGTE(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorLT applies the LT predicate on the "last_error" field.
+func LastErrorLT(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorLTE applies the LTE predicate on the "last_error" field.
+func LastErrorLTE(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorContains applies the Contains predicate on the "last_error" field.
+func LastErrorContains(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorHasPrefix applies the HasPrefix predicate on the "last_error" field.
+func LastErrorHasPrefix(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorHasSuffix applies the HasSuffix predicate on the "last_error" field.
+func LastErrorHasSuffix(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorIsNil applies the IsNil predicate on the "last_error" field.
+func LastErrorIsNil() predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldLastError)))
+	})
+}
+
+// LastErrorNotNil applies the NotNil predicate on the "last_error" field.
+func LastErrorNotNil() predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldLastError)))
+	})
+}
+
+// LastErrorEqualFold applies the EqualFold predicate on the "last_error" field.
+func LastErrorEqualFold(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldLastError), v))
+	})
+}
+
+// LastErrorContainsFold applies the ContainsFold predicate on the "last_error" field.
+func LastErrorContainsFold(v string) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldLastError), v))
+	})
+}
+
+// DeadAtEQ applies the EQ predicate on the "dead_at" field.
+func DeadAtEQ(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDeadAt), v))
+	})
+}
+
+// DeadAtNEQ applies the NEQ predicate on the "dead_at" field.
+func DeadAtNEQ(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldDeadAt), v))
+	})
+}
+
+// DeadAtIn applies the In predicate on the "dead_at" field.
+func DeadAtIn(vs ...time.Time) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldDeadAt), v...))
+	})
+}
+
+// DeadAtNotIn applies the NotIn predicate on the "dead_at" field.
+func DeadAtNotIn(vs ...time.Time) predicate.Outbox {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Outbox(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldDeadAt), v...))
+	})
+}
+
+// DeadAtGT applies the GT predicate on the "dead_at" field.
+func DeadAtGT(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldDeadAt), v))
+	})
+}
+
+// DeadAtGTE applies the GTE predicate on the "dead_at" field.
+func DeadAtGTE(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldDeadAt), v))
+	})
+}
+
+// DeadAtLT applies the LT predicate on the "dead_at" field.
+func DeadAtLT(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldDeadAt), v))
+	})
+}
+
+// DeadAtLTE applies the LTE predicate on the "dead_at" field.
+func DeadAtLTE(v time.Time) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldDeadAt), v))
+	})
+}
+
+// DeadAtIsNil applies the IsNil predicate on the "dead_at" field.
+func DeadAtIsNil() predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldDeadAt)))
+	})
+}
+
+// DeadAtNotNil applies the NotNil predicate on the "dead_at" field.
+func DeadAtNotNil() predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldDeadAt)))
+	})
+}
+
+// And groups list of predicates with the AND operator between them.
+func And(predicates ...predicate.Outbox) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups list of predicates with the OR operator between them.
+func Or(predicates ...predicate.Outbox) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Outbox) predicate.Outbox {
+	return predicate.Outbox(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,366 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/outbox"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// OutboxCreate is the builder for creating a Outbox entity.
+type OutboxCreate struct {
+	config
+	mutation *OutboxMutation
+	hooks    []Hook
+}
+
+// SetAggregateType sets the aggregate_type field.
+func (oc *OutboxCreate) SetAggregateType(s string) *OutboxCreate {
+	oc.mutation.SetAggregateType(s)
+	return oc
+}
+
+// SetAggregateID sets the aggregate_id field.
+func (oc *OutboxCreate) SetAggregateID(s string) *OutboxCreate {
+	oc.mutation.SetAggregateID(s)
+	return oc
+}
+
+// SetEventType sets the event_type field.
+func (oc *OutboxCreate) SetEventType(s string) *OutboxCreate {
+	oc.mutation.SetEventType(s)
+	return oc
+}
+
+// SetPayload sets the payload field.
+func (oc *OutboxCreate) SetPayload(b []byte) *OutboxCreate {
+	oc.mutation.SetPayload(b)
+	return oc
+}
+
+// SetCreatedAt sets the created_at field.
+func (oc *OutboxCreate) SetCreatedAt(t time.Time) *OutboxCreate {
+	oc.mutation.SetCreatedAt(t)
+	return oc
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableCreatedAt(t *time.Time) *OutboxCreate {
+	if t != nil {
+		oc.SetCreatedAt(*t)
+	}
+	return oc
+}
+
+// SetPublishedAt sets the published_at field.
+func (oc *OutboxCreate) SetPublishedAt(t time.Time) *OutboxCreate {
+	oc.mutation.SetPublishedAt(t)
+	return oc
+}
+
+// SetNillablePublishedAt sets the published_at field if the given value is not nil.
+func (oc *OutboxCreate) SetNillablePublishedAt(t *time.Time) *OutboxCreate {
+	if t != nil {
+		oc.SetPublishedAt(*t)
+	}
+	return oc
+}
+
+// SetAttempts sets the attempts field.
+func (oc *OutboxCreate) SetAttempts(i int) *OutboxCreate {
+	oc.mutation.SetAttempts(i)
+	return oc
+}
+
+// SetNillableAttempts sets the attempts field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableAttempts(i *int) *OutboxCreate {
+	if i != nil {
+		oc.SetAttempts(*i)
+	}
+	return oc
+}
+
+// SetLastError sets the last_error field.
+func (oc *OutboxCreate) SetLastError(s string) *OutboxCreate {
+	oc.mutation.SetLastError(s)
+	return oc
+}
+
+// SetNillableLastError sets the last_error field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableLastError(s *string) *OutboxCreate {
+	if s != nil {
+		oc.SetLastError(*s)
+	}
+	return oc
+}
+
+// SetDeadAt sets the dead_at field.
+func (oc *OutboxCreate) SetDeadAt(t time.Time) *OutboxCreate {
+	oc.mutation.SetDeadAt(t)
+	return oc
+}
+
+// SetNillableDeadAt sets the dead_at field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableDeadAt(t *time.Time) *OutboxCreate {
+	if t != nil {
+		oc.SetDeadAt(*t)
+	}
+	return oc
+}
+
+// Mutation returns the OutboxMutation object of the builder.
+func (oc *OutboxCreate) Mutation() *OutboxMutation {
+	return oc.mutation
+}
+
+// Save creates the Outbox in the database.
+func (oc *OutboxCreate) Save(ctx context.Context) (*Outbox, error) {
+	var (
+		err  error
+		node *Outbox
+	)
+	oc.defaults()
+	if len(oc.hooks) == 0 {
+		if err = oc.check(); err != nil {
+			return nil, err
+		}
+		node, err = oc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OutboxMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = oc.check(); err != nil {
+				return nil, err
+			}
+			oc.mutation = mutation
+			node, err = oc.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(oc.hooks) - 1; i >= 0; i-- {
+			mut = oc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, oc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (oc *OutboxCreate) SaveX(ctx context.Context) *Outbox {
+	v, err := oc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// defaults sets the default values of the builder before save.
+func (oc *OutboxCreate) defaults() {
+	if _, ok := oc.mutation.CreatedAt(); !ok {
+		v := outbox.DefaultCreatedAt()
+		oc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := oc.mutation.Attempts(); !ok {
+		v := outbox.DefaultAttempts
+		oc.mutation.SetAttempts(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (oc *OutboxCreate) check() error {
+	if _, ok := oc.mutation.AggregateType(); !ok {
+		return &ValidationError{Name: "aggregate_type", err: errors.New("ent: missing required field \"aggregate_type\"")}
+	}
+	if _, ok := oc.mutation.AggregateID(); !ok {
+		return &ValidationError{Name: "aggregate_id", err: errors.New("ent: missing required field \"aggregate_id\"")}
+	}
+	if _, ok := oc.mutation.EventType(); !ok {
+		return &ValidationError{Name: "event_type", err: errors.New("ent: missing required field \"event_type\"")}
+	}
+	if _, ok := oc.mutation.Payload(); !ok {
+		return &ValidationError{Name: "payload", err: errors.New("ent: missing required field \"payload\"")}
+	}
+	if _, ok := oc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New("ent: missing required field \"created_at\"")}
+	}
+	if _, ok := oc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New("ent: missing required field \"attempts\"")}
+	}
+	return nil
+}
+
+func (oc *OutboxCreate) sqlSave(ctx context.Context) (*Outbox, error) {
+	_node, _spec := oc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, oc.driver, _spec); err != nil {
+		if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (oc *OutboxCreate) createSpec() (*Outbox, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Outbox{config: oc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: outbox.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outbox.FieldID,
+			},
+		}
+	)
+	if value, ok := oc.mutation.AggregateType(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldAggregateType,
+		})
+		_node.AggregateType = value
+	}
+	if value, ok := oc.mutation.AggregateID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldAggregateID,
+		})
+		_node.AggregateID = value
+	}
+	if value, ok := oc.mutation.EventType(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldEventType,
+		})
+		_node.EventType = value
+	}
+	if value, ok := oc.mutation.Payload(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: outbox.FieldPayload,
+		})
+		_node.Payload = value
+	}
+	if value, ok := oc.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	if value, ok := oc.mutation.PublishedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldPublishedAt,
+		})
+		_node.PublishedAt = &value
+	}
+	if value, ok := oc.mutation.Attempts(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outbox.FieldAttempts,
+		})
+		_node.Attempts = value
+	}
+	if value, ok := oc.mutation.LastError(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldLastError,
+		})
+		_node.LastError = value
+	}
+	if value, ok := oc.mutation.DeadAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldDeadAt,
+		})
+		_node.DeadAt = &value
+	}
+	return _node, _spec
+}
+
+// OutboxCreateBulk is the builder for creating a bulk of Outbox entities.
+type OutboxCreateBulk struct {
+	config
+	builders []*OutboxCreate
+}
+
+// Save creates the Outbox entities in the database.
+func (ocb *OutboxCreateBulk) Save(ctx context.Context) ([]*Outbox, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(ocb.builders))
+	nodes := make([]*Outbox, len(ocb.builders))
+	mutators := make([]Mutator, len(ocb.builders))
+	for i := range ocb.builders {
+		func(i int, root context.Context) {
+			builder := ocb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OutboxMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ocb.builders[i+1].mutation)
+				} else {
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ocb.driver, &sqlgraph.BatchCreateSpec{Nodes: specs}); err != nil {
+						if cerr, ok := isSQLConstraintError(err); ok {
+							err = cerr
+						}
+					}
+				}
+				mutation.done = true
+				if err != nil {
+					return nil, err
+				}
+				id := specs[i].ID.Value.(int64)
+				nodes[i].ID = int(id)
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ocb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ocb *OutboxCreateBulk) SaveX(ctx context.Context) []*Outbox {
+	v, err := ocb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,108 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/outbox"
+	"go-api/ent/predicate"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// OutboxDelete is the builder for deleting a Outbox entity.
+type OutboxDelete struct {
+	config
+	hooks    []Hook
+	mutation *OutboxMutation
+}
+
+// Where adds a new predicate to the delete builder.
+func (od *OutboxDelete) Where(ps ...predicate.Outbox) *OutboxDelete {
+	od.mutation.predicates = append(od.mutation.predicates, ps...)
+	return od
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (od *OutboxDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(od.hooks) == 0 {
+		affected, err = od.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OutboxMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			od.mutation = mutation
+			affected, err = od.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(od.hooks) - 1; i >= 0; i-- {
+			mut = od.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, od.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (od *OutboxDelete) ExecX(ctx context.Context) int {
+	n, err := od.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (od *OutboxDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: outbox.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outbox.FieldID,
+			},
+		},
+	}
+	if ps := od.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return sqlgraph.DeleteNodes(ctx, od.driver, _spec)
+}
+
+// OutboxDeleteOne is the builder for deleting a single Outbox entity.
+type OutboxDeleteOne struct {
+	od *OutboxDelete
+}
+
+// Exec executes the deletion query.
+func (odo *OutboxDeleteOne) Exec(ctx context.Context) error {
+	n, err := odo.od.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{outbox.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (odo *OutboxDeleteOne) ExecX(ctx context.Context) {
+	odo.od.ExecX(ctx)
+}
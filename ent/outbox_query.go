@@ -0,0 +1,881 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/outbox"
+	"go-api/ent/predicate"
+	"math"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// OutboxQuery is the builder for querying Outbox entities.
+type OutboxQuery struct {
+	config
+	limit      *int
+	offset     *int
+	order      []OrderFunc
+	unique     []string
+	predicates []predicate.Outbox
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the builder.
+func (oq *OutboxQuery) Where(ps ...predicate.Outbox) *OutboxQuery {
+	oq.predicates = append(oq.predicates, ps...)
+	return oq
+}
+
+// Limit adds a limit step to the query.
+func (oq *OutboxQuery) Limit(limit int) *OutboxQuery {
+	oq.limit = &limit
+	return oq
+}
+
+// Offset adds an offset step to the query.
+func (oq *OutboxQuery) Offset(offset int) *OutboxQuery {
+	oq.offset = &offset
+	return oq
+}
+
+// Order adds an order step to the query.
+func (oq *OutboxQuery) Order(o ...OrderFunc) *OutboxQuery {
+	oq.order = append(oq.order, o...)
+	return oq
+}
+
+// First returns the first Outbox entity in the query. Returns *NotFoundError when no outbox was found.
+func (oq *OutboxQuery) First(ctx context.Context) (*Outbox, error) {
+	nodes, err := oq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{outbox.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (oq *OutboxQuery) FirstX(ctx context.Context) *Outbox {
+	node, err := oq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first Outbox id in the query. Returns *NotFoundError when no id was found.
+func (oq *OutboxQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = oq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{outbox.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (oq *OutboxQuery) FirstIDX(ctx context.Context) int {
+	id, err := oq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns the only Outbox entity in the query, returns an error if not exactly one entity was returned.
+func (oq *OutboxQuery) Only(ctx context.Context) (*Outbox, error) {
+	nodes, err := oq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{outbox.Label}
+	default:
+		return nil, &NotSingularError{outbox.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (oq *OutboxQuery) OnlyX(ctx context.Context) *Outbox {
+	node, err := oq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID returns the only Outbox id in the query, returns an error if not exactly one id was returned.
+func (oq *OutboxQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = oq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = &NotSingularError{outbox.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (oq *OutboxQuery) OnlyIDX(ctx context.Context) int {
+	id, err := oq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of Outboxes.
+func (oq *OutboxQuery) All(ctx context.Context) ([]*Outbox, error) {
+	if err := oq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return oq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (oq *OutboxQuery) AllX(ctx context.Context) []*Outbox {
+	nodes, err := oq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of Outbox ids.
+func (oq *OutboxQuery) IDs(ctx context.Context) ([]int, error) {
+	var ids []int
+	if err := oq.Select(outbox.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (oq *OutboxQuery) IDsX(ctx context.Context) []int {
+	ids, err := oq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (oq *OutboxQuery) Count(ctx context.Context) (int, error) {
+	if err := oq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return oq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (oq *OutboxQuery) CountX(ctx context.Context) int {
+	count, err := oq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (oq *OutboxQuery) Exist(ctx context.Context) (bool, error) {
+	if err := oq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return oq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (oq *OutboxQuery) ExistX(ctx context.Context) bool {
+	exist, err := oq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the query builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (oq *OutboxQuery) Clone() *OutboxQuery {
+	if oq == nil {
+		return nil
+	}
+	return &OutboxQuery{
+		config:     oq.config,
+		limit:      oq.limit,
+		offset:     oq.offset,
+		order:      append([]OrderFunc{}, oq.order...),
+		unique:     append([]string{}, oq.unique...),
+		predicates: append([]predicate.Outbox{}, oq.predicates...),
+		// clone intermediate query.
+		sql:  oq.sql.Clone(),
+		path: oq.path,
+	}
+}
+
+// GroupBy used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		AggregateType string `json:"aggregate_type"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.Outbox.Query().
+//		GroupBy(outbox.FieldAggregateType).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (oq *OutboxQuery) GroupBy(field string, fields ...string) *OutboxGroupBy {
+	group := &OutboxGroupBy{config: oq.config}
+	group.fields = append([]string{field}, fields...)
+	group.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := oq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return oq.sqlQuery(), nil
+	}
+	return group
+}
+
+// Select one or more fields from the given query.
+//
+// Example:
+//
+//	var v []struct {
+//		AggregateType string `json:"aggregate_type"`
+//	}
+//
+//	client.Outbox.Query().
+//		Select(outbox.FieldAggregateType).
+//		Scan(ctx, &v)
+func (oq *OutboxQuery) Select(field string, fields ...string) *OutboxSelect {
+	selector := &OutboxSelect{config: oq.config}
+	selector.fields = append([]string{field}, fields...)
+	selector.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := oq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return oq.sqlQuery(), nil
+	}
+	return selector
+}
+
+func (oq *OutboxQuery) prepareQuery(ctx context.Context) error {
+	if oq.path != nil {
+		prev, err := oq.path(ctx)
+		if err != nil {
+			return err
+		}
+		oq.sql = prev
+	}
+	return nil
+}
+
+func (oq *OutboxQuery) sqlAll(ctx context.Context) ([]*Outbox, error) {
+	var (
+		nodes = []*Outbox{}
+		_spec = oq.querySpec()
+	)
+	_spec.ScanValues = func() []interface{} {
+		node := &Outbox{config: oq.config}
+		nodes = append(nodes, node)
+		values := node.scanValues()
+		return values
+	}
+	_spec.Assign = func(values ...interface{}) error {
+		if len(nodes) == 0 {
+			return fmt.Errorf("ent: Assign called without calling ScanValues")
+		}
+		node := nodes[len(nodes)-1]
+		return node.assignValues(values...)
+	}
+	if err := sqlgraph.QueryNodes(ctx, oq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (oq *OutboxQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := oq.querySpec()
+	return sqlgraph.CountNodes(ctx, oq.driver, _spec)
+}
+
+func (oq *OutboxQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := oq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ent: check existence: %v", err)
+	}
+	return n > 0, nil
+}
+
+func (oq *OutboxQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   outbox.Table,
+			Columns: outbox.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outbox.FieldID,
+			},
+		},
+		From:   oq.sql,
+		Unique: true,
+	}
+	if ps := oq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := oq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := oq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := oq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector, outbox.ValidColumn)
+			}
+		}
+	}
+	return _spec
+}
+
+func (oq *OutboxQuery) sqlQuery() *sql.Selector {
+	builder := sql.Dialect(oq.driver.Dialect())
+	t1 := builder.Table(outbox.Table)
+	selector := builder.Select(t1.Columns(outbox.Columns...)...).From(t1)
+	if oq.sql != nil {
+		selector = oq.sql
+		selector.Select(selector.Columns(outbox.Columns...)...)
+	}
+	for _, p := range oq.predicates {
+		p(selector)
+	}
+	for _, p := range oq.order {
+		p(selector, outbox.ValidColumn)
+	}
+	if offset := oq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := oq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// OutboxGroupBy is the builder for group-by Outbox entities.
+type OutboxGroupBy struct {
+	config
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (ogb *OutboxGroupBy) Aggregate(fns ...AggregateFunc) *OutboxGroupBy {
+	ogb.fns = append(ogb.fns, fns...)
+	return ogb
+}
+
+// Scan applies the group-by query and scan the result into the given value.
+func (ogb *OutboxGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := ogb.path(ctx)
+	if err != nil {
+		return err
+	}
+	ogb.sql = query
+	return ogb.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (ogb *OutboxGroupBy) ScanX(ctx context.Context, v interface{}) {
+	if err := ogb.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from group-by. It is only allowed when querying group-by with one field.
+func (ogb *OutboxGroupBy) Strings(ctx context.Context) ([]string, error) {
+	if len(ogb.fields) > 1 {
+		return nil, errors.New("ent: OutboxGroupBy.Strings is not achievable when grouping more than 1 field")
+	}
+	var v []string
+	if err := ogb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (ogb *OutboxGroupBy) StringsX(ctx context.Context) []string {
+	v, err := ogb.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from group-by. It is only allowed when querying group-by with one field.
+func (ogb *OutboxGroupBy) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = ogb.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = fmt.Errorf("ent: OutboxGroupBy.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (ogb *OutboxGroupBy) StringX(ctx context.Context) string {
+	v, err := ogb.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from group-by. It is only allowed when querying group-by with one field.
+func (ogb *OutboxGroupBy) Ints(ctx context.Context) ([]int, error) {
+	if len(ogb.fields) > 1 {
+		return nil, errors.New("ent: OutboxGroupBy.Ints is not achievable when grouping more than 1 field")
+	}
+	var v []int
+	if err := ogb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (ogb *OutboxGroupBy) IntsX(ctx context.Context) []int {
+	v, err := ogb.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from group-by. It is only allowed when querying group-by with one field.
+func (ogb *OutboxGroupBy) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = ogb.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = fmt.Errorf("ent: OutboxGroupBy.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (ogb *OutboxGroupBy) IntX(ctx context.Context) int {
+	v, err := ogb.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from group-by. It is only allowed when querying group-by with one field.
+func (ogb *OutboxGroupBy) Float64s(ctx context.Context) ([]float64, error) {
+	if len(ogb.fields) > 1 {
+		return nil, errors.New("ent: OutboxGroupBy.Float64s is not achievable when grouping more than 1 field")
+	}
+	var v []float64
+	if err := ogb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (ogb *OutboxGroupBy) Float64sX(ctx context.Context) []float64 {
+	v, err := ogb.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from group-by. It is only allowed when querying group-by with one field.
+func (ogb *OutboxGroupBy) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = ogb.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = fmt.Errorf("ent: OutboxGroupBy.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (ogb *OutboxGroupBy) Float64X(ctx context.Context) float64 {
+	v, err := ogb.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from group-by. It is only allowed when querying group-by with one field.
+func (ogb *OutboxGroupBy) Bools(ctx context.Context) ([]bool, error) {
+	if len(ogb.fields) > 1 {
+		return nil, errors.New("ent: OutboxGroupBy.Bools is not achievable when grouping more than 1 field")
+	}
+	var v []bool
+	if err := ogb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (ogb *OutboxGroupBy) BoolsX(ctx context.Context) []bool {
+	v, err := ogb.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from group-by. It is only allowed when querying group-by with one field.
+func (ogb *OutboxGroupBy) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = ogb.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = fmt.Errorf("ent: OutboxGroupBy.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (ogb *OutboxGroupBy) BoolX(ctx context.Context) bool {
+	v, err := ogb.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (ogb *OutboxGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range ogb.fields {
+		if !outbox.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := ogb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ogb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (ogb *OutboxGroupBy) sqlQuery() *sql.Selector {
+	selector := ogb.sql
+	columns := make([]string, 0, len(ogb.fields)+len(ogb.fns))
+	columns = append(columns, ogb.fields...)
+	for _, fn := range ogb.fns {
+		columns = append(columns, fn(selector, outbox.ValidColumn))
+	}
+	return selector.Select(columns...).GroupBy(ogb.fields...)
+}
+
+// OutboxSelect is the builder for select fields of Outbox entities.
+type OutboxSelect struct {
+	config
+	fields []string
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Scan applies the selector query and scan the result into the given value.
+func (os *OutboxSelect) Scan(ctx context.Context, v interface{}) error {
+	query, err := os.path(ctx)
+	if err != nil {
+		return err
+	}
+	os.sql = query
+	return os.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (os *OutboxSelect) ScanX(ctx context.Context, v interface{}) {
+	if err := os.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from selector. It is only allowed when selecting one field.
+func (os *OutboxSelect) Strings(ctx context.Context) ([]string, error) {
+	if len(os.fields) > 1 {
+		return nil, errors.New("ent: OutboxSelect.Strings is not achievable when selecting more than 1 field")
+	}
+	var v []string
+	if err := os.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (os *OutboxSelect) StringsX(ctx context.Context) []string {
+	v, err := os.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from selector. It is only allowed when selecting one field.
+func (os *OutboxSelect) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = os.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = fmt.Errorf("ent: OutboxSelect.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (os *OutboxSelect) StringX(ctx context.Context) string {
+	v, err := os.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from selector. It is only allowed when selecting one field.
+func (os *OutboxSelect) Ints(ctx context.Context) ([]int, error) {
+	if len(os.fields) > 1 {
+		return nil, errors.New("ent: OutboxSelect.Ints is not achievable when selecting more than 1 field")
+	}
+	var v []int
+	if err := os.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (os *OutboxSelect) IntsX(ctx context.Context) []int {
+	v, err := os.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from selector. It is only allowed when selecting one field.
+func (os *OutboxSelect) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = os.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = fmt.Errorf("ent: OutboxSelect.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (os *OutboxSelect) IntX(ctx context.Context) int {
+	v, err := os.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from selector. It is only allowed when selecting one field.
+func (os *OutboxSelect) Float64s(ctx context.Context) ([]float64, error) {
+	if len(os.fields) > 1 {
+		return nil, errors.New("ent: OutboxSelect.Float64s is not achievable when selecting more than 1 field")
+	}
+	var v []float64
+	if err := os.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (os *OutboxSelect) Float64sX(ctx context.Context) []float64 {
+	v, err := os.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from selector. It is only allowed when selecting one field.
+func (os *OutboxSelect) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = os.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = fmt.Errorf("ent: OutboxSelect.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (os *OutboxSelect) Float64X(ctx context.Context) float64 {
+	v, err := os.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from selector. It is only allowed when selecting one field.
+func (os *OutboxSelect) Bools(ctx context.Context) ([]bool, error) {
+	if len(os.fields) > 1 {
+		return nil, errors.New("ent: OutboxSelect.Bools is not achievable when selecting more than 1 field")
+	}
+	var v []bool
+	if err := os.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (os *OutboxSelect) BoolsX(ctx context.Context) []bool {
+	v, err := os.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from selector. It is only allowed when selecting one field.
+func (os *OutboxSelect) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = os.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{outbox.Label}
+	default:
+		err = fmt.Errorf("ent: OutboxSelect.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (os *OutboxSelect) BoolX(ctx context.Context) bool {
+	v, err := os.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (os *OutboxSelect) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range os.fields {
+		if !outbox.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for selection", f)}
+		}
+	}
+	rows := &sql.Rows{}
+	query, args := os.sqlQuery().Query()
+	if err := os.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (os *OutboxSelect) sqlQuery() sql.Querier {
+	selector := os.sql
+	selector.Select(selector.Columns(os.fields...)...)
+	return selector
+}
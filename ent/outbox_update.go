@@ -0,0 +1,620 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/outbox"
+	"go-api/ent/predicate"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// OutboxUpdate is the builder for updating Outbox entities.
+type OutboxUpdate struct {
+	config
+	hooks    []Hook
+	mutation *OutboxMutation
+}
+
+// Where adds a new predicate for the builder.
+func (ou *OutboxUpdate) Where(ps ...predicate.Outbox) *OutboxUpdate {
+	ou.mutation.predicates = append(ou.mutation.predicates, ps...)
+	return ou
+}
+
+// SetAggregateType sets the aggregate_type field.
+func (ou *OutboxUpdate) SetAggregateType(s string) *OutboxUpdate {
+	ou.mutation.SetAggregateType(s)
+	return ou
+}
+
+// SetAggregateID sets the aggregate_id field.
+func (ou *OutboxUpdate) SetAggregateID(s string) *OutboxUpdate {
+	ou.mutation.SetAggregateID(s)
+	return ou
+}
+
+// SetEventType sets the event_type field.
+func (ou *OutboxUpdate) SetEventType(s string) *OutboxUpdate {
+	ou.mutation.SetEventType(s)
+	return ou
+}
+
+// SetPayload sets the payload field.
+func (ou *OutboxUpdate) SetPayload(b []byte) *OutboxUpdate {
+	ou.mutation.SetPayload(b)
+	return ou
+}
+
+// SetCreatedAt sets the created_at field.
+func (ou *OutboxUpdate) SetCreatedAt(t time.Time) *OutboxUpdate {
+	ou.mutation.SetCreatedAt(t)
+	return ou
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillableCreatedAt(t *time.Time) *OutboxUpdate {
+	if t != nil {
+		ou.SetCreatedAt(*t)
+	}
+	return ou
+}
+
+// SetPublishedAt sets the published_at field.
+func (ou *OutboxUpdate) SetPublishedAt(t time.Time) *OutboxUpdate {
+	ou.mutation.SetPublishedAt(t)
+	return ou
+}
+
+// SetNillablePublishedAt sets the published_at field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillablePublishedAt(t *time.Time) *OutboxUpdate {
+	if t != nil {
+		ou.SetPublishedAt(*t)
+	}
+	return ou
+}
+
+// ClearPublishedAt clears the value of published_at.
+func (ou *OutboxUpdate) ClearPublishedAt() *OutboxUpdate {
+	ou.mutation.ClearPublishedAt()
+	return ou
+}
+
+// SetAttempts sets the attempts field.
+func (ou *OutboxUpdate) SetAttempts(i int) *OutboxUpdate {
+	ou.mutation.ResetAttempts()
+	ou.mutation.SetAttempts(i)
+	return ou
+}
+
+// SetNillableAttempts sets the attempts field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillableAttempts(i *int) *OutboxUpdate {
+	if i != nil {
+		ou.SetAttempts(*i)
+	}
+	return ou
+}
+
+// AddAttempts adds i to attempts.
+func (ou *OutboxUpdate) AddAttempts(i int) *OutboxUpdate {
+	ou.mutation.AddAttempts(i)
+	return ou
+}
+
+// SetLastError sets the last_error field.
+func (ou *OutboxUpdate) SetLastError(s string) *OutboxUpdate {
+	ou.mutation.SetLastError(s)
+	return ou
+}
+
+// SetNillableLastError sets the last_error field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillableLastError(s *string) *OutboxUpdate {
+	if s != nil {
+		ou.SetLastError(*s)
+	}
+	return ou
+}
+
+// ClearLastError clears the value of last_error.
+func (ou *OutboxUpdate) ClearLastError() *OutboxUpdate {
+	ou.mutation.ClearLastError()
+	return ou
+}
+
+// SetDeadAt sets the dead_at field.
+func (ou *OutboxUpdate) SetDeadAt(t time.Time) *OutboxUpdate {
+	ou.mutation.SetDeadAt(t)
+	return ou
+}
+
+// SetNillableDeadAt sets the dead_at field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillableDeadAt(t *time.Time) *OutboxUpdate {
+	if t != nil {
+		ou.SetDeadAt(*t)
+	}
+	return ou
+}
+
+// ClearDeadAt clears the value of dead_at.
+func (ou *OutboxUpdate) ClearDeadAt() *OutboxUpdate {
+	ou.mutation.ClearDeadAt()
+	return ou
+}
+
+// Mutation returns the OutboxMutation object of the builder.
+func (ou *OutboxUpdate) Mutation() *OutboxMutation {
+	return ou.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ou *OutboxUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(ou.hooks) == 0 {
+		affected, err = ou.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OutboxMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			ou.mutation = mutation
+			affected, err = ou.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(ou.hooks) - 1; i >= 0; i-- {
+			mut = ou.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ou.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ou *OutboxUpdate) SaveX(ctx context.Context) int {
+	affected, err := ou.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ou *OutboxUpdate) Exec(ctx context.Context) error {
+	_, err := ou.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ou *OutboxUpdate) ExecX(ctx context.Context) {
+	if err := ou.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (ou *OutboxUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   outbox.Table,
+			Columns: outbox.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outbox.FieldID,
+			},
+		},
+	}
+	if ps := ou.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ou.mutation.AggregateType(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldAggregateType,
+		})
+	}
+	if value, ok := ou.mutation.AggregateID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldAggregateID,
+		})
+	}
+	if value, ok := ou.mutation.EventType(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldEventType,
+		})
+	}
+	if value, ok := ou.mutation.Payload(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: outbox.FieldPayload,
+		})
+	}
+	if value, ok := ou.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldCreatedAt,
+		})
+	}
+	if value, ok := ou.mutation.PublishedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldPublishedAt,
+		})
+	}
+	if ou.mutation.PublishedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: outbox.FieldPublishedAt,
+		})
+	}
+	if value, ok := ou.mutation.Attempts(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outbox.FieldAttempts,
+		})
+	}
+	if value, ok := ou.mutation.AddedAttempts(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outbox.FieldAttempts,
+		})
+	}
+	if value, ok := ou.mutation.LastError(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldLastError,
+		})
+	}
+	if ou.mutation.LastErrorCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: outbox.FieldLastError,
+		})
+	}
+	if value, ok := ou.mutation.DeadAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldDeadAt,
+		})
+	}
+	if ou.mutation.DeadAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: outbox.FieldDeadAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ou.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outbox.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// OutboxUpdateOne is the builder for updating a single Outbox entity.
+type OutboxUpdateOne struct {
+	config
+	hooks    []Hook
+	mutation *OutboxMutation
+}
+
+// SetAggregateType sets the aggregate_type field.
+func (ouo *OutboxUpdateOne) SetAggregateType(s string) *OutboxUpdateOne {
+	ouo.mutation.SetAggregateType(s)
+	return ouo
+}
+
+// SetAggregateID sets the aggregate_id field.
+func (ouo *OutboxUpdateOne) SetAggregateID(s string) *OutboxUpdateOne {
+	ouo.mutation.SetAggregateID(s)
+	return ouo
+}
+
+// SetEventType sets the event_type field.
+func (ouo *OutboxUpdateOne) SetEventType(s string) *OutboxUpdateOne {
+	ouo.mutation.SetEventType(s)
+	return ouo
+}
+
+// SetPayload sets the payload field.
+func (ouo *OutboxUpdateOne) SetPayload(b []byte) *OutboxUpdateOne {
+	ouo.mutation.SetPayload(b)
+	return ouo
+}
+
+// SetCreatedAt sets the created_at field.
+func (ouo *OutboxUpdateOne) SetCreatedAt(t time.Time) *OutboxUpdateOne {
+	ouo.mutation.SetCreatedAt(t)
+	return ouo
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillableCreatedAt(t *time.Time) *OutboxUpdateOne {
+	if t != nil {
+		ouo.SetCreatedAt(*t)
+	}
+	return ouo
+}
+
+// SetPublishedAt sets the published_at field.
+func (ouo *OutboxUpdateOne) SetPublishedAt(t time.Time) *OutboxUpdateOne {
+	ouo.mutation.SetPublishedAt(t)
+	return ouo
+}
+
+// SetNillablePublishedAt sets the published_at field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillablePublishedAt(t *time.Time) *OutboxUpdateOne {
+	if t != nil {
+		ouo.SetPublishedAt(*t)
+	}
+	return ouo
+}
+
+// ClearPublishedAt clears the value of published_at.
+func (ouo *OutboxUpdateOne) ClearPublishedAt() *OutboxUpdateOne {
+	ouo.mutation.ClearPublishedAt()
+	return ouo
+}
+
+// SetAttempts sets the attempts field.
+func (ouo *OutboxUpdateOne) SetAttempts(i int) *OutboxUpdateOne {
+	ouo.mutation.ResetAttempts()
+	ouo.mutation.SetAttempts(i)
+	return ouo
+}
+
+// SetNillableAttempts sets the attempts field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillableAttempts(i *int) *OutboxUpdateOne {
+	if i != nil {
+		ouo.SetAttempts(*i)
+	}
+	return ouo
+}
+
+// AddAttempts adds i to attempts.
+func (ouo *OutboxUpdateOne) AddAttempts(i int) *OutboxUpdateOne {
+	ouo.mutation.AddAttempts(i)
+	return ouo
+}
+
+// SetLastError sets the last_error field.
+func (ouo *OutboxUpdateOne) SetLastError(s string) *OutboxUpdateOne {
+	ouo.mutation.SetLastError(s)
+	return ouo
+}
+
+// SetNillableLastError sets the last_error field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillableLastError(s *string) *OutboxUpdateOne {
+	if s != nil {
+		ouo.SetLastError(*s)
+	}
+	return ouo
+}
+
+// ClearLastError clears the value of last_error.
+func (ouo *OutboxUpdateOne) ClearLastError() *OutboxUpdateOne {
+	ouo.mutation.ClearLastError()
+	return ouo
+}
+
+// SetDeadAt sets the dead_at field.
+func (ouo *OutboxUpdateOne) SetDeadAt(t time.Time) *OutboxUpdateOne {
+	ouo.mutation.SetDeadAt(t)
+	return ouo
+}
+
+// SetNillableDeadAt sets the dead_at field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillableDeadAt(t *time.Time) *OutboxUpdateOne {
+	if t != nil {
+		ouo.SetDeadAt(*t)
+	}
+	return ouo
+}
+
+// ClearDeadAt clears the value of dead_at.
+func (ouo *OutboxUpdateOne) ClearDeadAt() *OutboxUpdateOne {
+	ouo.mutation.ClearDeadAt()
+	return ouo
+}
+
+// Mutation returns the OutboxMutation object of the builder.
+func (ouo *OutboxUpdateOne) Mutation() *OutboxMutation {
+	return ouo.mutation
+}
+
+// Save executes the query and returns the updated entity.
+func (ouo *OutboxUpdateOne) Save(ctx context.Context) (*Outbox, error) {
+	var (
+		err  error
+		node *Outbox
+	)
+	if len(ouo.hooks) == 0 {
+		node, err = ouo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OutboxMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			ouo.mutation = mutation
+			node, err = ouo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(ouo.hooks) - 1; i >= 0; i-- {
+			mut = ouo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ouo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ouo *OutboxUpdateOne) SaveX(ctx context.Context) *Outbox {
+	node, err := ouo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ouo *OutboxUpdateOne) Exec(ctx context.Context) error {
+	_, err := ouo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ouo *OutboxUpdateOne) ExecX(ctx context.Context) {
+	if err := ouo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (ouo *OutboxUpdateOne) sqlSave(ctx context.Context) (_node *Outbox, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   outbox.Table,
+			Columns: outbox.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outbox.FieldID,
+			},
+		},
+	}
+	id, ok := ouo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "ID", err: fmt.Errorf("missing Outbox.ID for update")}
+	}
+	_spec.Node.ID.Value = id
+	if value, ok := ouo.mutation.AggregateType(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldAggregateType,
+		})
+	}
+	if value, ok := ouo.mutation.AggregateID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldAggregateID,
+		})
+	}
+	if value, ok := ouo.mutation.EventType(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldEventType,
+		})
+	}
+	if value, ok := ouo.mutation.Payload(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: outbox.FieldPayload,
+		})
+	}
+	if value, ok := ouo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldCreatedAt,
+		})
+	}
+	if value, ok := ouo.mutation.PublishedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldPublishedAt,
+		})
+	}
+	if ouo.mutation.PublishedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: outbox.FieldPublishedAt,
+		})
+	}
+	if value, ok := ouo.mutation.Attempts(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outbox.FieldAttempts,
+		})
+	}
+	if value, ok := ouo.mutation.AddedAttempts(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outbox.FieldAttempts,
+		})
+	}
+	if value, ok := ouo.mutation.LastError(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outbox.FieldLastError,
+		})
+	}
+	if ouo.mutation.LastErrorCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: outbox.FieldLastError,
+		})
+	}
+	if value, ok := ouo.mutation.DeadAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outbox.FieldDeadAt,
+		})
+	}
+	if ouo.mutation.DeadAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: outbox.FieldDeadAt,
+		})
+	}
+	_node = &Outbox{config: ouo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues()
+	if err = sqlgraph.UpdateNode(ctx, ouo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outbox.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -5,6 +5,7 @@ package ent
 import (
 	"fmt"
 	"go-api/ent/pet"
+	"go-api/ent/user"
 	"strings"
 	"time"
 
@@ -16,18 +17,70 @@ type Pet struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id"`
 	// CreatedAt holds the value of the "created_at" field.
-	CreatedAt time.Time `json:"created_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 	// UpdatedAt holds the value of the "updated_at" field.
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name"`
+	// Species holds the value of the "species" field.
+	Species string `json:"species"`
+	// Birthday holds the value of the "birthday" field.
+	Birthday *time.Time `json:"birthday"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PetQuery when eager-loading is set.
+	Edges     PetEdges `json:"edges"`
+	user_pets *int
+}
+
+// PetEdges holds the relations/edges for other nodes in the graph.
+type PetEdges struct {
+	// Owner holds the value of the owner edge.
+	Owner *User
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PetEdges) OwnerOrErr() (*User, error) {
+	if e.loadedTypes[0] {
+		if e.Owner == nil {
+			// The edge owner was loaded in eager-loading,
+			// but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.Owner, nil
+	}
+	return nil, &NotLoadedError{edge: "owner"}
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Pet) scanValues() []interface{} {
 	return []interface{}{
-		&sql.NullInt64{}, // id
-		&sql.NullTime{},  // created_at
-		&sql.NullTime{},  // updated_at
+		&sql.NullInt64{},  // id
+		&sql.NullTime{},   // deleted_at
+		&sql.NullInt64{},  // tenant_id
+		&sql.NullTime{},   // created_at
+		&sql.NullTime{},   // updated_at
+		&sql.NullInt64{},  // version
+		&sql.NullString{}, // name
+		&sql.NullString{}, // species
+		&sql.NullTime{},   // birthday
+	}
+}
+
+// fkValues returns the types for scanning foreign-keys values from sql.Rows.
+func (*Pet) fkValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{}, // user_pets
 	}
 }
 
@@ -44,18 +97,64 @@ func (pe *Pet) assignValues(values ...interface{}) error {
 	pe.ID = int(value.Int64)
 	values = values[1:]
 	if value, ok := values[0].(*sql.NullTime); !ok {
-		return fmt.Errorf("unexpected type %T for field created_at", values[0])
+		return fmt.Errorf("unexpected type %T for field deleted_at", values[0])
+	} else if value.Valid {
+		pe.DeletedAt = new(time.Time)
+		*pe.DeletedAt = value.Time
+	}
+	if value, ok := values[1].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field tenant_id", values[1])
+	} else if value.Valid {
+		pe.TenantID = int(value.Int64)
+	}
+	if value, ok := values[2].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[2])
 	} else if value.Valid {
 		pe.CreatedAt = value.Time
 	}
-	if value, ok := values[1].(*sql.NullTime); !ok {
-		return fmt.Errorf("unexpected type %T for field updated_at", values[1])
+	if value, ok := values[3].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field updated_at", values[3])
 	} else if value.Valid {
 		pe.UpdatedAt = value.Time
 	}
+	if value, ok := values[4].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field version", values[4])
+	} else if value.Valid {
+		pe.Version = int(value.Int64)
+	}
+	if value, ok := values[5].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field name", values[5])
+	} else if value.Valid {
+		pe.Name = value.String
+	}
+	if value, ok := values[6].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field species", values[6])
+	} else if value.Valid {
+		pe.Species = value.String
+	}
+	if value, ok := values[7].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field birthday", values[7])
+	} else if value.Valid {
+		pe.Birthday = new(time.Time)
+		*pe.Birthday = value.Time
+	}
+	values = values[8:]
+	if len(values) == len(pet.ForeignKeys) {
+		if value, ok := values[0].(*sql.NullInt64); !ok {
+			return fmt.Errorf("unexpected type %T for edge-field user_pets", value)
+		} else if value.Valid {
+			pe.user_pets = new(int)
+			*pe.user_pets = int(value.Int64)
+		}
+	}
 	return nil
 }
 
+// QueryOwner queries the owner edge of the Pet.
+func (pe *Pet) QueryOwner() *UserQuery {
+	return (&PetClient{config: pe.config}).QueryOwner(pe)
+}
+
 // Update returns a builder for updating this Pet.
 // Note that, you need to call Pet.Unwrap() before calling this method, if this Pet
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -79,10 +178,26 @@ func (pe *Pet) String() string {
 	var builder strings.Builder
 	builder.WriteString("Pet(")
 	builder.WriteString(fmt.Sprintf("id=%v", pe.ID))
+	if v := pe.DeletedAt; v != nil {
+		builder.WriteString(", deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", pe.TenantID))
 	builder.WriteString(", created_at=")
 	builder.WriteString(pe.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", updated_at=")
 	builder.WriteString(pe.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", version=")
+	builder.WriteString(fmt.Sprintf("%v", pe.Version))
+	builder.WriteString(", name=")
+	builder.WriteString(pe.Name)
+	builder.WriteString(", species=")
+	builder.WriteString(pe.Species)
+	if v := pe.Birthday; v != nil {
+		builder.WriteString(", birthday=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -4,6 +4,8 @@ package pet
 
 import (
 	"time"
+
+	"github.com/facebook/ent"
 )
 
 const (
@@ -11,20 +13,53 @@ const (
 	Label = "pet"
 	// FieldID holds the string denoting the id field in the database.
 	FieldID = "id"
+	// FieldDeletedAt holds the string denoting the deleted_at field in the database.
+	FieldDeletedAt = "deleted_at"
+	// FieldTenantID holds the string denoting the tenant_id field in the database.
+	FieldTenantID = "tenant_id"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
 	FieldUpdatedAt = "updated_at"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldSpecies holds the string denoting the species field in the database.
+	FieldSpecies = "species"
+	// FieldBirthday holds the string denoting the birthday field in the database.
+	FieldBirthday = "birthday"
+
+	// EdgeOwner holds the string denoting the owner edge name in mutations.
+	EdgeOwner = "owner"
 
 	// Table holds the table name of the pet in the database.
 	Table = "pets"
+	// OwnerTable is the table the holds the owner relation/edge.
+	OwnerTable = "pets"
+	// OwnerInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	OwnerInverseTable = "users"
+	// OwnerColumn is the table column denoting the owner relation/edge.
+	OwnerColumn = "user_pets"
 )
 
 // Columns holds all SQL columns for pet fields.
 var Columns = []string{
 	FieldID,
+	FieldDeletedAt,
+	FieldTenantID,
 	FieldCreatedAt,
 	FieldUpdatedAt,
+	FieldVersion,
+	FieldName,
+	FieldSpecies,
+	FieldBirthday,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the Pet type.
+var ForeignKeys = []string{
+	"user_pets",
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -34,12 +69,34 @@ func ValidColumn(column string) bool {
 			return true
 		}
 	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
 	return false
 }
 
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "go-api/ent/runtime"
 var (
+	Hooks  [3]ent.Hook
+	Policy ent.Policy
+	// DefaultTenantID holds the default value on creation for the tenant_id field.
+	DefaultTenantID int
 	// DefaultCreatedAt holds the default value on creation for the created_at field.
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultVersion holds the default value on creation for the version field.
+	DefaultVersion int
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// SpeciesValidator is a validator for the "species" field. It is called by the builders before save.
+	SpeciesValidator func(string) error
 )
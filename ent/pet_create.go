@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"go-api/ent/pet"
+	"go-api/ent/user"
 	"time"
 
 	"github.com/facebook/ent/dialect/sql/sqlgraph"
@@ -20,6 +21,34 @@ type PetCreate struct {
 	hooks    []Hook
 }
 
+// SetDeletedAt sets the deleted_at field.
+func (pc *PetCreate) SetDeletedAt(t time.Time) *PetCreate {
+	pc.mutation.SetDeletedAt(t)
+	return pc
+}
+
+// SetNillableDeletedAt sets the deleted_at field if the given value is not nil.
+func (pc *PetCreate) SetNillableDeletedAt(t *time.Time) *PetCreate {
+	if t != nil {
+		pc.SetDeletedAt(*t)
+	}
+	return pc
+}
+
+// SetTenantID sets the tenant_id field.
+func (pc *PetCreate) SetTenantID(i int) *PetCreate {
+	pc.mutation.SetTenantID(i)
+	return pc
+}
+
+// SetNillableTenantID sets the tenant_id field if the given value is not nil.
+func (pc *PetCreate) SetNillableTenantID(i *int) *PetCreate {
+	if i != nil {
+		pc.SetTenantID(*i)
+	}
+	return pc
+}
+
 // SetCreatedAt sets the created_at field.
 func (pc *PetCreate) SetCreatedAt(t time.Time) *PetCreate {
 	pc.mutation.SetCreatedAt(t)
@@ -48,6 +77,57 @@ func (pc *PetCreate) SetNillableUpdatedAt(t *time.Time) *PetCreate {
 	return pc
 }
 
+// SetVersion sets the version field.
+func (pc *PetCreate) SetVersion(i int) *PetCreate {
+	pc.mutation.SetVersion(i)
+	return pc
+}
+
+// SetNillableVersion sets the version field if the given value is not nil.
+func (pc *PetCreate) SetNillableVersion(i *int) *PetCreate {
+	if i != nil {
+		pc.SetVersion(*i)
+	}
+	return pc
+}
+
+// SetName sets the name field.
+func (pc *PetCreate) SetName(s string) *PetCreate {
+	pc.mutation.SetName(s)
+	return pc
+}
+
+// SetSpecies sets the species field.
+func (pc *PetCreate) SetSpecies(s string) *PetCreate {
+	pc.mutation.SetSpecies(s)
+	return pc
+}
+
+// SetBirthday sets the birthday field.
+func (pc *PetCreate) SetBirthday(t time.Time) *PetCreate {
+	pc.mutation.SetBirthday(t)
+	return pc
+}
+
+// SetNillableBirthday sets the birthday field if the given value is not nil.
+func (pc *PetCreate) SetNillableBirthday(t *time.Time) *PetCreate {
+	if t != nil {
+		pc.SetBirthday(*t)
+	}
+	return pc
+}
+
+// SetOwnerID sets the owner edge to User by id.
+func (pc *PetCreate) SetOwnerID(id int) *PetCreate {
+	pc.mutation.SetOwnerID(id)
+	return pc
+}
+
+// SetOwner sets the owner edge to User.
+func (pc *PetCreate) SetOwner(u *User) *PetCreate {
+	return pc.SetOwnerID(u.ID)
+}
+
 // Mutation returns the PetMutation object of the builder.
 func (pc *PetCreate) Mutation() *PetMutation {
 	return pc.mutation
@@ -100,6 +180,10 @@ func (pc *PetCreate) SaveX(ctx context.Context) *Pet {
 
 // defaults sets the default values of the builder before save.
 func (pc *PetCreate) defaults() {
+	if _, ok := pc.mutation.TenantID(); !ok {
+		v := pet.DefaultTenantID
+		pc.mutation.SetTenantID(v)
+	}
 	if _, ok := pc.mutation.CreatedAt(); !ok {
 		v := pet.DefaultCreatedAt()
 		pc.mutation.SetCreatedAt(v)
@@ -108,16 +192,45 @@ func (pc *PetCreate) defaults() {
 		v := pet.DefaultUpdatedAt()
 		pc.mutation.SetUpdatedAt(v)
 	}
+	if _, ok := pc.mutation.Version(); !ok {
+		v := pet.DefaultVersion
+		pc.mutation.SetVersion(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
 func (pc *PetCreate) check() error {
+	if _, ok := pc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New("ent: missing required field \"tenant_id\"")}
+	}
 	if _, ok := pc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New("ent: missing required field \"created_at\"")}
 	}
 	if _, ok := pc.mutation.UpdatedAt(); !ok {
 		return &ValidationError{Name: "updated_at", err: errors.New("ent: missing required field \"updated_at\"")}
 	}
+	if _, ok := pc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New("ent: missing required field \"version\"")}
+	}
+	if _, ok := pc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New("ent: missing required field \"name\"")}
+	}
+	if v, ok := pc.mutation.Name(); ok {
+		if err := pet.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	if _, ok := pc.mutation.Species(); !ok {
+		return &ValidationError{Name: "species", err: errors.New("ent: missing required field \"species\"")}
+	}
+	if v, ok := pc.mutation.Species(); ok {
+		if err := pet.SpeciesValidator(v); err != nil {
+			return &ValidationError{Name: "species", err: fmt.Errorf("ent: validator failed for field \"species\": %w", err)}
+		}
+	}
+	if _, ok := pc.mutation.OwnerID(); !ok {
+		return &ValidationError{Name: "owner", err: errors.New("ent: missing required edge \"owner\"")}
+	}
 	return nil
 }
 
@@ -145,6 +258,22 @@ func (pc *PetCreate) createSpec() (*Pet, *sqlgraph.CreateSpec) {
 			},
 		}
 	)
+	if value, ok := pc.mutation.DeletedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: pet.FieldDeletedAt,
+		})
+		_node.DeletedAt = &value
+	}
+	if value, ok := pc.mutation.TenantID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: pet.FieldTenantID,
+		})
+		_node.TenantID = value
+	}
 	if value, ok := pc.mutation.CreatedAt(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeTime,
@@ -161,6 +290,57 @@ func (pc *PetCreate) createSpec() (*Pet, *sqlgraph.CreateSpec) {
 		})
 		_node.UpdatedAt = value
 	}
+	if value, ok := pc.mutation.Version(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: pet.FieldVersion,
+		})
+		_node.Version = value
+	}
+	if value, ok := pc.mutation.Name(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pet.FieldName,
+		})
+		_node.Name = value
+	}
+	if value, ok := pc.mutation.Species(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pet.FieldSpecies,
+		})
+		_node.Species = value
+	}
+	if value, ok := pc.mutation.Birthday(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: pet.FieldBirthday,
+		})
+		_node.Birthday = &value
+	}
+	if nodes := pc.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pet.OwnerTable,
+			Columns: []string{pet.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -4,9 +4,11 @@ package ent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-api/ent/pet"
 	"go-api/ent/predicate"
+	"go-api/ent/user"
 	"time"
 
 	"github.com/facebook/ent/dialect/sql"
@@ -27,46 +29,118 @@ func (pu *PetUpdate) Where(ps ...predicate.Pet) *PetUpdate {
 	return pu
 }
 
-// SetCreatedAt sets the created_at field.
-func (pu *PetUpdate) SetCreatedAt(t time.Time) *PetUpdate {
-	pu.mutation.SetCreatedAt(t)
+// SetDeletedAt sets the deleted_at field.
+func (pu *PetUpdate) SetDeletedAt(t time.Time) *PetUpdate {
+	pu.mutation.SetDeletedAt(t)
 	return pu
 }
 
-// SetNillableCreatedAt sets the created_at field if the given value is not nil.
-func (pu *PetUpdate) SetNillableCreatedAt(t *time.Time) *PetUpdate {
+// SetNillableDeletedAt sets the deleted_at field if the given value is not nil.
+func (pu *PetUpdate) SetNillableDeletedAt(t *time.Time) *PetUpdate {
 	if t != nil {
-		pu.SetCreatedAt(*t)
+		pu.SetDeletedAt(*t)
 	}
 	return pu
 }
 
+// ClearDeletedAt clears the value of deleted_at.
+func (pu *PetUpdate) ClearDeletedAt() *PetUpdate {
+	pu.mutation.ClearDeletedAt()
+	return pu
+}
+
 // SetUpdatedAt sets the updated_at field.
 func (pu *PetUpdate) SetUpdatedAt(t time.Time) *PetUpdate {
 	pu.mutation.SetUpdatedAt(t)
 	return pu
 }
 
-// SetNillableUpdatedAt sets the updated_at field if the given value is not nil.
-func (pu *PetUpdate) SetNillableUpdatedAt(t *time.Time) *PetUpdate {
+// SetVersion sets the version field.
+func (pu *PetUpdate) SetVersion(i int) *PetUpdate {
+	pu.mutation.ResetVersion()
+	pu.mutation.SetVersion(i)
+	return pu
+}
+
+// SetNillableVersion sets the version field if the given value is not nil.
+func (pu *PetUpdate) SetNillableVersion(i *int) *PetUpdate {
+	if i != nil {
+		pu.SetVersion(*i)
+	}
+	return pu
+}
+
+// AddVersion adds i to version.
+func (pu *PetUpdate) AddVersion(i int) *PetUpdate {
+	pu.mutation.AddVersion(i)
+	return pu
+}
+
+// SetName sets the name field.
+func (pu *PetUpdate) SetName(s string) *PetUpdate {
+	pu.mutation.SetName(s)
+	return pu
+}
+
+// SetSpecies sets the species field.
+func (pu *PetUpdate) SetSpecies(s string) *PetUpdate {
+	pu.mutation.SetSpecies(s)
+	return pu
+}
+
+// SetBirthday sets the birthday field.
+func (pu *PetUpdate) SetBirthday(t time.Time) *PetUpdate {
+	pu.mutation.SetBirthday(t)
+	return pu
+}
+
+// SetNillableBirthday sets the birthday field if the given value is not nil.
+func (pu *PetUpdate) SetNillableBirthday(t *time.Time) *PetUpdate {
 	if t != nil {
-		pu.SetUpdatedAt(*t)
+		pu.SetBirthday(*t)
 	}
 	return pu
 }
 
+// ClearBirthday clears the value of birthday.
+func (pu *PetUpdate) ClearBirthday() *PetUpdate {
+	pu.mutation.ClearBirthday()
+	return pu
+}
+
+// SetOwnerID sets the owner edge to User by id.
+func (pu *PetUpdate) SetOwnerID(id int) *PetUpdate {
+	pu.mutation.SetOwnerID(id)
+	return pu
+}
+
+// SetOwner sets the owner edge to User.
+func (pu *PetUpdate) SetOwner(u *User) *PetUpdate {
+	return pu.SetOwnerID(u.ID)
+}
+
 // Mutation returns the PetMutation object of the builder.
 func (pu *PetUpdate) Mutation() *PetMutation {
 	return pu.mutation
 }
 
+// ClearOwner clears the "owner" edge to type User.
+func (pu *PetUpdate) ClearOwner() *PetUpdate {
+	pu.mutation.ClearOwner()
+	return pu
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (pu *PetUpdate) Save(ctx context.Context) (int, error) {
 	var (
 		err      error
 		affected int
 	)
+	pu.defaults()
 	if len(pu.hooks) == 0 {
+		if err = pu.check(); err != nil {
+			return 0, err
+		}
 		affected, err = pu.sqlSave(ctx)
 	} else {
 		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
@@ -74,6 +148,9 @@ func (pu *PetUpdate) Save(ctx context.Context) (int, error) {
 			if !ok {
 				return nil, fmt.Errorf("unexpected mutation type %T", m)
 			}
+			if err = pu.check(); err != nil {
+				return 0, err
+			}
 			pu.mutation = mutation
 			affected, err = pu.sqlSave(ctx)
 			mutation.done = true
@@ -111,6 +188,32 @@ func (pu *PetUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (pu *PetUpdate) defaults() {
+	if _, ok := pu.mutation.UpdatedAt(); !ok {
+		v := pet.UpdateDefaultUpdatedAt()
+		pu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pu *PetUpdate) check() error {
+	if v, ok := pu.mutation.Name(); ok {
+		if err := pet.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	if v, ok := pu.mutation.Species(); ok {
+		if err := pet.SpeciesValidator(v); err != nil {
+			return &ValidationError{Name: "species", err: fmt.Errorf("ent: validator failed for field \"species\": %w", err)}
+		}
+	}
+	if _, ok := pu.mutation.OwnerID(); pu.mutation.OwnerCleared() && !ok {
+		return errors.New("ent: clearing a required unique edge \"owner\"")
+	}
+	return nil
+}
+
 func (pu *PetUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	_spec := &sqlgraph.UpdateSpec{
 		Node: &sqlgraph.NodeSpec{
@@ -129,11 +232,17 @@ func (pu *PetUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
-	if value, ok := pu.mutation.CreatedAt(); ok {
+	if value, ok := pu.mutation.DeletedAt(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeTime,
 			Value:  value,
-			Column: pet.FieldCreatedAt,
+			Column: pet.FieldDeletedAt,
+		})
+	}
+	if pu.mutation.DeletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: pet.FieldDeletedAt,
 		})
 	}
 	if value, ok := pu.mutation.UpdatedAt(); ok {
@@ -143,6 +252,82 @@ func (pu *PetUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: pet.FieldUpdatedAt,
 		})
 	}
+	if value, ok := pu.mutation.Version(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: pet.FieldVersion,
+		})
+	}
+	if value, ok := pu.mutation.AddedVersion(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: pet.FieldVersion,
+		})
+	}
+	if value, ok := pu.mutation.Name(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pet.FieldName,
+		})
+	}
+	if value, ok := pu.mutation.Species(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pet.FieldSpecies,
+		})
+	}
+	if value, ok := pu.mutation.Birthday(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: pet.FieldBirthday,
+		})
+	}
+	if pu.mutation.BirthdayCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: pet.FieldBirthday,
+		})
+	}
+	if pu.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pet.OwnerTable,
+			Columns: []string{pet.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pet.OwnerTable,
+			Columns: []string{pet.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{pet.Label}
@@ -161,46 +346,118 @@ type PetUpdateOne struct {
 	mutation *PetMutation
 }
 
-// SetCreatedAt sets the created_at field.
-func (puo *PetUpdateOne) SetCreatedAt(t time.Time) *PetUpdateOne {
-	puo.mutation.SetCreatedAt(t)
+// SetDeletedAt sets the deleted_at field.
+func (puo *PetUpdateOne) SetDeletedAt(t time.Time) *PetUpdateOne {
+	puo.mutation.SetDeletedAt(t)
 	return puo
 }
 
-// SetNillableCreatedAt sets the created_at field if the given value is not nil.
-func (puo *PetUpdateOne) SetNillableCreatedAt(t *time.Time) *PetUpdateOne {
+// SetNillableDeletedAt sets the deleted_at field if the given value is not nil.
+func (puo *PetUpdateOne) SetNillableDeletedAt(t *time.Time) *PetUpdateOne {
 	if t != nil {
-		puo.SetCreatedAt(*t)
+		puo.SetDeletedAt(*t)
 	}
 	return puo
 }
 
+// ClearDeletedAt clears the value of deleted_at.
+func (puo *PetUpdateOne) ClearDeletedAt() *PetUpdateOne {
+	puo.mutation.ClearDeletedAt()
+	return puo
+}
+
 // SetUpdatedAt sets the updated_at field.
 func (puo *PetUpdateOne) SetUpdatedAt(t time.Time) *PetUpdateOne {
 	puo.mutation.SetUpdatedAt(t)
 	return puo
 }
 
-// SetNillableUpdatedAt sets the updated_at field if the given value is not nil.
-func (puo *PetUpdateOne) SetNillableUpdatedAt(t *time.Time) *PetUpdateOne {
+// SetVersion sets the version field.
+func (puo *PetUpdateOne) SetVersion(i int) *PetUpdateOne {
+	puo.mutation.ResetVersion()
+	puo.mutation.SetVersion(i)
+	return puo
+}
+
+// SetNillableVersion sets the version field if the given value is not nil.
+func (puo *PetUpdateOne) SetNillableVersion(i *int) *PetUpdateOne {
+	if i != nil {
+		puo.SetVersion(*i)
+	}
+	return puo
+}
+
+// AddVersion adds i to version.
+func (puo *PetUpdateOne) AddVersion(i int) *PetUpdateOne {
+	puo.mutation.AddVersion(i)
+	return puo
+}
+
+// SetName sets the name field.
+func (puo *PetUpdateOne) SetName(s string) *PetUpdateOne {
+	puo.mutation.SetName(s)
+	return puo
+}
+
+// SetSpecies sets the species field.
+func (puo *PetUpdateOne) SetSpecies(s string) *PetUpdateOne {
+	puo.mutation.SetSpecies(s)
+	return puo
+}
+
+// SetBirthday sets the birthday field.
+func (puo *PetUpdateOne) SetBirthday(t time.Time) *PetUpdateOne {
+	puo.mutation.SetBirthday(t)
+	return puo
+}
+
+// SetNillableBirthday sets the birthday field if the given value is not nil.
+func (puo *PetUpdateOne) SetNillableBirthday(t *time.Time) *PetUpdateOne {
 	if t != nil {
-		puo.SetUpdatedAt(*t)
+		puo.SetBirthday(*t)
 	}
 	return puo
 }
 
+// ClearBirthday clears the value of birthday.
+func (puo *PetUpdateOne) ClearBirthday() *PetUpdateOne {
+	puo.mutation.ClearBirthday()
+	return puo
+}
+
+// SetOwnerID sets the owner edge to User by id.
+func (puo *PetUpdateOne) SetOwnerID(id int) *PetUpdateOne {
+	puo.mutation.SetOwnerID(id)
+	return puo
+}
+
+// SetOwner sets the owner edge to User.
+func (puo *PetUpdateOne) SetOwner(u *User) *PetUpdateOne {
+	return puo.SetOwnerID(u.ID)
+}
+
 // Mutation returns the PetMutation object of the builder.
 func (puo *PetUpdateOne) Mutation() *PetMutation {
 	return puo.mutation
 }
 
+// ClearOwner clears the "owner" edge to type User.
+func (puo *PetUpdateOne) ClearOwner() *PetUpdateOne {
+	puo.mutation.ClearOwner()
+	return puo
+}
+
 // Save executes the query and returns the updated entity.
 func (puo *PetUpdateOne) Save(ctx context.Context) (*Pet, error) {
 	var (
 		err  error
 		node *Pet
 	)
+	puo.defaults()
 	if len(puo.hooks) == 0 {
+		if err = puo.check(); err != nil {
+			return nil, err
+		}
 		node, err = puo.sqlSave(ctx)
 	} else {
 		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
@@ -208,6 +465,9 @@ func (puo *PetUpdateOne) Save(ctx context.Context) (*Pet, error) {
 			if !ok {
 				return nil, fmt.Errorf("unexpected mutation type %T", m)
 			}
+			if err = puo.check(); err != nil {
+				return nil, err
+			}
 			puo.mutation = mutation
 			node, err = puo.sqlSave(ctx)
 			mutation.done = true
@@ -245,6 +505,32 @@ func (puo *PetUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (puo *PetUpdateOne) defaults() {
+	if _, ok := puo.mutation.UpdatedAt(); !ok {
+		v := pet.UpdateDefaultUpdatedAt()
+		puo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (puo *PetUpdateOne) check() error {
+	if v, ok := puo.mutation.Name(); ok {
+		if err := pet.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	if v, ok := puo.mutation.Species(); ok {
+		if err := pet.SpeciesValidator(v); err != nil {
+			return &ValidationError{Name: "species", err: fmt.Errorf("ent: validator failed for field \"species\": %w", err)}
+		}
+	}
+	if _, ok := puo.mutation.OwnerID(); puo.mutation.OwnerCleared() && !ok {
+		return errors.New("ent: clearing a required unique edge \"owner\"")
+	}
+	return nil
+}
+
 func (puo *PetUpdateOne) sqlSave(ctx context.Context) (_node *Pet, err error) {
 	_spec := &sqlgraph.UpdateSpec{
 		Node: &sqlgraph.NodeSpec{
@@ -261,11 +547,17 @@ func (puo *PetUpdateOne) sqlSave(ctx context.Context) (_node *Pet, err error) {
 		return nil, &ValidationError{Name: "ID", err: fmt.Errorf("missing Pet.ID for update")}
 	}
 	_spec.Node.ID.Value = id
-	if value, ok := puo.mutation.CreatedAt(); ok {
+	if value, ok := puo.mutation.DeletedAt(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeTime,
 			Value:  value,
-			Column: pet.FieldCreatedAt,
+			Column: pet.FieldDeletedAt,
+		})
+	}
+	if puo.mutation.DeletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: pet.FieldDeletedAt,
 		})
 	}
 	if value, ok := puo.mutation.UpdatedAt(); ok {
@@ -275,6 +567,82 @@ func (puo *PetUpdateOne) sqlSave(ctx context.Context) (_node *Pet, err error) {
 			Column: pet.FieldUpdatedAt,
 		})
 	}
+	if value, ok := puo.mutation.Version(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: pet.FieldVersion,
+		})
+	}
+	if value, ok := puo.mutation.AddedVersion(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: pet.FieldVersion,
+		})
+	}
+	if value, ok := puo.mutation.Name(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pet.FieldName,
+		})
+	}
+	if value, ok := puo.mutation.Species(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pet.FieldSpecies,
+		})
+	}
+	if value, ok := puo.mutation.Birthday(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: pet.FieldBirthday,
+		})
+	}
+	if puo.mutation.BirthdayCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: pet.FieldBirthday,
+		})
+	}
+	if puo.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pet.OwnerTable,
+			Columns: []string{pet.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pet.OwnerTable,
+			Columns: []string{pet.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Pet{config: puo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues()
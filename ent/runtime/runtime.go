@@ -2,9 +2,451 @@
 
 package runtime
 
-// The schema-stitching logic is generated in go-api/ent/runtime.go
+import (
+	"context"
+	"go-api/ent/auditlog"
+	"go-api/ent/datarequest"
+	"go-api/ent/identity"
+	"go-api/ent/oauthclient"
+	"go-api/ent/outbox"
+	"go-api/ent/pet"
+	"go-api/ent/schema"
+	"go-api/ent/tenant"
+	"go-api/ent/user"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"time"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/privacy"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	auditlogMixin := schema.AuditLog{}.Mixin()
+	auditlog.Policy = privacy.NewPolicies(auditlogMixin[0], schema.AuditLog{})
+	auditlog.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := auditlog.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	auditlogMixinHooks0 := auditlogMixin[0].Hooks()
+
+	auditlog.Hooks[1] = auditlogMixinHooks0[0]
+	auditlogMixinFields0 := auditlogMixin[0].Fields()
+	auditlogFields := schema.AuditLog{}.Fields()
+	_ = auditlogFields
+	// auditlogDescTenantID is the schema descriptor for tenant_id field.
+	auditlogDescTenantID := auditlogMixinFields0[0].Descriptor()
+	// auditlog.DefaultTenantID holds the default value on creation for the tenant_id field.
+	auditlog.DefaultTenantID = auditlogDescTenantID.Default.(int)
+	// auditlogDescCreatedAt is the schema descriptor for created_at field.
+	auditlogDescCreatedAt := auditlogFields[5].Descriptor()
+	// auditlog.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditlog.DefaultCreatedAt = auditlogDescCreatedAt.Default.(func() time.Time)
+	datarequestMixin := schema.DataRequest{}.Mixin()
+	datarequest.Policy = privacy.NewPolicies(datarequestMixin[0], schema.DataRequest{})
+	datarequest.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := datarequest.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	datarequestMixinHooks0 := datarequestMixin[0].Hooks()
+
+	datarequest.Hooks[1] = datarequestMixinHooks0[0]
+	datarequestMixinFields0 := datarequestMixin[0].Fields()
+	datarequestFields := schema.DataRequest{}.Fields()
+	_ = datarequestFields
+	// datarequestDescTenantID is the schema descriptor for tenant_id field.
+	datarequestDescTenantID := datarequestMixinFields0[0].Descriptor()
+	// datarequest.DefaultTenantID holds the default value on creation for the tenant_id field.
+	datarequest.DefaultTenantID = datarequestDescTenantID.Default.(int)
+	// datarequestDescObjectKey is the schema descriptor for object_key field.
+	datarequestDescObjectKey := datarequestFields[3].Descriptor()
+	// datarequest.DefaultObjectKey holds the default value on creation for the object_key field.
+	datarequest.DefaultObjectKey = datarequestDescObjectKey.Default.(string)
+	// datarequestDescError is the schema descriptor for error field.
+	datarequestDescError := datarequestFields[4].Descriptor()
+	// datarequest.DefaultError holds the default value on creation for the error field.
+	datarequest.DefaultError = datarequestDescError.Default.(string)
+	// datarequestDescCreatedAt is the schema descriptor for created_at field.
+	datarequestDescCreatedAt := datarequestFields[8].Descriptor()
+	// datarequest.DefaultCreatedAt holds the default value on creation for the created_at field.
+	datarequest.DefaultCreatedAt = datarequestDescCreatedAt.Default.(func() time.Time)
+	identityMixin := schema.Identity{}.Mixin()
+	identity.Policy = privacy.NewPolicies(identityMixin[0], schema.Identity{})
+	identity.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := identity.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	identityMixinHooks0 := identityMixin[0].Hooks()
+
+	identity.Hooks[1] = identityMixinHooks0[0]
+	identityMixinFields0 := identityMixin[0].Fields()
+	identityFields := schema.Identity{}.Fields()
+	_ = identityFields
+	// identityDescTenantID is the schema descriptor for tenant_id field.
+	identityDescTenantID := identityMixinFields0[0].Descriptor()
+	// identity.DefaultTenantID holds the default value on creation for the tenant_id field.
+	identity.DefaultTenantID = identityDescTenantID.Default.(int)
+	// identityDescProvider is the schema descriptor for provider field.
+	identityDescProvider := identityFields[0].Descriptor()
+	// identity.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	identity.ProviderValidator = identityDescProvider.Validators[0].(func(string) error)
+	// identityDescSubject is the schema descriptor for subject field.
+	identityDescSubject := identityFields[1].Descriptor()
+	// identity.SubjectValidator is a validator for the "subject" field. It is called by the builders before save.
+	identity.SubjectValidator = identityDescSubject.Validators[0].(func(string) error)
+	// identityDescEmail is the schema descriptor for email field.
+	identityDescEmail := identityFields[2].Descriptor()
+	// identity.DefaultEmail holds the default value on creation for the email field.
+	identity.DefaultEmail = identityDescEmail.Default.(string)
+	// identityDescCreatedAt is the schema descriptor for created_at field.
+	identityDescCreatedAt := identityFields[3].Descriptor()
+	// identity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	identity.DefaultCreatedAt = identityDescCreatedAt.Default.(func() time.Time)
+	oauthclientMixin := schema.OAuthClient{}.Mixin()
+	oauthclient.Policy = privacy.NewPolicies(oauthclientMixin[0], schema.OAuthClient{})
+	oauthclient.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := oauthclient.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	oauthclientMixinHooks0 := oauthclientMixin[0].Hooks()
+
+	oauthclient.Hooks[1] = oauthclientMixinHooks0[0]
+	oauthclientMixinFields0 := oauthclientMixin[0].Fields()
+	oauthclientFields := schema.OAuthClient{}.Fields()
+	_ = oauthclientFields
+	// oauthclientDescTenantID is the schema descriptor for tenant_id field.
+	oauthclientDescTenantID := oauthclientMixinFields0[0].Descriptor()
+	// oauthclient.DefaultTenantID holds the default value on creation for the tenant_id field.
+	oauthclient.DefaultTenantID = oauthclientDescTenantID.Default.(int)
+	// oauthclientDescSecretHash is the schema descriptor for secret_hash field.
+	oauthclientDescSecretHash := oauthclientFields[1].Descriptor()
+	// oauthclient.DefaultSecretHash holds the default value on creation for the secret_hash field.
+	oauthclient.DefaultSecretHash = oauthclientDescSecretHash.Default.(string)
+	// oauthclientDescName is the schema descriptor for name field.
+	oauthclientDescName := oauthclientFields[2].Descriptor()
+	// oauthclient.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	oauthclient.NameValidator = func() func(string) error {
+		validators := oauthclientDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// oauthclientDescRedirectUris is the schema descriptor for redirect_uris field.
+	oauthclientDescRedirectUris := oauthclientFields[3].Descriptor()
+	// oauthclient.DefaultRedirectUris holds the default value on creation for the redirect_uris field.
+	oauthclient.DefaultRedirectUris = oauthclientDescRedirectUris.Default.(string)
+	// oauthclientDescScopes is the schema descriptor for scopes field.
+	oauthclientDescScopes := oauthclientFields[4].Descriptor()
+	// oauthclient.DefaultScopes holds the default value on creation for the scopes field.
+	oauthclient.DefaultScopes = oauthclientDescScopes.Default.(string)
+	// oauthclientDescGrantTypes is the schema descriptor for grant_types field.
+	oauthclientDescGrantTypes := oauthclientFields[5].Descriptor()
+	// oauthclient.DefaultGrantTypes holds the default value on creation for the grant_types field.
+	oauthclient.DefaultGrantTypes = oauthclientDescGrantTypes.Default.(string)
+	// oauthclientDescCreatedBy is the schema descriptor for created_by field.
+	oauthclientDescCreatedBy := oauthclientFields[6].Descriptor()
+	// oauthclient.DefaultCreatedBy holds the default value on creation for the created_by field.
+	oauthclient.DefaultCreatedBy = oauthclientDescCreatedBy.Default.(int)
+	// oauthclientDescCreatedAt is the schema descriptor for created_at field.
+	oauthclientDescCreatedAt := oauthclientFields[7].Descriptor()
+	// oauthclient.DefaultCreatedAt holds the default value on creation for the created_at field.
+	oauthclient.DefaultCreatedAt = oauthclientDescCreatedAt.Default.(func() time.Time)
+	outboxFields := schema.Outbox{}.Fields()
+	_ = outboxFields
+	// outboxDescCreatedAt is the schema descriptor for created_at field.
+	outboxDescCreatedAt := outboxFields[4].Descriptor()
+	// outbox.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outbox.DefaultCreatedAt = outboxDescCreatedAt.Default.(func() time.Time)
+	// outboxDescAttempts is the schema descriptor for attempts field.
+	outboxDescAttempts := outboxFields[6].Descriptor()
+	// outbox.DefaultAttempts holds the default value on creation for the attempts field.
+	outbox.DefaultAttempts = outboxDescAttempts.Default.(int)
+	petMixin := schema.Pet{}.Mixin()
+	pet.Policy = privacy.NewPolicies(petMixin[0], petMixin[1], schema.Pet{})
+	pet.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := pet.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	petMixinHooks1 := petMixin[1].Hooks()
+	petMixinHooks3 := petMixin[3].Hooks()
+
+	pet.Hooks[1] = petMixinHooks1[0]
+
+	pet.Hooks[2] = petMixinHooks3[0]
+	petMixinFields1 := petMixin[1].Fields()
+	petMixinFields2 := petMixin[2].Fields()
+	petMixinFields3 := petMixin[3].Fields()
+	petFields := schema.Pet{}.Fields()
+	_ = petFields
+	// petDescTenantID is the schema descriptor for tenant_id field.
+	petDescTenantID := petMixinFields1[0].Descriptor()
+	// pet.DefaultTenantID holds the default value on creation for the tenant_id field.
+	pet.DefaultTenantID = petDescTenantID.Default.(int)
+	// petDescCreatedAt is the schema descriptor for created_at field.
+	petDescCreatedAt := petMixinFields2[0].Descriptor()
+	// pet.DefaultCreatedAt holds the default value on creation for the created_at field.
+	pet.DefaultCreatedAt = petDescCreatedAt.Default.(func() time.Time)
+	// petDescUpdatedAt is the schema descriptor for updated_at field.
+	petDescUpdatedAt := petMixinFields2[1].Descriptor()
+	// pet.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	pet.DefaultUpdatedAt = petDescUpdatedAt.Default.(func() time.Time)
+	// pet.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	pet.UpdateDefaultUpdatedAt = petDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// petDescVersion is the schema descriptor for version field.
+	petDescVersion := petMixinFields3[0].Descriptor()
+	// pet.DefaultVersion holds the default value on creation for the version field.
+	pet.DefaultVersion = petDescVersion.Default.(int)
+	// petDescName is the schema descriptor for name field.
+	petDescName := petFields[0].Descriptor()
+	// pet.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	pet.NameValidator = func() func(string) error {
+		validators := petDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// petDescSpecies is the schema descriptor for species field.
+	petDescSpecies := petFields[1].Descriptor()
+	// pet.SpeciesValidator is a validator for the "species" field. It is called by the builders before save.
+	pet.SpeciesValidator = func() func(string) error {
+		validators := petDescSpecies.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(species string) error {
+			for _, fn := range fns {
+				if err := fn(species); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	tenantFields := schema.Tenant{}.Fields()
+	_ = tenantFields
+	// tenantDescName is the schema descriptor for name field.
+	tenantDescName := tenantFields[0].Descriptor()
+	// tenant.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	tenant.NameValidator = tenantDescName.Validators[0].(func(string) error)
+	// tenantDescStatus is the schema descriptor for status field.
+	tenantDescStatus := tenantFields[1].Descriptor()
+	// tenant.DefaultStatus holds the default value on creation for the status field.
+	tenant.DefaultStatus = tenantDescStatus.Default.(string)
+	// tenantDescCreatedAt is the schema descriptor for created_at field.
+	tenantDescCreatedAt := tenantFields[3].Descriptor()
+	// tenant.DefaultCreatedAt holds the default value on creation for the created_at field.
+	tenant.DefaultCreatedAt = tenantDescCreatedAt.Default.(func() time.Time)
+	// tenantDescUpdatedAt is the schema descriptor for updated_at field.
+	tenantDescUpdatedAt := tenantFields[4].Descriptor()
+	// tenant.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	tenant.DefaultUpdatedAt = tenantDescUpdatedAt.Default.(func() time.Time)
+	// tenant.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	tenant.UpdateDefaultUpdatedAt = tenantDescUpdatedAt.UpdateDefault.(func() time.Time)
+	userMixin := schema.User{}.Mixin()
+	user.Policy = privacy.NewPolicies(userMixin[0], userMixin[1], schema.User{})
+	user.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := user.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	userMixinHooks1 := userMixin[1].Hooks()
+	userMixinHooks3 := userMixin[3].Hooks()
+
+	user.Hooks[1] = userMixinHooks1[0]
+
+	user.Hooks[2] = userMixinHooks3[0]
+	userMixinFields1 := userMixin[1].Fields()
+	userMixinFields2 := userMixin[2].Fields()
+	userMixinFields3 := userMixin[3].Fields()
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescTenantID is the schema descriptor for tenant_id field.
+	userDescTenantID := userMixinFields1[0].Descriptor()
+	// user.DefaultTenantID holds the default value on creation for the tenant_id field.
+	user.DefaultTenantID = userDescTenantID.Default.(int)
+	// userDescCreatedAt is the schema descriptor for created_at field.
+	userDescCreatedAt := userMixinFields2[0].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
+	// userDescUpdatedAt is the schema descriptor for updated_at field.
+	userDescUpdatedAt := userMixinFields2[1].Descriptor()
+	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
+	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// userDescVersion is the schema descriptor for version field.
+	userDescVersion := userMixinFields3[0].Descriptor()
+	// user.DefaultVersion holds the default value on creation for the version field.
+	user.DefaultVersion = userDescVersion.Default.(int)
+	// userDescRole is the schema descriptor for role field.
+	userDescRole := userFields[6].Descriptor()
+	// user.DefaultRole holds the default value on creation for the role field.
+	user.DefaultRole = userDescRole.Default.(string)
+	webhookdeliveryMixin := schema.WebhookDelivery{}.Mixin()
+	webhookdelivery.Policy = privacy.NewPolicies(webhookdeliveryMixin[0], schema.WebhookDelivery{})
+	webhookdelivery.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := webhookdelivery.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	webhookdeliveryMixinHooks0 := webhookdeliveryMixin[0].Hooks()
+
+	webhookdelivery.Hooks[1] = webhookdeliveryMixinHooks0[0]
+	webhookdeliveryMixinFields0 := webhookdeliveryMixin[0].Fields()
+	webhookdeliveryFields := schema.WebhookDelivery{}.Fields()
+	_ = webhookdeliveryFields
+	// webhookdeliveryDescTenantID is the schema descriptor for tenant_id field.
+	webhookdeliveryDescTenantID := webhookdeliveryMixinFields0[0].Descriptor()
+	// webhookdelivery.DefaultTenantID holds the default value on creation for the tenant_id field.
+	webhookdelivery.DefaultTenantID = webhookdeliveryDescTenantID.Default.(int)
+	// webhookdeliveryDescUserID is the schema descriptor for user_id field.
+	webhookdeliveryDescUserID := webhookdeliveryFields[2].Descriptor()
+	// webhookdelivery.DefaultUserID holds the default value on creation for the user_id field.
+	webhookdelivery.DefaultUserID = webhookdeliveryDescUserID.Default.(int)
+	// webhookdeliveryDescAttempts is the schema descriptor for attempts field.
+	webhookdeliveryDescAttempts := webhookdeliveryFields[5].Descriptor()
+	// webhookdelivery.DefaultAttempts holds the default value on creation for the attempts field.
+	webhookdelivery.DefaultAttempts = webhookdeliveryDescAttempts.Default.(int)
+	// webhookdeliveryDescResponseStatus is the schema descriptor for response_status field.
+	webhookdeliveryDescResponseStatus := webhookdeliveryFields[6].Descriptor()
+	// webhookdelivery.DefaultResponseStatus holds the default value on creation for the response_status field.
+	webhookdelivery.DefaultResponseStatus = webhookdeliveryDescResponseStatus.Default.(int)
+	// webhookdeliveryDescResponseBody is the schema descriptor for response_body field.
+	webhookdeliveryDescResponseBody := webhookdeliveryFields[7].Descriptor()
+	// webhookdelivery.DefaultResponseBody holds the default value on creation for the response_body field.
+	webhookdelivery.DefaultResponseBody = webhookdeliveryDescResponseBody.Default.(string)
+	// webhookdeliveryDescError is the schema descriptor for error field.
+	webhookdeliveryDescError := webhookdeliveryFields[8].Descriptor()
+	// webhookdelivery.DefaultError holds the default value on creation for the error field.
+	webhookdelivery.DefaultError = webhookdeliveryDescError.Default.(string)
+	// webhookdeliveryDescDurationMs is the schema descriptor for duration_ms field.
+	webhookdeliveryDescDurationMs := webhookdeliveryFields[9].Descriptor()
+	// webhookdelivery.DefaultDurationMs holds the default value on creation for the duration_ms field.
+	webhookdelivery.DefaultDurationMs = webhookdeliveryDescDurationMs.Default.(int64)
+	// webhookdeliveryDescReplayOf is the schema descriptor for replay_of field.
+	webhookdeliveryDescReplayOf := webhookdeliveryFields[10].Descriptor()
+	// webhookdelivery.DefaultReplayOf holds the default value on creation for the replay_of field.
+	webhookdelivery.DefaultReplayOf = webhookdeliveryDescReplayOf.Default.(int)
+	// webhookdeliveryDescCreatedAt is the schema descriptor for created_at field.
+	webhookdeliveryDescCreatedAt := webhookdeliveryFields[11].Descriptor()
+	// webhookdelivery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookdelivery.DefaultCreatedAt = webhookdeliveryDescCreatedAt.Default.(func() time.Time)
+	webhookendpointMixin := schema.WebhookEndpoint{}.Mixin()
+	webhookendpoint.Policy = privacy.NewPolicies(webhookendpointMixin[0], schema.WebhookEndpoint{})
+	webhookendpoint.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := webhookendpoint.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	webhookendpointMixinHooks0 := webhookendpointMixin[0].Hooks()
+
+	webhookendpoint.Hooks[1] = webhookendpointMixinHooks0[0]
+	webhookendpointMixinFields0 := webhookendpointMixin[0].Fields()
+	webhookendpointFields := schema.WebhookEndpoint{}.Fields()
+	_ = webhookendpointFields
+	// webhookendpointDescTenantID is the schema descriptor for tenant_id field.
+	webhookendpointDescTenantID := webhookendpointMixinFields0[0].Descriptor()
+	// webhookendpoint.DefaultTenantID holds the default value on creation for the tenant_id field.
+	webhookendpoint.DefaultTenantID = webhookendpointDescTenantID.Default.(int)
+	// webhookendpointDescURL is the schema descriptor for url field.
+	webhookendpointDescURL := webhookendpointFields[1].Descriptor()
+	// webhookendpoint.URLValidator is a validator for the "url" field. It is called by the builders before save.
+	webhookendpoint.URLValidator = func() func(string) error {
+		validators := webhookendpointDescURL.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(url string) error {
+			for _, fn := range fns {
+				if err := fn(url); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// webhookendpointDescEvents is the schema descriptor for events field.
+	webhookendpointDescEvents := webhookendpointFields[3].Descriptor()
+	// webhookendpoint.DefaultEvents holds the default value on creation for the events field.
+	webhookendpoint.DefaultEvents = webhookendpointDescEvents.Default.(string)
+	// webhookendpointDescDescription is the schema descriptor for description field.
+	webhookendpointDescDescription := webhookendpointFields[4].Descriptor()
+	// webhookendpoint.DefaultDescription holds the default value on creation for the description field.
+	webhookendpoint.DefaultDescription = webhookendpointDescDescription.Default.(string)
+	// webhookendpoint.DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	webhookendpoint.DescriptionValidator = webhookendpointDescDescription.Validators[0].(func(string) error)
+	// webhookendpointDescEnabled is the schema descriptor for enabled field.
+	webhookendpointDescEnabled := webhookendpointFields[5].Descriptor()
+	// webhookendpoint.DefaultEnabled holds the default value on creation for the enabled field.
+	webhookendpoint.DefaultEnabled = webhookendpointDescEnabled.Default.(bool)
+	// webhookendpointDescFailures is the schema descriptor for failures field.
+	webhookendpointDescFailures := webhookendpointFields[6].Descriptor()
+	// webhookendpoint.DefaultFailures holds the default value on creation for the failures field.
+	webhookendpoint.DefaultFailures = webhookendpointDescFailures.Default.(int)
+	// webhookendpointDescCreatedAt is the schema descriptor for created_at field.
+	webhookendpointDescCreatedAt := webhookendpointFields[8].Descriptor()
+	// webhookendpoint.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookendpoint.DefaultCreatedAt = webhookendpointDescCreatedAt.Default.(func() time.Time)
+	// webhookendpointDescUpdatedAt is the schema descriptor for updated_at field.
+	webhookendpointDescUpdatedAt := webhookendpointFields[9].Descriptor()
+	// webhookendpoint.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhookendpoint.DefaultUpdatedAt = webhookendpointDescUpdatedAt.Default.(func() time.Time)
+	// webhookendpoint.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhookendpoint.UpdateDefaultUpdatedAt = webhookendpointDescUpdatedAt.UpdateDefault.(func() time.Time)
+}
 
 const (
-	Version = "v0.5.1"                                          // Version of ent codegen.
-	Sum     = "h1:XNIUdx1m8s2IBf36cnDoKKIaS2VaGJI/jkMKr8G9adE=" // Sum of ent codegen.
+	Version = "v0.5.1" // Version of ent codegen.
 )
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
+)
+
+// AuditLog holds the schema definition for the AuditLog entity.
+// 管理操作的审计记录, 只追加不修改, 租户管理员只能看到本租户的记录
+type AuditLog struct {
+	ent.Schema
+}
+
+// Mixin of the AuditLog.
+func (AuditLog) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+	}
+}
+
+// Fields of the AuditLog.
+func (AuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("actor_id").StructTag(`json:"actor_id"`),
+		field.String("action").StructTag(`json:"action"`),
+		field.String("target").StructTag(`json:"target"`),
+		field.Text("before").StructTag(`json:"before"`).Optional(),
+		field.Text("after").StructTag(`json:"after"`).Optional(),
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
+	}
+}
+
+// Edges of the AuditLog.
+func (AuditLog) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the AuditLog.
+func (AuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("target", "created_at"),
+		index.Fields("actor_id"),
+	}
+}
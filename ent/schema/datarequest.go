@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/dialect/entsql"
+	"github.com/facebook/ent/schema"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
+)
+
+// DataRequest holds the schema definition for the DataRequest entity.
+// 用户的数据导出与注销申请, 注销在宽限期结束后执行, 期间可以撤销
+type DataRequest struct {
+	ent.Schema
+}
+
+// Annotations of the DataRequest.
+func (DataRequest) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "data_requests"},
+	}
+}
+
+// Mixin of the DataRequest.
+func (DataRequest) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+	}
+}
+
+// Fields of the DataRequest.
+// 用户注销后申请记录仍然保留, 所以只记录 user_id 而不关联用户
+func (DataRequest) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id").StructTag(`json:"user_id"`).Immutable(),
+		field.Enum("kind").StructTag(`json:"kind"`).Values("export", "erasure").Immutable(),
+		field.Enum("status").StructTag(`json:"status"`).
+			Values("pending", "completed", "cancelled", "failed", "expired").
+			Default("pending"),
+		// object_key 导出文件在对象存储中的位置
+		field.String("object_key").StructTag(`json:"object_key"`).Default(""),
+		field.String("error").StructTag(`json:"error"`).Default(""),
+		// scheduled_at 注销的执行时间
+		field.Time("scheduled_at").StructTag(`json:"scheduled_at"`).Optional().Nillable(),
+		// expires_at 导出文件的过期时间
+		field.Time("expires_at").StructTag(`json:"expires_at"`).Optional().Nillable(),
+		field.Time("completed_at").StructTag(`json:"completed_at"`).Optional().Nillable(),
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
+	}
+}
+
+// Edges of the DataRequest.
+func (DataRequest) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the DataRequest.
+func (DataRequest) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "user_id"),
+		index.Fields("kind", "status", "scheduled_at"),
+	}
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/schema/edge"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
+)
+
+// Identity holds the schema definition for the Identity entity.
+// 用户在第三方 OIDC 提供方的账号
+type Identity struct {
+	ent.Schema
+}
+
+// Mixin of the Identity.
+func (Identity) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+	}
+}
+
+// Fields of the Identity.
+func (Identity) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("provider").StructTag(`json:"provider"`).NotEmpty(),
+		field.String("subject").StructTag(`json:"subject"`).NotEmpty(),
+		field.String("email").StructTag(`json:"email"`).Default(""),
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
+	}
+}
+
+// Edges of the Identity.
+func (Identity) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("identities").Unique().Required(),
+	}
+}
+
+// Indexes of the Identity.
+// 同一租户内第三方账号只能绑定一个用户
+func (Identity) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "provider", "subject").Unique(),
+	}
+}
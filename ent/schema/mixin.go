@@ -0,0 +1,170 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gen "go-api/ent"
+	"go-api/ent/hook"
+	"go-api/ent/pet"
+	"go-api/ent/privacy"
+	"go-api/ent/user"
+	"go-api/entx"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/entql"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/mixin"
+)
+
+// TimeMixin 创建时间与修改时间, 修改时自动更新
+type TimeMixin struct {
+	mixin.Schema
+}
+
+// Fields of the TimeMixin.
+func (TimeMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now).Immutable(),
+		field.Time("updated_at").StructTag(`json:"updated_at"`).Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// SoftDeleteMixin 软删除, 查询与修改默认不包含 deleted_at 不为空的数据, 需要时使用 entx.IncludeDeleted(ctx)
+// 删除数据时设置 deleted_at, 物理删除由定时任务完成; 唯一索引仍然包含软删除的数据, 写入前检查唯一性时需要包含它们
+// 放在 TenantMixin 之前, 租户规则允许后不再执行之后的规则
+type SoftDeleteMixin struct {
+	mixin.Schema
+}
+
+// Fields of the SoftDeleteMixin.
+func (SoftDeleteMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("deleted_at").StructTag(`json:"deleted_at"`).Optional().Nillable(),
+	}
+}
+
+// Policy of the SoftDeleteMixin.
+func (SoftDeleteMixin) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			filterDeleted(),
+		},
+		Mutation: privacy.MutationPolicy{
+			privacy.OnMutationOperation(filterDeleted(), ent.OpUpdate|ent.OpDelete|ent.OpDeleteOne),
+			denyDeleted(),
+		},
+	}
+}
+
+// filterDeleted 过滤软删除的数据, 只追加条件, 由之后的规则做出决定
+func filterDeleted() privacy.QueryMutationRule {
+	return privacy.FilterFunc(func(ctx context.Context, f privacy.Filter) error {
+		if !entx.DeletedIncluded(ctx) {
+			f.Where(entql.FieldNil("deleted_at"))
+		}
+		return privacy.Skip
+	})
+}
+
+// denyDeleted 按 id 更新单条数据时不带查询条件, 需要先确认数据没有被软删除
+// 读取旧数据时同样过滤软删除的数据, 已删除时返回 NotFound, 与数据不存在一致
+func denyDeleted() privacy.MutationRule {
+	type oldDeletedAt interface {
+		OldDeletedAt(context.Context) (*time.Time, error)
+	}
+	rule := privacy.MutationRuleFunc(func(ctx context.Context, m ent.Mutation) error {
+		if entx.DeletedIncluded(ctx) {
+			return privacy.Skip
+		}
+		om, ok := m.(oldDeletedAt)
+		if !ok {
+			return privacy.Denyf("unexpected mutation type %T", m)
+		}
+		deletedAt, err := om.OldDeletedAt(ctx)
+		if err != nil {
+			return err
+		}
+		if deletedAt != nil {
+			return privacy.Denyf("%s is deleted", m.Type())
+		}
+		return privacy.Skip
+	})
+	return privacy.OnMutationOperation(rule, ent.OpUpdateOne)
+}
+
+// VersionMixin 乐观锁, 每次更新版本号加一
+// UpdateOne 时用 SetVersion 传入读取时的版本, 数据已被他人修改时返回 entx.ErrVersionConflict;
+// 以版本为条件的更新与其他字段的写入是两条语句, 需要在事务中更新才能在写入失败时随事务回滚
+type VersionMixin struct {
+	mixin.Schema
+}
+
+// Fields of the VersionMixin.
+func (VersionMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("version").StructTag(`json:"version"`).Default(0),
+	}
+}
+
+// Hooks of the VersionMixin.
+func (VersionMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(checkVersion, ent.OpUpdate|ent.OpUpdateOne),
+	}
+}
+
+// checkVersion 更新时版本号加一, UpdateOne 传入了版本时以它为条件更新
+func checkVersion(next ent.Mutator) ent.Mutator {
+	type versionMutation interface {
+		ID() (int, bool)
+		Version() (int, bool)
+		ResetVersion()
+		AddVersion(int)
+	}
+	return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+		vm, ok := m.(versionMutation)
+		if !ok {
+			return nil, fmt.Errorf("unexpected mutation type %T", m)
+		}
+		expected, check := vm.Version()
+		if check && m.Op().Is(ent.OpUpdate) {
+			// 批量更新按条件筛选版本, 显式设置的版本号原样写入
+			return next.Mutate(ctx, m)
+		}
+		vm.ResetVersion()
+		if !check {
+			vm.AddVersion(1)
+			return next.Mutate(ctx, m)
+		}
+
+		// UpdateOne 只按 id 更新, 先以 version = expected 为条件把版本号加一,
+		// 没有更新到数据说明已被他人修改; 事务中这一行在提交前保持锁定
+		id, ok := vm.ID()
+		if !ok {
+			return nil, fmt.Errorf("missing id in %s mutation", m.Type())
+		}
+		n, err := bumpVersion(ctx, m, id, expected)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, entx.ErrVersionConflict
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// bumpVersion 把版本号为 expected 的数据的版本号加一, 返回更新的行数
+// 批量更新同样经过隐私规则与本 hook, 限定在当前租户内并且不包含软删除的数据
+func bumpVersion(ctx context.Context, m ent.Mutation, id, expected int) (int, error) {
+	switch m := m.(type) {
+	case *gen.PetMutation:
+		return m.Client().Pet.Update().Where(pet.ID(id), pet.Version(expected)).Save(ctx)
+	case *gen.UserMutation:
+		return m.Client().User.Update().Where(user.ID(id), user.Version(expected)).Save(ctx)
+	default:
+		return 0, fmt.Errorf("unexpected mutation type %T", m)
+	}
+}
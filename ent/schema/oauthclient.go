@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/dialect/entsql"
+	"github.com/facebook/ent/schema"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
+)
+
+// OAuthClient holds the schema definition for the OAuthClient entity.
+// 注册到授权服务器的第三方应用
+type OAuthClient struct {
+	ent.Schema
+}
+
+// Annotations of the OAuthClient.
+func (OAuthClient) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "oauth_clients"},
+	}
+}
+
+// Mixin of the OAuthClient.
+func (OAuthClient) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+	}
+}
+
+// Fields of the OAuthClient.
+// redirect_uris, scopes, grant_types 均以空格分隔
+func (OAuthClient) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("client_id").StructTag(`json:"client_id"`).Unique().Immutable(),
+		field.String("secret_hash").Default("").Sensitive(),
+		field.String("name").StructTag(`json:"name"`).NotEmpty().MaxLen(100),
+		field.Text("redirect_uris").StructTag(`json:"redirect_uris"`).Default(""),
+		field.String("scopes").StructTag(`json:"scopes"`).Default(""),
+		field.String("grant_types").StructTag(`json:"grant_types"`).Default(""),
+		field.Int("created_by").StructTag(`json:"created_by"`).Default(0),
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
+	}
+}
+
+// Edges of the OAuthClient.
+func (OAuthClient) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the OAuthClient.
+func (OAuthClient) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "created_at"),
+	}
+}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
+)
+
+// Outbox holds the schema definition for the Outbox entity.
+// 领域事件与业务数据在同一个事务内写入, 由 relay 异步投递
+// 不属于租户数据: 只有 relay, 清理任务与用户注销等后台代码跨租户读写, 没有接口向租户暴露,
+// 事件按用户 id 定位聚合, 用户 id 在所有租户之间唯一
+type Outbox struct {
+	ent.Schema
+}
+
+// Fields of the Outbox.
+func (Outbox) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("aggregate_type").StructTag(`json:"aggregate_type"`),
+		field.String("aggregate_id").StructTag(`json:"aggregate_id"`),
+		field.String("event_type").StructTag(`json:"event_type"`),
+		field.Bytes("payload").StructTag(`json:"payload"`),
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
+		field.Time("published_at").StructTag(`json:"published_at"`).Optional().Nillable(),
+		field.Int("attempts").StructTag(`json:"attempts"`).Default(0),
+		field.String("last_error").StructTag(`json:"last_error"`).Optional(),
+		// dead_at 投递失败次数达到上限的时间, 不再投递也不再阻塞同一聚合的后续事件
+		field.Time("dead_at").StructTag(`json:"dead_at"`).Optional().Nillable(),
+	}
+}
+
+// Edges of the Outbox.
+func (Outbox) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Outbox.
+func (Outbox) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("published_at"),
+		index.Fields("aggregate_type", "aggregate_id"),
+	}
+}
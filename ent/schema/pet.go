@@ -1,10 +1,10 @@
 package schema
 
 import (
-	"time"
-
 	"github.com/facebook/ent"
+	"github.com/facebook/ent/schema/edge"
 	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
 )
 
 // Pet holds the schema definition for the Pet entity.
@@ -12,15 +12,35 @@ type Pet struct {
 	ent.Schema
 }
 
+// Mixin of the Pet.
+func (Pet) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		SoftDeleteMixin{},
+		TenantMixin{},
+		TimeMixin{},
+		VersionMixin{},
+	}
+}
+
 // Fields of the Pet.
 func (Pet) Fields() []ent.Field {
 	return []ent.Field{
-		field.Time("created_at").Default(time.Now),
-		field.Time("updated_at").Default(time.Now),
+		field.String("name").StructTag(`json:"name"`).NotEmpty().MaxLen(30),
+		field.String("species").StructTag(`json:"species"`).NotEmpty().MaxLen(30),
+		field.Time("birthday").StructTag(`json:"birthday"`).Optional().Nillable(),
 	}
 }
 
 // Edges of the Pet.
 func (Pet) Edges() []ent.Edge {
-	return nil
+	return []ent.Edge{
+		edge.From("owner", User.Type).Ref("pets").Unique().Required(),
+	}
+}
+
+// Indexes of the Pet.
+func (Pet) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("species"),
+	}
 }
@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go-api/ent/hook"
+	"go-api/ent/privacy"
+	"go-api/tenancy"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/entql"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/mixin"
+)
+
+// tenantName 租户名称, 可以作为子域名
+var tenantName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Tenant holds the schema definition for the Tenant entity.
+// 租户, name 用于子域名与 X-Tenant 请求头
+type Tenant struct {
+	ent.Schema
+}
+
+// Fields of the Tenant.
+func (Tenant) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").StructTag(`json:"name"`).Unique().Match(tenantName),
+		field.String("status").StructTag(`json:"status"`).Default("active"),
+		// config 租户级别的配置, json 对象, 覆盖同名的环境变量
+		field.Text("config").StructTag(`json:"config"`).Optional(),
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
+		field.Time("updated_at").StructTag(`json:"updated_at"`).Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Tenant.
+func (Tenant) Edges() []ent.Edge {
+	return nil
+}
+
+// TenantMixin 租户数据, 查询与修改自动限定在 context 中的租户内, 创建时自动写入租户 id
+type TenantMixin struct {
+	mixin.Schema
+}
+
+// Fields of the TenantMixin.
+// 默认值为默认租户, 迁移时已有数据归入默认租户
+func (TenantMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("tenant_id").StructTag(`json:"tenant_id"`).Default(tenancy.DefaultID).Immutable(),
+	}
+}
+
+// Policy of the TenantMixin.
+func (TenantMixin) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			allowSystem(),
+			filterTenant(),
+		},
+		Mutation: privacy.MutationPolicy{
+			allowSystem(),
+			denyOtherTenant(),
+			filterTenant(),
+		},
+	}
+}
+
+// Hooks of the TenantMixin.
+func (TenantMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(setTenant, ent.OpCreate),
+	}
+}
+
+// allowSystem 跨租户的内部代码不受限制
+func allowSystem() privacy.QueryMutationRule {
+	return privacy.ContextQueryMutationRule(func(ctx context.Context) error {
+		if tenancy.IsSystem(ctx) {
+			return privacy.Allow
+		}
+		return privacy.Skip
+	})
+}
+
+// filterTenant 限定在 context 中的租户内, 没有租户时拒绝
+func filterTenant() privacy.QueryMutationRule {
+	type tenantFilter interface {
+		WhereTenantID(entql.IntP)
+	}
+	return privacy.FilterFunc(func(ctx context.Context, f privacy.Filter) error {
+		id := tenancy.ID(ctx)
+		if id == 0 {
+			return privacy.Denyf("missing tenant in context")
+		}
+		tf, ok := f.(tenantFilter)
+		if !ok {
+			return privacy.Denyf("unexpected filter type %T", f)
+		}
+		tf.WhereTenantID(entql.IntEQ(id))
+		return privacy.Allow
+	})
+}
+
+// denyOtherTenant 按 id 更新单条数据时不带查询条件, 需要先确认数据属于当前租户
+func denyOtherTenant() privacy.MutationRule {
+	type oldTenant interface {
+		OldTenantID(context.Context) (int, error)
+	}
+	rule := privacy.MutationRuleFunc(func(ctx context.Context, m ent.Mutation) error {
+		om, ok := m.(oldTenant)
+		if !ok {
+			return privacy.Denyf("unexpected mutation type %T", m)
+		}
+		id, err := om.OldTenantID(ctx)
+		if err != nil {
+			return privacy.Denyf("load tenant of %s: %v", m.Type(), err)
+		}
+		if id != tenancy.ID(ctx) {
+			return privacy.Denyf("%s belongs to tenant %d", m.Type(), id)
+		}
+		return privacy.Skip
+	})
+	return privacy.OnMutationOperation(rule, ent.OpUpdateOne)
+}
+
+// setTenant 创建时写入 context 中的租户, 租户内不能为其他租户创建数据
+// 没有租户的 System context 使用指定的租户 id, 未指定时为默认租户
+func setTenant(next ent.Mutator) ent.Mutator {
+	type tenantMutation interface {
+		SetTenantID(int)
+	}
+	return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+		tm, ok := m.(tenantMutation)
+		if !ok {
+			return nil, fmt.Errorf("unexpected mutation type %T", m)
+		}
+		if id := tenancy.ID(ctx); id != 0 {
+			tm.SetTenantID(id)
+		}
+		return next.Mutate(ctx, m)
+	})
+}
@@ -1,10 +1,10 @@
 package schema
 
 import (
-	"time"
-
 	"github.com/facebook/ent"
+	"github.com/facebook/ent/schema/edge"
 	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
 )
 
 // User holds the schema definition for the User entity.
@@ -12,6 +12,16 @@ type User struct {
 	ent.Schema
 }
 
+// Mixin of the User.
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		SoftDeleteMixin{},
+		TenantMixin{},
+		TimeMixin{},
+		VersionMixin{},
+	}
+}
+
 // Fields of the User.
 func (User) Fields() []ent.Field {
 	return []ent.Field{
@@ -21,13 +31,22 @@ func (User) Fields() []ent.Field {
 		field.String("nickname").StructTag(`json:"nickname"`),
 		field.String("status").StructTag(`json:"status"`),
 		field.String("avatar").StructTag(`json:"avatar" size:"1000"`),
-		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
-		field.Time("updated_at").StructTag(`json:"updated_at"`).Default(time.Now).UpdateDefault(time.Now),
-		field.Time("deleted_at").StructTag(`json:"deleted_at"`),
+		field.String("role").StructTag(`json:"role"`).Default("user"),
 	}
 }
 
 // Edges of the User.
 func (User) Edges() []ent.Edge {
-	return nil
+	return []ent.Edge{
+		edge.To("pets", Pet.Type),
+		edge.To("identities", Identity.Type),
+	}
+}
+
+// Indexes of the User.
+// 用户名在租户内唯一
+func (User) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "username").Unique(),
+	}
 }
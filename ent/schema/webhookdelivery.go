@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/dialect/entsql"
+	"github.com/facebook/ent/schema"
+	"github.com/facebook/ent/schema/edge"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
+)
+
+// WebhookDelivery holds the schema definition for the WebhookDelivery entity.
+// 一个事件向一个地址的投递, 记录最后一次请求的结果; 重放时创建新的投递
+type WebhookDelivery struct {
+	ent.Schema
+}
+
+// Annotations of the WebhookDelivery.
+func (WebhookDelivery) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "webhook_deliveries"},
+	}
+}
+
+// Mixin of the WebhookDelivery.
+func (WebhookDelivery) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+	}
+}
+
+// Fields of the WebhookDelivery.
+func (WebhookDelivery) Fields() []ent.Field {
+	return []ent.Field{
+		// event_id outbox 事件 id, 接收方按它去重
+		field.Int("event_id").StructTag(`json:"event_id"`).Immutable(),
+		field.String("event_type").StructTag(`json:"event_type"`).Immutable(),
+		// user_id 事件涉及的用户, 用户注销时删除相关的投递
+		field.Int("user_id").StructTag(`json:"user_id"`).Default(0).Immutable(),
+		field.Bytes("payload").StructTag(`json:"payload"`).Immutable(),
+		field.Enum("status").StructTag(`json:"status"`).Values("pending", "succeeded", "failed").Default("pending"),
+		field.Int("attempts").StructTag(`json:"attempts"`).Default(0),
+		field.Int("response_status").StructTag(`json:"response_status"`).Default(0),
+		field.Text("response_body").StructTag(`json:"response_body"`).Default(""),
+		field.String("error").StructTag(`json:"error"`).Default(""),
+		field.Int64("duration_ms").StructTag(`json:"duration_ms"`).Default(0),
+		// replay_of 重放的原投递 id
+		field.Int("replay_of").StructTag(`json:"replay_of"`).Default(0).Immutable(),
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
+		field.Time("last_attempt_at").StructTag(`json:"last_attempt_at"`).Optional().Nillable(),
+		field.Time("delivered_at").StructTag(`json:"delivered_at"`).Optional().Nillable(),
+	}
+}
+
+// Edges of the WebhookDelivery.
+func (WebhookDelivery) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("endpoint", WebhookEndpoint.Type).Ref("deliveries").Unique().Required(),
+	}
+}
+
+// Indexes of the WebhookDelivery.
+func (WebhookDelivery) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("event_id").Edges("endpoint"),
+		index.Fields("tenant_id", "created_at"),
+		index.Fields("user_id"),
+	}
+}
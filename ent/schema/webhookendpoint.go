@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/facebook/ent"
+	"github.com/facebook/ent/dialect/entsql"
+	"github.com/facebook/ent/schema"
+	"github.com/facebook/ent/schema/edge"
+	"github.com/facebook/ent/schema/field"
+	"github.com/facebook/ent/schema/index"
+)
+
+// WebhookEndpoint holds the schema definition for the WebhookEndpoint entity.
+// 接收事件通知的地址, 连续投递失败达到阈值后自动停用
+type WebhookEndpoint struct {
+	ent.Schema
+}
+
+// Annotations of the WebhookEndpoint.
+func (WebhookEndpoint) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "webhook_endpoints"},
+	}
+}
+
+// Mixin of the WebhookEndpoint.
+func (WebhookEndpoint) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+	}
+}
+
+// Fields of the WebhookEndpoint.
+// events 以空格分隔
+func (WebhookEndpoint) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("owner_id").StructTag(`json:"owner_id"`),
+		field.String("url").StructTag(`json:"url"`).NotEmpty().MaxLen(2048),
+		field.String("secret").Sensitive(),
+		field.String("events").StructTag(`json:"events"`).Default(""),
+		field.String("description").StructTag(`json:"description"`).Default("").MaxLen(200),
+		field.Bool("enabled").StructTag(`json:"enabled"`).Default(true),
+		// failures 连续失败次数, 投递成功时清零
+		field.Int("failures").StructTag(`json:"failures"`).Default(0),
+		field.Time("disabled_at").StructTag(`json:"disabled_at"`).Optional().Nillable(),
+		field.Time("created_at").StructTag(`json:"created_at"`).Default(time.Now),
+		field.Time("updated_at").StructTag(`json:"updated_at"`).Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the WebhookEndpoint.
+func (WebhookEndpoint) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("deliveries", WebhookDelivery.Type),
+	}
+}
+
+// Indexes of the WebhookEndpoint.
+func (WebhookEndpoint) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "owner_id"),
+	}
+}
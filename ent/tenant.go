@@ -0,0 +1,127 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-api/ent/tenant"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// Tenant is the model entity for the Tenant schema.
+type Tenant struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name"`
+	// Status holds the value of the "status" field.
+	Status string `json:"status"`
+	// Config holds the value of the "config" field.
+	Config string `json:"config"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Tenant) scanValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{},  // id
+		&sql.NullString{}, // name
+		&sql.NullString{}, // status
+		&sql.NullString{}, // config
+		&sql.NullTime{},   // created_at
+		&sql.NullTime{},   // updated_at
+	}
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Tenant fields.
+func (t *Tenant) assignValues(values ...interface{}) error {
+	if m, n := len(values), len(tenant.Columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	value, ok := values[0].(*sql.NullInt64)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for field id", value)
+	}
+	t.ID = int(value.Int64)
+	values = values[1:]
+	if value, ok := values[0].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field name", values[0])
+	} else if value.Valid {
+		t.Name = value.String
+	}
+	if value, ok := values[1].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field status", values[1])
+	} else if value.Valid {
+		t.Status = value.String
+	}
+	if value, ok := values[2].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field config", values[2])
+	} else if value.Valid {
+		t.Config = value.String
+	}
+	if value, ok := values[3].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[3])
+	} else if value.Valid {
+		t.CreatedAt = value.Time
+	}
+	if value, ok := values[4].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field updated_at", values[4])
+	} else if value.Valid {
+		t.UpdatedAt = value.Time
+	}
+	return nil
+}
+
+// Update returns a builder for updating this Tenant.
+// Note that, you need to call Tenant.Unwrap() before calling this method, if this Tenant
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (t *Tenant) Update() *TenantUpdateOne {
+	return (&TenantClient{config: t.config}).UpdateOne(t)
+}
+
+// Unwrap unwraps the entity that was returned from a transaction after it was closed,
+// so that all next queries will be executed through the driver which created the transaction.
+func (t *Tenant) Unwrap() *Tenant {
+	tx, ok := t.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Tenant is not a transactional entity")
+	}
+	t.config.driver = tx.drv
+	return t
+}
+
+// String implements the fmt.Stringer.
+func (t *Tenant) String() string {
+	var builder strings.Builder
+	builder.WriteString("Tenant(")
+	builder.WriteString(fmt.Sprintf("id=%v", t.ID))
+	builder.WriteString(", name=")
+	builder.WriteString(t.Name)
+	builder.WriteString(", status=")
+	builder.WriteString(t.Status)
+	builder.WriteString(", config=")
+	builder.WriteString(t.Config)
+	builder.WriteString(", created_at=")
+	builder.WriteString(t.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", updated_at=")
+	builder.WriteString(t.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Tenants is a parsable slice of Tenant.
+type Tenants []*Tenant
+
+func (t Tenants) config(cfg config) {
+	for _i := range t {
+		t[_i].config = cfg
+	}
+}
@@ -0,0 +1,305 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/tenant"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// TenantCreate is the builder for creating a Tenant entity.
+type TenantCreate struct {
+	config
+	mutation *TenantMutation
+	hooks    []Hook
+}
+
+// SetName sets the name field.
+func (tc *TenantCreate) SetName(s string) *TenantCreate {
+	tc.mutation.SetName(s)
+	return tc
+}
+
+// SetStatus sets the status field.
+func (tc *TenantCreate) SetStatus(s string) *TenantCreate {
+	tc.mutation.SetStatus(s)
+	return tc
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (tc *TenantCreate) SetNillableStatus(s *string) *TenantCreate {
+	if s != nil {
+		tc.SetStatus(*s)
+	}
+	return tc
+}
+
+// SetConfig sets the config field.
+func (tc *TenantCreate) SetConfig(s string) *TenantCreate {
+	tc.mutation.SetConfig(s)
+	return tc
+}
+
+// SetNillableConfig sets the config field if the given value is not nil.
+func (tc *TenantCreate) SetNillableConfig(s *string) *TenantCreate {
+	if s != nil {
+		tc.SetConfig(*s)
+	}
+	return tc
+}
+
+// SetCreatedAt sets the created_at field.
+func (tc *TenantCreate) SetCreatedAt(t time.Time) *TenantCreate {
+	tc.mutation.SetCreatedAt(t)
+	return tc
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (tc *TenantCreate) SetNillableCreatedAt(t *time.Time) *TenantCreate {
+	if t != nil {
+		tc.SetCreatedAt(*t)
+	}
+	return tc
+}
+
+// SetUpdatedAt sets the updated_at field.
+func (tc *TenantCreate) SetUpdatedAt(t time.Time) *TenantCreate {
+	tc.mutation.SetUpdatedAt(t)
+	return tc
+}
+
+// SetNillableUpdatedAt sets the updated_at field if the given value is not nil.
+func (tc *TenantCreate) SetNillableUpdatedAt(t *time.Time) *TenantCreate {
+	if t != nil {
+		tc.SetUpdatedAt(*t)
+	}
+	return tc
+}
+
+// Mutation returns the TenantMutation object of the builder.
+func (tc *TenantCreate) Mutation() *TenantMutation {
+	return tc.mutation
+}
+
+// Save creates the Tenant in the database.
+func (tc *TenantCreate) Save(ctx context.Context) (*Tenant, error) {
+	var (
+		err  error
+		node *Tenant
+	)
+	tc.defaults()
+	if len(tc.hooks) == 0 {
+		if err = tc.check(); err != nil {
+			return nil, err
+		}
+		node, err = tc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*TenantMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = tc.check(); err != nil {
+				return nil, err
+			}
+			tc.mutation = mutation
+			node, err = tc.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(tc.hooks) - 1; i >= 0; i-- {
+			mut = tc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, tc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tc *TenantCreate) SaveX(ctx context.Context) *Tenant {
+	v, err := tc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// defaults sets the default values of the builder before save.
+func (tc *TenantCreate) defaults() {
+	if _, ok := tc.mutation.Status(); !ok {
+		v := tenant.DefaultStatus
+		tc.mutation.SetStatus(v)
+	}
+	if _, ok := tc.mutation.CreatedAt(); !ok {
+		v := tenant.DefaultCreatedAt()
+		tc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := tc.mutation.UpdatedAt(); !ok {
+		v := tenant.DefaultUpdatedAt()
+		tc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tc *TenantCreate) check() error {
+	if _, ok := tc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New("ent: missing required field \"name\"")}
+	}
+	if v, ok := tc.mutation.Name(); ok {
+		if err := tenant.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	if _, ok := tc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New("ent: missing required field \"status\"")}
+	}
+	if _, ok := tc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New("ent: missing required field \"created_at\"")}
+	}
+	if _, ok := tc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New("ent: missing required field \"updated_at\"")}
+	}
+	return nil
+}
+
+func (tc *TenantCreate) sqlSave(ctx context.Context) (*Tenant, error) {
+	_node, _spec := tc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, tc.driver, _spec); err != nil {
+		if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (tc *TenantCreate) createSpec() (*Tenant, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Tenant{config: tc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: tenant.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: tenant.FieldID,
+			},
+		}
+	)
+	if value, ok := tc.mutation.Name(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldName,
+		})
+		_node.Name = value
+	}
+	if value, ok := tc.mutation.Status(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldStatus,
+		})
+		_node.Status = value
+	}
+	if value, ok := tc.mutation.Config(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldConfig,
+		})
+		_node.Config = value
+	}
+	if value, ok := tc.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: tenant.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	if value, ok := tc.mutation.UpdatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: tenant.FieldUpdatedAt,
+		})
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// TenantCreateBulk is the builder for creating a bulk of Tenant entities.
+type TenantCreateBulk struct {
+	config
+	builders []*TenantCreate
+}
+
+// Save creates the Tenant entities in the database.
+func (tcb *TenantCreateBulk) Save(ctx context.Context) ([]*Tenant, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(tcb.builders))
+	nodes := make([]*Tenant, len(tcb.builders))
+	mutators := make([]Mutator, len(tcb.builders))
+	for i := range tcb.builders {
+		func(i int, root context.Context) {
+			builder := tcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TenantMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, tcb.builders[i+1].mutation)
+				} else {
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, tcb.driver, &sqlgraph.BatchCreateSpec{Nodes: specs}); err != nil {
+						if cerr, ok := isSQLConstraintError(err); ok {
+							err = cerr
+						}
+					}
+				}
+				mutation.done = true
+				if err != nil {
+					return nil, err
+				}
+				id := specs[i].ID.Value.(int64)
+				nodes[i].ID = int(id)
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, tcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tcb *TenantCreateBulk) SaveX(ctx context.Context) []*Tenant {
+	v, err := tcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,458 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/predicate"
+	"go-api/ent/tenant"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// TenantUpdate is the builder for updating Tenant entities.
+type TenantUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TenantMutation
+}
+
+// Where adds a new predicate for the builder.
+func (tu *TenantUpdate) Where(ps ...predicate.Tenant) *TenantUpdate {
+	tu.mutation.predicates = append(tu.mutation.predicates, ps...)
+	return tu
+}
+
+// SetName sets the name field.
+func (tu *TenantUpdate) SetName(s string) *TenantUpdate {
+	tu.mutation.SetName(s)
+	return tu
+}
+
+// SetStatus sets the status field.
+func (tu *TenantUpdate) SetStatus(s string) *TenantUpdate {
+	tu.mutation.SetStatus(s)
+	return tu
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableStatus(s *string) *TenantUpdate {
+	if s != nil {
+		tu.SetStatus(*s)
+	}
+	return tu
+}
+
+// SetConfig sets the config field.
+func (tu *TenantUpdate) SetConfig(s string) *TenantUpdate {
+	tu.mutation.SetConfig(s)
+	return tu
+}
+
+// SetNillableConfig sets the config field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableConfig(s *string) *TenantUpdate {
+	if s != nil {
+		tu.SetConfig(*s)
+	}
+	return tu
+}
+
+// ClearConfig clears the value of config.
+func (tu *TenantUpdate) ClearConfig() *TenantUpdate {
+	tu.mutation.ClearConfig()
+	return tu
+}
+
+// SetCreatedAt sets the created_at field.
+func (tu *TenantUpdate) SetCreatedAt(t time.Time) *TenantUpdate {
+	tu.mutation.SetCreatedAt(t)
+	return tu
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableCreatedAt(t *time.Time) *TenantUpdate {
+	if t != nil {
+		tu.SetCreatedAt(*t)
+	}
+	return tu
+}
+
+// SetUpdatedAt sets the updated_at field.
+func (tu *TenantUpdate) SetUpdatedAt(t time.Time) *TenantUpdate {
+	tu.mutation.SetUpdatedAt(t)
+	return tu
+}
+
+// Mutation returns the TenantMutation object of the builder.
+func (tu *TenantUpdate) Mutation() *TenantMutation {
+	return tu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tu *TenantUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	tu.defaults()
+	if len(tu.hooks) == 0 {
+		if err = tu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = tu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*TenantMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = tu.check(); err != nil {
+				return 0, err
+			}
+			tu.mutation = mutation
+			affected, err = tu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(tu.hooks) - 1; i >= 0; i-- {
+			mut = tu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, tu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tu *TenantUpdate) SaveX(ctx context.Context) int {
+	affected, err := tu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tu *TenantUpdate) Exec(ctx context.Context) error {
+	_, err := tu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tu *TenantUpdate) ExecX(ctx context.Context) {
+	if err := tu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tu *TenantUpdate) defaults() {
+	if _, ok := tu.mutation.UpdatedAt(); !ok {
+		v := tenant.UpdateDefaultUpdatedAt()
+		tu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tu *TenantUpdate) check() error {
+	if v, ok := tu.mutation.Name(); ok {
+		if err := tenant.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	return nil
+}
+
+func (tu *TenantUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   tenant.Table,
+			Columns: tenant.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: tenant.FieldID,
+			},
+		},
+	}
+	if ps := tu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := tu.mutation.Name(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldName,
+		})
+	}
+	if value, ok := tu.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldStatus,
+		})
+	}
+	if value, ok := tu.mutation.Config(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldConfig,
+		})
+	}
+	if tu.mutation.ConfigCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: tenant.FieldConfig,
+		})
+	}
+	if value, ok := tu.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: tenant.FieldCreatedAt,
+		})
+	}
+	if value, ok := tu.mutation.UpdatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: tenant.FieldUpdatedAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenant.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// TenantUpdateOne is the builder for updating a single Tenant entity.
+type TenantUpdateOne struct {
+	config
+	hooks    []Hook
+	mutation *TenantMutation
+}
+
+// SetName sets the name field.
+func (tuo *TenantUpdateOne) SetName(s string) *TenantUpdateOne {
+	tuo.mutation.SetName(s)
+	return tuo
+}
+
+// SetStatus sets the status field.
+func (tuo *TenantUpdateOne) SetStatus(s string) *TenantUpdateOne {
+	tuo.mutation.SetStatus(s)
+	return tuo
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableStatus(s *string) *TenantUpdateOne {
+	if s != nil {
+		tuo.SetStatus(*s)
+	}
+	return tuo
+}
+
+// SetConfig sets the config field.
+func (tuo *TenantUpdateOne) SetConfig(s string) *TenantUpdateOne {
+	tuo.mutation.SetConfig(s)
+	return tuo
+}
+
+// SetNillableConfig sets the config field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableConfig(s *string) *TenantUpdateOne {
+	if s != nil {
+		tuo.SetConfig(*s)
+	}
+	return tuo
+}
+
+// ClearConfig clears the value of config.
+func (tuo *TenantUpdateOne) ClearConfig() *TenantUpdateOne {
+	tuo.mutation.ClearConfig()
+	return tuo
+}
+
+// SetCreatedAt sets the created_at field.
+func (tuo *TenantUpdateOne) SetCreatedAt(t time.Time) *TenantUpdateOne {
+	tuo.mutation.SetCreatedAt(t)
+	return tuo
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableCreatedAt(t *time.Time) *TenantUpdateOne {
+	if t != nil {
+		tuo.SetCreatedAt(*t)
+	}
+	return tuo
+}
+
+// SetUpdatedAt sets the updated_at field.
+func (tuo *TenantUpdateOne) SetUpdatedAt(t time.Time) *TenantUpdateOne {
+	tuo.mutation.SetUpdatedAt(t)
+	return tuo
+}
+
+// Mutation returns the TenantMutation object of the builder.
+func (tuo *TenantUpdateOne) Mutation() *TenantMutation {
+	return tuo.mutation
+}
+
+// Save executes the query and returns the updated entity.
+func (tuo *TenantUpdateOne) Save(ctx context.Context) (*Tenant, error) {
+	var (
+		err  error
+		node *Tenant
+	)
+	tuo.defaults()
+	if len(tuo.hooks) == 0 {
+		if err = tuo.check(); err != nil {
+			return nil, err
+		}
+		node, err = tuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*TenantMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = tuo.check(); err != nil {
+				return nil, err
+			}
+			tuo.mutation = mutation
+			node, err = tuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(tuo.hooks) - 1; i >= 0; i-- {
+			mut = tuo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, tuo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tuo *TenantUpdateOne) SaveX(ctx context.Context) *Tenant {
+	node, err := tuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (tuo *TenantUpdateOne) Exec(ctx context.Context) error {
+	_, err := tuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tuo *TenantUpdateOne) ExecX(ctx context.Context) {
+	if err := tuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tuo *TenantUpdateOne) defaults() {
+	if _, ok := tuo.mutation.UpdatedAt(); !ok {
+		v := tenant.UpdateDefaultUpdatedAt()
+		tuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tuo *TenantUpdateOne) check() error {
+	if v, ok := tuo.mutation.Name(); ok {
+		if err := tenant.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf("ent: validator failed for field \"name\": %w", err)}
+		}
+	}
+	return nil
+}
+
+func (tuo *TenantUpdateOne) sqlSave(ctx context.Context) (_node *Tenant, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   tenant.Table,
+			Columns: tenant.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: tenant.FieldID,
+			},
+		},
+	}
+	id, ok := tuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "ID", err: fmt.Errorf("missing Tenant.ID for update")}
+	}
+	_spec.Node.ID.Value = id
+	if value, ok := tuo.mutation.Name(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldName,
+		})
+	}
+	if value, ok := tuo.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldStatus,
+		})
+	}
+	if value, ok := tuo.mutation.Config(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: tenant.FieldConfig,
+		})
+	}
+	if tuo.mutation.ConfigCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: tenant.FieldConfig,
+		})
+	}
+	if value, ok := tuo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: tenant.FieldCreatedAt,
+		})
+	}
+	if value, ok := tuo.mutation.UpdatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: tenant.FieldUpdatedAt,
+		})
+	}
+	_node = &Tenant{config: tuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues()
+	if err = sqlgraph.UpdateNode(ctx, tuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenant.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	return _node, nil
+}
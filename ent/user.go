@@ -16,6 +16,16 @@ type User struct {
 	config `json:"-" size:"-"`
 	// ID of the ent.
 	ID int `json:"id,primary_key"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version"`
 	// Username holds the value of the "username" field.
 	Username string `json:"username"`
 	// PasswordDigest holds the value of the "password_digest" field.
@@ -26,26 +36,57 @@ type User struct {
 	Status string `json:"status"`
 	// Avatar holds the value of the "avatar" field.
 	Avatar string `json:"avatar" size:"1000"`
-	// CreatedAt holds the value of the "created_at" field.
-	CreatedAt time.Time `json:"created_at"`
-	// UpdatedAt holds the value of the "updated_at" field.
-	UpdatedAt time.Time `json:"updated_at"`
-	// DeletedAt holds the value of the "deleted_at" field.
-	DeletedAt time.Time `json:"deleted_at"`
+	// Role holds the value of the "role" field.
+	Role string `json:"role"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the UserQuery when eager-loading is set.
+	Edges UserEdges `json:"edges"`
+}
+
+// UserEdges holds the relations/edges for other nodes in the graph.
+type UserEdges struct {
+	// Pets holds the value of the pets edge.
+	Pets []*Pet
+	// Identities holds the value of the identities edge.
+	Identities []*Identity
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// PetsOrErr returns the Pets value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PetsOrErr() ([]*Pet, error) {
+	if e.loadedTypes[0] {
+		return e.Pets, nil
+	}
+	return nil, &NotLoadedError{edge: "pets"}
+}
+
+// IdentitiesOrErr returns the Identities value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) IdentitiesOrErr() ([]*Identity, error) {
+	if e.loadedTypes[1] {
+		return e.Identities, nil
+	}
+	return nil, &NotLoadedError{edge: "identities"}
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues() []interface{} {
 	return []interface{}{
 		&sql.NullInt64{},  // id
+		&sql.NullTime{},   // deleted_at
+		&sql.NullInt64{},  // tenant_id
+		&sql.NullTime{},   // created_at
+		&sql.NullTime{},   // updated_at
+		&sql.NullInt64{},  // version
 		&sql.NullString{}, // username
 		&sql.NullString{}, // password_digest
 		&sql.NullString{}, // nickname
 		&sql.NullString{}, // status
 		&sql.NullString{}, // avatar
-		&sql.NullTime{},   // created_at
-		&sql.NullTime{},   // updated_at
-		&sql.NullTime{},   // deleted_at
+		&sql.NullString{}, // role
 	}
 }
 
@@ -61,49 +102,75 @@ func (u *User) assignValues(values ...interface{}) error {
 	}
 	u.ID = int(value.Int64)
 	values = values[1:]
-	if value, ok := values[0].(*sql.NullString); !ok {
-		return fmt.Errorf("unexpected type %T for field username", values[0])
+	if value, ok := values[0].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field deleted_at", values[0])
+	} else if value.Valid {
+		u.DeletedAt = new(time.Time)
+		*u.DeletedAt = value.Time
+	}
+	if value, ok := values[1].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field tenant_id", values[1])
+	} else if value.Valid {
+		u.TenantID = int(value.Int64)
+	}
+	if value, ok := values[2].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[2])
+	} else if value.Valid {
+		u.CreatedAt = value.Time
+	}
+	if value, ok := values[3].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field updated_at", values[3])
+	} else if value.Valid {
+		u.UpdatedAt = value.Time
+	}
+	if value, ok := values[4].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field version", values[4])
+	} else if value.Valid {
+		u.Version = int(value.Int64)
+	}
+	if value, ok := values[5].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field username", values[5])
 	} else if value.Valid {
 		u.Username = value.String
 	}
-	if value, ok := values[1].(*sql.NullString); !ok {
-		return fmt.Errorf("unexpected type %T for field password_digest", values[1])
+	if value, ok := values[6].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field password_digest", values[6])
 	} else if value.Valid {
 		u.PasswordDigest = value.String
 	}
-	if value, ok := values[2].(*sql.NullString); !ok {
-		return fmt.Errorf("unexpected type %T for field nickname", values[2])
+	if value, ok := values[7].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field nickname", values[7])
 	} else if value.Valid {
 		u.Nickname = value.String
 	}
-	if value, ok := values[3].(*sql.NullString); !ok {
-		return fmt.Errorf("unexpected type %T for field status", values[3])
+	if value, ok := values[8].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field status", values[8])
 	} else if value.Valid {
 		u.Status = value.String
 	}
-	if value, ok := values[4].(*sql.NullString); !ok {
-		return fmt.Errorf("unexpected type %T for field avatar", values[4])
+	if value, ok := values[9].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field avatar", values[9])
 	} else if value.Valid {
 		u.Avatar = value.String
 	}
-	if value, ok := values[5].(*sql.NullTime); !ok {
-		return fmt.Errorf("unexpected type %T for field created_at", values[5])
-	} else if value.Valid {
-		u.CreatedAt = value.Time
-	}
-	if value, ok := values[6].(*sql.NullTime); !ok {
-		return fmt.Errorf("unexpected type %T for field updated_at", values[6])
-	} else if value.Valid {
-		u.UpdatedAt = value.Time
-	}
-	if value, ok := values[7].(*sql.NullTime); !ok {
-		return fmt.Errorf("unexpected type %T for field deleted_at", values[7])
+	if value, ok := values[10].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field role", values[10])
 	} else if value.Valid {
-		u.DeletedAt = value.Time
+		u.Role = value.String
 	}
 	return nil
 }
 
+// QueryPets queries the pets edge of the User.
+func (u *User) QueryPets() *PetQuery {
+	return (&UserClient{config: u.config}).QueryPets(u)
+}
+
+// QueryIdentities queries the identities edge of the User.
+func (u *User) QueryIdentities() *IdentityQuery {
+	return (&UserClient{config: u.config}).QueryIdentities(u)
+}
+
 // Update returns a builder for updating this User.
 // Note that, you need to call User.Unwrap() before calling this method, if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -127,6 +194,18 @@ func (u *User) String() string {
 	var builder strings.Builder
 	builder.WriteString("User(")
 	builder.WriteString(fmt.Sprintf("id=%v", u.ID))
+	if v := u.DeletedAt; v != nil {
+		builder.WriteString(", deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", u.TenantID))
+	builder.WriteString(", created_at=")
+	builder.WriteString(u.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", updated_at=")
+	builder.WriteString(u.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", version=")
+	builder.WriteString(fmt.Sprintf("%v", u.Version))
 	builder.WriteString(", username=")
 	builder.WriteString(u.Username)
 	builder.WriteString(", password_digest=")
@@ -137,12 +216,8 @@ func (u *User) String() string {
 	builder.WriteString(u.Status)
 	builder.WriteString(", avatar=")
 	builder.WriteString(u.Avatar)
-	builder.WriteString(", created_at=")
-	builder.WriteString(u.CreatedAt.Format(time.ANSIC))
-	builder.WriteString(", updated_at=")
-	builder.WriteString(u.UpdatedAt.Format(time.ANSIC))
-	builder.WriteString(", deleted_at=")
-	builder.WriteString(u.DeletedAt.Format(time.ANSIC))
+	builder.WriteString(", role=")
+	builder.WriteString(u.Role)
 	builder.WriteByte(')')
 	return builder.String()
 }
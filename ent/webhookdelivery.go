@@ -0,0 +1,282 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// WebhookDelivery is the model entity for the WebhookDelivery schema.
+type WebhookDelivery struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id"`
+	// EventID holds the value of the "event_id" field.
+	EventID int `json:"event_id"`
+	// EventType holds the value of the "event_type" field.
+	EventType string `json:"event_type"`
+	// UserID holds the value of the "user_id" field.
+	UserID int `json:"user_id"`
+	// Payload holds the value of the "payload" field.
+	Payload []byte `json:"payload"`
+	// Status holds the value of the "status" field.
+	Status webhookdelivery.Status `json:"status"`
+	// Attempts holds the value of the "attempts" field.
+	Attempts int `json:"attempts"`
+	// ResponseStatus holds the value of the "response_status" field.
+	ResponseStatus int `json:"response_status"`
+	// ResponseBody holds the value of the "response_body" field.
+	ResponseBody string `json:"response_body"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error"`
+	// DurationMs holds the value of the "duration_ms" field.
+	DurationMs int64 `json:"duration_ms"`
+	// ReplayOf holds the value of the "replay_of" field.
+	ReplayOf int `json:"replay_of"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+	// LastAttemptAt holds the value of the "last_attempt_at" field.
+	LastAttemptAt *time.Time `json:"last_attempt_at"`
+	// DeliveredAt holds the value of the "delivered_at" field.
+	DeliveredAt *time.Time `json:"delivered_at"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the WebhookDeliveryQuery when eager-loading is set.
+	Edges                       WebhookDeliveryEdges `json:"edges"`
+	webhook_endpoint_deliveries *int
+}
+
+// WebhookDeliveryEdges holds the relations/edges for other nodes in the graph.
+type WebhookDeliveryEdges struct {
+	// Endpoint holds the value of the endpoint edge.
+	Endpoint *WebhookEndpoint
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// EndpointOrErr returns the Endpoint value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e WebhookDeliveryEdges) EndpointOrErr() (*WebhookEndpoint, error) {
+	if e.loadedTypes[0] {
+		if e.Endpoint == nil {
+			// The edge endpoint was loaded in eager-loading,
+			// but was not found.
+			return nil, &NotFoundError{label: webhookendpoint.Label}
+		}
+		return e.Endpoint, nil
+	}
+	return nil, &NotLoadedError{edge: "endpoint"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*WebhookDelivery) scanValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{},  // id
+		&sql.NullInt64{},  // tenant_id
+		&sql.NullInt64{},  // event_id
+		&sql.NullString{}, // event_type
+		&sql.NullInt64{},  // user_id
+		&[]byte{},         // payload
+		&sql.NullString{}, // status
+		&sql.NullInt64{},  // attempts
+		&sql.NullInt64{},  // response_status
+		&sql.NullString{}, // response_body
+		&sql.NullString{}, // error
+		&sql.NullInt64{},  // duration_ms
+		&sql.NullInt64{},  // replay_of
+		&sql.NullTime{},   // created_at
+		&sql.NullTime{},   // last_attempt_at
+		&sql.NullTime{},   // delivered_at
+	}
+}
+
+// fkValues returns the types for scanning foreign-keys values from sql.Rows.
+func (*WebhookDelivery) fkValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{}, // webhook_endpoint_deliveries
+	}
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the WebhookDelivery fields.
+func (wd *WebhookDelivery) assignValues(values ...interface{}) error {
+	if m, n := len(values), len(webhookdelivery.Columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	value, ok := values[0].(*sql.NullInt64)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for field id", value)
+	}
+	wd.ID = int(value.Int64)
+	values = values[1:]
+	if value, ok := values[0].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field tenant_id", values[0])
+	} else if value.Valid {
+		wd.TenantID = int(value.Int64)
+	}
+	if value, ok := values[1].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field event_id", values[1])
+	} else if value.Valid {
+		wd.EventID = int(value.Int64)
+	}
+	if value, ok := values[2].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field event_type", values[2])
+	} else if value.Valid {
+		wd.EventType = value.String
+	}
+	if value, ok := values[3].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field user_id", values[3])
+	} else if value.Valid {
+		wd.UserID = int(value.Int64)
+	}
+	if value, ok := values[4].(*[]byte); !ok {
+		return fmt.Errorf("unexpected type %T for field payload", values[4])
+	} else if value != nil {
+		wd.Payload = *value
+	}
+	if value, ok := values[5].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field status", values[5])
+	} else if value.Valid {
+		wd.Status = webhookdelivery.Status(value.String)
+	}
+	if value, ok := values[6].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field attempts", values[6])
+	} else if value.Valid {
+		wd.Attempts = int(value.Int64)
+	}
+	if value, ok := values[7].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field response_status", values[7])
+	} else if value.Valid {
+		wd.ResponseStatus = int(value.Int64)
+	}
+	if value, ok := values[8].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field response_body", values[8])
+	} else if value.Valid {
+		wd.ResponseBody = value.String
+	}
+	if value, ok := values[9].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field error", values[9])
+	} else if value.Valid {
+		wd.Error = value.String
+	}
+	if value, ok := values[10].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field duration_ms", values[10])
+	} else if value.Valid {
+		wd.DurationMs = value.Int64
+	}
+	if value, ok := values[11].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field replay_of", values[11])
+	} else if value.Valid {
+		wd.ReplayOf = int(value.Int64)
+	}
+	if value, ok := values[12].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[12])
+	} else if value.Valid {
+		wd.CreatedAt = value.Time
+	}
+	if value, ok := values[13].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field last_attempt_at", values[13])
+	} else if value.Valid {
+		wd.LastAttemptAt = new(time.Time)
+		*wd.LastAttemptAt = value.Time
+	}
+	if value, ok := values[14].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field delivered_at", values[14])
+	} else if value.Valid {
+		wd.DeliveredAt = new(time.Time)
+		*wd.DeliveredAt = value.Time
+	}
+	values = values[15:]
+	if len(values) == len(webhookdelivery.ForeignKeys) {
+		if value, ok := values[0].(*sql.NullInt64); !ok {
+			return fmt.Errorf("unexpected type %T for edge-field webhook_endpoint_deliveries", value)
+		} else if value.Valid {
+			wd.webhook_endpoint_deliveries = new(int)
+			*wd.webhook_endpoint_deliveries = int(value.Int64)
+		}
+	}
+	return nil
+}
+
+// QueryEndpoint queries the endpoint edge of the WebhookDelivery.
+func (wd *WebhookDelivery) QueryEndpoint() *WebhookEndpointQuery {
+	return (&WebhookDeliveryClient{config: wd.config}).QueryEndpoint(wd)
+}
+
+// Update returns a builder for updating this WebhookDelivery.
+// Note that, you need to call WebhookDelivery.Unwrap() before calling this method, if this WebhookDelivery
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (wd *WebhookDelivery) Update() *WebhookDeliveryUpdateOne {
+	return (&WebhookDeliveryClient{config: wd.config}).UpdateOne(wd)
+}
+
+// Unwrap unwraps the entity that was returned from a transaction after it was closed,
+// so that all next queries will be executed through the driver which created the transaction.
+func (wd *WebhookDelivery) Unwrap() *WebhookDelivery {
+	tx, ok := wd.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: WebhookDelivery is not a transactional entity")
+	}
+	wd.config.driver = tx.drv
+	return wd
+}
+
+// String implements the fmt.Stringer.
+func (wd *WebhookDelivery) String() string {
+	var builder strings.Builder
+	builder.WriteString("WebhookDelivery(")
+	builder.WriteString(fmt.Sprintf("id=%v", wd.ID))
+	builder.WriteString(", tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", wd.TenantID))
+	builder.WriteString(", event_id=")
+	builder.WriteString(fmt.Sprintf("%v", wd.EventID))
+	builder.WriteString(", event_type=")
+	builder.WriteString(wd.EventType)
+	builder.WriteString(", user_id=")
+	builder.WriteString(fmt.Sprintf("%v", wd.UserID))
+	builder.WriteString(", payload=")
+	builder.WriteString(fmt.Sprintf("%v", wd.Payload))
+	builder.WriteString(", status=")
+	builder.WriteString(fmt.Sprintf("%v", wd.Status))
+	builder.WriteString(", attempts=")
+	builder.WriteString(fmt.Sprintf("%v", wd.Attempts))
+	builder.WriteString(", response_status=")
+	builder.WriteString(fmt.Sprintf("%v", wd.ResponseStatus))
+	builder.WriteString(", response_body=")
+	builder.WriteString(wd.ResponseBody)
+	builder.WriteString(", error=")
+	builder.WriteString(wd.Error)
+	builder.WriteString(", duration_ms=")
+	builder.WriteString(fmt.Sprintf("%v", wd.DurationMs))
+	builder.WriteString(", replay_of=")
+	builder.WriteString(fmt.Sprintf("%v", wd.ReplayOf))
+	builder.WriteString(", created_at=")
+	builder.WriteString(wd.CreatedAt.Format(time.ANSIC))
+	if v := wd.LastAttemptAt; v != nil {
+		builder.WriteString(", last_attempt_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	if v := wd.DeliveredAt; v != nil {
+		builder.WriteString(", delivered_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// WebhookDeliveries is a parsable slice of WebhookDelivery.
+type WebhookDeliveries []*WebhookDelivery
+
+func (wd WebhookDeliveries) config(cfg config) {
+	for _i := range wd {
+		wd[_i].config = cfg
+	}
+}
@@ -0,0 +1,598 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// WebhookDeliveryCreate is the builder for creating a WebhookDelivery entity.
+type WebhookDeliveryCreate struct {
+	config
+	mutation *WebhookDeliveryMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the tenant_id field.
+func (wdc *WebhookDeliveryCreate) SetTenantID(i int) *WebhookDeliveryCreate {
+	wdc.mutation.SetTenantID(i)
+	return wdc
+}
+
+// SetNillableTenantID sets the tenant_id field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableTenantID(i *int) *WebhookDeliveryCreate {
+	if i != nil {
+		wdc.SetTenantID(*i)
+	}
+	return wdc
+}
+
+// SetEventID sets the event_id field.
+func (wdc *WebhookDeliveryCreate) SetEventID(i int) *WebhookDeliveryCreate {
+	wdc.mutation.SetEventID(i)
+	return wdc
+}
+
+// SetEventType sets the event_type field.
+func (wdc *WebhookDeliveryCreate) SetEventType(s string) *WebhookDeliveryCreate {
+	wdc.mutation.SetEventType(s)
+	return wdc
+}
+
+// SetUserID sets the user_id field.
+func (wdc *WebhookDeliveryCreate) SetUserID(i int) *WebhookDeliveryCreate {
+	wdc.mutation.SetUserID(i)
+	return wdc
+}
+
+// SetNillableUserID sets the user_id field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableUserID(i *int) *WebhookDeliveryCreate {
+	if i != nil {
+		wdc.SetUserID(*i)
+	}
+	return wdc
+}
+
+// SetPayload sets the payload field.
+func (wdc *WebhookDeliveryCreate) SetPayload(b []byte) *WebhookDeliveryCreate {
+	wdc.mutation.SetPayload(b)
+	return wdc
+}
+
+// SetStatus sets the status field.
+func (wdc *WebhookDeliveryCreate) SetStatus(w webhookdelivery.Status) *WebhookDeliveryCreate {
+	wdc.mutation.SetStatus(w)
+	return wdc
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableStatus(w *webhookdelivery.Status) *WebhookDeliveryCreate {
+	if w != nil {
+		wdc.SetStatus(*w)
+	}
+	return wdc
+}
+
+// SetAttempts sets the attempts field.
+func (wdc *WebhookDeliveryCreate) SetAttempts(i int) *WebhookDeliveryCreate {
+	wdc.mutation.SetAttempts(i)
+	return wdc
+}
+
+// SetNillableAttempts sets the attempts field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableAttempts(i *int) *WebhookDeliveryCreate {
+	if i != nil {
+		wdc.SetAttempts(*i)
+	}
+	return wdc
+}
+
+// SetResponseStatus sets the response_status field.
+func (wdc *WebhookDeliveryCreate) SetResponseStatus(i int) *WebhookDeliveryCreate {
+	wdc.mutation.SetResponseStatus(i)
+	return wdc
+}
+
+// SetNillableResponseStatus sets the response_status field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableResponseStatus(i *int) *WebhookDeliveryCreate {
+	if i != nil {
+		wdc.SetResponseStatus(*i)
+	}
+	return wdc
+}
+
+// SetResponseBody sets the response_body field.
+func (wdc *WebhookDeliveryCreate) SetResponseBody(s string) *WebhookDeliveryCreate {
+	wdc.mutation.SetResponseBody(s)
+	return wdc
+}
+
+// SetNillableResponseBody sets the response_body field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableResponseBody(s *string) *WebhookDeliveryCreate {
+	if s != nil {
+		wdc.SetResponseBody(*s)
+	}
+	return wdc
+}
+
+// SetError sets the error field.
+func (wdc *WebhookDeliveryCreate) SetError(s string) *WebhookDeliveryCreate {
+	wdc.mutation.SetError(s)
+	return wdc
+}
+
+// SetNillableError sets the error field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableError(s *string) *WebhookDeliveryCreate {
+	if s != nil {
+		wdc.SetError(*s)
+	}
+	return wdc
+}
+
+// SetDurationMs sets the duration_ms field.
+func (wdc *WebhookDeliveryCreate) SetDurationMs(i int64) *WebhookDeliveryCreate {
+	wdc.mutation.SetDurationMs(i)
+	return wdc
+}
+
+// SetNillableDurationMs sets the duration_ms field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableDurationMs(i *int64) *WebhookDeliveryCreate {
+	if i != nil {
+		wdc.SetDurationMs(*i)
+	}
+	return wdc
+}
+
+// SetReplayOf sets the replay_of field.
+func (wdc *WebhookDeliveryCreate) SetReplayOf(i int) *WebhookDeliveryCreate {
+	wdc.mutation.SetReplayOf(i)
+	return wdc
+}
+
+// SetNillableReplayOf sets the replay_of field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableReplayOf(i *int) *WebhookDeliveryCreate {
+	if i != nil {
+		wdc.SetReplayOf(*i)
+	}
+	return wdc
+}
+
+// SetCreatedAt sets the created_at field.
+func (wdc *WebhookDeliveryCreate) SetCreatedAt(t time.Time) *WebhookDeliveryCreate {
+	wdc.mutation.SetCreatedAt(t)
+	return wdc
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableCreatedAt(t *time.Time) *WebhookDeliveryCreate {
+	if t != nil {
+		wdc.SetCreatedAt(*t)
+	}
+	return wdc
+}
+
+// SetLastAttemptAt sets the last_attempt_at field.
+func (wdc *WebhookDeliveryCreate) SetLastAttemptAt(t time.Time) *WebhookDeliveryCreate {
+	wdc.mutation.SetLastAttemptAt(t)
+	return wdc
+}
+
+// SetNillableLastAttemptAt sets the last_attempt_at field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableLastAttemptAt(t *time.Time) *WebhookDeliveryCreate {
+	if t != nil {
+		wdc.SetLastAttemptAt(*t)
+	}
+	return wdc
+}
+
+// SetDeliveredAt sets the delivered_at field.
+func (wdc *WebhookDeliveryCreate) SetDeliveredAt(t time.Time) *WebhookDeliveryCreate {
+	wdc.mutation.SetDeliveredAt(t)
+	return wdc
+}
+
+// SetNillableDeliveredAt sets the delivered_at field if the given value is not nil.
+func (wdc *WebhookDeliveryCreate) SetNillableDeliveredAt(t *time.Time) *WebhookDeliveryCreate {
+	if t != nil {
+		wdc.SetDeliveredAt(*t)
+	}
+	return wdc
+}
+
+// SetEndpointID sets the endpoint edge to WebhookEndpoint by id.
+func (wdc *WebhookDeliveryCreate) SetEndpointID(id int) *WebhookDeliveryCreate {
+	wdc.mutation.SetEndpointID(id)
+	return wdc
+}
+
+// SetEndpoint sets the endpoint edge to WebhookEndpoint.
+func (wdc *WebhookDeliveryCreate) SetEndpoint(w *WebhookEndpoint) *WebhookDeliveryCreate {
+	return wdc.SetEndpointID(w.ID)
+}
+
+// Mutation returns the WebhookDeliveryMutation object of the builder.
+func (wdc *WebhookDeliveryCreate) Mutation() *WebhookDeliveryMutation {
+	return wdc.mutation
+}
+
+// Save creates the WebhookDelivery in the database.
+func (wdc *WebhookDeliveryCreate) Save(ctx context.Context) (*WebhookDelivery, error) {
+	var (
+		err  error
+		node *WebhookDelivery
+	)
+	wdc.defaults()
+	if len(wdc.hooks) == 0 {
+		if err = wdc.check(); err != nil {
+			return nil, err
+		}
+		node, err = wdc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*WebhookDeliveryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = wdc.check(); err != nil {
+				return nil, err
+			}
+			wdc.mutation = mutation
+			node, err = wdc.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(wdc.hooks) - 1; i >= 0; i-- {
+			mut = wdc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, wdc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (wdc *WebhookDeliveryCreate) SaveX(ctx context.Context) *WebhookDelivery {
+	v, err := wdc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// defaults sets the default values of the builder before save.
+func (wdc *WebhookDeliveryCreate) defaults() {
+	if _, ok := wdc.mutation.TenantID(); !ok {
+		v := webhookdelivery.DefaultTenantID
+		wdc.mutation.SetTenantID(v)
+	}
+	if _, ok := wdc.mutation.UserID(); !ok {
+		v := webhookdelivery.DefaultUserID
+		wdc.mutation.SetUserID(v)
+	}
+	if _, ok := wdc.mutation.Status(); !ok {
+		v := webhookdelivery.DefaultStatus
+		wdc.mutation.SetStatus(v)
+	}
+	if _, ok := wdc.mutation.Attempts(); !ok {
+		v := webhookdelivery.DefaultAttempts
+		wdc.mutation.SetAttempts(v)
+	}
+	if _, ok := wdc.mutation.ResponseStatus(); !ok {
+		v := webhookdelivery.DefaultResponseStatus
+		wdc.mutation.SetResponseStatus(v)
+	}
+	if _, ok := wdc.mutation.ResponseBody(); !ok {
+		v := webhookdelivery.DefaultResponseBody
+		wdc.mutation.SetResponseBody(v)
+	}
+	if _, ok := wdc.mutation.Error(); !ok {
+		v := webhookdelivery.DefaultError
+		wdc.mutation.SetError(v)
+	}
+	if _, ok := wdc.mutation.DurationMs(); !ok {
+		v := webhookdelivery.DefaultDurationMs
+		wdc.mutation.SetDurationMs(v)
+	}
+	if _, ok := wdc.mutation.ReplayOf(); !ok {
+		v := webhookdelivery.DefaultReplayOf
+		wdc.mutation.SetReplayOf(v)
+	}
+	if _, ok := wdc.mutation.CreatedAt(); !ok {
+		v := webhookdelivery.DefaultCreatedAt()
+		wdc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (wdc *WebhookDeliveryCreate) check() error {
+	if _, ok := wdc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New("ent: missing required field \"tenant_id\"")}
+	}
+	if _, ok := wdc.mutation.EventID(); !ok {
+		return &ValidationError{Name: "event_id", err: errors.New("ent: missing required field \"event_id\"")}
+	}
+	if _, ok := wdc.mutation.EventType(); !ok {
+		return &ValidationError{Name: "event_type", err: errors.New("ent: missing required field \"event_type\"")}
+	}
+	if _, ok := wdc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New("ent: missing required field \"user_id\"")}
+	}
+	if _, ok := wdc.mutation.Payload(); !ok {
+		return &ValidationError{Name: "payload", err: errors.New("ent: missing required field \"payload\"")}
+	}
+	if _, ok := wdc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New("ent: missing required field \"status\"")}
+	}
+	if v, ok := wdc.mutation.Status(); ok {
+		if err := webhookdelivery.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf("ent: validator failed for field \"status\": %w", err)}
+		}
+	}
+	if _, ok := wdc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New("ent: missing required field \"attempts\"")}
+	}
+	if _, ok := wdc.mutation.ResponseStatus(); !ok {
+		return &ValidationError{Name: "response_status", err: errors.New("ent: missing required field \"response_status\"")}
+	}
+	if _, ok := wdc.mutation.ResponseBody(); !ok {
+		return &ValidationError{Name: "response_body", err: errors.New("ent: missing required field \"response_body\"")}
+	}
+	if _, ok := wdc.mutation.Error(); !ok {
+		return &ValidationError{Name: "error", err: errors.New("ent: missing required field \"error\"")}
+	}
+	if _, ok := wdc.mutation.DurationMs(); !ok {
+		return &ValidationError{Name: "duration_ms", err: errors.New("ent: missing required field \"duration_ms\"")}
+	}
+	if _, ok := wdc.mutation.ReplayOf(); !ok {
+		return &ValidationError{Name: "replay_of", err: errors.New("ent: missing required field \"replay_of\"")}
+	}
+	if _, ok := wdc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New("ent: missing required field \"created_at\"")}
+	}
+	if _, ok := wdc.mutation.EndpointID(); !ok {
+		return &ValidationError{Name: "endpoint", err: errors.New("ent: missing required edge \"endpoint\"")}
+	}
+	return nil
+}
+
+func (wdc *WebhookDeliveryCreate) sqlSave(ctx context.Context) (*WebhookDelivery, error) {
+	_node, _spec := wdc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, wdc.driver, _spec); err != nil {
+		if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (wdc *WebhookDeliveryCreate) createSpec() (*WebhookDelivery, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WebhookDelivery{config: wdc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: webhookdelivery.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookdelivery.FieldID,
+			},
+		}
+	)
+	if value, ok := wdc.mutation.TenantID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldTenantID,
+		})
+		_node.TenantID = value
+	}
+	if value, ok := wdc.mutation.EventID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldEventID,
+		})
+		_node.EventID = value
+	}
+	if value, ok := wdc.mutation.EventType(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookdelivery.FieldEventType,
+		})
+		_node.EventType = value
+	}
+	if value, ok := wdc.mutation.UserID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldUserID,
+		})
+		_node.UserID = value
+	}
+	if value, ok := wdc.mutation.Payload(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: webhookdelivery.FieldPayload,
+		})
+		_node.Payload = value
+	}
+	if value, ok := wdc.mutation.Status(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: webhookdelivery.FieldStatus,
+		})
+		_node.Status = value
+	}
+	if value, ok := wdc.mutation.Attempts(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldAttempts,
+		})
+		_node.Attempts = value
+	}
+	if value, ok := wdc.mutation.ResponseStatus(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldResponseStatus,
+		})
+		_node.ResponseStatus = value
+	}
+	if value, ok := wdc.mutation.ResponseBody(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookdelivery.FieldResponseBody,
+		})
+		_node.ResponseBody = value
+	}
+	if value, ok := wdc.mutation.Error(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookdelivery.FieldError,
+		})
+		_node.Error = value
+	}
+	if value, ok := wdc.mutation.DurationMs(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: webhookdelivery.FieldDurationMs,
+		})
+		_node.DurationMs = value
+	}
+	if value, ok := wdc.mutation.ReplayOf(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldReplayOf,
+		})
+		_node.ReplayOf = value
+	}
+	if value, ok := wdc.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	if value, ok := wdc.mutation.LastAttemptAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldLastAttemptAt,
+		})
+		_node.LastAttemptAt = &value
+	}
+	if value, ok := wdc.mutation.DeliveredAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldDeliveredAt,
+		})
+		_node.DeliveredAt = &value
+	}
+	if nodes := wdc.mutation.EndpointIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   webhookdelivery.EndpointTable,
+			Columns: []string{webhookdelivery.EndpointColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookendpoint.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// WebhookDeliveryCreateBulk is the builder for creating a bulk of WebhookDelivery entities.
+type WebhookDeliveryCreateBulk struct {
+	config
+	builders []*WebhookDeliveryCreate
+}
+
+// Save creates the WebhookDelivery entities in the database.
+func (wdcb *WebhookDeliveryCreateBulk) Save(ctx context.Context) ([]*WebhookDelivery, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(wdcb.builders))
+	nodes := make([]*WebhookDelivery, len(wdcb.builders))
+	mutators := make([]Mutator, len(wdcb.builders))
+	for i := range wdcb.builders {
+		func(i int, root context.Context) {
+			builder := wdcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WebhookDeliveryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, wdcb.builders[i+1].mutation)
+				} else {
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, wdcb.driver, &sqlgraph.BatchCreateSpec{Nodes: specs}); err != nil {
+						if cerr, ok := isSQLConstraintError(err); ok {
+							err = cerr
+						}
+					}
+				}
+				mutation.done = true
+				if err != nil {
+					return nil, err
+				}
+				id := specs[i].ID.Value.(int64)
+				nodes[i].ID = int(id)
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, wdcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (wdcb *WebhookDeliveryCreateBulk) SaveX(ctx context.Context) []*WebhookDelivery {
+	v, err := wdcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,860 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/predicate"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// WebhookDeliveryUpdate is the builder for updating WebhookDelivery entities.
+type WebhookDeliveryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *WebhookDeliveryMutation
+}
+
+// Where adds a new predicate for the builder.
+func (wdu *WebhookDeliveryUpdate) Where(ps ...predicate.WebhookDelivery) *WebhookDeliveryUpdate {
+	wdu.mutation.predicates = append(wdu.mutation.predicates, ps...)
+	return wdu
+}
+
+// SetStatus sets the status field.
+func (wdu *WebhookDeliveryUpdate) SetStatus(w webhookdelivery.Status) *WebhookDeliveryUpdate {
+	wdu.mutation.SetStatus(w)
+	return wdu
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableStatus(w *webhookdelivery.Status) *WebhookDeliveryUpdate {
+	if w != nil {
+		wdu.SetStatus(*w)
+	}
+	return wdu
+}
+
+// SetAttempts sets the attempts field.
+func (wdu *WebhookDeliveryUpdate) SetAttempts(i int) *WebhookDeliveryUpdate {
+	wdu.mutation.ResetAttempts()
+	wdu.mutation.SetAttempts(i)
+	return wdu
+}
+
+// SetNillableAttempts sets the attempts field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableAttempts(i *int) *WebhookDeliveryUpdate {
+	if i != nil {
+		wdu.SetAttempts(*i)
+	}
+	return wdu
+}
+
+// AddAttempts adds i to attempts.
+func (wdu *WebhookDeliveryUpdate) AddAttempts(i int) *WebhookDeliveryUpdate {
+	wdu.mutation.AddAttempts(i)
+	return wdu
+}
+
+// SetResponseStatus sets the response_status field.
+func (wdu *WebhookDeliveryUpdate) SetResponseStatus(i int) *WebhookDeliveryUpdate {
+	wdu.mutation.ResetResponseStatus()
+	wdu.mutation.SetResponseStatus(i)
+	return wdu
+}
+
+// SetNillableResponseStatus sets the response_status field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableResponseStatus(i *int) *WebhookDeliveryUpdate {
+	if i != nil {
+		wdu.SetResponseStatus(*i)
+	}
+	return wdu
+}
+
+// AddResponseStatus adds i to response_status.
+func (wdu *WebhookDeliveryUpdate) AddResponseStatus(i int) *WebhookDeliveryUpdate {
+	wdu.mutation.AddResponseStatus(i)
+	return wdu
+}
+
+// SetResponseBody sets the response_body field.
+func (wdu *WebhookDeliveryUpdate) SetResponseBody(s string) *WebhookDeliveryUpdate {
+	wdu.mutation.SetResponseBody(s)
+	return wdu
+}
+
+// SetNillableResponseBody sets the response_body field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableResponseBody(s *string) *WebhookDeliveryUpdate {
+	if s != nil {
+		wdu.SetResponseBody(*s)
+	}
+	return wdu
+}
+
+// SetError sets the error field.
+func (wdu *WebhookDeliveryUpdate) SetError(s string) *WebhookDeliveryUpdate {
+	wdu.mutation.SetError(s)
+	return wdu
+}
+
+// SetNillableError sets the error field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableError(s *string) *WebhookDeliveryUpdate {
+	if s != nil {
+		wdu.SetError(*s)
+	}
+	return wdu
+}
+
+// SetDurationMs sets the duration_ms field.
+func (wdu *WebhookDeliveryUpdate) SetDurationMs(i int64) *WebhookDeliveryUpdate {
+	wdu.mutation.ResetDurationMs()
+	wdu.mutation.SetDurationMs(i)
+	return wdu
+}
+
+// SetNillableDurationMs sets the duration_ms field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableDurationMs(i *int64) *WebhookDeliveryUpdate {
+	if i != nil {
+		wdu.SetDurationMs(*i)
+	}
+	return wdu
+}
+
+// AddDurationMs adds i to duration_ms.
+func (wdu *WebhookDeliveryUpdate) AddDurationMs(i int64) *WebhookDeliveryUpdate {
+	wdu.mutation.AddDurationMs(i)
+	return wdu
+}
+
+// SetCreatedAt sets the created_at field.
+func (wdu *WebhookDeliveryUpdate) SetCreatedAt(t time.Time) *WebhookDeliveryUpdate {
+	wdu.mutation.SetCreatedAt(t)
+	return wdu
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableCreatedAt(t *time.Time) *WebhookDeliveryUpdate {
+	if t != nil {
+		wdu.SetCreatedAt(*t)
+	}
+	return wdu
+}
+
+// SetLastAttemptAt sets the last_attempt_at field.
+func (wdu *WebhookDeliveryUpdate) SetLastAttemptAt(t time.Time) *WebhookDeliveryUpdate {
+	wdu.mutation.SetLastAttemptAt(t)
+	return wdu
+}
+
+// SetNillableLastAttemptAt sets the last_attempt_at field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableLastAttemptAt(t *time.Time) *WebhookDeliveryUpdate {
+	if t != nil {
+		wdu.SetLastAttemptAt(*t)
+	}
+	return wdu
+}
+
+// ClearLastAttemptAt clears the value of last_attempt_at.
+func (wdu *WebhookDeliveryUpdate) ClearLastAttemptAt() *WebhookDeliveryUpdate {
+	wdu.mutation.ClearLastAttemptAt()
+	return wdu
+}
+
+// SetDeliveredAt sets the delivered_at field.
+func (wdu *WebhookDeliveryUpdate) SetDeliveredAt(t time.Time) *WebhookDeliveryUpdate {
+	wdu.mutation.SetDeliveredAt(t)
+	return wdu
+}
+
+// SetNillableDeliveredAt sets the delivered_at field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableDeliveredAt(t *time.Time) *WebhookDeliveryUpdate {
+	if t != nil {
+		wdu.SetDeliveredAt(*t)
+	}
+	return wdu
+}
+
+// ClearDeliveredAt clears the value of delivered_at.
+func (wdu *WebhookDeliveryUpdate) ClearDeliveredAt() *WebhookDeliveryUpdate {
+	wdu.mutation.ClearDeliveredAt()
+	return wdu
+}
+
+// SetEndpointID sets the endpoint edge to WebhookEndpoint by id.
+func (wdu *WebhookDeliveryUpdate) SetEndpointID(id int) *WebhookDeliveryUpdate {
+	wdu.mutation.SetEndpointID(id)
+	return wdu
+}
+
+// SetEndpoint sets the endpoint edge to WebhookEndpoint.
+func (wdu *WebhookDeliveryUpdate) SetEndpoint(w *WebhookEndpoint) *WebhookDeliveryUpdate {
+	return wdu.SetEndpointID(w.ID)
+}
+
+// Mutation returns the WebhookDeliveryMutation object of the builder.
+func (wdu *WebhookDeliveryUpdate) Mutation() *WebhookDeliveryMutation {
+	return wdu.mutation
+}
+
+// ClearEndpoint clears the "endpoint" edge to type WebhookEndpoint.
+func (wdu *WebhookDeliveryUpdate) ClearEndpoint() *WebhookDeliveryUpdate {
+	wdu.mutation.ClearEndpoint()
+	return wdu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (wdu *WebhookDeliveryUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(wdu.hooks) == 0 {
+		if err = wdu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = wdu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*WebhookDeliveryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = wdu.check(); err != nil {
+				return 0, err
+			}
+			wdu.mutation = mutation
+			affected, err = wdu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(wdu.hooks) - 1; i >= 0; i-- {
+			mut = wdu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, wdu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (wdu *WebhookDeliveryUpdate) SaveX(ctx context.Context) int {
+	affected, err := wdu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (wdu *WebhookDeliveryUpdate) Exec(ctx context.Context) error {
+	_, err := wdu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wdu *WebhookDeliveryUpdate) ExecX(ctx context.Context) {
+	if err := wdu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (wdu *WebhookDeliveryUpdate) check() error {
+	if v, ok := wdu.mutation.Status(); ok {
+		if err := webhookdelivery.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf("ent: validator failed for field \"status\": %w", err)}
+		}
+	}
+	if _, ok := wdu.mutation.EndpointID(); wdu.mutation.EndpointCleared() && !ok {
+		return errors.New("ent: clearing a required unique edge \"endpoint\"")
+	}
+	return nil
+}
+
+func (wdu *WebhookDeliveryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   webhookdelivery.Table,
+			Columns: webhookdelivery.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookdelivery.FieldID,
+			},
+		},
+	}
+	if ps := wdu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := wdu.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: webhookdelivery.FieldStatus,
+		})
+	}
+	if value, ok := wdu.mutation.Attempts(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldAttempts,
+		})
+	}
+	if value, ok := wdu.mutation.AddedAttempts(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldAttempts,
+		})
+	}
+	if value, ok := wdu.mutation.ResponseStatus(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldResponseStatus,
+		})
+	}
+	if value, ok := wdu.mutation.AddedResponseStatus(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldResponseStatus,
+		})
+	}
+	if value, ok := wdu.mutation.ResponseBody(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookdelivery.FieldResponseBody,
+		})
+	}
+	if value, ok := wdu.mutation.Error(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookdelivery.FieldError,
+		})
+	}
+	if value, ok := wdu.mutation.DurationMs(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: webhookdelivery.FieldDurationMs,
+		})
+	}
+	if value, ok := wdu.mutation.AddedDurationMs(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: webhookdelivery.FieldDurationMs,
+		})
+	}
+	if value, ok := wdu.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldCreatedAt,
+		})
+	}
+	if value, ok := wdu.mutation.LastAttemptAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldLastAttemptAt,
+		})
+	}
+	if wdu.mutation.LastAttemptAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: webhookdelivery.FieldLastAttemptAt,
+		})
+	}
+	if value, ok := wdu.mutation.DeliveredAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldDeliveredAt,
+		})
+	}
+	if wdu.mutation.DeliveredAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: webhookdelivery.FieldDeliveredAt,
+		})
+	}
+	if wdu.mutation.EndpointCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   webhookdelivery.EndpointTable,
+			Columns: []string{webhookdelivery.EndpointColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookendpoint.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := wdu.mutation.EndpointIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   webhookdelivery.EndpointTable,
+			Columns: []string{webhookdelivery.EndpointColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookendpoint.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, wdu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{webhookdelivery.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// WebhookDeliveryUpdateOne is the builder for updating a single WebhookDelivery entity.
+type WebhookDeliveryUpdateOne struct {
+	config
+	hooks    []Hook
+	mutation *WebhookDeliveryMutation
+}
+
+// SetStatus sets the status field.
+func (wduo *WebhookDeliveryUpdateOne) SetStatus(w webhookdelivery.Status) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetStatus(w)
+	return wduo
+}
+
+// SetNillableStatus sets the status field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableStatus(w *webhookdelivery.Status) *WebhookDeliveryUpdateOne {
+	if w != nil {
+		wduo.SetStatus(*w)
+	}
+	return wduo
+}
+
+// SetAttempts sets the attempts field.
+func (wduo *WebhookDeliveryUpdateOne) SetAttempts(i int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.ResetAttempts()
+	wduo.mutation.SetAttempts(i)
+	return wduo
+}
+
+// SetNillableAttempts sets the attempts field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableAttempts(i *int) *WebhookDeliveryUpdateOne {
+	if i != nil {
+		wduo.SetAttempts(*i)
+	}
+	return wduo
+}
+
+// AddAttempts adds i to attempts.
+func (wduo *WebhookDeliveryUpdateOne) AddAttempts(i int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.AddAttempts(i)
+	return wduo
+}
+
+// SetResponseStatus sets the response_status field.
+func (wduo *WebhookDeliveryUpdateOne) SetResponseStatus(i int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.ResetResponseStatus()
+	wduo.mutation.SetResponseStatus(i)
+	return wduo
+}
+
+// SetNillableResponseStatus sets the response_status field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableResponseStatus(i *int) *WebhookDeliveryUpdateOne {
+	if i != nil {
+		wduo.SetResponseStatus(*i)
+	}
+	return wduo
+}
+
+// AddResponseStatus adds i to response_status.
+func (wduo *WebhookDeliveryUpdateOne) AddResponseStatus(i int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.AddResponseStatus(i)
+	return wduo
+}
+
+// SetResponseBody sets the response_body field.
+func (wduo *WebhookDeliveryUpdateOne) SetResponseBody(s string) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetResponseBody(s)
+	return wduo
+}
+
+// SetNillableResponseBody sets the response_body field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableResponseBody(s *string) *WebhookDeliveryUpdateOne {
+	if s != nil {
+		wduo.SetResponseBody(*s)
+	}
+	return wduo
+}
+
+// SetError sets the error field.
+func (wduo *WebhookDeliveryUpdateOne) SetError(s string) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetError(s)
+	return wduo
+}
+
+// SetNillableError sets the error field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableError(s *string) *WebhookDeliveryUpdateOne {
+	if s != nil {
+		wduo.SetError(*s)
+	}
+	return wduo
+}
+
+// SetDurationMs sets the duration_ms field.
+func (wduo *WebhookDeliveryUpdateOne) SetDurationMs(i int64) *WebhookDeliveryUpdateOne {
+	wduo.mutation.ResetDurationMs()
+	wduo.mutation.SetDurationMs(i)
+	return wduo
+}
+
+// SetNillableDurationMs sets the duration_ms field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableDurationMs(i *int64) *WebhookDeliveryUpdateOne {
+	if i != nil {
+		wduo.SetDurationMs(*i)
+	}
+	return wduo
+}
+
+// AddDurationMs adds i to duration_ms.
+func (wduo *WebhookDeliveryUpdateOne) AddDurationMs(i int64) *WebhookDeliveryUpdateOne {
+	wduo.mutation.AddDurationMs(i)
+	return wduo
+}
+
+// SetCreatedAt sets the created_at field.
+func (wduo *WebhookDeliveryUpdateOne) SetCreatedAt(t time.Time) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetCreatedAt(t)
+	return wduo
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableCreatedAt(t *time.Time) *WebhookDeliveryUpdateOne {
+	if t != nil {
+		wduo.SetCreatedAt(*t)
+	}
+	return wduo
+}
+
+// SetLastAttemptAt sets the last_attempt_at field.
+func (wduo *WebhookDeliveryUpdateOne) SetLastAttemptAt(t time.Time) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetLastAttemptAt(t)
+	return wduo
+}
+
+// SetNillableLastAttemptAt sets the last_attempt_at field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableLastAttemptAt(t *time.Time) *WebhookDeliveryUpdateOne {
+	if t != nil {
+		wduo.SetLastAttemptAt(*t)
+	}
+	return wduo
+}
+
+// ClearLastAttemptAt clears the value of last_attempt_at.
+func (wduo *WebhookDeliveryUpdateOne) ClearLastAttemptAt() *WebhookDeliveryUpdateOne {
+	wduo.mutation.ClearLastAttemptAt()
+	return wduo
+}
+
+// SetDeliveredAt sets the delivered_at field.
+func (wduo *WebhookDeliveryUpdateOne) SetDeliveredAt(t time.Time) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetDeliveredAt(t)
+	return wduo
+}
+
+// SetNillableDeliveredAt sets the delivered_at field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableDeliveredAt(t *time.Time) *WebhookDeliveryUpdateOne {
+	if t != nil {
+		wduo.SetDeliveredAt(*t)
+	}
+	return wduo
+}
+
+// ClearDeliveredAt clears the value of delivered_at.
+func (wduo *WebhookDeliveryUpdateOne) ClearDeliveredAt() *WebhookDeliveryUpdateOne {
+	wduo.mutation.ClearDeliveredAt()
+	return wduo
+}
+
+// SetEndpointID sets the endpoint edge to WebhookEndpoint by id.
+func (wduo *WebhookDeliveryUpdateOne) SetEndpointID(id int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetEndpointID(id)
+	return wduo
+}
+
+// SetEndpoint sets the endpoint edge to WebhookEndpoint.
+func (wduo *WebhookDeliveryUpdateOne) SetEndpoint(w *WebhookEndpoint) *WebhookDeliveryUpdateOne {
+	return wduo.SetEndpointID(w.ID)
+}
+
+// Mutation returns the WebhookDeliveryMutation object of the builder.
+func (wduo *WebhookDeliveryUpdateOne) Mutation() *WebhookDeliveryMutation {
+	return wduo.mutation
+}
+
+// ClearEndpoint clears the "endpoint" edge to type WebhookEndpoint.
+func (wduo *WebhookDeliveryUpdateOne) ClearEndpoint() *WebhookDeliveryUpdateOne {
+	wduo.mutation.ClearEndpoint()
+	return wduo
+}
+
+// Save executes the query and returns the updated entity.
+func (wduo *WebhookDeliveryUpdateOne) Save(ctx context.Context) (*WebhookDelivery, error) {
+	var (
+		err  error
+		node *WebhookDelivery
+	)
+	if len(wduo.hooks) == 0 {
+		if err = wduo.check(); err != nil {
+			return nil, err
+		}
+		node, err = wduo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*WebhookDeliveryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = wduo.check(); err != nil {
+				return nil, err
+			}
+			wduo.mutation = mutation
+			node, err = wduo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(wduo.hooks) - 1; i >= 0; i-- {
+			mut = wduo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, wduo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (wduo *WebhookDeliveryUpdateOne) SaveX(ctx context.Context) *WebhookDelivery {
+	node, err := wduo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (wduo *WebhookDeliveryUpdateOne) Exec(ctx context.Context) error {
+	_, err := wduo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wduo *WebhookDeliveryUpdateOne) ExecX(ctx context.Context) {
+	if err := wduo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (wduo *WebhookDeliveryUpdateOne) check() error {
+	if v, ok := wduo.mutation.Status(); ok {
+		if err := webhookdelivery.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf("ent: validator failed for field \"status\": %w", err)}
+		}
+	}
+	if _, ok := wduo.mutation.EndpointID(); wduo.mutation.EndpointCleared() && !ok {
+		return errors.New("ent: clearing a required unique edge \"endpoint\"")
+	}
+	return nil
+}
+
+func (wduo *WebhookDeliveryUpdateOne) sqlSave(ctx context.Context) (_node *WebhookDelivery, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   webhookdelivery.Table,
+			Columns: webhookdelivery.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookdelivery.FieldID,
+			},
+		},
+	}
+	id, ok := wduo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "ID", err: fmt.Errorf("missing WebhookDelivery.ID for update")}
+	}
+	_spec.Node.ID.Value = id
+	if value, ok := wduo.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: webhookdelivery.FieldStatus,
+		})
+	}
+	if value, ok := wduo.mutation.Attempts(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldAttempts,
+		})
+	}
+	if value, ok := wduo.mutation.AddedAttempts(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldAttempts,
+		})
+	}
+	if value, ok := wduo.mutation.ResponseStatus(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldResponseStatus,
+		})
+	}
+	if value, ok := wduo.mutation.AddedResponseStatus(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookdelivery.FieldResponseStatus,
+		})
+	}
+	if value, ok := wduo.mutation.ResponseBody(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookdelivery.FieldResponseBody,
+		})
+	}
+	if value, ok := wduo.mutation.Error(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookdelivery.FieldError,
+		})
+	}
+	if value, ok := wduo.mutation.DurationMs(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: webhookdelivery.FieldDurationMs,
+		})
+	}
+	if value, ok := wduo.mutation.AddedDurationMs(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: webhookdelivery.FieldDurationMs,
+		})
+	}
+	if value, ok := wduo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldCreatedAt,
+		})
+	}
+	if value, ok := wduo.mutation.LastAttemptAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldLastAttemptAt,
+		})
+	}
+	if wduo.mutation.LastAttemptAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: webhookdelivery.FieldLastAttemptAt,
+		})
+	}
+	if value, ok := wduo.mutation.DeliveredAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookdelivery.FieldDeliveredAt,
+		})
+	}
+	if wduo.mutation.DeliveredAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: webhookdelivery.FieldDeliveredAt,
+		})
+	}
+	if wduo.mutation.EndpointCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   webhookdelivery.EndpointTable,
+			Columns: []string{webhookdelivery.EndpointColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookendpoint.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := wduo.mutation.EndpointIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   webhookdelivery.EndpointTable,
+			Columns: []string{webhookdelivery.EndpointColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookendpoint.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &WebhookDelivery{config: wduo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues()
+	if err = sqlgraph.UpdateNode(ctx, wduo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{webhookdelivery.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	return _node, nil
+}
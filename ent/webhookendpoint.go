@@ -0,0 +1,215 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-api/ent/webhookendpoint"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// WebhookEndpoint is the model entity for the WebhookEndpoint schema.
+type WebhookEndpoint struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id"`
+	// OwnerID holds the value of the "owner_id" field.
+	OwnerID int `json:"owner_id"`
+	// URL holds the value of the "url" field.
+	URL string `json:"url"`
+	// Secret holds the value of the "secret" field.
+	Secret string `json:"-"`
+	// Events holds the value of the "events" field.
+	Events string `json:"events"`
+	// Description holds the value of the "description" field.
+	Description string `json:"description"`
+	// Enabled holds the value of the "enabled" field.
+	Enabled bool `json:"enabled"`
+	// Failures holds the value of the "failures" field.
+	Failures int `json:"failures"`
+	// DisabledAt holds the value of the "disabled_at" field.
+	DisabledAt *time.Time `json:"disabled_at"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the WebhookEndpointQuery when eager-loading is set.
+	Edges WebhookEndpointEdges `json:"edges"`
+}
+
+// WebhookEndpointEdges holds the relations/edges for other nodes in the graph.
+type WebhookEndpointEdges struct {
+	// Deliveries holds the value of the deliveries edge.
+	Deliveries []*WebhookDelivery
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// DeliveriesOrErr returns the Deliveries value or an error if the edge
+// was not loaded in eager-loading.
+func (e WebhookEndpointEdges) DeliveriesOrErr() ([]*WebhookDelivery, error) {
+	if e.loadedTypes[0] {
+		return e.Deliveries, nil
+	}
+	return nil, &NotLoadedError{edge: "deliveries"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*WebhookEndpoint) scanValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{},  // id
+		&sql.NullInt64{},  // tenant_id
+		&sql.NullInt64{},  // owner_id
+		&sql.NullString{}, // url
+		&sql.NullString{}, // secret
+		&sql.NullString{}, // events
+		&sql.NullString{}, // description
+		&sql.NullBool{},   // enabled
+		&sql.NullInt64{},  // failures
+		&sql.NullTime{},   // disabled_at
+		&sql.NullTime{},   // created_at
+		&sql.NullTime{},   // updated_at
+	}
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the WebhookEndpoint fields.
+func (we *WebhookEndpoint) assignValues(values ...interface{}) error {
+	if m, n := len(values), len(webhookendpoint.Columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	value, ok := values[0].(*sql.NullInt64)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for field id", value)
+	}
+	we.ID = int(value.Int64)
+	values = values[1:]
+	if value, ok := values[0].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field tenant_id", values[0])
+	} else if value.Valid {
+		we.TenantID = int(value.Int64)
+	}
+	if value, ok := values[1].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field owner_id", values[1])
+	} else if value.Valid {
+		we.OwnerID = int(value.Int64)
+	}
+	if value, ok := values[2].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field url", values[2])
+	} else if value.Valid {
+		we.URL = value.String
+	}
+	if value, ok := values[3].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field secret", values[3])
+	} else if value.Valid {
+		we.Secret = value.String
+	}
+	if value, ok := values[4].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field events", values[4])
+	} else if value.Valid {
+		we.Events = value.String
+	}
+	if value, ok := values[5].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field description", values[5])
+	} else if value.Valid {
+		we.Description = value.String
+	}
+	if value, ok := values[6].(*sql.NullBool); !ok {
+		return fmt.Errorf("unexpected type %T for field enabled", values[6])
+	} else if value.Valid {
+		we.Enabled = value.Bool
+	}
+	if value, ok := values[7].(*sql.NullInt64); !ok {
+		return fmt.Errorf("unexpected type %T for field failures", values[7])
+	} else if value.Valid {
+		we.Failures = int(value.Int64)
+	}
+	if value, ok := values[8].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field disabled_at", values[8])
+	} else if value.Valid {
+		we.DisabledAt = new(time.Time)
+		*we.DisabledAt = value.Time
+	}
+	if value, ok := values[9].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[9])
+	} else if value.Valid {
+		we.CreatedAt = value.Time
+	}
+	if value, ok := values[10].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field updated_at", values[10])
+	} else if value.Valid {
+		we.UpdatedAt = value.Time
+	}
+	return nil
+}
+
+// QueryDeliveries queries the deliveries edge of the WebhookEndpoint.
+func (we *WebhookEndpoint) QueryDeliveries() *WebhookDeliveryQuery {
+	return (&WebhookEndpointClient{config: we.config}).QueryDeliveries(we)
+}
+
+// Update returns a builder for updating this WebhookEndpoint.
+// Note that, you need to call WebhookEndpoint.Unwrap() before calling this method, if this WebhookEndpoint
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (we *WebhookEndpoint) Update() *WebhookEndpointUpdateOne {
+	return (&WebhookEndpointClient{config: we.config}).UpdateOne(we)
+}
+
+// Unwrap unwraps the entity that was returned from a transaction after it was closed,
+// so that all next queries will be executed through the driver which created the transaction.
+func (we *WebhookEndpoint) Unwrap() *WebhookEndpoint {
+	tx, ok := we.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: WebhookEndpoint is not a transactional entity")
+	}
+	we.config.driver = tx.drv
+	return we
+}
+
+// String implements the fmt.Stringer.
+func (we *WebhookEndpoint) String() string {
+	var builder strings.Builder
+	builder.WriteString("WebhookEndpoint(")
+	builder.WriteString(fmt.Sprintf("id=%v", we.ID))
+	builder.WriteString(", tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", we.TenantID))
+	builder.WriteString(", owner_id=")
+	builder.WriteString(fmt.Sprintf("%v", we.OwnerID))
+	builder.WriteString(", url=")
+	builder.WriteString(we.URL)
+	builder.WriteString(", secret=<sensitive>")
+	builder.WriteString(", events=")
+	builder.WriteString(we.Events)
+	builder.WriteString(", description=")
+	builder.WriteString(we.Description)
+	builder.WriteString(", enabled=")
+	builder.WriteString(fmt.Sprintf("%v", we.Enabled))
+	builder.WriteString(", failures=")
+	builder.WriteString(fmt.Sprintf("%v", we.Failures))
+	if v := we.DisabledAt; v != nil {
+		builder.WriteString(", disabled_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", created_at=")
+	builder.WriteString(we.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", updated_at=")
+	builder.WriteString(we.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// WebhookEndpoints is a parsable slice of WebhookEndpoint.
+type WebhookEndpoints []*WebhookEndpoint
+
+func (we WebhookEndpoints) config(cfg config) {
+	for _i := range we {
+		we[_i].config = cfg
+	}
+}
@@ -0,0 +1,495 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// WebhookEndpointCreate is the builder for creating a WebhookEndpoint entity.
+type WebhookEndpointCreate struct {
+	config
+	mutation *WebhookEndpointMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the tenant_id field.
+func (wec *WebhookEndpointCreate) SetTenantID(i int) *WebhookEndpointCreate {
+	wec.mutation.SetTenantID(i)
+	return wec
+}
+
+// SetNillableTenantID sets the tenant_id field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableTenantID(i *int) *WebhookEndpointCreate {
+	if i != nil {
+		wec.SetTenantID(*i)
+	}
+	return wec
+}
+
+// SetOwnerID sets the owner_id field.
+func (wec *WebhookEndpointCreate) SetOwnerID(i int) *WebhookEndpointCreate {
+	wec.mutation.SetOwnerID(i)
+	return wec
+}
+
+// SetURL sets the url field.
+func (wec *WebhookEndpointCreate) SetURL(s string) *WebhookEndpointCreate {
+	wec.mutation.SetURL(s)
+	return wec
+}
+
+// SetSecret sets the secret field.
+func (wec *WebhookEndpointCreate) SetSecret(s string) *WebhookEndpointCreate {
+	wec.mutation.SetSecret(s)
+	return wec
+}
+
+// SetEvents sets the events field.
+func (wec *WebhookEndpointCreate) SetEvents(s string) *WebhookEndpointCreate {
+	wec.mutation.SetEvents(s)
+	return wec
+}
+
+// SetNillableEvents sets the events field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableEvents(s *string) *WebhookEndpointCreate {
+	if s != nil {
+		wec.SetEvents(*s)
+	}
+	return wec
+}
+
+// SetDescription sets the description field.
+func (wec *WebhookEndpointCreate) SetDescription(s string) *WebhookEndpointCreate {
+	wec.mutation.SetDescription(s)
+	return wec
+}
+
+// SetNillableDescription sets the description field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableDescription(s *string) *WebhookEndpointCreate {
+	if s != nil {
+		wec.SetDescription(*s)
+	}
+	return wec
+}
+
+// SetEnabled sets the enabled field.
+func (wec *WebhookEndpointCreate) SetEnabled(b bool) *WebhookEndpointCreate {
+	wec.mutation.SetEnabled(b)
+	return wec
+}
+
+// SetNillableEnabled sets the enabled field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableEnabled(b *bool) *WebhookEndpointCreate {
+	if b != nil {
+		wec.SetEnabled(*b)
+	}
+	return wec
+}
+
+// SetFailures sets the failures field.
+func (wec *WebhookEndpointCreate) SetFailures(i int) *WebhookEndpointCreate {
+	wec.mutation.SetFailures(i)
+	return wec
+}
+
+// SetNillableFailures sets the failures field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableFailures(i *int) *WebhookEndpointCreate {
+	if i != nil {
+		wec.SetFailures(*i)
+	}
+	return wec
+}
+
+// SetDisabledAt sets the disabled_at field.
+func (wec *WebhookEndpointCreate) SetDisabledAt(t time.Time) *WebhookEndpointCreate {
+	wec.mutation.SetDisabledAt(t)
+	return wec
+}
+
+// SetNillableDisabledAt sets the disabled_at field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableDisabledAt(t *time.Time) *WebhookEndpointCreate {
+	if t != nil {
+		wec.SetDisabledAt(*t)
+	}
+	return wec
+}
+
+// SetCreatedAt sets the created_at field.
+func (wec *WebhookEndpointCreate) SetCreatedAt(t time.Time) *WebhookEndpointCreate {
+	wec.mutation.SetCreatedAt(t)
+	return wec
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableCreatedAt(t *time.Time) *WebhookEndpointCreate {
+	if t != nil {
+		wec.SetCreatedAt(*t)
+	}
+	return wec
+}
+
+// SetUpdatedAt sets the updated_at field.
+func (wec *WebhookEndpointCreate) SetUpdatedAt(t time.Time) *WebhookEndpointCreate {
+	wec.mutation.SetUpdatedAt(t)
+	return wec
+}
+
+// SetNillableUpdatedAt sets the updated_at field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableUpdatedAt(t *time.Time) *WebhookEndpointCreate {
+	if t != nil {
+		wec.SetUpdatedAt(*t)
+	}
+	return wec
+}
+
+// AddDeliveryIDs adds the deliveries edge to WebhookDelivery by ids.
+func (wec *WebhookEndpointCreate) AddDeliveryIDs(ids ...int) *WebhookEndpointCreate {
+	wec.mutation.AddDeliveryIDs(ids...)
+	return wec
+}
+
+// AddDeliveries adds the deliveries edges to WebhookDelivery.
+func (wec *WebhookEndpointCreate) AddDeliveries(w ...*WebhookDelivery) *WebhookEndpointCreate {
+	ids := make([]int, len(w))
+	for i := range w {
+		ids[i] = w[i].ID
+	}
+	return wec.AddDeliveryIDs(ids...)
+}
+
+// Mutation returns the WebhookEndpointMutation object of the builder.
+func (wec *WebhookEndpointCreate) Mutation() *WebhookEndpointMutation {
+	return wec.mutation
+}
+
+// Save creates the WebhookEndpoint in the database.
+func (wec *WebhookEndpointCreate) Save(ctx context.Context) (*WebhookEndpoint, error) {
+	var (
+		err  error
+		node *WebhookEndpoint
+	)
+	wec.defaults()
+	if len(wec.hooks) == 0 {
+		if err = wec.check(); err != nil {
+			return nil, err
+		}
+		node, err = wec.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*WebhookEndpointMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = wec.check(); err != nil {
+				return nil, err
+			}
+			wec.mutation = mutation
+			node, err = wec.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(wec.hooks) - 1; i >= 0; i-- {
+			mut = wec.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, wec.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (wec *WebhookEndpointCreate) SaveX(ctx context.Context) *WebhookEndpoint {
+	v, err := wec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// defaults sets the default values of the builder before save.
+func (wec *WebhookEndpointCreate) defaults() {
+	if _, ok := wec.mutation.TenantID(); !ok {
+		v := webhookendpoint.DefaultTenantID
+		wec.mutation.SetTenantID(v)
+	}
+	if _, ok := wec.mutation.Events(); !ok {
+		v := webhookendpoint.DefaultEvents
+		wec.mutation.SetEvents(v)
+	}
+	if _, ok := wec.mutation.Description(); !ok {
+		v := webhookendpoint.DefaultDescription
+		wec.mutation.SetDescription(v)
+	}
+	if _, ok := wec.mutation.Enabled(); !ok {
+		v := webhookendpoint.DefaultEnabled
+		wec.mutation.SetEnabled(v)
+	}
+	if _, ok := wec.mutation.Failures(); !ok {
+		v := webhookendpoint.DefaultFailures
+		wec.mutation.SetFailures(v)
+	}
+	if _, ok := wec.mutation.CreatedAt(); !ok {
+		v := webhookendpoint.DefaultCreatedAt()
+		wec.mutation.SetCreatedAt(v)
+	}
+	if _, ok := wec.mutation.UpdatedAt(); !ok {
+		v := webhookendpoint.DefaultUpdatedAt()
+		wec.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (wec *WebhookEndpointCreate) check() error {
+	if _, ok := wec.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New("ent: missing required field \"tenant_id\"")}
+	}
+	if _, ok := wec.mutation.OwnerID(); !ok {
+		return &ValidationError{Name: "owner_id", err: errors.New("ent: missing required field \"owner_id\"")}
+	}
+	if _, ok := wec.mutation.URL(); !ok {
+		return &ValidationError{Name: "url", err: errors.New("ent: missing required field \"url\"")}
+	}
+	if v, ok := wec.mutation.URL(); ok {
+		if err := webhookendpoint.URLValidator(v); err != nil {
+			return &ValidationError{Name: "url", err: fmt.Errorf("ent: validator failed for field \"url\": %w", err)}
+		}
+	}
+	if _, ok := wec.mutation.Secret(); !ok {
+		return &ValidationError{Name: "secret", err: errors.New("ent: missing required field \"secret\"")}
+	}
+	if _, ok := wec.mutation.Events(); !ok {
+		return &ValidationError{Name: "events", err: errors.New("ent: missing required field \"events\"")}
+	}
+	if _, ok := wec.mutation.Description(); !ok {
+		return &ValidationError{Name: "description", err: errors.New("ent: missing required field \"description\"")}
+	}
+	if v, ok := wec.mutation.Description(); ok {
+		if err := webhookendpoint.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf("ent: validator failed for field \"description\": %w", err)}
+		}
+	}
+	if _, ok := wec.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New("ent: missing required field \"enabled\"")}
+	}
+	if _, ok := wec.mutation.Failures(); !ok {
+		return &ValidationError{Name: "failures", err: errors.New("ent: missing required field \"failures\"")}
+	}
+	if _, ok := wec.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New("ent: missing required field \"created_at\"")}
+	}
+	if _, ok := wec.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New("ent: missing required field \"updated_at\"")}
+	}
+	return nil
+}
+
+func (wec *WebhookEndpointCreate) sqlSave(ctx context.Context) (*WebhookEndpoint, error) {
+	_node, _spec := wec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, wec.driver, _spec); err != nil {
+		if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (wec *WebhookEndpointCreate) createSpec() (*WebhookEndpoint, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WebhookEndpoint{config: wec.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: webhookendpoint.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookendpoint.FieldID,
+			},
+		}
+	)
+	if value, ok := wec.mutation.TenantID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldTenantID,
+		})
+		_node.TenantID = value
+	}
+	if value, ok := wec.mutation.OwnerID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldOwnerID,
+		})
+		_node.OwnerID = value
+	}
+	if value, ok := wec.mutation.URL(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldURL,
+		})
+		_node.URL = value
+	}
+	if value, ok := wec.mutation.Secret(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldSecret,
+		})
+		_node.Secret = value
+	}
+	if value, ok := wec.mutation.Events(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldEvents,
+		})
+		_node.Events = value
+	}
+	if value, ok := wec.mutation.Description(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldDescription,
+		})
+		_node.Description = value
+	}
+	if value, ok := wec.mutation.Enabled(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: webhookendpoint.FieldEnabled,
+		})
+		_node.Enabled = value
+	}
+	if value, ok := wec.mutation.Failures(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldFailures,
+		})
+		_node.Failures = value
+	}
+	if value, ok := wec.mutation.DisabledAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldDisabledAt,
+		})
+		_node.DisabledAt = &value
+	}
+	if value, ok := wec.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	if value, ok := wec.mutation.UpdatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldUpdatedAt,
+		})
+		_node.UpdatedAt = value
+	}
+	if nodes := wec.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookdelivery.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// WebhookEndpointCreateBulk is the builder for creating a bulk of WebhookEndpoint entities.
+type WebhookEndpointCreateBulk struct {
+	config
+	builders []*WebhookEndpointCreate
+}
+
+// Save creates the WebhookEndpoint entities in the database.
+func (wecb *WebhookEndpointCreateBulk) Save(ctx context.Context) ([]*WebhookEndpoint, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(wecb.builders))
+	nodes := make([]*WebhookEndpoint, len(wecb.builders))
+	mutators := make([]Mutator, len(wecb.builders))
+	for i := range wecb.builders {
+		func(i int, root context.Context) {
+			builder := wecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WebhookEndpointMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, wecb.builders[i+1].mutation)
+				} else {
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, wecb.driver, &sqlgraph.BatchCreateSpec{Nodes: specs}); err != nil {
+						if cerr, ok := isSQLConstraintError(err); ok {
+							err = cerr
+						}
+					}
+				}
+				mutation.done = true
+				if err != nil {
+					return nil, err
+				}
+				id := specs[i].ID.Value.(int64)
+				nodes[i].ID = int(id)
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, wecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (wecb *WebhookEndpointCreateBulk) SaveX(ctx context.Context) []*WebhookEndpoint {
+	v, err := wecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,108 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/predicate"
+	"go-api/ent/webhookendpoint"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// WebhookEndpointDelete is the builder for deleting a WebhookEndpoint entity.
+type WebhookEndpointDelete struct {
+	config
+	hooks    []Hook
+	mutation *WebhookEndpointMutation
+}
+
+// Where adds a new predicate to the delete builder.
+func (wed *WebhookEndpointDelete) Where(ps ...predicate.WebhookEndpoint) *WebhookEndpointDelete {
+	wed.mutation.predicates = append(wed.mutation.predicates, ps...)
+	return wed
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (wed *WebhookEndpointDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(wed.hooks) == 0 {
+		affected, err = wed.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*WebhookEndpointMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			wed.mutation = mutation
+			affected, err = wed.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(wed.hooks) - 1; i >= 0; i-- {
+			mut = wed.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, wed.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wed *WebhookEndpointDelete) ExecX(ctx context.Context) int {
+	n, err := wed.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (wed *WebhookEndpointDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: webhookendpoint.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookendpoint.FieldID,
+			},
+		},
+	}
+	if ps := wed.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return sqlgraph.DeleteNodes(ctx, wed.driver, _spec)
+}
+
+// WebhookEndpointDeleteOne is the builder for deleting a single WebhookEndpoint entity.
+type WebhookEndpointDeleteOne struct {
+	wed *WebhookEndpointDelete
+}
+
+// Exec executes the deletion query.
+func (wedo *WebhookEndpointDeleteOne) Exec(ctx context.Context) error {
+	n, err := wedo.wed.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{webhookendpoint.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wedo *WebhookEndpointDeleteOne) ExecX(ctx context.Context) {
+	wedo.wed.ExecX(ctx)
+}
@@ -0,0 +1,957 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"go-api/ent/predicate"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"math"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// WebhookEndpointQuery is the builder for querying WebhookEndpoint entities.
+type WebhookEndpointQuery struct {
+	config
+	limit      *int
+	offset     *int
+	order      []OrderFunc
+	unique     []string
+	predicates []predicate.WebhookEndpoint
+	// eager-loading edges.
+	withDeliveries *WebhookDeliveryQuery
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the builder.
+func (weq *WebhookEndpointQuery) Where(ps ...predicate.WebhookEndpoint) *WebhookEndpointQuery {
+	weq.predicates = append(weq.predicates, ps...)
+	return weq
+}
+
+// Limit adds a limit step to the query.
+func (weq *WebhookEndpointQuery) Limit(limit int) *WebhookEndpointQuery {
+	weq.limit = &limit
+	return weq
+}
+
+// Offset adds an offset step to the query.
+func (weq *WebhookEndpointQuery) Offset(offset int) *WebhookEndpointQuery {
+	weq.offset = &offset
+	return weq
+}
+
+// Order adds an order step to the query.
+func (weq *WebhookEndpointQuery) Order(o ...OrderFunc) *WebhookEndpointQuery {
+	weq.order = append(weq.order, o...)
+	return weq
+}
+
+// QueryDeliveries chains the current query on the deliveries edge.
+func (weq *WebhookEndpointQuery) QueryDeliveries() *WebhookDeliveryQuery {
+	query := &WebhookDeliveryQuery{config: weq.config}
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := weq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := weq.sqlQuery()
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(webhookendpoint.Table, webhookendpoint.FieldID, selector),
+			sqlgraph.To(webhookdelivery.Table, webhookdelivery.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, webhookendpoint.DeliveriesTable, webhookendpoint.DeliveriesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(weq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first WebhookEndpoint entity in the query. Returns *NotFoundError when no webhookendpoint was found.
+func (weq *WebhookEndpointQuery) First(ctx context.Context) (*WebhookEndpoint, error) {
+	nodes, err := weq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{webhookendpoint.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (weq *WebhookEndpointQuery) FirstX(ctx context.Context) *WebhookEndpoint {
+	node, err := weq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first WebhookEndpoint id in the query. Returns *NotFoundError when no id was found.
+func (weq *WebhookEndpointQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = weq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{webhookendpoint.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (weq *WebhookEndpointQuery) FirstIDX(ctx context.Context) int {
+	id, err := weq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns the only WebhookEndpoint entity in the query, returns an error if not exactly one entity was returned.
+func (weq *WebhookEndpointQuery) Only(ctx context.Context) (*WebhookEndpoint, error) {
+	nodes, err := weq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{webhookendpoint.Label}
+	default:
+		return nil, &NotSingularError{webhookendpoint.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (weq *WebhookEndpointQuery) OnlyX(ctx context.Context) *WebhookEndpoint {
+	node, err := weq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID returns the only WebhookEndpoint id in the query, returns an error if not exactly one id was returned.
+func (weq *WebhookEndpointQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = weq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = &NotSingularError{webhookendpoint.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (weq *WebhookEndpointQuery) OnlyIDX(ctx context.Context) int {
+	id, err := weq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of WebhookEndpoints.
+func (weq *WebhookEndpointQuery) All(ctx context.Context) ([]*WebhookEndpoint, error) {
+	if err := weq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return weq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (weq *WebhookEndpointQuery) AllX(ctx context.Context) []*WebhookEndpoint {
+	nodes, err := weq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of WebhookEndpoint ids.
+func (weq *WebhookEndpointQuery) IDs(ctx context.Context) ([]int, error) {
+	var ids []int
+	if err := weq.Select(webhookendpoint.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (weq *WebhookEndpointQuery) IDsX(ctx context.Context) []int {
+	ids, err := weq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (weq *WebhookEndpointQuery) Count(ctx context.Context) (int, error) {
+	if err := weq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return weq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (weq *WebhookEndpointQuery) CountX(ctx context.Context) int {
+	count, err := weq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (weq *WebhookEndpointQuery) Exist(ctx context.Context) (bool, error) {
+	if err := weq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return weq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (weq *WebhookEndpointQuery) ExistX(ctx context.Context) bool {
+	exist, err := weq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the query builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (weq *WebhookEndpointQuery) Clone() *WebhookEndpointQuery {
+	if weq == nil {
+		return nil
+	}
+	return &WebhookEndpointQuery{
+		config:         weq.config,
+		limit:          weq.limit,
+		offset:         weq.offset,
+		order:          append([]OrderFunc{}, weq.order...),
+		unique:         append([]string{}, weq.unique...),
+		predicates:     append([]predicate.WebhookEndpoint{}, weq.predicates...),
+		withDeliveries: weq.withDeliveries.Clone(),
+		// clone intermediate query.
+		sql:  weq.sql.Clone(),
+		path: weq.path,
+	}
+}
+
+//	WithDeliveries tells the query-builder to eager-loads the nodes that are connected to
+//
+// the "deliveries" edge. The optional arguments used to configure the query builder of the edge.
+func (weq *WebhookEndpointQuery) WithDeliveries(opts ...func(*WebhookDeliveryQuery)) *WebhookEndpointQuery {
+	query := &WebhookDeliveryQuery{config: weq.config}
+	for _, opt := range opts {
+		opt(query)
+	}
+	weq.withDeliveries = query
+	return weq
+}
+
+// GroupBy used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID int `json:"tenant_id"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.WebhookEndpoint.Query().
+//		GroupBy(webhookendpoint.FieldTenantID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (weq *WebhookEndpointQuery) GroupBy(field string, fields ...string) *WebhookEndpointGroupBy {
+	group := &WebhookEndpointGroupBy{config: weq.config}
+	group.fields = append([]string{field}, fields...)
+	group.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := weq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return weq.sqlQuery(), nil
+	}
+	return group
+}
+
+// Select one or more fields from the given query.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID int `json:"tenant_id"`
+//	}
+//
+//	client.WebhookEndpoint.Query().
+//		Select(webhookendpoint.FieldTenantID).
+//		Scan(ctx, &v)
+func (weq *WebhookEndpointQuery) Select(field string, fields ...string) *WebhookEndpointSelect {
+	selector := &WebhookEndpointSelect{config: weq.config}
+	selector.fields = append([]string{field}, fields...)
+	selector.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := weq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return weq.sqlQuery(), nil
+	}
+	return selector
+}
+
+func (weq *WebhookEndpointQuery) prepareQuery(ctx context.Context) error {
+	if weq.path != nil {
+		prev, err := weq.path(ctx)
+		if err != nil {
+			return err
+		}
+		weq.sql = prev
+	}
+	if err := webhookendpoint.Policy.EvalQuery(ctx, weq); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (weq *WebhookEndpointQuery) sqlAll(ctx context.Context) ([]*WebhookEndpoint, error) {
+	var (
+		nodes       = []*WebhookEndpoint{}
+		_spec       = weq.querySpec()
+		loadedTypes = [1]bool{
+			weq.withDeliveries != nil,
+		}
+	)
+	_spec.ScanValues = func() []interface{} {
+		node := &WebhookEndpoint{config: weq.config}
+		nodes = append(nodes, node)
+		values := node.scanValues()
+		return values
+	}
+	_spec.Assign = func(values ...interface{}) error {
+		if len(nodes) == 0 {
+			return fmt.Errorf("ent: Assign called without calling ScanValues")
+		}
+		node := nodes[len(nodes)-1]
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(values...)
+	}
+	if err := sqlgraph.QueryNodes(ctx, weq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+
+	if query := weq.withDeliveries; query != nil {
+		fks := make([]driver.Value, 0, len(nodes))
+		nodeids := make(map[int]*WebhookEndpoint)
+		for i := range nodes {
+			fks = append(fks, nodes[i].ID)
+			nodeids[nodes[i].ID] = nodes[i]
+			nodes[i].Edges.Deliveries = []*WebhookDelivery{}
+		}
+		query.withFKs = true
+		query.Where(predicate.WebhookDelivery(func(s *sql.Selector) {
+			s.Where(sql.InValues(webhookendpoint.DeliveriesColumn, fks...))
+		}))
+		neighbors, err := query.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			fk := n.webhook_endpoint_deliveries
+			if fk == nil {
+				return nil, fmt.Errorf(`foreign-key "webhook_endpoint_deliveries" is nil for node %v`, n.ID)
+			}
+			node, ok := nodeids[*fk]
+			if !ok {
+				return nil, fmt.Errorf(`unexpected foreign-key "webhook_endpoint_deliveries" returned %v for node %v`, *fk, n.ID)
+			}
+			node.Edges.Deliveries = append(node.Edges.Deliveries, n)
+		}
+	}
+
+	return nodes, nil
+}
+
+func (weq *WebhookEndpointQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := weq.querySpec()
+	return sqlgraph.CountNodes(ctx, weq.driver, _spec)
+}
+
+func (weq *WebhookEndpointQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := weq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ent: check existence: %v", err)
+	}
+	return n > 0, nil
+}
+
+func (weq *WebhookEndpointQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   webhookendpoint.Table,
+			Columns: webhookendpoint.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookendpoint.FieldID,
+			},
+		},
+		From:   weq.sql,
+		Unique: true,
+	}
+	if ps := weq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := weq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := weq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := weq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector, webhookendpoint.ValidColumn)
+			}
+		}
+	}
+	return _spec
+}
+
+func (weq *WebhookEndpointQuery) sqlQuery() *sql.Selector {
+	builder := sql.Dialect(weq.driver.Dialect())
+	t1 := builder.Table(webhookendpoint.Table)
+	selector := builder.Select(t1.Columns(webhookendpoint.Columns...)...).From(t1)
+	if weq.sql != nil {
+		selector = weq.sql
+		selector.Select(selector.Columns(webhookendpoint.Columns...)...)
+	}
+	for _, p := range weq.predicates {
+		p(selector)
+	}
+	for _, p := range weq.order {
+		p(selector, webhookendpoint.ValidColumn)
+	}
+	if offset := weq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := weq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// WebhookEndpointGroupBy is the builder for group-by WebhookEndpoint entities.
+type WebhookEndpointGroupBy struct {
+	config
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (wegb *WebhookEndpointGroupBy) Aggregate(fns ...AggregateFunc) *WebhookEndpointGroupBy {
+	wegb.fns = append(wegb.fns, fns...)
+	return wegb
+}
+
+// Scan applies the group-by query and scan the result into the given value.
+func (wegb *WebhookEndpointGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := wegb.path(ctx)
+	if err != nil {
+		return err
+	}
+	wegb.sql = query
+	return wegb.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) ScanX(ctx context.Context, v interface{}) {
+	if err := wegb.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from group-by. It is only allowed when querying group-by with one field.
+func (wegb *WebhookEndpointGroupBy) Strings(ctx context.Context) ([]string, error) {
+	if len(wegb.fields) > 1 {
+		return nil, errors.New("ent: WebhookEndpointGroupBy.Strings is not achievable when grouping more than 1 field")
+	}
+	var v []string
+	if err := wegb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) StringsX(ctx context.Context) []string {
+	v, err := wegb.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from group-by. It is only allowed when querying group-by with one field.
+func (wegb *WebhookEndpointGroupBy) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = wegb.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = fmt.Errorf("ent: WebhookEndpointGroupBy.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) StringX(ctx context.Context) string {
+	v, err := wegb.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from group-by. It is only allowed when querying group-by with one field.
+func (wegb *WebhookEndpointGroupBy) Ints(ctx context.Context) ([]int, error) {
+	if len(wegb.fields) > 1 {
+		return nil, errors.New("ent: WebhookEndpointGroupBy.Ints is not achievable when grouping more than 1 field")
+	}
+	var v []int
+	if err := wegb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) IntsX(ctx context.Context) []int {
+	v, err := wegb.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from group-by. It is only allowed when querying group-by with one field.
+func (wegb *WebhookEndpointGroupBy) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = wegb.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = fmt.Errorf("ent: WebhookEndpointGroupBy.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) IntX(ctx context.Context) int {
+	v, err := wegb.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from group-by. It is only allowed when querying group-by with one field.
+func (wegb *WebhookEndpointGroupBy) Float64s(ctx context.Context) ([]float64, error) {
+	if len(wegb.fields) > 1 {
+		return nil, errors.New("ent: WebhookEndpointGroupBy.Float64s is not achievable when grouping more than 1 field")
+	}
+	var v []float64
+	if err := wegb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) Float64sX(ctx context.Context) []float64 {
+	v, err := wegb.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from group-by. It is only allowed when querying group-by with one field.
+func (wegb *WebhookEndpointGroupBy) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = wegb.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = fmt.Errorf("ent: WebhookEndpointGroupBy.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) Float64X(ctx context.Context) float64 {
+	v, err := wegb.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from group-by. It is only allowed when querying group-by with one field.
+func (wegb *WebhookEndpointGroupBy) Bools(ctx context.Context) ([]bool, error) {
+	if len(wegb.fields) > 1 {
+		return nil, errors.New("ent: WebhookEndpointGroupBy.Bools is not achievable when grouping more than 1 field")
+	}
+	var v []bool
+	if err := wegb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) BoolsX(ctx context.Context) []bool {
+	v, err := wegb.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from group-by. It is only allowed when querying group-by with one field.
+func (wegb *WebhookEndpointGroupBy) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = wegb.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = fmt.Errorf("ent: WebhookEndpointGroupBy.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (wegb *WebhookEndpointGroupBy) BoolX(ctx context.Context) bool {
+	v, err := wegb.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (wegb *WebhookEndpointGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range wegb.fields {
+		if !webhookendpoint.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := wegb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := wegb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (wegb *WebhookEndpointGroupBy) sqlQuery() *sql.Selector {
+	selector := wegb.sql
+	columns := make([]string, 0, len(wegb.fields)+len(wegb.fns))
+	columns = append(columns, wegb.fields...)
+	for _, fn := range wegb.fns {
+		columns = append(columns, fn(selector, webhookendpoint.ValidColumn))
+	}
+	return selector.Select(columns...).GroupBy(wegb.fields...)
+}
+
+// WebhookEndpointSelect is the builder for select fields of WebhookEndpoint entities.
+type WebhookEndpointSelect struct {
+	config
+	fields []string
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Scan applies the selector query and scan the result into the given value.
+func (wes *WebhookEndpointSelect) Scan(ctx context.Context, v interface{}) error {
+	query, err := wes.path(ctx)
+	if err != nil {
+		return err
+	}
+	wes.sql = query
+	return wes.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) ScanX(ctx context.Context, v interface{}) {
+	if err := wes.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from selector. It is only allowed when selecting one field.
+func (wes *WebhookEndpointSelect) Strings(ctx context.Context) ([]string, error) {
+	if len(wes.fields) > 1 {
+		return nil, errors.New("ent: WebhookEndpointSelect.Strings is not achievable when selecting more than 1 field")
+	}
+	var v []string
+	if err := wes.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) StringsX(ctx context.Context) []string {
+	v, err := wes.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from selector. It is only allowed when selecting one field.
+func (wes *WebhookEndpointSelect) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = wes.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = fmt.Errorf("ent: WebhookEndpointSelect.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) StringX(ctx context.Context) string {
+	v, err := wes.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from selector. It is only allowed when selecting one field.
+func (wes *WebhookEndpointSelect) Ints(ctx context.Context) ([]int, error) {
+	if len(wes.fields) > 1 {
+		return nil, errors.New("ent: WebhookEndpointSelect.Ints is not achievable when selecting more than 1 field")
+	}
+	var v []int
+	if err := wes.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) IntsX(ctx context.Context) []int {
+	v, err := wes.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from selector. It is only allowed when selecting one field.
+func (wes *WebhookEndpointSelect) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = wes.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = fmt.Errorf("ent: WebhookEndpointSelect.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) IntX(ctx context.Context) int {
+	v, err := wes.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from selector. It is only allowed when selecting one field.
+func (wes *WebhookEndpointSelect) Float64s(ctx context.Context) ([]float64, error) {
+	if len(wes.fields) > 1 {
+		return nil, errors.New("ent: WebhookEndpointSelect.Float64s is not achievable when selecting more than 1 field")
+	}
+	var v []float64
+	if err := wes.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) Float64sX(ctx context.Context) []float64 {
+	v, err := wes.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from selector. It is only allowed when selecting one field.
+func (wes *WebhookEndpointSelect) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = wes.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = fmt.Errorf("ent: WebhookEndpointSelect.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) Float64X(ctx context.Context) float64 {
+	v, err := wes.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from selector. It is only allowed when selecting one field.
+func (wes *WebhookEndpointSelect) Bools(ctx context.Context) ([]bool, error) {
+	if len(wes.fields) > 1 {
+		return nil, errors.New("ent: WebhookEndpointSelect.Bools is not achievable when selecting more than 1 field")
+	}
+	var v []bool
+	if err := wes.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) BoolsX(ctx context.Context) []bool {
+	v, err := wes.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from selector. It is only allowed when selecting one field.
+func (wes *WebhookEndpointSelect) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = wes.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{webhookendpoint.Label}
+	default:
+		err = fmt.Errorf("ent: WebhookEndpointSelect.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (wes *WebhookEndpointSelect) BoolX(ctx context.Context) bool {
+	v, err := wes.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (wes *WebhookEndpointSelect) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range wes.fields {
+		if !webhookendpoint.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for selection", f)}
+		}
+	}
+	rows := &sql.Rows{}
+	query, args := wes.sqlQuery().Query()
+	if err := wes.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (wes *WebhookEndpointSelect) sqlQuery() sql.Querier {
+	selector := wes.sql
+	selector.Select(selector.Columns(wes.fields...)...)
+	return selector
+}
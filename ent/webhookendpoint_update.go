@@ -0,0 +1,883 @@
+// Code generated by entc, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent/predicate"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+	"github.com/facebook/ent/dialect/sql/sqlgraph"
+	"github.com/facebook/ent/schema/field"
+)
+
+// WebhookEndpointUpdate is the builder for updating WebhookEndpoint entities.
+type WebhookEndpointUpdate struct {
+	config
+	hooks    []Hook
+	mutation *WebhookEndpointMutation
+}
+
+// Where adds a new predicate for the builder.
+func (weu *WebhookEndpointUpdate) Where(ps ...predicate.WebhookEndpoint) *WebhookEndpointUpdate {
+	weu.mutation.predicates = append(weu.mutation.predicates, ps...)
+	return weu
+}
+
+// SetOwnerID sets the owner_id field.
+func (weu *WebhookEndpointUpdate) SetOwnerID(i int) *WebhookEndpointUpdate {
+	weu.mutation.ResetOwnerID()
+	weu.mutation.SetOwnerID(i)
+	return weu
+}
+
+// AddOwnerID adds i to owner_id.
+func (weu *WebhookEndpointUpdate) AddOwnerID(i int) *WebhookEndpointUpdate {
+	weu.mutation.AddOwnerID(i)
+	return weu
+}
+
+// SetURL sets the url field.
+func (weu *WebhookEndpointUpdate) SetURL(s string) *WebhookEndpointUpdate {
+	weu.mutation.SetURL(s)
+	return weu
+}
+
+// SetSecret sets the secret field.
+func (weu *WebhookEndpointUpdate) SetSecret(s string) *WebhookEndpointUpdate {
+	weu.mutation.SetSecret(s)
+	return weu
+}
+
+// SetEvents sets the events field.
+func (weu *WebhookEndpointUpdate) SetEvents(s string) *WebhookEndpointUpdate {
+	weu.mutation.SetEvents(s)
+	return weu
+}
+
+// SetNillableEvents sets the events field if the given value is not nil.
+func (weu *WebhookEndpointUpdate) SetNillableEvents(s *string) *WebhookEndpointUpdate {
+	if s != nil {
+		weu.SetEvents(*s)
+	}
+	return weu
+}
+
+// SetDescription sets the description field.
+func (weu *WebhookEndpointUpdate) SetDescription(s string) *WebhookEndpointUpdate {
+	weu.mutation.SetDescription(s)
+	return weu
+}
+
+// SetNillableDescription sets the description field if the given value is not nil.
+func (weu *WebhookEndpointUpdate) SetNillableDescription(s *string) *WebhookEndpointUpdate {
+	if s != nil {
+		weu.SetDescription(*s)
+	}
+	return weu
+}
+
+// SetEnabled sets the enabled field.
+func (weu *WebhookEndpointUpdate) SetEnabled(b bool) *WebhookEndpointUpdate {
+	weu.mutation.SetEnabled(b)
+	return weu
+}
+
+// SetNillableEnabled sets the enabled field if the given value is not nil.
+func (weu *WebhookEndpointUpdate) SetNillableEnabled(b *bool) *WebhookEndpointUpdate {
+	if b != nil {
+		weu.SetEnabled(*b)
+	}
+	return weu
+}
+
+// SetFailures sets the failures field.
+func (weu *WebhookEndpointUpdate) SetFailures(i int) *WebhookEndpointUpdate {
+	weu.mutation.ResetFailures()
+	weu.mutation.SetFailures(i)
+	return weu
+}
+
+// SetNillableFailures sets the failures field if the given value is not nil.
+func (weu *WebhookEndpointUpdate) SetNillableFailures(i *int) *WebhookEndpointUpdate {
+	if i != nil {
+		weu.SetFailures(*i)
+	}
+	return weu
+}
+
+// AddFailures adds i to failures.
+func (weu *WebhookEndpointUpdate) AddFailures(i int) *WebhookEndpointUpdate {
+	weu.mutation.AddFailures(i)
+	return weu
+}
+
+// SetDisabledAt sets the disabled_at field.
+func (weu *WebhookEndpointUpdate) SetDisabledAt(t time.Time) *WebhookEndpointUpdate {
+	weu.mutation.SetDisabledAt(t)
+	return weu
+}
+
+// SetNillableDisabledAt sets the disabled_at field if the given value is not nil.
+func (weu *WebhookEndpointUpdate) SetNillableDisabledAt(t *time.Time) *WebhookEndpointUpdate {
+	if t != nil {
+		weu.SetDisabledAt(*t)
+	}
+	return weu
+}
+
+// ClearDisabledAt clears the value of disabled_at.
+func (weu *WebhookEndpointUpdate) ClearDisabledAt() *WebhookEndpointUpdate {
+	weu.mutation.ClearDisabledAt()
+	return weu
+}
+
+// SetCreatedAt sets the created_at field.
+func (weu *WebhookEndpointUpdate) SetCreatedAt(t time.Time) *WebhookEndpointUpdate {
+	weu.mutation.SetCreatedAt(t)
+	return weu
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (weu *WebhookEndpointUpdate) SetNillableCreatedAt(t *time.Time) *WebhookEndpointUpdate {
+	if t != nil {
+		weu.SetCreatedAt(*t)
+	}
+	return weu
+}
+
+// SetUpdatedAt sets the updated_at field.
+func (weu *WebhookEndpointUpdate) SetUpdatedAt(t time.Time) *WebhookEndpointUpdate {
+	weu.mutation.SetUpdatedAt(t)
+	return weu
+}
+
+// AddDeliveryIDs adds the deliveries edge to WebhookDelivery by ids.
+func (weu *WebhookEndpointUpdate) AddDeliveryIDs(ids ...int) *WebhookEndpointUpdate {
+	weu.mutation.AddDeliveryIDs(ids...)
+	return weu
+}
+
+// AddDeliveries adds the deliveries edges to WebhookDelivery.
+func (weu *WebhookEndpointUpdate) AddDeliveries(w ...*WebhookDelivery) *WebhookEndpointUpdate {
+	ids := make([]int, len(w))
+	for i := range w {
+		ids[i] = w[i].ID
+	}
+	return weu.AddDeliveryIDs(ids...)
+}
+
+// Mutation returns the WebhookEndpointMutation object of the builder.
+func (weu *WebhookEndpointUpdate) Mutation() *WebhookEndpointMutation {
+	return weu.mutation
+}
+
+// ClearDeliveries clears all "deliveries" edges to type WebhookDelivery.
+func (weu *WebhookEndpointUpdate) ClearDeliveries() *WebhookEndpointUpdate {
+	weu.mutation.ClearDeliveries()
+	return weu
+}
+
+// RemoveDeliveryIDs removes the deliveries edge to WebhookDelivery by ids.
+func (weu *WebhookEndpointUpdate) RemoveDeliveryIDs(ids ...int) *WebhookEndpointUpdate {
+	weu.mutation.RemoveDeliveryIDs(ids...)
+	return weu
+}
+
+// RemoveDeliveries removes deliveries edges to WebhookDelivery.
+func (weu *WebhookEndpointUpdate) RemoveDeliveries(w ...*WebhookDelivery) *WebhookEndpointUpdate {
+	ids := make([]int, len(w))
+	for i := range w {
+		ids[i] = w[i].ID
+	}
+	return weu.RemoveDeliveryIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (weu *WebhookEndpointUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	weu.defaults()
+	if len(weu.hooks) == 0 {
+		if err = weu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = weu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*WebhookEndpointMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = weu.check(); err != nil {
+				return 0, err
+			}
+			weu.mutation = mutation
+			affected, err = weu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(weu.hooks) - 1; i >= 0; i-- {
+			mut = weu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, weu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (weu *WebhookEndpointUpdate) SaveX(ctx context.Context) int {
+	affected, err := weu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (weu *WebhookEndpointUpdate) Exec(ctx context.Context) error {
+	_, err := weu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (weu *WebhookEndpointUpdate) ExecX(ctx context.Context) {
+	if err := weu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (weu *WebhookEndpointUpdate) defaults() {
+	if _, ok := weu.mutation.UpdatedAt(); !ok {
+		v := webhookendpoint.UpdateDefaultUpdatedAt()
+		weu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (weu *WebhookEndpointUpdate) check() error {
+	if v, ok := weu.mutation.URL(); ok {
+		if err := webhookendpoint.URLValidator(v); err != nil {
+			return &ValidationError{Name: "url", err: fmt.Errorf("ent: validator failed for field \"url\": %w", err)}
+		}
+	}
+	if v, ok := weu.mutation.Description(); ok {
+		if err := webhookendpoint.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf("ent: validator failed for field \"description\": %w", err)}
+		}
+	}
+	return nil
+}
+
+func (weu *WebhookEndpointUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   webhookendpoint.Table,
+			Columns: webhookendpoint.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookendpoint.FieldID,
+			},
+		},
+	}
+	if ps := weu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := weu.mutation.OwnerID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldOwnerID,
+		})
+	}
+	if value, ok := weu.mutation.AddedOwnerID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldOwnerID,
+		})
+	}
+	if value, ok := weu.mutation.URL(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldURL,
+		})
+	}
+	if value, ok := weu.mutation.Secret(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldSecret,
+		})
+	}
+	if value, ok := weu.mutation.Events(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldEvents,
+		})
+	}
+	if value, ok := weu.mutation.Description(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldDescription,
+		})
+	}
+	if value, ok := weu.mutation.Enabled(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: webhookendpoint.FieldEnabled,
+		})
+	}
+	if value, ok := weu.mutation.Failures(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldFailures,
+		})
+	}
+	if value, ok := weu.mutation.AddedFailures(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldFailures,
+		})
+	}
+	if value, ok := weu.mutation.DisabledAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldDisabledAt,
+		})
+	}
+	if weu.mutation.DisabledAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: webhookendpoint.FieldDisabledAt,
+		})
+	}
+	if value, ok := weu.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldCreatedAt,
+		})
+	}
+	if value, ok := weu.mutation.UpdatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldUpdatedAt,
+		})
+	}
+	if weu.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookdelivery.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := weu.mutation.RemovedDeliveriesIDs(); len(nodes) > 0 && !weu.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookdelivery.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := weu.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookdelivery.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, weu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{webhookendpoint.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// WebhookEndpointUpdateOne is the builder for updating a single WebhookEndpoint entity.
+type WebhookEndpointUpdateOne struct {
+	config
+	hooks    []Hook
+	mutation *WebhookEndpointMutation
+}
+
+// SetOwnerID sets the owner_id field.
+func (weuo *WebhookEndpointUpdateOne) SetOwnerID(i int) *WebhookEndpointUpdateOne {
+	weuo.mutation.ResetOwnerID()
+	weuo.mutation.SetOwnerID(i)
+	return weuo
+}
+
+// AddOwnerID adds i to owner_id.
+func (weuo *WebhookEndpointUpdateOne) AddOwnerID(i int) *WebhookEndpointUpdateOne {
+	weuo.mutation.AddOwnerID(i)
+	return weuo
+}
+
+// SetURL sets the url field.
+func (weuo *WebhookEndpointUpdateOne) SetURL(s string) *WebhookEndpointUpdateOne {
+	weuo.mutation.SetURL(s)
+	return weuo
+}
+
+// SetSecret sets the secret field.
+func (weuo *WebhookEndpointUpdateOne) SetSecret(s string) *WebhookEndpointUpdateOne {
+	weuo.mutation.SetSecret(s)
+	return weuo
+}
+
+// SetEvents sets the events field.
+func (weuo *WebhookEndpointUpdateOne) SetEvents(s string) *WebhookEndpointUpdateOne {
+	weuo.mutation.SetEvents(s)
+	return weuo
+}
+
+// SetNillableEvents sets the events field if the given value is not nil.
+func (weuo *WebhookEndpointUpdateOne) SetNillableEvents(s *string) *WebhookEndpointUpdateOne {
+	if s != nil {
+		weuo.SetEvents(*s)
+	}
+	return weuo
+}
+
+// SetDescription sets the description field.
+func (weuo *WebhookEndpointUpdateOne) SetDescription(s string) *WebhookEndpointUpdateOne {
+	weuo.mutation.SetDescription(s)
+	return weuo
+}
+
+// SetNillableDescription sets the description field if the given value is not nil.
+func (weuo *WebhookEndpointUpdateOne) SetNillableDescription(s *string) *WebhookEndpointUpdateOne {
+	if s != nil {
+		weuo.SetDescription(*s)
+	}
+	return weuo
+}
+
+// SetEnabled sets the enabled field.
+func (weuo *WebhookEndpointUpdateOne) SetEnabled(b bool) *WebhookEndpointUpdateOne {
+	weuo.mutation.SetEnabled(b)
+	return weuo
+}
+
+// SetNillableEnabled sets the enabled field if the given value is not nil.
+func (weuo *WebhookEndpointUpdateOne) SetNillableEnabled(b *bool) *WebhookEndpointUpdateOne {
+	if b != nil {
+		weuo.SetEnabled(*b)
+	}
+	return weuo
+}
+
+// SetFailures sets the failures field.
+func (weuo *WebhookEndpointUpdateOne) SetFailures(i int) *WebhookEndpointUpdateOne {
+	weuo.mutation.ResetFailures()
+	weuo.mutation.SetFailures(i)
+	return weuo
+}
+
+// SetNillableFailures sets the failures field if the given value is not nil.
+func (weuo *WebhookEndpointUpdateOne) SetNillableFailures(i *int) *WebhookEndpointUpdateOne {
+	if i != nil {
+		weuo.SetFailures(*i)
+	}
+	return weuo
+}
+
+// AddFailures adds i to failures.
+func (weuo *WebhookEndpointUpdateOne) AddFailures(i int) *WebhookEndpointUpdateOne {
+	weuo.mutation.AddFailures(i)
+	return weuo
+}
+
+// SetDisabledAt sets the disabled_at field.
+func (weuo *WebhookEndpointUpdateOne) SetDisabledAt(t time.Time) *WebhookEndpointUpdateOne {
+	weuo.mutation.SetDisabledAt(t)
+	return weuo
+}
+
+// SetNillableDisabledAt sets the disabled_at field if the given value is not nil.
+func (weuo *WebhookEndpointUpdateOne) SetNillableDisabledAt(t *time.Time) *WebhookEndpointUpdateOne {
+	if t != nil {
+		weuo.SetDisabledAt(*t)
+	}
+	return weuo
+}
+
+// ClearDisabledAt clears the value of disabled_at.
+func (weuo *WebhookEndpointUpdateOne) ClearDisabledAt() *WebhookEndpointUpdateOne {
+	weuo.mutation.ClearDisabledAt()
+	return weuo
+}
+
+// SetCreatedAt sets the created_at field.
+func (weuo *WebhookEndpointUpdateOne) SetCreatedAt(t time.Time) *WebhookEndpointUpdateOne {
+	weuo.mutation.SetCreatedAt(t)
+	return weuo
+}
+
+// SetNillableCreatedAt sets the created_at field if the given value is not nil.
+func (weuo *WebhookEndpointUpdateOne) SetNillableCreatedAt(t *time.Time) *WebhookEndpointUpdateOne {
+	if t != nil {
+		weuo.SetCreatedAt(*t)
+	}
+	return weuo
+}
+
+// SetUpdatedAt sets the updated_at field.
+func (weuo *WebhookEndpointUpdateOne) SetUpdatedAt(t time.Time) *WebhookEndpointUpdateOne {
+	weuo.mutation.SetUpdatedAt(t)
+	return weuo
+}
+
+// AddDeliveryIDs adds the deliveries edge to WebhookDelivery by ids.
+func (weuo *WebhookEndpointUpdateOne) AddDeliveryIDs(ids ...int) *WebhookEndpointUpdateOne {
+	weuo.mutation.AddDeliveryIDs(ids...)
+	return weuo
+}
+
+// AddDeliveries adds the deliveries edges to WebhookDelivery.
+func (weuo *WebhookEndpointUpdateOne) AddDeliveries(w ...*WebhookDelivery) *WebhookEndpointUpdateOne {
+	ids := make([]int, len(w))
+	for i := range w {
+		ids[i] = w[i].ID
+	}
+	return weuo.AddDeliveryIDs(ids...)
+}
+
+// Mutation returns the WebhookEndpointMutation object of the builder.
+func (weuo *WebhookEndpointUpdateOne) Mutation() *WebhookEndpointMutation {
+	return weuo.mutation
+}
+
+// ClearDeliveries clears all "deliveries" edges to type WebhookDelivery.
+func (weuo *WebhookEndpointUpdateOne) ClearDeliveries() *WebhookEndpointUpdateOne {
+	weuo.mutation.ClearDeliveries()
+	return weuo
+}
+
+// RemoveDeliveryIDs removes the deliveries edge to WebhookDelivery by ids.
+func (weuo *WebhookEndpointUpdateOne) RemoveDeliveryIDs(ids ...int) *WebhookEndpointUpdateOne {
+	weuo.mutation.RemoveDeliveryIDs(ids...)
+	return weuo
+}
+
+// RemoveDeliveries removes deliveries edges to WebhookDelivery.
+func (weuo *WebhookEndpointUpdateOne) RemoveDeliveries(w ...*WebhookDelivery) *WebhookEndpointUpdateOne {
+	ids := make([]int, len(w))
+	for i := range w {
+		ids[i] = w[i].ID
+	}
+	return weuo.RemoveDeliveryIDs(ids...)
+}
+
+// Save executes the query and returns the updated entity.
+func (weuo *WebhookEndpointUpdateOne) Save(ctx context.Context) (*WebhookEndpoint, error) {
+	var (
+		err  error
+		node *WebhookEndpoint
+	)
+	weuo.defaults()
+	if len(weuo.hooks) == 0 {
+		if err = weuo.check(); err != nil {
+			return nil, err
+		}
+		node, err = weuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*WebhookEndpointMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = weuo.check(); err != nil {
+				return nil, err
+			}
+			weuo.mutation = mutation
+			node, err = weuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(weuo.hooks) - 1; i >= 0; i-- {
+			mut = weuo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, weuo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (weuo *WebhookEndpointUpdateOne) SaveX(ctx context.Context) *WebhookEndpoint {
+	node, err := weuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (weuo *WebhookEndpointUpdateOne) Exec(ctx context.Context) error {
+	_, err := weuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (weuo *WebhookEndpointUpdateOne) ExecX(ctx context.Context) {
+	if err := weuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (weuo *WebhookEndpointUpdateOne) defaults() {
+	if _, ok := weuo.mutation.UpdatedAt(); !ok {
+		v := webhookendpoint.UpdateDefaultUpdatedAt()
+		weuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (weuo *WebhookEndpointUpdateOne) check() error {
+	if v, ok := weuo.mutation.URL(); ok {
+		if err := webhookendpoint.URLValidator(v); err != nil {
+			return &ValidationError{Name: "url", err: fmt.Errorf("ent: validator failed for field \"url\": %w", err)}
+		}
+	}
+	if v, ok := weuo.mutation.Description(); ok {
+		if err := webhookendpoint.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf("ent: validator failed for field \"description\": %w", err)}
+		}
+	}
+	return nil
+}
+
+func (weuo *WebhookEndpointUpdateOne) sqlSave(ctx context.Context) (_node *WebhookEndpoint, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   webhookendpoint.Table,
+			Columns: webhookendpoint.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: webhookendpoint.FieldID,
+			},
+		},
+	}
+	id, ok := weuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "ID", err: fmt.Errorf("missing WebhookEndpoint.ID for update")}
+	}
+	_spec.Node.ID.Value = id
+	if value, ok := weuo.mutation.OwnerID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldOwnerID,
+		})
+	}
+	if value, ok := weuo.mutation.AddedOwnerID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldOwnerID,
+		})
+	}
+	if value, ok := weuo.mutation.URL(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldURL,
+		})
+	}
+	if value, ok := weuo.mutation.Secret(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldSecret,
+		})
+	}
+	if value, ok := weuo.mutation.Events(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldEvents,
+		})
+	}
+	if value, ok := weuo.mutation.Description(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: webhookendpoint.FieldDescription,
+		})
+	}
+	if value, ok := weuo.mutation.Enabled(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: webhookendpoint.FieldEnabled,
+		})
+	}
+	if value, ok := weuo.mutation.Failures(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldFailures,
+		})
+	}
+	if value, ok := weuo.mutation.AddedFailures(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: webhookendpoint.FieldFailures,
+		})
+	}
+	if value, ok := weuo.mutation.DisabledAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldDisabledAt,
+		})
+	}
+	if weuo.mutation.DisabledAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: webhookendpoint.FieldDisabledAt,
+		})
+	}
+	if value, ok := weuo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldCreatedAt,
+		})
+	}
+	if value, ok := weuo.mutation.UpdatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: webhookendpoint.FieldUpdatedAt,
+		})
+	}
+	if weuo.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookdelivery.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := weuo.mutation.RemovedDeliveriesIDs(); len(nodes) > 0 && !weuo.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookdelivery.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := weuo.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: webhookdelivery.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &WebhookEndpoint{config: weuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues()
+	if err = sqlgraph.UpdateNode(ctx, weuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{webhookendpoint.Label}
+		} else if cerr, ok := isSQLConstraintError(err); ok {
+			err = cerr
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -0,0 +1,25 @@
+// Package entx ent schema 中通用 mixin 使用的 context 与错误
+//
+// 服务层通过它控制软删除的过滤并识别版本冲突, 不需要引用 schema 包
+package entx
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrVersionConflict 更新时数据已被他人修改, 版本号与读取时不一致
+var ErrVersionConflict = errors.New("ent: version conflict, the row was modified concurrently")
+
+type includeDeletedKey struct{}
+
+// IncludeDeleted 返回查询与修改包含软删除数据的 context
+func IncludeDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// DeletedIncluded 查询与修改是否包含软删除的数据
+func DeletedIncluded(ctx context.Context) bool {
+	included, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return included
+}
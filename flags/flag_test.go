@@ -0,0 +1,76 @@
+package flags
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	f := Flag{Name: "new_login", Enabled: true, Rollout: 0, Allow: []uint{7}}
+	if f.Evaluate(7) != VariantOn {
+		t.Fatal("allowlisted user not included")
+	}
+	if f.Evaluate(8) != "" {
+		t.Fatal("rollout 0 included a user")
+	}
+
+	f.Rollout = 100
+	if f.Evaluate(0) != VariantOn {
+		t.Fatal("full rollout excluded anonymous user")
+	}
+	f.Enabled = false
+	if f.Evaluate(7) != "" {
+		t.Fatal("disabled flag included allowlisted user")
+	}
+}
+
+func TestRolloutIsStableAndMonotonic(t *testing.T) {
+	const users = 20000
+	small := Flag{Name: "checkout", Enabled: true, Rollout: 10}
+	large := small
+	large.Rollout = 25
+
+	in := 0
+	for id := uint(1); id <= users; id++ {
+		if small.Evaluate(id) != small.Evaluate(id) {
+			t.Fatalf("user %d not stable", id)
+		}
+		if small.Evaluate(id) != "" && large.Evaluate(id) == "" {
+			t.Fatalf("user %d dropped when rollout increased", id)
+		}
+		if large.Evaluate(id) != "" {
+			in++
+		}
+	}
+	if ratio := float64(in) / users; math.Abs(ratio-0.25) > 0.02 {
+		t.Fatalf("rollout ratio %.3f, want about 0.25", ratio)
+	}
+	if small.Evaluate(0) != "" {
+		t.Fatal("partial rollout included anonymous user")
+	}
+}
+
+func TestVariants(t *testing.T) {
+	f := Flag{Name: "theme", Enabled: true, Rollout: 100, Variants: []Variant{{"blue", 3}, {"green", 1}, {"off", 0}}}
+	if err := f.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]int{}
+	for id := uint(1); id <= 8000; id++ {
+		counts[f.Evaluate(id)]++
+	}
+	if counts["off"] != 0 || math.Abs(float64(counts["blue"])/8000-0.75) > 0.03 {
+		t.Fatalf("unexpected distribution %v", counts)
+	}
+
+	for _, bad := range []Flag{
+		{Name: "Bad Name"},
+		{Name: "x", Rollout: 101},
+		{Name: "x", Variants: []Variant{{"a", 1}, {"a", 1}}},
+		{Name: "x", Variants: []Variant{{"a", 0}}},
+	} {
+		if bad.Validate() == nil {
+			t.Errorf("%+v passed validation", bad)
+		}
+	}
+}
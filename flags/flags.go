@@ -0,0 +1,188 @@
+// Package flags 功能开关, 支持按用户 id 百分比灰度, 白名单与多变体
+//
+// 开关保存在 redis 或 yaml 文件中, 每个实例在内存中缓存一份,
+// 通过 redis pub/sub 通知其他实例刷新, 另有定时刷新兜底
+package flags
+
+import (
+	"context"
+	"go-api/cache"
+	"go-api/middleware"
+	"go-api/util"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+// Manager 开关的内存缓存
+type Manager struct {
+	mu      sync.RWMutex
+	flags   map[string]Flag
+	store   Store
+	redis   redis.UniversalClient
+	channel string
+}
+
+// NewManager 创建 Manager, rdb 为 nil 时不通知其他实例
+func NewManager(store Store, rdb redis.UniversalClient, channel string) *Manager {
+	return &Manager{
+		flags:   make(map[string]Flag),
+		store:   store,
+		redis:   rdb,
+		channel: channel,
+	}
+}
+
+// Default 默认 Manager, 由 Init 初始化, 之前所有开关均为关闭
+var Default = NewManager(nil, nil, "")
+
+// Init 初始化默认 Manager 并开始接收刷新通知
+// FLAGS_FILE 设置时从 yaml 文件读取开关(只读), 否则保存在 redis 的 FLAGS_KEY 中
+// FLAGS_REFRESH 定时刷新间隔秒数, 默认为 30
+func Init(rdb redis.UniversalClient) {
+	var store Store
+	if path := os.Getenv("FLAGS_FILE"); path != "" {
+		store = NewFileStore(path)
+	} else {
+		key := os.Getenv("FLAGS_KEY")
+		if key == "" {
+			key = "flags"
+		}
+		store = NewRedisStore(rdb, cache.Prefixed(key))
+	}
+	Default = NewManager(store, rdb, cache.Prefixed("flags:changed"))
+	if err := Default.Load(context.Background()); err != nil {
+		util.Log().Error("加载功能开关失败 %v", err)
+	}
+
+	interval, err := strconv.Atoi(os.Getenv("FLAGS_REFRESH"))
+	if err != nil || interval <= 0 {
+		interval = 30
+	}
+	go Default.Run(context.Background(), time.Duration(interval)*time.Second)
+}
+
+// Load 从存储重新加载全部开关, 定义不合法的开关会被跳过
+func (m *Manager) Load(ctx context.Context) error {
+	if m.store == nil {
+		return nil
+	}
+	list, err := m.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	flags := make(map[string]Flag, len(list))
+	for _, f := range list {
+		if err := f.Validate(); err != nil {
+			util.Log().Warning("跳过功能开关 %v", err)
+			continue
+		}
+		flags[f.Name] = f
+	}
+	m.mu.Lock()
+	m.flags = flags
+	m.mu.Unlock()
+	return nil
+}
+
+// Run 收到其他实例的修改通知或每隔 interval 重新加载, ctx 取消后退出
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	var changed <-chan *redis.Message
+	if m.redis != nil {
+		sub := m.redis.Subscribe(ctx, m.channel)
+		defer sub.Close()
+		changed = sub.Channel()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		case <-ticker.C:
+		}
+		if err := m.Load(ctx); err != nil && ctx.Err() == nil {
+			util.Log().Warning("刷新功能开关失败 %v", err)
+		}
+	}
+}
+
+// Save 保存开关并通知所有实例刷新
+func (m *Manager) Save(ctx context.Context, f Flag) error {
+	if m.store == nil {
+		return ErrReadOnly
+	}
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	f.UpdatedAt = util.Now().Unix()
+	if err := m.store.Save(ctx, f); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.flags[f.Name] = f
+	m.mu.Unlock()
+	if m.redis != nil {
+		return m.redis.Publish(ctx, m.channel, f.Name).Err()
+	}
+	return nil
+}
+
+// Get 查询开关
+func (m *Manager) Get(name string) (Flag, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	f, ok := m.flags[name]
+	return f, ok
+}
+
+// All 全部开关, 按名称排序
+func (m *Manager) All() []Flag {
+	m.mu.RLock()
+	list := make([]Flag, 0, len(m.flags))
+	for _, f := range m.flags {
+		list = append(list, f)
+	}
+	m.mu.RUnlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Variant 用户命中的变体, 开关不存在或未命中时返回空字符串
+func (m *Manager) Variant(name string, userID uint) string {
+	f, ok := m.Get(name)
+	if !ok {
+		return ""
+	}
+	return f.Evaluate(userID)
+}
+
+// Enabled 开关是否对用户打开
+func (m *Manager) Enabled(name string, userID uint) bool {
+	return m.Variant(name, userID) != ""
+}
+
+// Enabled 开关是否对当前请求的用户打开, 需要在 JWTAuth 之后使用才能按用户灰度
+func Enabled(c *gin.Context, name string) bool {
+	return Default.Enabled(name, userID(c))
+}
+
+// VariantOf 当前请求的用户命中的变体
+func VariantOf(c *gin.Context, name string) string {
+	return Default.Variant(name, userID(c))
+}
+
+func userID(c *gin.Context) uint {
+	if claims, _ := c.Get("claims"); claims != nil {
+		if u, ok := claims.(*middleware.CustomClaims); ok {
+			return u.ID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package flags
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-api/middleware"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestPubSubRefresh(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	writer := NewManager(NewRedisStore(rdb, "flags"), rdb, "flags:changed")
+	reader := NewManager(NewRedisStore(rdb, "flags"), rdb, "flags:changed")
+	go reader.Run(ctx, time.Hour)
+	// 等待订阅建立
+	for deadline := time.Now().Add(time.Second); len(mr.PubSubChannels("")) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription not established")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := writer.Save(ctx, Flag{Name: "new_login", Enabled: true, Allow: []uint{3}}); err != nil {
+		t.Fatal(err)
+	}
+	if !writer.Enabled("new_login", 3) {
+		t.Fatal("writer cache not updated")
+	}
+	for deadline := time.Now().Add(time.Second); !reader.Enabled("new_login", 3); {
+		if time.Now().After(deadline) {
+			t.Fatal("reader not refreshed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.yaml")
+	yaml := `flags:
+  - name: new_login
+    enabled: true
+    rollout: 100
+  - name: Invalid Name
+    enabled: true
+`
+	if err := os.WriteFile(path, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(NewFileStore(path), nil, "")
+	if err := m.Load(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.All()) != 1 || !m.Enabled("new_login", 0) {
+		t.Fatalf("unexpected flags %+v", m.All())
+	}
+	if err := m.Save(context.Background(), Flag{Name: "other"}); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("save to file store: %v", err)
+	}
+}
+
+func TestGinHelpers(t *testing.T) {
+	m := NewManager(nil, nil, "")
+	m.flags["beta"] = Flag{Name: "beta", Enabled: true, Allow: []uint{42}, Variants: []Variant{{"b", 1}}}
+	old := Default
+	Default = m
+	defer func() { Default = old }()
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if Enabled(c, "beta") {
+		t.Fatal("anonymous request enabled")
+	}
+	c.Set("claims", &middleware.CustomClaims{ID: 42})
+	if !Enabled(c, "beta") || VariantOf(c, "beta") != "b" || Enabled(c, "missing") {
+		t.Fatal("unexpected evaluation for allowlisted user")
+	}
+}
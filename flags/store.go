@@ -0,0 +1,95 @@
+package flags
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/go-redis/redis/v8"
+	"gopkg.in/yaml.v2"
+)
+
+// ErrReadOnly 存储不支持修改
+var ErrReadOnly = errors.New("flags: store is read-only")
+
+// Store 开关存储
+type Store interface {
+	// Load 读取全部开关
+	Load(ctx context.Context) ([]Flag, error)
+	// Save 新增或覆盖开关
+	Save(ctx context.Context, f Flag) error
+}
+
+// RedisStore 以 hash 保存开关, field 为开关名称, value 为 json
+type RedisStore struct {
+	redis redis.UniversalClient
+	key   string
+}
+
+// NewRedisStore 创建 redis 存储
+func NewRedisStore(rdb redis.UniversalClient, key string) *RedisStore {
+	return &RedisStore{redis: rdb, key: key}
+}
+
+// Load 读取全部开关
+func (s *RedisStore) Load(ctx context.Context) ([]Flag, error) {
+	values, err := s.redis.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Flag, 0, len(values))
+	for _, v := range values {
+		var f Flag
+		if err := json.Unmarshal([]byte(v), &f); err != nil {
+			return nil, err
+		}
+		list = append(list, f)
+	}
+	return list, nil
+}
+
+// Save 新增或覆盖开关
+func (s *RedisStore) Save(ctx context.Context, f Flag) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return s.redis.HSet(ctx, s.key, f.Name, data).Err()
+}
+
+// FileStore 从 yaml 文件读取开关, 只读, 适合本地开发和测试
+//
+//	flags:
+//	  - name: new_login
+//	    enabled: true
+//	    rollout: 20
+//	    allow: [1, 2]
+type FileStore struct {
+	path string
+}
+
+// NewFileStore 创建文件存储
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load 读取全部开关
+func (s *FileStore) Load(ctx context.Context) ([]Flag, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Flags []Flag `yaml:"flags"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return file.Flags, nil
+}
+
+// Save 文件存储不支持修改
+func (s *FileStore) Save(ctx context.Context, f Flag) error {
+	return ErrReadOnly
+}
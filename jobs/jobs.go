@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"go-api/cache"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// 队列名称, 数值越大优先级越高
+const (
+	QueueCritical = "critical"
+	QueueDefault  = "default"
+	QueueLow      = "low"
+)
+
+// Queues 队列与权重
+var Queues = map[string]int{
+	QueueCritical: 6,
+	QueueDefault:  3,
+	QueueLow:      1,
+}
+
+const (
+	// DefaultMaxRetry 默认重试次数, 用尽后任务进入死信(archived)队列
+	DefaultMaxRetry = 10
+	retryBaseDelay  = 10 * time.Second
+	retryMaxDelay   = time.Hour
+)
+
+var (
+	client    *asynq.Client
+	inspector *asynq.Inspector
+)
+
+// RedisOpt 任务队列使用的 redis 配置, 与缓存共用同一个 redis 及连接方式
+// asynq 的键固定以 asynq: 开头, 不使用 REDIS_KEY_PREFIX, 多个环境需要使用不同的 REDIS_DB
+func RedisOpt() asynq.RedisConnOpt {
+	cfg := cache.RedisConfigFromEnv()
+	switch cfg.Mode {
+	case cache.ModeSentinel:
+		return asynq.RedisFailoverClientOpt{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.Addrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+			PoolSize:         cfg.PoolSize,
+		}
+	case cache.ModeCluster:
+		return asynq.RedisClusterClientOpt{
+			Addrs:    cfg.Addrs,
+			Password: cfg.Password,
+		}
+	}
+	opt := asynq.RedisClientOpt{
+		Password: cfg.Password,
+		DB:       cfg.DB,
+		PoolSize: cfg.PoolSize,
+	}
+	if len(cfg.Addrs) > 0 {
+		opt.Addr = cfg.Addrs[0]
+	}
+	return opt
+}
+
+// Init 初始化任务客户端
+func Init() {
+	client = asynq.NewClient(RedisOpt())
+	inspector = asynq.NewInspector(RedisOpt())
+}
+
+// Close 关闭任务客户端, 没有初始化时不做任何事
+func Close() error {
+	if client == nil {
+		return nil
+	}
+	err := client.Close()
+	if ierr := inspector.Close(); err == nil {
+		err = ierr
+	}
+	client, inspector = nil, nil
+	return err
+}
+
+// Inspector 队列查看与管理, Init 之前为 nil
+func Inspector() *asynq.Inspector {
+	return inspector
+}
+
+// RetryDelay 指数退避重试间隔, 加入随机抖动避免同时重试
+func RetryDelay(n int, _ error, _ *asynq.Task) time.Duration {
+	delay := float64(retryBaseDelay) * math.Pow(2, float64(n))
+	if delay > float64(retryMaxDelay) {
+		delay = float64(retryMaxDelay)
+	}
+	jitter := rand.Float64() * delay * 0.2
+	return time.Duration(delay*0.9 + jitter)
+}
@@ -0,0 +1,134 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+)
+
+type testPayload struct {
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// newQueue 把任务投递到 miniredis 中的真实队列
+func newQueue(t *testing.T) {
+	m := miniredis.RunT(t)
+	t.Setenv("REDIS_ADDR", m.Addr())
+	t.Setenv("REDIS_MODE", "")
+	Init()
+	t.Cleanup(func() { Close() })
+}
+
+func TestTaskEncoding(t *testing.T) {
+	newQueue(t)
+	ctx := context.Background()
+	task := NewTask[testPayload]("test:encoding", asynq.Queue(QueueLow))
+
+	info, err := task.Enqueue(ctx, testPayload{UserID: 7, Email: "a@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Queue != QueueLow || info.MaxRetry != DefaultMaxRetry || string(info.Payload) != `{"user_id":7,"email":"a@example.com"}` {
+		t.Fatalf("unexpected task %+v %s", info, info.Payload)
+	}
+	// 投递时的选项覆盖任务类型的默认选项
+	info, err = task.Enqueue(ctx, testPayload{UserID: 8}, asynq.MaxRetry(1))
+	if err != nil || info.MaxRetry != 1 {
+		t.Fatalf("override: %+v %v", info, err)
+	}
+
+	// 处理函数收到解码后的载荷
+	var got testPayload
+	task.Handle(func(ctx context.Context, p testPayload) error {
+		got = p
+		return nil
+	})
+	if err := mux.ProcessTask(ctx, asynq.NewTask(task.Type(), info.Payload)); err != nil || got.UserID != 8 {
+		t.Fatalf("process: %+v %v", got, err)
+	}
+	// 载荷无法解析时不再重试
+	if err := mux.ProcessTask(ctx, asynq.NewTask(task.Type(), []byte("{"))); !errors.Is(err, SkipRetry) {
+		t.Fatalf("malformed payload: %v", err)
+	}
+}
+
+// recorder 记录投递的任务
+type recorder struct {
+	tasks []*asynq.Task
+}
+
+func (r *recorder) Enqueue(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	r.tasks = append(r.tasks, task)
+	return &asynq.TaskInfo{Type: task.Type(), Payload: task.Payload(), State: asynq.TaskStatePending}, nil
+}
+
+func TestEnqueuer(t *testing.T) {
+	r := &recorder{}
+	Default = r
+	defer func() { Default = Asynq }()
+
+	task := NewTask[testPayload]("test:enqueuer")
+	calls := 0
+	task.Handle(func(ctx context.Context, p testPayload) error {
+		calls++
+		return nil
+	})
+	if _, err := task.Enqueue(context.Background(), testPayload{UserID: 1}); err != nil || len(r.tasks) != 1 || calls != 0 {
+		t.Fatalf("enqueue: %v tasks=%d calls=%d", err, len(r.tasks), calls)
+	}
+	// 投递的任务可以交给注册的处理函数执行
+	if err := Process(context.Background(), r.tasks[0]); err != nil || calls != 1 {
+		t.Fatalf("process: %v calls=%d", err, calls)
+	}
+
+	// 没有初始化时投递到队列返回错误
+	Default = Asynq
+	if _, err := task.Enqueue(context.Background(), testPayload{UserID: 1}); err == nil {
+		t.Fatal("enqueued without client")
+	}
+}
+
+func TestUniqueTask(t *testing.T) {
+	newQueue(t)
+	ctx := context.Background()
+	task := NewTask[testPayload]("test:unique", asynq.Unique(time.Hour))
+
+	if _, err := task.Enqueue(ctx, testPayload{UserID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := task.Enqueue(ctx, testPayload{UserID: 1}); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("duplicate: %v", err)
+	}
+	// 载荷不同是不同的任务
+	if _, err := task.Enqueue(ctx, testPayload{UserID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	tasks, err := Inspector().ListPendingTasks(QueueDefault)
+	if err != nil || len(tasks) != 2 {
+		t.Fatalf("pending: %d %v", len(tasks), err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	prev := time.Duration(0)
+	for n := 0; n < 20; n++ {
+		delay := RetryDelay(n, nil, nil)
+		base := retryBaseDelay << n
+		if base > retryMaxDelay {
+			base = retryMaxDelay
+		}
+		// 抖动范围为 0.9 到 1.1 倍
+		if delay < base*9/10 || delay > base*11/10 {
+			t.Fatalf("RetryDelay(%d) = %s, base %s", n, delay, base)
+		}
+		if base < retryMaxDelay && delay <= prev {
+			t.Fatalf("RetryDelay(%d) = %s not increasing from %s", n, delay, prev)
+		}
+		prev = delay
+	}
+}
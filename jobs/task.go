@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-api/dbresolver"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+var (
+	// ErrDuplicate 唯一任务在有效期内已存在
+	ErrDuplicate = asynq.ErrDuplicateTask
+	// SkipRetry 处理函数返回包装了它的错误时不再重试, 直接进入死信队列
+	SkipRetry = asynq.SkipRetry
+)
+
+var mux = asynq.NewServeMux()
+
+// Enqueuer 投递任务的方式, opts 已经合并了任务类型的默认选项
+type Enqueuer interface {
+	Enqueue(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+// asynqEnqueuer 投递到 asynq 队列, 需要先调用 Init
+type asynqEnqueuer struct{}
+
+func (asynqEnqueuer) Enqueue(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if client == nil {
+		return nil, errors.New("jobs: client not initialized")
+	}
+	return client.EnqueueContext(ctx, task, opts...)
+}
+
+// Asynq 投递到 asynq 队列的 Enqueuer
+var Asynq Enqueuer = asynqEnqueuer{}
+
+// Default 任务投递使用的 Enqueuer, 测试中替换为同步执行的实现
+var Default = Asynq
+
+// Process 按任务类型调用 Handle 注册的处理函数
+func Process(ctx context.Context, task *asynq.Task) error {
+	return mux.ProcessTask(ctx, task)
+}
+
+// schedule 定时任务
+type schedule struct {
+	cronspec string
+	task     *asynq.Task
+	opts     []asynq.Option
+}
+
+var schedules []schedule
+
+// Task 类型化的任务定义, T 为任务载荷
+type Task[T any] struct {
+	typ  string
+	opts []asynq.Option
+}
+
+// NewTask 定义任务类型, opts 为该类型任务的默认选项, 如队列, 重试次数, 唯一性
+func NewTask[T any](typ string, opts ...asynq.Option) *Task[T] {
+	defaults := []asynq.Option{asynq.Queue(QueueDefault), asynq.MaxRetry(DefaultMaxRetry)}
+	return &Task[T]{
+		typ:  typ,
+		opts: append(defaults, opts...),
+	}
+}
+
+// Type 任务类型名称
+func (t *Task[T]) Type() string {
+	return t.typ
+}
+
+func (t *Task[T]) build(payload T) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("jobs: marshal %s payload: %w", t.typ, err)
+	}
+	return asynq.NewTask(t.typ, data), nil
+}
+
+// Enqueue 投递任务, opts 覆盖任务类型的默认选项
+// 唯一任务重复投递时返回 ErrDuplicate
+func (t *Task[T]) Enqueue(ctx context.Context, payload T, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	task, err := t.build(payload)
+	if err != nil {
+		return nil, err
+	}
+	return Default.Enqueue(ctx, task, append(append([]asynq.Option{}, t.opts...), opts...)...)
+}
+
+// EnqueueIn 延迟 delay 后执行
+func (t *Task[T]) EnqueueIn(ctx context.Context, delay time.Duration, payload T, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	return t.Enqueue(ctx, payload, append(opts, asynq.ProcessIn(delay))...)
+}
+
+// Handle 注册任务处理函数, 在 worker 模式下执行
+// 任务通常在写入后立即投递, 从库可能还没有同步, 所以处理函数中的查询都使用主库
+func (t *Task[T]) Handle(fn func(ctx context.Context, payload T) error) {
+	mux.HandleFunc(t.typ, func(ctx context.Context, task *asynq.Task) error {
+		ctx = dbresolver.Primary(ctx)
+		var payload T
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			// 载荷无法解析时重试也没有意义
+			return fmt.Errorf("jobs: unmarshal %s payload: %v: %w", t.typ, err, SkipRetry)
+		}
+		return fn(ctx, payload)
+	})
+}
+
+// Schedule 按 cron 表达式周期性投递任务, 如 "@daily", "*/5 * * * *"
+func (t *Task[T]) Schedule(cronspec string, payload T, opts ...asynq.Option) {
+	task, err := t.build(payload)
+	if err != nil {
+		panic(err)
+	}
+	schedules = append(schedules, schedule{
+		cronspec: cronspec,
+		task:     task,
+		opts:     append(append([]asynq.Option{}, t.opts...), opts...),
+	})
+}
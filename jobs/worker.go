@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"go-api/util"
+	"os"
+	"strconv"
+
+	"github.com/hibiken/asynq"
+)
+
+// Run 启动 worker 处理任务并运行定时任务调度, 收到 SIGTERM/SIGINT 后退出
+// JOBS_CONCURRENCY 并发处理数, 默认为 10
+func Run() error {
+	concurrency, err := strconv.Atoi(os.Getenv("JOBS_CONCURRENCY"))
+	if err != nil || concurrency <= 0 {
+		concurrency = 10
+	}
+
+	srv := asynq.NewServer(RedisOpt(), asynq.Config{
+		Concurrency:    concurrency,
+		Queues:         Queues,
+		RetryDelayFunc: RetryDelay,
+		ErrorHandler:   asynq.ErrorHandlerFunc(reportError),
+		LogLevel:       asynq.WarnLevel,
+	})
+
+	scheduler := asynq.NewScheduler(RedisOpt(), nil)
+	for _, s := range schedules {
+		if _, err := scheduler.Register(s.cronspec, s.task, s.opts...); err != nil {
+			return err
+		}
+	}
+	if err := scheduler.Start(); err != nil {
+		return err
+	}
+	defer scheduler.Shutdown()
+
+	return srv.Run(mux)
+}
+
+// reportError 记录失败任务, 重试次数用尽的任务会进入死信队列
+func reportError(ctx context.Context, task *asynq.Task, err error) {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	id, _ := asynq.GetTaskID(ctx)
+	if retried >= maxRetry {
+		util.Log().Error("任务 %s(%s) 重试耗尽, 进入死信队列: %v", task.Type(), id, err)
+		return
+	}
+	util.Log().Warning("任务 %s(%s) 第 %d 次执行失败: %v", task.Type(), id, retried+1, err)
+}
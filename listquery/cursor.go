@@ -0,0 +1,33 @@
+package listquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// cursor 游标内容, 对客户端不透明
+type cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+func encodeCursor(sort string, values []string) string {
+	data, _ := json.Marshal(cursor{Sort: sort, Values: values})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw, sort string) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.New("无效的游标")
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errors.New("无效的游标")
+	}
+	if c.Sort != sort {
+		return nil, errors.New("游标与排序条件不一致")
+	}
+	return c.Values, nil
+}
@@ -0,0 +1,76 @@
+package listquery
+
+import (
+	"go-api/serializer"
+	"reflect"
+	"strings"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// Where 转换为 ent 的类型化谓词, 如 client.Pet.Query().Where(listquery.Where[predicate.Pet](q)...)
+func Where[P ~func(*sql.Selector)](q *Query) []P {
+	preds := q.Predicates()
+	typed := make([]P, 0, len(preds))
+	for _, p := range preds {
+		typed = append(typed, P(p))
+	}
+	return typed
+}
+
+// Order 转换为 ent 的排序函数, 如 Order(listquery.Order[ent.OrderFunc](q)...)
+func Order[O ~func(*sql.Selector, func(string) bool)](q *Query) []O {
+	orders := q.Orders()
+	typed := make([]O, 0, len(orders))
+	for _, o := range orders {
+		typed = append(typed, O(o))
+	}
+	return typed
+}
+
+// Paginate 截取本页数据并生成下一页的游标, items 为按 Limit 查询的结果
+func Paginate[T any](q *Query, items []T) ([]T, serializer.ListMeta) {
+	meta := serializer.ListMeta{Size: q.Size}
+	if len(items) <= q.Size {
+		return items, meta
+	}
+	items = items[:q.Size]
+	last := items[len(items)-1]
+	vals := make([]string, 0, len(q.Sorts))
+	for _, s := range q.Sorts {
+		vals = append(vals, formatValue(fieldValue(last, s.Field.Name)))
+	}
+	meta.HasMore = true
+	meta.NextCursor = encodeCursor(q.sortKey(), vals)
+	return items, meta
+}
+
+// fieldValue 按 json tag 或列名读取 ent 实体的字段值
+func fieldValue(item interface{}, name string) interface{} {
+	v := reflect.Indirect(reflect.ValueOf(item))
+	t := v.Type()
+	camel := toCamel(name)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == name || f.Name == camel {
+			return v.Field(i).Interface()
+		}
+	}
+	return nil
+}
+
+// toCamel ent 的字段命名: created_at -> CreatedAt, id -> ID
+func toCamel(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,266 @@
+package listquery
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/facebook/ent/dialect/sql"
+)
+
+// idField 主键, 作为排序的最后一列保证游标唯一
+const idField = "id"
+
+// Filter 一个过滤条件
+type Filter struct {
+	Field  Field
+	Op     Op
+	Values []interface{}
+}
+
+// Sort 一个排序字段
+type Sort struct {
+	Field Field
+	Desc  bool
+}
+
+// Query 解析后的列表查询
+type Query struct {
+	Filters []Filter
+	Sorts   []Sort
+	Size    int
+	// after 游标中的值, 与 Sorts 一一对应
+	after []interface{}
+}
+
+// Parse 解析 ?filter=status:eq:active,created_at:gt:2020-01-01T00:00:00Z&sort=-created_at&page[size]=20&page[after]=<cursor>
+// 字段与操作符必须在 schema 白名单中
+func Parse(schema *Schema, values url.Values) (*Query, error) {
+	q := &Query{}
+
+	for _, raw := range values["filter"] {
+		for _, expr := range strings.Split(raw, ",") {
+			if expr == "" {
+				continue
+			}
+			f, err := parseFilter(schema, expr)
+			if err != nil {
+				return nil, err
+			}
+			q.Filters = append(q.Filters, f)
+		}
+	}
+
+	sort := values.Get("sort")
+	if sort == "" {
+		sort = schema.DefaultSort
+	}
+	if err := q.parseSort(schema, sort); err != nil {
+		return nil, err
+	}
+
+	q.Size = schema.DefaultSize
+	if q.Size == 0 {
+		q.Size = 20
+	}
+	if s := values.Get("page[size]"); s != "" {
+		size, err := strconv.Atoi(s)
+		if err != nil || size < 1 {
+			return nil, errors.New("page[size] 必须是正整数")
+		}
+		q.Size = size
+	}
+	max := schema.MaxSize
+	if max == 0 {
+		max = 100
+	}
+	if q.Size > max {
+		q.Size = max
+	}
+
+	if after := values.Get("page[after]"); after != "" {
+		vals, err := decodeCursor(after, q.sortKey())
+		if err != nil {
+			return nil, err
+		}
+		if len(vals) != len(q.Sorts) {
+			return nil, errors.New("无效的游标")
+		}
+		for i, s := range q.Sorts {
+			v, err := parseValue(s.Field.Type, vals[i])
+			if err != nil {
+				return nil, errors.New("无效的游标")
+			}
+			q.after = append(q.after, v)
+		}
+	}
+	return q, nil
+}
+
+func parseFilter(schema *Schema, expr string) (Filter, error) {
+	parts := strings.SplitN(expr, ":", 3)
+	if len(parts) != 3 {
+		return Filter{}, fmt.Errorf("过滤条件格式错误: %s", expr)
+	}
+	field, ok := schema.field(parts[0])
+	if !ok {
+		return Filter{}, fmt.Errorf("不支持过滤字段: %s", parts[0])
+	}
+	op := Op(parts[1])
+	if !field.allows(op) {
+		return Filter{}, fmt.Errorf("字段 %s 不支持操作符 %s", field.Name, op)
+	}
+	raws := []string{parts[2]}
+	if op == OpIn {
+		raws = strings.Split(parts[2], "|")
+	}
+	f := Filter{Field: field, Op: op}
+	for _, raw := range raws {
+		v, err := parseValue(field.Type, raw)
+		if err != nil {
+			return Filter{}, fmt.Errorf("字段 %s 的值无效: %s", field.Name, raw)
+		}
+		f.Values = append(f.Values, v)
+	}
+	return f, nil
+}
+
+func (q *Query) parseSort(schema *Schema, sort string) error {
+	hasID := false
+	for _, name := range strings.Split(sort, ",") {
+		if name == "" {
+			continue
+		}
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		field, ok := schema.field(name)
+		if !ok || !field.Sortable {
+			return fmt.Errorf("不支持排序字段: %s", name)
+		}
+		q.Sorts = append(q.Sorts, Sort{Field: field, Desc: desc})
+		hasID = hasID || name == idField
+	}
+	if !hasID {
+		// 主键与最后一个排序字段同向, 作为相同值时的次序
+		desc := len(q.Sorts) > 0 && q.Sorts[len(q.Sorts)-1].Desc
+		id, _ := schema.field(idField)
+		q.Sorts = append(q.Sorts, Sort{Field: id, Desc: desc})
+	}
+	return nil
+}
+
+// sortKey 排序描述, 写入游标防止在不同排序下复用
+func (q *Query) sortKey() string {
+	keys := make([]string, 0, len(q.Sorts))
+	for _, s := range q.Sorts {
+		if s.Desc {
+			keys = append(keys, "-"+s.Field.Name)
+		} else {
+			keys = append(keys, s.Field.Name)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+func parseValue(t Type, raw string) (interface{}, error) {
+	switch t {
+	case Int:
+		return strconv.Atoi(raw)
+	case Time:
+		return time.Parse(time.RFC3339Nano, raw)
+	case Bool:
+		return strconv.ParseBool(raw)
+	}
+	return raw, nil
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// Predicates 过滤条件与游标条件
+func (q *Query) Predicates() []func(*sql.Selector) {
+	preds := make([]func(*sql.Selector), 0, len(q.Filters)+1)
+	for _, f := range q.Filters {
+		f := f
+		preds = append(preds, func(s *sql.Selector) {
+			s.Where(f.predicate(s.C(f.Field.Name)))
+		})
+	}
+	if q.after != nil {
+		preds = append(preds, q.keyset)
+	}
+	return preds
+}
+
+func (f Filter) predicate(col string) *sql.Predicate {
+	switch f.Op {
+	case OpNE:
+		return sql.NEQ(col, f.Values[0])
+	case OpGT:
+		return sql.GT(col, f.Values[0])
+	case OpGTE:
+		return sql.GTE(col, f.Values[0])
+	case OpLT:
+		return sql.LT(col, f.Values[0])
+	case OpLTE:
+		return sql.LTE(col, f.Values[0])
+	case OpIn:
+		return sql.In(col, f.Values...)
+	case OpContains:
+		return sql.Contains(col, fmt.Sprint(f.Values[0]))
+	}
+	return sql.EQ(col, f.Values[0])
+}
+
+// keyset (a, b, id) 位于游标之后: a > va OR (a = va AND b > vb) OR (a = va AND b = vb AND id > vid)
+func (q *Query) keyset(s *sql.Selector) {
+	ors := make([]*sql.Predicate, 0, len(q.Sorts))
+	for i, sort := range q.Sorts {
+		ands := make([]*sql.Predicate, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, sql.EQ(s.C(q.Sorts[j].Field.Name), q.after[j]))
+		}
+		col := s.C(sort.Field.Name)
+		if sort.Desc {
+			ands = append(ands, sql.LT(col, q.after[i]))
+		} else {
+			ands = append(ands, sql.GT(col, q.after[i]))
+		}
+		ors = append(ors, sql.And(ands...))
+	}
+	s.Where(sql.Or(ors...))
+}
+
+// Orders 排序条件
+func (q *Query) Orders() []func(*sql.Selector, func(string) bool) {
+	orders := make([]func(*sql.Selector, func(string) bool), 0, len(q.Sorts))
+	for _, sort := range q.Sorts {
+		sort := sort
+		orders = append(orders, func(s *sql.Selector, _ func(string) bool) {
+			if sort.Desc {
+				s.OrderBy(sql.Desc(s.C(sort.Field.Name)))
+			} else {
+				s.OrderBy(sql.Asc(s.C(sort.Field.Name)))
+			}
+		})
+	}
+	return orders
+}
+
+// Limit 查询条数, 多取一条用于判断是否还有下一页
+func (q *Query) Limit() int {
+	return q.Size + 1
+}
@@ -0,0 +1,118 @@
+package listquery
+
+import (
+	"context"
+	"go-api/ent"
+	"go-api/ent/enttest"
+	"go-api/ent/predicate"
+	"go-api/tenancy"
+	"net/url"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var testSchema = &Schema{
+	Fields: []Field{
+		{Name: "name", Type: String, Sortable: true},
+		{Name: "species", Type: String},
+		{Name: "created_at", Type: Time, Sortable: true},
+	},
+	DefaultSort: "-created_at",
+	DefaultSize: 2,
+}
+
+func TestParseRejectsUnknown(t *testing.T) {
+	cases := []string{
+		"filter=password:eq:x",
+		"filter=species:gt:cat",
+		"filter=created_at:eq:yesterday",
+		"filter=species",
+		"sort=species",
+		"page[size]=0",
+		"page[after]=!!",
+	}
+	for _, raw := range cases {
+		values, _ := url.ParseQuery(raw)
+		if _, err := Parse(testSchema, values); err == nil {
+			t.Errorf("%s: expected error", raw)
+		}
+	}
+}
+
+func TestCursorBoundToSort(t *testing.T) {
+	cursor := encodeCursor("-created_at,-id", []string{time.Now().Format(time.RFC3339Nano), "1"})
+	values := url.Values{"page[after]": {cursor}}
+	if _, err := Parse(testSchema, values); err != nil {
+		t.Fatal(err)
+	}
+	values.Set("sort", "name")
+	if _, err := Parse(testSchema, values); err == nil {
+		t.Fatal("cursor reused with a different sort")
+	}
+}
+
+func TestKeysetPagination(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:listquery?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := tenancy.System(context.Background())
+
+	owner := client.User.Create().
+		SetUsername("owner").SetNickname("owner").SetPasswordDigest("x").
+		SetStatus("active").SetAvatar("").
+		SaveX(ctx)
+	// 相同的创建时间依靠主键区分先后
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	species := []string{"cat", "dog", "cat", "cat", "cat"}
+	for i, s := range species {
+		client.Pet.Create().
+			SetName(s).SetSpecies(s).SetOwner(owner).
+			SetCreatedAt(base.Add(time.Duration(i/2) * time.Hour)).
+			SaveX(ctx)
+	}
+
+	list := func(values url.Values) ([]*ent.Pet, string) {
+		q, err := Parse(testSchema, values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items := client.Pet.Query().
+			Where(Where[predicate.Pet](q)...).
+			Order(Order[ent.OrderFunc](q)...).
+			Limit(q.Limit()).
+			AllX(ctx)
+		items, meta := Paginate(q, items)
+		if meta.HasMore != (meta.NextCursor != "") {
+			t.Fatalf("inconsistent meta %+v", meta)
+		}
+		return items, meta.NextCursor
+	}
+
+	values := url.Values{"filter": {"species:in:cat|bird"}}
+	var ids []int
+	for {
+		items, next := list(values)
+		for _, p := range items {
+			ids = append(ids, p.ID)
+		}
+		if next == "" {
+			break
+		}
+		values.Set("page[after]", next)
+	}
+	want := []int{5, 4, 3, 1}
+	if len(ids) != len(want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", ids, want)
+		}
+	}
+
+	items, _ := list(url.Values{"filter": {"created_at:lt:2020-01-01T01:00:00Z,species:ne:cat"}})
+	if len(items) != 1 || items[0].ID != 2 {
+		t.Fatalf("unexpected items %v", items)
+	}
+}
@@ -0,0 +1,78 @@
+package listquery
+
+// Type 字段类型, 决定过滤值的解析方式和允许的操作符
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Time
+	Bool
+)
+
+// Op 过滤操作符
+type Op string
+
+const (
+	OpEQ       Op = "eq"
+	OpNE       Op = "ne"
+	OpGT       Op = "gt"
+	OpGTE      Op = "gte"
+	OpLT       Op = "lt"
+	OpLTE      Op = "lte"
+	OpIn       Op = "in"
+	OpContains Op = "contains"
+)
+
+// defaultOps 各类型默认允许的操作符
+var defaultOps = map[Type][]Op{
+	String: {OpEQ, OpNE, OpIn, OpContains},
+	Int:    {OpEQ, OpNE, OpGT, OpGTE, OpLT, OpLTE, OpIn},
+	Time:   {OpEQ, OpGT, OpGTE, OpLT, OpLTE},
+	Bool:   {OpEQ, OpNE},
+}
+
+// Field 允许过滤或排序的字段
+type Field struct {
+	// Name 查询参数中的字段名, 同时也是 ent 的列名
+	Name string
+	Type Type
+	// Ops 允许的操作符, 为空时使用类型的默认值, 不允许过滤时设为 []Op{}
+	Ops []Op
+	// Sortable 是否允许排序, 可为空的字段不能用于游标分页
+	Sortable bool
+}
+
+func (f Field) allows(op Op) bool {
+	ops := f.Ops
+	if ops == nil {
+		ops = defaultOps[f.Type]
+	}
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema 一个 ent 类型的列表查询白名单
+type Schema struct {
+	Fields []Field
+	// DefaultSort 默认排序, 如 "-created_at"
+	DefaultSort string
+	DefaultSize int
+	MaxSize     int
+}
+
+func (s *Schema) field(name string) (Field, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	if name == idField {
+		return Field{Name: idField, Type: Int, Sortable: true}, true
+	}
+	return Field{}, false
+}
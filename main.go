@@ -1,12 +1,10 @@
 package main
 
 import (
-	"github.com/gin-contrib/pprof"
-	"go-api/conf"
+	"go-api/cli"
 	_ "go-api/docs"
-	"go-api/server"
 	_ "go.uber.org/automaxprocs"
-	"runtime"
+	"os"
 )
 
 // @title Gin swagger
@@ -19,16 +17,6 @@ import (
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 // @host localhost
 func main() {
-	//Ballast，一种精准控制 Go GC 提高性能的方法
-	//https://mp.weixin.qq.com/s/OVUsHNXGz_FicwkYgdCUdQ
-	//https://medium.com/a-journey-with-go/go-keeping-a-variable-alive-c28e3633673a
-	ballast := make([]byte, 10<<30)
-	// 从配置文件读取配置
-	conf.Init()
-
-	// 装载路由
-	r := server.NewRouter()
-	pprof.Register(r)
-	r.Run(":3000")
-	runtime.KeepAlive(ballast)
+	// 没有参数时启动 API 服务, 其余子命令见 go-api help
+	os.Exit(cli.New().Run(os.Args[1:]))
 }
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"go-api/serializer"
+	"go-api/tenancy"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminRequired 需要管理员权限, 在 JWTAuth 之后使用
+// 角色为 admin 的用户签发的 token 带有管理员角色, ADMIN_USER_IDS 额外指定的管理员用户 id, 逗号分隔
+// 第三方应用的 token 没有管理员权限
+func AdminRequired() gin.HandlerFunc {
+	admins := make(map[uint]bool)
+	for _, s := range strings.Split(os.Getenv("ADMIN_USER_IDS"), ",") {
+		if id, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+			admins[uint(id)] = true
+		}
+	}
+	return func(c *gin.Context) {
+		if claims, _ := c.Get("claims"); claims != nil {
+			if u, ok := claims.(*CustomClaims); ok && !u.Delegated() && (u.Role == "admin" || admins[u.ID]) {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(403, serializer.Err(serializer.CodeNoRightErr, "无权访问", nil))
+		c.Abort()
+	}
+}
+
+// PlatformAdmin 只允许默认租户的管理员, 在 AdminRequired 之后使用
+// 任务队列, 功能开关等不区分租户的全局资源使用, 其他租户的管理员只能管理本租户的数据
+func PlatformAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, _ := c.Get("claims"); claims != nil {
+			if u, ok := claims.(*CustomClaims); ok && (u.Tenant == 0 || u.Tenant == tenancy.DefaultID) {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(403, serializer.Err(serializer.CodeNoRightErr, "只有默认租户的管理员可以访问", nil))
+		c.Abort()
+	}
+}
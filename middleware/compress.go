@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// incompressible 本身已压缩过的内容类型, 再次压缩没有收益
+var incompressible = []string{
+	"image/", "video/", "audio/", "font/woff",
+	"application/zip", "application/gzip", "application/x-gzip", "application/x-brotli",
+	"application/x-7z-compressed", "application/x-rar-compressed", "application/pdf",
+	"application/octet-stream", "text/event-stream",
+}
+
+// compressKey gin context 中保存 compressWriter 的键, ETag 据此判断响应是否会被压缩
+const compressKey = "compress"
+
+// compressWriter 先缓存 minSize 字节, 超过阈值且类型可压缩时才开始压缩
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+	buf      bytes.Buffer
+	// decided 已决定是否压缩, encoder 为空表示原样输出
+	decided bool
+	encoder io.WriteCloser
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		return w.write(b)
+	}
+	w.buf.Write(b)
+	if w.buf.Len() >= w.minSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) write(b []byte) (int, error) {
+	if w.encoder != nil {
+		return w.encoder.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// decide 根据已缓存的内容决定是否压缩, 并输出缓存
+func (w *compressWriter) decide(large bool) error {
+	w.decided = true
+	header := w.Header()
+	if large && compressible(w.Status(), header) {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.encoding)
+		if w.encoding == "br" {
+			w.encoder = brotli.NewWriterLevel(w.ResponseWriter, brotli.DefaultCompression)
+		} else {
+			w.encoder, _ = gzip.NewWriterLevel(w.ResponseWriter, gzip.DefaultCompression)
+		}
+	}
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// Flush 流式响应提前决定, 已压缩的部分同时刷新
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(w.buf.Len() >= w.minSize)
+	}
+	if f, ok := w.encoder.(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+func (w *compressWriter) close() {
+	if !w.decided {
+		w.decide(false)
+	}
+	if w.encoder != nil {
+		w.encoder.Close()
+	}
+}
+
+// encodingFor 长度为 size 的完整响应是否会被压缩, 返回使用的编码, 不压缩时为空
+func (w *compressWriter) encodingFor(size int) string {
+	if size >= w.minSize && compressible(w.Status(), w.Header()) {
+		return w.encoding
+	}
+	return ""
+}
+
+func compressible(status int, header http.Header) bool {
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, t := range incompressible {
+		if strings.HasPrefix(contentType, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// Compress 响应压缩中间件, 按 Accept-Encoding 选择 br 或 gzip
+// COMPRESS_MIN_SIZE 压缩阈值字节数, 默认为 1024, 小于阈值的响应原样输出
+func Compress() gin.HandlerFunc {
+	minSize := 1024
+	if n, err := strconv.Atoi(os.Getenv("COMPRESS_MIN_SIZE")); err == nil && n > 0 {
+		minSize = n
+	}
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := acceptEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+		w := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, minSize: minSize}
+		c.Writer = w
+		c.Set(compressKey, w)
+		defer w.close()
+		c.Next()
+	}
+}
+
+// acceptEncoding 选择客户端支持的编码, q 值相同时优先 br
+func acceptEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name != "br" && name != "gzip" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > bestQ || (q == bestQ && name == "br") {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
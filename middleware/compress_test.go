@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"go-api/serializer"
+)
+
+func newCompressRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compress())
+	r.GET("/large", func(c *gin.Context) {
+		c.String(200, strings.Repeat("go-api ", 1000))
+	})
+	r.GET("/small", func(c *gin.Context) {
+		c.String(200, "pong")
+	})
+	r.GET("/image", func(c *gin.Context) {
+		c.Data(200, "image/png", bytes.Repeat([]byte{1}, 4096))
+	})
+	return r
+}
+
+func TestCompress(t *testing.T) {
+	r := newCompressRouter()
+	cases := []struct {
+		path, accept, encoding string
+	}{
+		{"/large", "gzip, deflate", "gzip"},
+		{"/large", "gzip, br", "br"},
+		{"/large", "br;q=0.5, gzip", "gzip"},
+		{"/large", "", ""},
+		{"/small", "gzip", ""},
+		{"/image", "gzip", ""},
+	}
+	for _, tc := range cases {
+		w := get(r, tc.path, map[string]string{"Accept-Encoding": tc.accept})
+		if got := w.Header().Get("Content-Encoding"); got != tc.encoding {
+			t.Errorf("%s %q: Content-Encoding = %q, want %q", tc.path, tc.accept, got, tc.encoding)
+			continue
+		}
+		var body io.Reader = w.Body
+		switch tc.encoding {
+		case "gzip":
+			zr, err := gzip.NewReader(w.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body = zr
+		case "br":
+			body = brotli.NewReader(w.Body)
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.path == "/large" && string(data) != strings.Repeat("go-api ", 1000) {
+			t.Errorf("%s %q: body mismatch", tc.path, tc.accept)
+		}
+	}
+}
+
+func TestCompressNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compress())
+	r.GET("/etag", ETag(""), func(c *gin.Context) {
+		c.JSON(200, serializer.Response{Data: strings.Repeat("a", 4096)})
+	})
+	w := get(r, "/etag", map[string]string{"Accept-Encoding": "gzip"})
+	etag := w.Header().Get("ETag")
+	if !strings.HasSuffix(etag, `;gzip"`) {
+		t.Fatalf("ETag = %q", etag)
+	}
+	w = get(r, "/etag", map[string]string{"Accept-Encoding": "gzip", "If-None-Match": etag})
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("unexpected response %d %v", w.Code, w.Header())
+	}
+}
@@ -1,35 +1,165 @@
 package middleware
 
 import (
+	"go-api/util"
+	"os"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-// Cors 跨域配置
-func Cors() gin.HandlerFunc {
-	config := cors.DefaultConfig()
-	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Cookie"}
-	if gin.Mode() == gin.ReleaseMode {
-		// 生产环境需要配置跨域域名，否则403
-		config.AllowOrigins = []string{"http://www.example.com"}
+// CorsPolicy 跨域策略
+type CorsPolicy struct {
+	// AllowOrigins 允许的来源, 支持通配符:
+	// "https://*.example.com" 匹配任意层级的子域名, "http://localhost:*" 匹配任意端口, "*" 匹配所有来源
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	MaxAge           time.Duration
+	AllowCredentials bool
+}
+
+// CorsRoute 路径前缀使用单独的跨域策略
+type CorsRoute struct {
+	Prefix string
+	Policy CorsPolicy
+}
+
+// DefaultCorsPolicy 默认跨域策略
+// 生产环境没有默认来源, 需要通过 CORS_ALLOW_ORIGINS 配置, 否则跨域请求 403;
+// 其他环境允许本地任意端口的请求
+func DefaultCorsPolicy() CorsPolicy {
+	policy := CorsPolicy{
+		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+		AllowHeaders: []string{
+			"Origin", "Content-Length", "Content-Type", "Cookie",
+			"Authorization", "token", CSRFHeader, TenantHeader, IdempotencyHeader,
+			"If-Match", "If-None-Match", "If-Modified-Since",
+		},
+		ExposeHeaders:    []string{"ETag", "Last-Modified", IdempotencyReplayedHeader},
+		MaxAge:           12 * time.Hour,
+		AllowCredentials: true,
+	}
+	if gin.Mode() != gin.ReleaseMode {
+		policy.AllowOrigins = []string{"http://127.0.0.1:*", "http://localhost:*", "http://gin.admin.test:*"}
+	}
+	return policy
+}
+
+// CorsConfig 用环境变量覆盖 base 中的配置
+// name 为空时读取 CORS_ALLOW_ORIGINS 等, 否则读取 CORS_{NAME}_ALLOW_ORIGINS 等, 未设置的项保留 base 的值
+// 列表项用逗号分隔, CORS_MAX_AGE 为秒数, CORS_ALLOW_CREDENTIALS 为 true 或 false
+func CorsConfig(name string, base CorsPolicy) CorsPolicy {
+	prefix := "CORS_"
+	if name != "" {
+		prefix += strings.ToUpper(name) + "_"
+	}
+	list := func(key string, value *[]string) {
+		if s, ok := os.LookupEnv(prefix + key); ok {
+			*value = nil
+			for _, item := range strings.Split(s, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					*value = append(*value, item)
+				}
+			}
+		}
+	}
+	list("ALLOW_ORIGINS", &base.AllowOrigins)
+	list("ALLOW_METHODS", &base.AllowMethods)
+	list("ALLOW_HEADERS", &base.AllowHeaders)
+	list("EXPOSE_HEADERS", &base.ExposeHeaders)
+	if s, err := strconv.Atoi(os.Getenv(prefix + "MAX_AGE")); err == nil && s >= 0 {
+		base.MaxAge = time.Duration(s) * time.Second
+	}
+	if b, err := strconv.ParseBool(os.Getenv(prefix + "ALLOW_CREDENTIALS")); err == nil {
+		base.AllowCredentials = b
+	}
+	return base
+}
+
+// originPattern 把来源的通配符编译为正则
+func originPattern(origin string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(strings.ToLower(strings.TrimSuffix(origin, "/")))
+	if strings.HasSuffix(expr, `:\*`) {
+		expr = strings.TrimSuffix(expr, `\*`) + `\d+`
+	}
+	expr = strings.ReplaceAll(expr, `\*\.`, `(?:[a-z0-9-]+\.)+`)
+	expr = strings.ReplaceAll(expr, `\*`, `[a-z0-9-]+`)
+	return regexp.MustCompile("^" + expr + "$")
+}
+
+// handler 生成策略对应的中间件, 来源的匹配规则只在这里编译一次
+func (policy CorsPolicy) handler() gin.HandlerFunc {
+	config := cors.Config{
+		AllowMethods:     policy.AllowMethods,
+		AllowHeaders:     policy.AllowHeaders,
+		ExposeHeaders:    policy.ExposeHeaders,
+		MaxAge:           policy.MaxAge,
+		AllowCredentials: policy.AllowCredentials,
+	}
+	var patterns []*regexp.Regexp
+	for _, origin := range policy.AllowOrigins {
+		if origin == "*" {
+			config.AllowAllOrigins = true
+			patterns = nil
+			break
+		}
+		patterns = append(patterns, originPattern(origin))
+	}
+	if config.AllowAllOrigins {
+		// 允许所有来源时不能携带 cookie, 否则任意网站都能以用户身份调用接口
+		if config.AllowCredentials {
+			util.Log().Warning("跨域策略允许所有来源, 已关闭 AllowCredentials")
+			config.AllowCredentials = false
+		}
 	} else {
-		// 测试环境下模糊匹配本地开头的请求
 		config.AllowOriginFunc = func(origin string) bool {
-			if regexp.MustCompile(`^http://127\.0\.0\.1:\d+$`).MatchString(origin) {
-				return true
-			}
-			if regexp.MustCompile(`^http://localhost:\d+$`).MatchString(origin) {
-				return true
-			}
-			if regexp.MustCompile(`^http://gin.admin.test:\d+$`).MatchString(origin) {
-				return true
+			origin = strings.ToLower(origin)
+			for _, pattern := range patterns {
+				if pattern.MatchString(origin) {
+					return true
+				}
 			}
 			return false
 		}
 	}
-	config.AllowCredentials = true
 	return cors.New(config)
 }
+
+// Cors 跨域中间件, 请求按最长的路径前缀匹配 routes 中的策略, 都不匹配时使用 policy
+// 预检请求没有对应的路由, 所以需要作为全局中间件按路径选择策略, 而不是挂在路由组上
+func Cors(policy CorsPolicy, routes ...CorsRoute) gin.HandlerFunc {
+	routes = append([]CorsRoute(nil), routes...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].Prefix) > len(routes[j].Prefix)
+	})
+	handlers := make([]gin.HandlerFunc, len(routes))
+	for i, route := range routes {
+		handlers[i] = route.Policy.handler()
+	}
+	fallback := policy.handler()
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		for i, route := range routes {
+			if matchPrefix(path, route.Prefix) {
+				handlers[i](c)
+				return
+			}
+		}
+		fallback(c)
+	}
+}
+
+// matchPrefix 按路径分段匹配前缀, /api/v1/ping 不匹配 /api/v1/pings
+func matchPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	return len(path) == len(prefix) || strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/'
+}
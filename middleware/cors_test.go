@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCorsRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("CORS_ALLOW_ORIGINS", "https://www.example.com, https://*.example.org, http://localhost:*")
+	t.Setenv("CORS_PUBLIC_MAX_AGE", "60")
+	public := CorsPolicy{AllowOrigins: []string{"*"}, AllowMethods: []string{"GET"}}
+
+	r := gin.New()
+	r.Use(Cors(CorsConfig("", DefaultCorsPolicy()),
+		CorsRoute{Prefix: "/api/ping", Policy: CorsConfig("PUBLIC", public)},
+	))
+	r.GET("/api/ping", func(c *gin.Context) { c.String(200, "pong") })
+	r.GET("/api/pings", func(c *gin.Context) { c.String(200, "pongs") })
+	r.POST("/api/pets", func(c *gin.Context) { c.String(200, "ok") })
+	return r
+}
+
+func TestCorsOrigins(t *testing.T) {
+	r := newCorsRouter(t)
+	cases := []struct {
+		origin string
+		allow  bool
+	}{
+		{"https://www.example.com", true},
+		{"https://WWW.example.com", true},
+		{"http://www.example.com", false},
+		{"https://a.example.org", true},
+		{"https://a.b.example.org", true},
+		{"https://example.org", false},
+		{"https://evilexample.org", false},
+		{"https://a.example.org.evil.com", false},
+		{"http://localhost:8080", true},
+		{"http://localhost", false},
+		{"http://127.0.0.1:8080", false},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/api/pets", nil)
+		req.Header.Set("Origin", tc.origin)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if got := w.Code == http.StatusOK && w.Header().Get("Access-Control-Allow-Origin") == tc.origin; got != tc.allow {
+			t.Errorf("%s: allowed = %v, want %v (status %d)", tc.origin, got, tc.allow, w.Code)
+		}
+		if tc.allow && w.Header().Get("Access-Control-Allow-Credentials") != "true" {
+			t.Errorf("%s: credentials not allowed", tc.origin)
+		}
+	}
+}
+
+func TestCorsPreflight(t *testing.T) {
+	r := newCorsRouter(t)
+	preflight := func(path, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, path, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		req.Header.Set("Access-Control-Request-Headers", "authorization, token")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := preflight("/api/pets", "https://www.example.com")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("preflight status %d", w.Code)
+	}
+	headers := w.Header().Get("Access-Control-Allow-Headers")
+	for _, h := range []string{"Authorization", "Token", "X-Csrf-Token"} {
+		if !containsHeader(headers, h) {
+			t.Errorf("allow headers %q missing %s", headers, h)
+		}
+	}
+
+	// 公开接口使用单独的策略
+	w = preflight("/api/ping", "https://anywhere.test")
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Fatalf("public preflight: %d %v", w.Code, w.Header())
+	}
+	if w.Header().Get("Access-Control-Allow-Credentials") != "" || w.Header().Get("Access-Control-Max-Age") != "60" {
+		t.Fatalf("public policy: %v", w.Header())
+	}
+	// 前缀按路径分段匹配
+	if w = preflight("/api/pings", "https://anywhere.test"); w.Code != http.StatusForbidden {
+		t.Fatalf("prefix matched other path: %d", w.Code)
+	}
+}
+
+func containsHeader(list, name string) bool {
+	for _, h := range strings.Split(list, ",") {
+		if http.CanonicalHeaderKey(strings.TrimSpace(h)) == http.CanonicalHeaderKey(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"go-api/dbresolver"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadYourWrites 每个请求使用一个数据库会话, 请求中写入之后的查询都使用主库,
+// 避免写入后立即读取时从库还没有同步
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(dbresolver.WithSession(c.Request.Context()))
+		c.Next()
+	}
+}
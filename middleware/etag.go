@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"go-api/serializer"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// bufferWriter 暂存响应体, 处理完成后再决定返回 304 还是原始响应
+type bufferWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bufferWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// ETag 条件请求中间件, 用于 GET 接口
+// 处理函数未通过 SetVersion 设置实体版本时, 由响应体生成弱 ETag,
+// msgpack 等非 JSON 格式与压缩后的响应在 ETag 中加上表示形式的后缀, 如 "pet-1;msgpack;gzip",
+// 请求头 If-None-Match/If-Modified-Since 命中时返回 304,
+// cacheControl 为该路由的 Cache-Control, 如 "private, no-cache", 只设置在成功的响应上
+func ETag(cacheControl string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &bufferWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		body := w.body.Bytes()
+		header := w.Header()
+		if code, ok := responseCode(header.Get("Content-Type"), body); ok && code == 0 && w.Status() == http.StatusOK {
+			if cacheControl != "" {
+				header.Set("Cache-Control", cacheControl)
+			}
+			etag := header.Get("ETag")
+			if etag == "" {
+				sum := sha1.Sum(body)
+				etag = `W/"` + hex.EncodeToString(sum[:])[:16] + `"`
+			}
+			etag = withRepresentation(etag, representation(c, header.Get("Content-Type"), len(body)))
+			header.Set("ETag", etag)
+			if notModified(c.Request, etag, header.Get("Last-Modified")) {
+				header.Del("Content-Type")
+				header.Del("Content-Length")
+				w.ResponseWriter.WriteHeader(http.StatusNotModified)
+				w.ResponseWriter.WriteHeaderNow()
+				return
+			}
+		}
+		w.ResponseWriter.Write(body)
+	}
+}
+
+// SetVersion 设置实体版本, 响应的 ETag 与 PUT/PATCH 时的 If-Match 使用同一个版本
+func SetVersion(c *gin.Context, v serializer.Versioned) {
+	c.Header("ETag", v.ETag())
+	if modified := v.LastModified(); !modified.IsZero() {
+		c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// MatchETag 判断 If-Match/If-None-Match 请求头是否包含 etag
+// weak 为 true 时忽略 W/ 前缀, If-None-Match 使用弱比较;
+// If-Match 使用强比较, 比较的是实体版本, 忽略表示形式的后缀, 以任意格式取得的 ETag 都可以用于更新
+func MatchETag(header, etag string, weak bool) bool {
+	if strings.TrimSpace(header) == "*" {
+		return etag != ""
+	}
+	if weak {
+		etag = strings.TrimPrefix(etag, "W/")
+	} else if strings.HasPrefix(etag, "W/") {
+		return false
+	} else {
+		etag = baseETag(etag)
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		} else {
+			candidate = baseETag(candidate)
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// representation 响应的表示形式, JSON 且不压缩时为空
+func representation(c *gin.Context, contentType string, size int) []string {
+	var parts []string
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "msgpack"):
+		parts = append(parts, "msgpack")
+	case strings.Contains(contentType, "protobuf"):
+		parts = append(parts, "protojson")
+	}
+	if v, ok := c.Get(compressKey); ok {
+		if encoding := v.(*compressWriter).encodingFor(size); encoding != "" {
+			parts = append(parts, encoding)
+		}
+	}
+	return parts
+}
+
+// withRepresentation 在 ETag 的引号内加上表示形式的后缀
+func withRepresentation(etag string, parts []string) string {
+	if len(parts) == 0 || !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return etag[:len(etag)-1] + ";" + strings.Join(parts, ";") + `"`
+}
+
+// baseETag 去掉表示形式的后缀
+func baseETag(etag string) string {
+	if i := strings.IndexByte(etag, ';'); i >= 0 && strings.HasSuffix(etag, `"`) {
+		return etag[:i] + `"`
+	}
+	return etag
+}
+
+// notModified If-None-Match 优先, 没有时才比较 If-Modified-Since
+func notModified(r *http.Request, etag, lastModified string) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return MatchETag(inm, etag, true)
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
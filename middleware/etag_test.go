@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+	"go-api/serializer"
+)
+
+type testVersion struct{}
+
+func (testVersion) ETag() string            { return `"pet-1"` }
+func (testVersion) LastModified() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+func newETagRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/hash", ETag("private, no-cache"), func(c *gin.Context) {
+		c.JSON(200, serializer.Response{Data: "hello"})
+	})
+	r.GET("/version", ETag(""), func(c *gin.Context) {
+		SetVersion(c, testVersion{})
+		c.JSON(200, serializer.Response{Data: "pet"})
+	})
+	r.GET("/error", ETag("private, max-age=60"), func(c *gin.Context) {
+		c.JSON(200, serializer.ParamErr("", nil))
+	})
+	r.GET("/msgpack", ETag(""), func(c *gin.Context) {
+		SetVersion(c, testVersion{})
+		c.Render(200, render.MsgPack{Data: serializer.Response{Data: "pet"}})
+	})
+	r.GET("/msgpack-error", ETag("private, max-age=60"), func(c *gin.Context) {
+		c.Render(200, render.MsgPack{Data: serializer.ParamErr("", nil)})
+	})
+	return r
+}
+
+func get(r http.Handler, path string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestETagFromBody(t *testing.T) {
+	r := newETagRouter()
+	w := get(r, "/hash", nil)
+	etag := w.Header().Get("ETag")
+	if w.Code != 200 || etag == "" || w.Body.Len() == 0 {
+		t.Fatalf("unexpected response %d %q %q", w.Code, etag, w.Body.String())
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != "private, no-cache" {
+		t.Fatalf("Cache-Control = %q", cc)
+	}
+
+	w = get(r, "/hash", map[string]string{"If-None-Match": `"other", ` + etag})
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("expected 304, got %d %q", w.Code, w.Body.String())
+	}
+	w = get(r, "/hash", map[string]string{"If-None-Match": `"other"`})
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestETagFromVersion(t *testing.T) {
+	r := newETagRouter()
+	w := get(r, "/version", nil)
+	if etag := w.Header().Get("ETag"); etag != `"pet-1"` {
+		t.Fatalf("ETag = %q", etag)
+	}
+	w = get(r, "/version", map[string]string{"If-Modified-Since": "Wed, 01 Jan 2020 00:00:00 GMT"})
+	if w.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", w.Code)
+	}
+	w = get(r, "/version", map[string]string{"If-Modified-Since": "Tue, 31 Dec 2019 00:00:00 GMT"})
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestETagSkipsErrors(t *testing.T) {
+	for _, path := range []string{"/error", "/msgpack-error"} {
+		w := get(newETagRouter(), path, nil)
+		if w.Header().Get("ETag") != "" || w.Header().Get("Cache-Control") != "" {
+			t.Fatalf("%s: error response cached: %v", path, w.Header())
+		}
+	}
+}
+
+func TestETagRepresentation(t *testing.T) {
+	r := newETagRouter()
+	if etag := get(r, "/msgpack", nil).Header().Get("ETag"); etag != `"pet-1;msgpack"` {
+		t.Fatalf("msgpack ETag = %q", etag)
+	}
+	// JSON 的 ETag 不能让 msgpack 响应返回 304
+	if w := get(r, "/msgpack", map[string]string{"If-None-Match": `"pet-1"`}); w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w := get(r, "/msgpack", map[string]string{"If-None-Match": `"pet-1;msgpack"`}); w.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", w.Code)
+	}
+
+	compressed := gin.New()
+	compressed.Use(Compress())
+	compressed.GET("/large", ETag(""), func(c *gin.Context) {
+		SetVersion(c, testVersion{})
+		c.JSON(200, serializer.Response{Data: strings.Repeat("pet ", 1000)})
+	})
+	if etag := get(compressed, "/large", nil).Header().Get("ETag"); etag != `"pet-1"` {
+		t.Fatalf("identity ETag = %q", etag)
+	}
+	gzipped := map[string]string{"Accept-Encoding": "gzip"}
+	if etag := get(compressed, "/large", gzipped).Header().Get("ETag"); etag != `"pet-1;gzip"` {
+		t.Fatalf("gzip ETag = %q", etag)
+	}
+	gzipped["If-None-Match"] = `"pet-1;gzip"`
+	if w := get(compressed, "/large", gzipped); w.Code != http.StatusNotModified || w.Header().Get("ETag") != `"pet-1;gzip"` {
+		t.Fatalf("expected 304, got %d %v", w.Code, w.Header())
+	}
+}
+
+func TestMatchETag(t *testing.T) {
+	cases := []struct {
+		header, etag string
+		weak, want   bool
+	}{
+		{`"a"`, `"a"`, false, true},
+		{`W/"a"`, `"a"`, true, true},
+		{`W/"a"`, `"a"`, false, false},
+		{`"a"`, `W/"a"`, false, false},
+		{`"b", "a"`, `"a"`, false, true},
+		{`*`, `"a"`, false, true},
+		{`"b"`, `"a"`, true, false},
+		// If-Match 忽略表示形式, If-None-Match 不忽略
+		{`"a;msgpack;gzip"`, `"a"`, false, true},
+		{`"a;gzip"`, `"a"`, true, false},
+	}
+	for _, c := range cases {
+		if got := MatchETag(c.header, c.etag, c.weak); got != c.want {
+			t.Errorf("MatchETag(%q, %q, %v) = %v", c.header, c.etag, c.weak, got)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"go-api/cache"
+	"go-api/serializer"
+	"go-api/util"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// IdempotencyHeader 客户端携带的幂等键
+	IdempotencyHeader = "Idempotency-Key"
+	// IdempotencyReplayedHeader 标记响应来自幂等缓存重放
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyPrefix = "idempotency:"
+)
+
+// idempotencyWait 等待并发的重复请求完成的最长时间
+var idempotencyWait = 5 * time.Second
+
+// idempotencyRecord 保存在 redis 中的请求指纹与响应
+type idempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// bodyWriter 记录写出的响应体, 用于幂等重放
+type bodyWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency 幂等键中间件
+// 请求头携带 Idempotency-Key 时, 相同用户的重复请求直接重放第一次的响应,
+// 复用幂等键但请求内容不同则拒绝; 未登录的请求不参与
+// IDEMPOTENCY_TTL 记录保存秒数, IDEMPOTENCY_ROUTES 参与的路由, 如 "POST /api/v1/pets", 为空时所有写操作都参与
+func Idempotency() gin.HandlerFunc {
+	ttl := 24 * time.Hour
+	if s, err := strconv.Atoi(os.Getenv("IDEMPOTENCY_TTL")); err == nil && s > 0 {
+		ttl = time.Duration(s) * time.Second
+	}
+	routes := make(map[string]bool)
+	for _, route := range strings.Split(os.Getenv("IDEMPOTENCY_ROUTES"), ",") {
+		if route = strings.Join(strings.Fields(route), " "); route != "" {
+			routes[route] = true
+		}
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyHeader)
+		if key == "" || !idempotentMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(routes) > 0 && !routes[c.Request.Method+" "+c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		// 无法确定用户的请求不参与, 避免不同用户共用同一份记录
+		user, ok := idempotencyUser(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		body, err := c.GetRawData()
+		if err != nil {
+			c.JSON(400, serializer.ParamErr("", err))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+
+		fingerprint := idempotencyFingerprint(c.Request.Method, c.FullPath(), user, body)
+		recordKey := cache.Key(c.Request.Context(), idempotencyPrefix+user+":"+util.StringToMD5(key))
+
+		ctx := c.Request.Context()
+		lock := util.NewRedisLock(cache.RedisClient, recordKey+":lock", idempotencyWait*2)
+		lockCtx, cancel := context.WithTimeout(ctx, idempotencyWait)
+		err = lock.Lock(lockCtx)
+		cancel()
+		if err != nil {
+			c.JSON(409, serializer.Err(serializer.CodeIdempotencyInFlight, "请求处理中, 请稍后重试", nil))
+			c.Abort()
+			return
+		}
+		// 处理时间超过锁的有效期时自动续期, 避免重复的请求在处理完成前拿到锁
+		lock.KeepAlive(ctx)
+		defer lock.Unlock(context.Background())
+
+		if raw, err := cache.RedisClient.Get(ctx, recordKey).Bytes(); err == nil {
+			var record idempotencyRecord
+			if err := json.Unmarshal(raw, &record); err == nil {
+				if record.Fingerprint != fingerprint {
+					c.JSON(422, serializer.Err(serializer.CodeIdempotencyMismatch, "幂等键已被其他请求使用", nil))
+					c.Abort()
+					return
+				}
+				c.Header(IdempotencyReplayedHeader, "true")
+				c.Data(record.Status, record.ContentType, record.Body)
+				c.Abort()
+				return
+			}
+		}
+
+		w := &bodyWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = w
+		c.Next()
+
+		// 服务端错误与业务错误允许客户端修正后使用同一个幂等键重试
+		if w.Status() >= http.StatusInternalServerError {
+			return
+		}
+		if code, ok := responseCode(w.Header().Get("Content-Type"), w.body.Bytes()); ok && code != 0 {
+			return
+		}
+		record, _ := json.Marshal(idempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      w.Status(),
+			ContentType: w.Header().Get("Content-Type"),
+			Body:        w.body.Bytes(),
+		})
+		if err := cache.RedisClient.Set(context.Background(), recordKey, record, ttl).Err(); err != nil {
+			util.Log().Error("保存幂等记录失败 %v", err)
+		}
+	}
+}
+
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// idempotencyUser 幂等记录按用户隔离, 与 JWTAuth 相同地从 token 请求头或 session 中确定用户
+// 中间件在 JWTAuth 之前执行, token 需要在这里校验; 第三方应用的 token 与用户自己的请求分开记录
+func idempotencyUser(c *gin.Context) (string, bool) {
+	claims, _ := c.Get("claims")
+	u, _ := claims.(*CustomClaims)
+	if u == nil {
+		if headerToken(c) != "" {
+			u = headerClaims(c)
+		} else {
+			u = sessionClaims(c)
+		}
+	}
+	if u == nil || u.ID == 0 {
+		return "", false
+	}
+	user := strconv.Itoa(u.Tenant) + ":" + strconv.Itoa(int(u.ID))
+	if u.Delegated() {
+		user += ":" + u.ClientID
+	}
+	return user, true
+}
+
+func idempotencyFingerprint(method, path, user string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	h := sha256.New()
+	h.Write([]byte(method + "\n" + path + "\n" + user + "\n"))
+	h.Write(bodyHash[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+	"go-api/cache"
+	"go-api/serializer"
+	"go-api/util"
+)
+
+// newIdempotencyRouter 使用 miniredis 的路由, calls 记录处理函数实际执行的次数
+func newIdempotencyRouter(t *testing.T) (*gin.Engine, *miniredis.Miniredis, *int32, chan struct{}) {
+	gin.SetMode(gin.TestMode)
+	m := miniredis.RunT(t)
+	client, old := redis.NewClient(&redis.Options{Addr: m.Addr()}), cache.RedisClient
+	cache.RedisClient = client
+	t.Cleanup(func() {
+		client.Close()
+		cache.RedisClient = old
+	})
+
+	var calls int32
+	release := make(chan struct{})
+	r := gin.New()
+	r.Use(Idempotency())
+	r.POST("/orders", func(c *gin.Context) {
+		n := atomic.AddInt32(&calls, 1)
+		c.JSON(200, serializer.Response{Data: n})
+	})
+	r.POST("/invalid", func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.JSON(200, serializer.ParamErr("参数错误", nil))
+	})
+	r.POST("/slow", func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-release:
+		case <-time.After(2 * time.Second):
+		}
+		c.JSON(200, serializer.Response{})
+	})
+	return r, m, &calls, release
+}
+
+// userToken 签发用户 id 的 token 并登记为当前有效的 token
+func userToken(t *testing.T, id int) string {
+	token, err := NewJWT().CreateToken(CustomClaims{ID: uint(id), StandardClaims: jwt.StandardClaims{ExpiresAt: util.Now().Add(time.Hour).Unix()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.RedisClient.Set(context.Background(), cache.TenantKey(0, "user:"+strconv.Itoa(id)), util.StringToMD5(token), 0)
+	return token
+}
+
+func post(r http.Handler, path, key, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyHeader, key)
+	if token != "" {
+		req.Header.Set("token", token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	r, m, calls, _ := newIdempotencyRouter(t)
+	token := userToken(t, 1)
+	first := post(r, "/orders", "k1", `{"a":1}`, token)
+	second := post(r, "/orders", "k1", `{"a":1}`, token)
+	if *calls != 1 || second.Header().Get(IdempotencyReplayedHeader) != "true" || second.Body.String() != first.Body.String() {
+		t.Fatalf("not replayed: calls=%d %q %q", *calls, first.Body.String(), second.Body.String())
+	}
+	// 不同的幂等键正常执行
+	if post(r, "/orders", "k2", `{"a":1}`, token); *calls != 2 {
+		t.Fatalf("calls = %d", *calls)
+	}
+
+	// 相同的键不同的请求内容
+	w := post(r, "/orders", "k1", `{"a":2}`, token)
+	var res serializer.Response
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if w.Code != 422 || res.Code != serializer.CodeIdempotencyMismatch || *calls != 2 {
+		t.Fatalf("mismatch: %d %s", w.Code, w.Body.String())
+	}
+
+	// 记录过期后重新执行
+	m.FastForward(25 * time.Hour)
+	if w := post(r, "/orders", "k1", `{"a":2}`, token); w.Code != 200 || w.Header().Get(IdempotencyReplayedHeader) != "" || *calls != 3 {
+		t.Fatalf("after expiry: %d %s calls=%d", w.Code, w.Body.String(), *calls)
+	}
+}
+
+func TestIdempotencyUsers(t *testing.T) {
+	r, _, calls, _ := newIdempotencyRouter(t)
+	alice, bob := userToken(t, 1), userToken(t, 2)
+
+	// 不同用户使用相同的幂等键与请求内容互不影响
+	first := post(r, "/orders", "k1", `{"a":1}`, alice)
+	if w := post(r, "/orders", "k1", `{"a":1}`, bob); w.Header().Get(IdempotencyReplayedHeader) != "" || w.Body.String() == first.Body.String() || *calls != 2 {
+		t.Fatalf("replayed another user's response: %s", w.Body.String())
+	}
+	// 未登录或 token 无效的请求不参与
+	for _, token := range []string{"", "invalid"} {
+		post(r, "/orders", "k2", `{"a":1}`, token)
+		if w := post(r, "/orders", "k2", `{"a":1}`, token); w.Header().Get(IdempotencyReplayedHeader) != "" {
+			t.Fatalf("anonymous request %q replayed", token)
+		}
+	}
+	if *calls != 6 {
+		t.Fatalf("calls = %d", *calls)
+	}
+}
+
+func TestIdempotencySkipsErrors(t *testing.T) {
+	r, _, calls, _ := newIdempotencyRouter(t)
+	token := userToken(t, 1)
+	post(r, "/invalid", "k1", `{}`, token)
+	if w := post(r, "/invalid", "k1", `{}`, token); w.Header().Get(IdempotencyReplayedHeader) != "" || *calls != 2 {
+		t.Fatalf("business error replayed: calls=%d", *calls)
+	}
+}
+
+func TestIdempotencyInFlight(t *testing.T) {
+	r, m, calls, release := newIdempotencyRouter(t)
+	token := userToken(t, 1)
+	old := idempotencyWait
+	idempotencyWait = 100 * time.Millisecond
+	defer func() { idempotencyWait = old }()
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- post(r, "/slow", "k1", `{}`, token) }()
+	for atomic.LoadInt32(calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// 处理时间超过锁的有效期 200ms, 续期使锁仍然有效
+	for i := 0; i < 2; i++ {
+		time.Sleep(100 * time.Millisecond)
+		m.FastForward(150 * time.Millisecond)
+	}
+
+	w := post(r, "/slow", "k1", `{}`, token)
+	var res serializer.Response
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if w.Code != 409 || res.Code != serializer.CodeIdempotencyInFlight {
+		t.Fatalf("concurrent: %d %s", w.Code, w.Body.String())
+	}
+	close(release)
+	if w := <-done; w.Code != 200 || atomic.LoadInt32(calls) != 1 {
+		t.Fatalf("first: %d calls=%d", w.Code, *calls)
+	}
+	if w := post(r, "/slow", "k1", `{}`, token); w.Header().Get(IdempotencyReplayedHeader) != "true" {
+		t.Fatalf("not replayed after completion: %v", w.Header())
+	}
+}
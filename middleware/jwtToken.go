@@ -1,11 +1,17 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"github.com/golang-jwt/jwt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go-api/auth/oauth"
 	"go-api/cache"
+	"go-api/ent"
+	"go-api/model"
 	"go-api/serializer"
+	"go-api/tenancy"
 	"go-api/util"
 	"os"
 	"strconv"
@@ -13,34 +19,54 @@ import (
 	"time"
 )
 
+// JWTAuth 校验请求头中的 token, 没有 token 时使用 Session 中间件登录的 session
 func JWTAuth() gin.HandlerFunc {
+	return jwtAuth(VerifyToken, true)
+}
+
+// JWTRefresh 刷新 token 接口使用, 过期时间在 TOKEN_REFRESH_GRACE 秒以内的 token 仍可用于换取新 token
+func JWTRefresh() gin.HandlerFunc {
+	return jwtAuth(verifyRefreshToken, false)
+}
+
+func jwtAuth(verify func(token string) (*CustomClaims, error), allowSession bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := c.Request.Header.Get("token")
+		token := headerToken(c)
+		if token == "" && allowSession {
+			if claims := sessionClaims(c); claims != nil {
+				if !BindTenantClaims(c, claims) {
+					return
+				}
+				c.Set("claims", claims)
+				c.Set(sessionAuth, true)
+				c.Next()
+				return
+			}
+		}
 		if token == "" {
 			c.JSON(200, serializer.Err(serializer.CodeTokenError, "缺少token", nil))
 			c.Abort()
 			return
 		}
 		//fmt.Fprintln(gin.DefaultWriter, token)
-		j := NewJWT()
-		// parseToken 解析token包含的信息
-		claims, err := j.ParseToken(token)
+		claims, err := verify(token)
 		if err != nil {
 			if err == TokenExpired {
 				c.JSON(200, serializer.Err(serializer.CodeTokenError, "已过期", err))
 				c.Abort()
 				return
 			}
+			if err == TokenRevoked {
+				c.JSON(400, serializer.Err(serializer.CodeParamErr, "token失效, 重新获取", nil))
+				c.Abort()
+				return
+			}
 			c.JSON(200, serializer.Err(serializer.CodeTokenError, "", err))
 			c.Abort()
 			return
 		}
 
-		tokenMD5 := util.StringToMD5(token)
-		key := strconv.Itoa(int(claims.ID))
-		if strings.Compare(cache.RedisClient.Get("user:"+key).Val(), tokenMD5) != 0 {
-			c.JSON(400, serializer.Err(serializer.CodeParamErr, "token失效, 重新获取", nil))
-			c.Abort()
+		if !BindTenantClaims(c, claims) {
 			return
 		}
 
@@ -51,6 +77,64 @@ func JWTAuth() gin.HandlerFunc {
 	}
 }
 
+// VerifyToken 解析token并校验是否为该用户当前有效的token
+func VerifyToken(token string) (*CustomClaims, error) {
+	j := NewJWT()
+	// parseToken 解析token包含的信息
+	claims, err := j.ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return claims, checkCurrent(token, claims)
+}
+
+// verifyRefreshToken 与 VerifyToken 相同, 但允许在宽限期内过期, 第三方应用的 token 不能刷新
+func verifyRefreshToken(token string) (*CustomClaims, error) {
+	claims, err := NewJWT().parseRefreshable(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Delegated() {
+		return nil, TokenInvalid
+	}
+	return claims, checkCurrent(token, claims)
+}
+
+// checkCurrent 登出或刷新后旧 token 失效
+// 第三方应用的 token 与用户登录的 token 互不影响, 检查是否被单独吊销, 是否签发于用户或应用的
+// 全部 token 被吊销之前, 以及授权的用户是否仍为激活状态
+func checkCurrent(token string, claims *CustomClaims) error {
+	if claims.Delegated() {
+		ctx := context.Background()
+		if oauth.Revoked(ctx, claims.Tenant, claims.Id) ||
+			oauth.RevokedBefore(ctx, claims.Tenant, int(claims.ID), claims.ClientID, claims.IssuedAt) {
+			return TokenRevoked
+		}
+		if claims.ID != 0 {
+			if u, err := loadUser(claims); err != nil || u.Status != model.Active {
+				return TokenRevoked
+			}
+		}
+		return nil
+	}
+	tokenMD5 := util.StringToMD5(token)
+	key := strconv.Itoa(int(claims.ID))
+	if strings.Compare(cache.RedisClient.Get(context.Background(), cache.TenantKey(claims.Tenant, "user:"+key)).Val(), tokenMD5) != 0 {
+		return TokenRevoked
+	}
+	return nil
+}
+
+// loadUser 从数据库读取 token 所属的用户, 已删除的用户返回 NotFound
+func loadUser(claims *CustomClaims) (*ent.User, error) {
+	id := claims.Tenant
+	if id == 0 {
+		id = tenancy.DefaultID
+	}
+	ctx := tenancy.NewContext(context.Background(), &tenancy.Tenant{ID: id})
+	return model.Client.User.Get(ctx, int(claims.ID))
+}
+
 type JWT struct {
 	SigningKey []byte
 }
@@ -60,6 +144,7 @@ var (
 	TokenNotValidYet error  = errors.New("token 尚未激活")
 	TokenMalformed   error  = errors.New("非法 token")
 	TokenInvalid     error  = errors.New("无法处理此 token")
+	TokenRevoked     error  = errors.New("token 已失效")
 	SignKey          string = "newtrekWang"
 )
 
@@ -68,9 +153,21 @@ type CustomClaims struct {
 	ID    uint   `json:"userId"`
 	Name  string `json:"name"`
 	Phone string `json:"phone"`
+	Role  string `json:"role,omitempty"`
+	// Tenant 用户所属租户 id
+	Tenant int `json:"tid,omitempty"`
+	// ClientID 第三方应用的 token 签发给的应用, 用户自己登录的 token 为空
+	ClientID string `json:"client_id,omitempty"`
+	// Scope 第三方应用的授权范围, 空格分隔
+	Scope string `json:"scope,omitempty"`
 	jwt.StandardClaims
 }
 
+// Delegated 是否为签发给第三方应用的 token
+func (c *CustomClaims) Delegated() bool {
+	return c.ClientID != ""
+}
+
 // 创建jwt 实例
 func NewJWT() *JWT {
 	return &JWT{
@@ -89,8 +186,18 @@ func SetSignKey(key string) string {
 	return SignKey
 }
 
-//创建token
+// TokenID 生成 token 的 jti, 测试中替换为确定的序列
+var TokenID = uuid.NewString
+
+func init() {
+	// token 的过期校验与签发使用同一个时钟
+	jwt.TimeFunc = func() time.Time { return util.Now() }
+}
+
+//创建token, 每次生成新的 jti, 同一秒内签发的 token 也互不相同
 func (j *JWT) CreateToken(claims CustomClaims) (string, error) {
+	claims.Id = TokenID()
+	claims.IssuedAt = util.Now().Unix()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(j.SigningKey)
 }
@@ -119,22 +226,45 @@ func (j *JWT) ParseToken(tokenString string) (*CustomClaims, error) {
 	return nil, TokenInvalid
 }
 
-//更新token
-func (j *JWT) RefreshToken(tokenString string) (string, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (i interface{}, e error) {
+// RefreshGrace token 过期后仍可用于刷新的时长, TOKEN_REFRESH_GRACE 秒, 默认 7 天
+// redis 中登记的当前 token 需要保留到宽限期结束
+func RefreshGrace() time.Duration {
+	grace, err := strconv.Atoi(os.Getenv("TOKEN_REFRESH_GRACE"))
+	if err != nil || grace <= 0 {
+		grace = 7 * 24 * 3600
+	}
+	return time.Duration(grace) * time.Second
+}
+
+// parseRefreshable 解析 token, 已过期但未超过 TOKEN_REFRESH_GRACE 秒(默认 7 天)的 token 视为有效
+func (j *JWT) parseRefreshable(tokenString string) (*CustomClaims, error) {
+	claims := &CustomClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (i interface{}, e error) {
 		return j.SigningKey, nil
 	})
+	if err == nil {
+		return claims, nil
+	}
+	ve, ok := err.(*jwt.ValidationError)
+	if !ok || ve.Errors != jwt.ValidationErrorExpired {
+		return j.ParseToken(tokenString)
+	}
+	if time.Unix(claims.ExpiresAt, 0).Add(RefreshGrace()).Before(util.Now()) {
+		return nil, TokenExpired
+	}
+	return claims, nil
+}
+
+//更新token
+func (j *JWT) RefreshToken(tokenString string) (string, error) {
+	claims, err := j.parseRefreshable(tokenString)
 	if err != nil {
 		return "", err
 	}
-	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
-		jwt.TimeFunc = time.Now
-		var ttl int
-		if ttl, err = strconv.Atoi(os.Getenv("TOKEN_TTL")); err != nil {
-			return "", err
-		}
-		claims.StandardClaims.ExpiresAt = time.Now().Add(time.Duration(ttl) * time.Second).Unix()
-		return j.CreateToken(*claims)
+	var ttl int
+	if ttl, err = strconv.Atoi(os.Getenv("TOKEN_TTL")); err != nil {
+		return "", err
 	}
-	return "", TokenInvalid
+	claims.StandardClaims.ExpiresAt = util.Now().Add(time.Duration(ttl) * time.Second).Unix()
+	return j.CreateToken(*claims)
 }
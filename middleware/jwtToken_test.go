@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+func expiredToken(t *testing.T, ago time.Duration) string {
+	t.Helper()
+	token, err := NewJWT().CreateToken(CustomClaims{
+		ID:             1,
+		Name:           "alice",
+		StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(-ago).Unix()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestRefreshGrace(t *testing.T) {
+	t.Setenv("TOKEN_TTL", "3600")
+	t.Setenv("TOKEN_REFRESH_GRACE", "600")
+	j := NewJWT()
+
+	// 普通接口不接受过期 token, 刷新接口在宽限期内接受
+	token := expiredToken(t, time.Minute)
+	if _, err := j.ParseToken(token); err != TokenExpired {
+		t.Fatalf("ParseToken err = %v", err)
+	}
+	claims, err := j.parseRefreshable(token)
+	if err != nil || claims.ID != 1 {
+		t.Fatalf("parseRefreshable = %+v, %v", claims, err)
+	}
+	refreshed, err := j.RefreshToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh, err := j.ParseToken(refreshed)
+	if err != nil || fresh.ID != 1 || fresh.ExpiresAt <= time.Now().Unix() {
+		t.Fatalf("refreshed token = %+v, %v", fresh, err)
+	}
+	if fresh.Id == "" || fresh.Id == claims.Id {
+		t.Fatalf("jti not renewed: %q -> %q", claims.Id, fresh.Id)
+	}
+
+	// 超过宽限期后不能再刷新
+	if _, err := j.RefreshToken(expiredToken(t, time.Hour)); err != TokenExpired {
+		t.Fatalf("RefreshToken after grace err = %v", err)
+	}
+	// 签名错误的 token 不因为过期判断而被放行
+	other := &JWT{[]byte("other")}
+	forged, _ := other.CreateToken(CustomClaims{ID: 1, StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(-time.Minute).Unix()}})
+	if _, err := j.parseRefreshable(forged); err == nil {
+		t.Fatal("forged token accepted")
+	}
+}
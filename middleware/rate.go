@@ -3,27 +3,80 @@ package middleware
 import (
 	"context"
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"go-api/cache"
 	"go-api/serializer"
-	"go.uber.org/ratelimit"
-	"golang.org/x/time/rate"
-	"os"
+	"go-api/tenancy"
+	"go-api/util"
 	"strconv"
-	"sync"
 	"time"
 )
 
-var r, b int
+// rateWait 请求最多排队等待的时间, 超过则拒绝
+const rateWait = 400 * time.Millisecond
 
-// 令牌桶|楼桶根据请求ip 限流
+// GCRA 限流, 等价于容量为 burst, 每 interval 微秒流入一个令牌的令牌桶
+// KEYS[1] 保存下一个令牌的理论到达时间(TAT), 时间取自 redis, 多个实例共享同一个桶
+// ARGV[1] interval 微秒, ARGV[2] burst, ARGV[3] 最多等待的微秒数, 小于 0 时不限
+// 返回 -1 代表拒绝, 否则返回需要等待的微秒数
+var rateScript = redis.NewScript(`
+local now = redis.call("TIME")
+now = tonumber(now[1]) * 1000000 + tonumber(now[2])
+local interval = tonumber(ARGV[1])
+local maxWait = tonumber(ARGV[3])
+local tat = tonumber(redis.call("GET", KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+tat = tat + interval
+local wait = tat - interval * tonumber(ARGV[2]) - now
+if maxWait >= 0 and wait > maxWait then
+	return -1
+end
+redis.call("SET", KEYS[1], string.format("%.0f", tat), "PX", math.ceil((tat - now) / 1000))
+if wait < 0 then
+	return 0
+end
+return wait
+`)
+
+// rateTake 从 key 对应的桶中取一个令牌, 需要等待时阻塞直到轮到本次请求
+// r 每秒请求数, b 突发请求数, maxWait 小于 0 时不限制等待时间
+func rateTake(ctx context.Context, key string, r, b int, maxWait time.Duration) (bool, error) {
+	if r <= 0 || b <= 0 {
+		return false, nil
+	}
+	interval := int64(time.Second/time.Microsecond) / int64(r)
+	wait, err := rateScript.Run(ctx, cache.RedisClient, []string{key}, interval, b, maxWait.Microseconds()).Int64()
+	if err != nil || wait < 0 {
+		return false, err
+	}
+	if wait > 0 {
+		timer := time.NewTimer(time.Duration(wait) * time.Microsecond)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return true, nil
+}
+
+// 令牌桶|楼桶根据租户和请求ip 限流, 计数保存在 redis 中, 多个实例共享限额
+// RATE_R 每秒请求数, RATE_B 突发请求数, 可以按租户配置覆盖, 需要在 Tenant 之后使用
+// redis 不可用时不限流
 func Rate() gin.HandlerFunc {
-	r, _ = strconv.Atoi(os.Getenv("RATE_R"))
-	b, _ = strconv.Atoi(os.Getenv("RATE_B"))
-	limiters:=&sync.Map{}
 	return func(c *gin.Context) {
-		l,_:=limiters.LoadOrStore(c.ClientIP(), rate.NewLimiter(rate.Limit(r), b))
-		ctx, cancel := context.WithTimeout(c, 400*time.Millisecond)
-		defer cancel()
-		if err := l.(*rate.Limiter).WaitN(ctx, 1); err != nil {
+		ctx := c.Request.Context()
+		r, _ := strconv.Atoi(tenancy.Config(ctx, "RATE_R"))
+		b, _ := strconv.Atoi(tenancy.Config(ctx, "RATE_B"))
+		ok, err := rateTake(ctx, cache.Key(ctx, "rate:"+c.ClientIP()), r, b, rateWait)
+		if err != nil && ctx.Err() == nil {
+			util.Log().Warning("限流失败 %v", err)
+			ok = true
+		}
+		if !ok {
 			c.JSON(400, serializer.Err(serializer.CodeOverClock, "请求频率过高", err))
 			c.Abort()
 			return
@@ -32,13 +85,13 @@ func Rate() gin.HandlerFunc {
 	}
 }
 
-//漏桶
+// LRate 漏桶, 按 ip 每秒放行 3 个请求, 超出的请求排队等待
 func LRate() gin.HandlerFunc {
-	limiters := &sync.Map{}
 	return func(c *gin.Context) {
-		key:=c.ClientIP()
-		l, _ := limiters.LoadOrStore(key, ratelimit.New(3))
-		l.(ratelimit.Limiter).Take()
+		ctx := c.Request.Context()
+		if _, err := rateTake(ctx, cache.Key(ctx, "lrate:"+c.ClientIP()), 3, 1, -1); err != nil && ctx.Err() == nil {
+			util.Log().Warning("限流失败 %v", err)
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"go-api/cache"
+	"go-api/serializer"
+)
+
+func TestRateShared(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := miniredis.RunT(t)
+	client, old := redis.NewClient(&redis.Options{Addr: m.Addr()}), cache.RedisClient
+	cache.RedisClient = client
+	t.Cleanup(func() {
+		client.Close()
+		cache.RedisClient = old
+	})
+	t.Setenv("RATE_R", "1")
+	t.Setenv("RATE_B", "2")
+
+	// 两个实例共享同一个桶
+	instances := make([]*gin.Engine, 2)
+	for i := range instances {
+		instances[i] = gin.New()
+		instances[i].Use(Rate())
+		instances[i].GET("/ping", func(c *gin.Context) { c.JSON(200, serializer.Response{}) })
+	}
+	get := func(r http.Handler, ip string) serializer.Response {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var res serializer.Response
+		json.Unmarshal(w.Body.Bytes(), &res)
+		return res
+	}
+	if get(instances[0], "192.0.2.1").Code != 0 || get(instances[1], "192.0.2.1").Code != 0 {
+		t.Fatal("burst rejected")
+	}
+	// 下一个令牌在一秒后到达, 超过最长等待时间
+	if res := get(instances[0], "192.0.2.1"); res.Code != serializer.CodeOverClock {
+		t.Fatalf("over burst: %+v", res)
+	}
+	if res := get(instances[1], "192.0.2.1"); res.Code != serializer.CodeOverClock {
+		t.Fatalf("over burst on another instance: %+v", res)
+	}
+	if ttl := m.TTL("rate:192.0.2.1"); ttl <= 0 || ttl > 3*time.Second {
+		t.Fatalf("ttl = %s", ttl)
+	}
+
+	// 等待时间不超过 400ms 时排队执行
+	t.Setenv("RATE_R", "10")
+	t.Setenv("RATE_B", "1")
+	start := time.Now()
+	for _, r := range instances {
+		if res := get(r, "198.51.100.1"); res.Code != 0 {
+			t.Fatalf("queued request rejected: %+v", res)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("queued request not delayed: %s", elapsed)
+	}
+
+	// redis 不可用时不限流
+	m.Close()
+	if res := get(instances[0], "192.0.2.1"); res.Code != 0 {
+		t.Fatalf("redis down: %+v", res)
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"encoding/json"
+	"github.com/ugorji/go/codec"
+	"strings"
+)
+
+// responseCode 解析响应体中的业务码, 业务错误同样以 200 返回
+// 支持 JSON 与 msgpack 响应, 无法解析时 ok 为 false
+func responseCode(contentType string, body []byte) (code int, ok bool) {
+	var res struct {
+		Code int `json:"code" codec:"code"`
+	}
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "msgpack"):
+		var mh codec.MsgpackHandle
+		if err := codec.NewDecoderBytes(body, &mh).Decode(&res); err != nil {
+			return 0, false
+		}
+	case strings.Contains(contentType, "json"):
+		if err := json.Unmarshal(body, &res); err != nil {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return res.Code, true
+}
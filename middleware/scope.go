@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"go-api/auth/oauth"
+	"go-api/serializer"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Scope 第三方应用的 token 需要包含授权范围 scope, 在 JWTAuth 之后使用
+// 用户自己登录的 token 不受限制; scope 保护的都是用户数据, 不代表用户的客户端凭证 token 一律拒绝
+func Scope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, _ := c.Get("claims"); claims != nil {
+			if u, ok := claims.(*CustomClaims); ok && (!u.Delegated() || (u.ID != 0 && oauth.Contains(u.Scope, scope))) {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(403, serializer.Err(serializer.CodeNoRightErr, "授权范围不足: "+scope, nil))
+		c.Abort()
+	}
+}
+
+// FirstParty 只允许用户自己登录的 token, 在 JWTAuth 之后使用
+// 登出, 绑定账号, 授权第三方应用等操作不能由第三方应用代为执行
+func FirstParty() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, _ := c.Get("claims"); claims != nil {
+			if u, ok := claims.(*CustomClaims); ok && !u.Delegated() {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(403, serializer.Err(serializer.CodeNoRightErr, "第三方应用无权访问", nil))
+		c.Abort()
+	}
+}
@@ -1,15 +1,448 @@
 package middleware
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/hex"
+	"go-api/cache"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/util"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-contrib/sessions"
-	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// SessionCookie 保存 session id 的 cookie
+	SessionCookie = "session"
+	// CSRFCookie 保存 CSRF token 的 cookie, 前端读取后放在 CSRFHeader 请求头中
+	CSRFCookie = "csrf_token"
+	// CSRFHeader 携带 CSRF token 的请求头
+	CSRFHeader = "X-CSRF-Token"
+
+	sessionUserKey   = "user_id"
+	sessionTenantKey = "tenant_id"
+	sessionCSRFKey   = "csrf"
+	// sessionCookieKey 上次下发 cookie 的时间
+	sessionCookieKey = "cookie_at"
+	// sessionAuth 请求通过 session cookie 登录
+	sessionAuth = "session_auth"
+	// sessionClaimsKey, headerClaimsKey 在请求内缓存登录用户, 多个中间件共用一次校验
+	sessionClaimsKey = "session_claims"
+	headerClaimsKey  = "header_claims"
 )
 
-// Session 初始化session
+// sessionStore redis 中的 session, cookie 中只保存签名后的 session id
+// 与缓存共用 cache.RedisClient
+type sessionStore struct {
+	secret []byte
+	ttl    time.Duration
+	secure bool
+}
+
+// Session 基于 redis 的 session, secret 用于签名 cookie 中的 session id
+// SESSION_TTL 闲置多少秒后过期, 默认 7 天, 每次访问顺延
+// 通过 sessions.Default(c) 读写, 登录后由 JWTAuth 作为 token 之外的另一种登录凭证
+// session 中只保存用户 id 与租户, 角色与状态每次请求从数据库读取
 func Session(secret string) gin.HandlerFunc {
-	store := cookie.NewStore([]byte(secret))
-	//Also set Secure: true if using SSL, you should though
-	store.Options(sessions.Options{HttpOnly: true, MaxAge: 7 * 86400, Path: "/"})
-	return sessions.Sessions("gin-session", store)
+	ttl, err := strconv.Atoi(os.Getenv("SESSION_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 7 * 86400
+	}
+	store := &sessionStore{
+		secret: []byte(secret),
+		ttl:    time.Duration(ttl) * time.Second,
+		secure: gin.Mode() == gin.ReleaseMode,
+	}
+	return func(c *gin.Context) {
+		s := &redisSession{store: store, c: c, values: make(map[interface{}]interface{})}
+		if cookie, err := c.Cookie(SessionCookie); err == nil {
+			if id, ok := store.verify(cookie); ok && s.load(id) {
+				// 闲置过期时间随访问顺延
+				s.touch()
+			}
+		}
+		c.Set(sessions.DefaultKey, s)
+		c.Next()
+	}
+}
+
+// sign 签名 session id
+func (st *sessionStore) sign(id string) string {
+	mac := hmac.New(sha256.New, st.secret)
+	mac.Write([]byte(id))
+	return id + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verify 校验 cookie 的签名, 返回 session id
+func (st *sessionStore) verify(cookie string) (string, bool) {
+	i := strings.LastIndexByte(cookie, '.')
+	if i <= 0 {
+		return "", false
+	}
+	id := cookie[:i]
+	return id, subtle.ConstantTimeCompare([]byte(st.sign(id)), []byte(cookie)) == 1
+}
+
+func sessionKey(id string) string {
+	return cache.Prefixed("session:" + id)
+}
+
+// userSessionsKey 用户全部 session 的 id, 用于退出全部设备
+func userSessionsKey(tenantID, userID int) string {
+	return cache.TenantKey(tenantID, "sessions:user:"+strconv.Itoa(userID))
+}
+
+// redisSession 实现 sessions.Session
+type redisSession struct {
+	store   *sessionStore
+	c       *gin.Context
+	id      string
+	values  map[interface{}]interface{}
+	written bool
+	maxAge  int
+}
+
+func (s *redisSession) load(id string) bool {
+	data, err := cache.RedisClient.Get(s.c, sessionKey(id)).Bytes()
+	if err != nil {
+		return false
+	}
+	values := make(map[interface{}]interface{})
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return false
+	}
+	s.id, s.values = id, values
+	return true
+}
+
+// touch 顺延 redis 中的过期时间
+// cookie 距上次下发超过 ttl 的十分之一时才重新下发, 不在每个响应中都带 Set-Cookie
+func (s *redisSession) touch() {
+	issued, _ := s.values[sessionCookieKey].(int64)
+	if util.Now().Sub(time.Unix(issued, 0)) < s.store.ttl/10 {
+		cache.RedisClient.Expire(s.c, sessionKey(s.id), s.store.ttl)
+		return
+	}
+	s.written = true
+	if err := s.Save(); err != nil {
+		util.Log().Warning("顺延 session 失败 %v", err)
+	}
+}
+
+func (s *redisSession) setCookies() {
+	maxAge := int(s.store.ttl / time.Second)
+	if s.maxAge != 0 {
+		maxAge = s.maxAge
+	}
+	http.SetCookie(s.c.Writer, &http.Cookie{
+		Name:     SessionCookie,
+		Value:    s.store.sign(s.id),
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   s.store.secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	if token, ok := s.values[sessionCSRFKey].(string); ok {
+		http.SetCookie(s.c.Writer, &http.Cookie{
+			Name:     CSRFCookie,
+			Value:    token,
+			Path:     "/",
+			MaxAge:   maxAge,
+			Secure:   s.store.secure,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+}
+
+func (s *redisSession) Get(key interface{}) interface{} {
+	return s.values[key]
+}
+
+func (s *redisSession) Set(key interface{}, val interface{}) {
+	s.values[key] = val
+	s.written = true
+}
+
+func (s *redisSession) Delete(key interface{}) {
+	delete(s.values, key)
+	s.written = true
+}
+
+func (s *redisSession) Clear() {
+	s.values = make(map[interface{}]interface{})
+	s.written = true
+}
+
+func (s *redisSession) AddFlash(value interface{}, vars ...string) {
+	key := "_flash"
+	if len(vars) > 0 {
+		key = vars[0]
+	}
+	flashes, _ := s.values[key].([]interface{})
+	s.Set(key, append(flashes, value))
+}
+
+func (s *redisSession) Flashes(vars ...string) []interface{} {
+	key := "_flash"
+	if len(vars) > 0 {
+		key = vars[0]
+	}
+	flashes, _ := s.values[key].([]interface{})
+	if flashes != nil {
+		s.Delete(key)
+	}
+	return flashes
+}
+
+// Options 只支持 MaxAge, 小于 0 时 Save 销毁 session
+func (s *redisSession) Options(options sessions.Options) {
+	s.maxAge = options.MaxAge
+	s.written = true
+}
+
+// Save 保存修改, 新的 session 在第一次保存时分配 id
+func (s *redisSession) Save() error {
+	if !s.written {
+		return nil
+	}
+	s.written = false
+	if s.maxAge < 0 {
+		return s.destroy()
+	}
+	if s.id == "" {
+		s.id = newSessionID()
+	}
+	// 保存后总是下发 cookie
+	s.values[sessionCookieKey] = util.Now().Unix()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.values); err != nil {
+		return err
+	}
+	ttl := s.store.ttl
+	if s.maxAge > 0 {
+		ttl = time.Duration(s.maxAge) * time.Second
+	}
+	if err := cache.RedisClient.Set(s.c, sessionKey(s.id), buf.Bytes(), ttl).Err(); err != nil {
+		return err
+	}
+	s.setCookies()
+	return nil
+}
+
+// destroy 删除 redis 中的 session 并清除 cookie
+func (s *redisSession) destroy() error {
+	if s.id != "" {
+		if userID, ok := s.values[sessionUserKey].(int); ok {
+			tenantID, _ := s.values[sessionTenantKey].(int)
+			cache.RedisClient.SRem(s.c, userSessionsKey(tenantID, userID), s.id)
+		}
+		if err := cache.RedisClient.Del(s.c, sessionKey(s.id)).Err(); err != nil {
+			return err
+		}
+	}
+	s.id, s.values = "", make(map[interface{}]interface{})
+	for _, name := range []string{SessionCookie, CSRFCookie} {
+		http.SetCookie(s.c.Writer, &http.Cookie{Name: name, Value: "", Path: "/", MaxAge: -1})
+	}
+	return nil
+}
+
+func newSessionID() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// currentSession 请求的 session, 没有使用 Session 中间件时返回 nil
+func currentSession(c *gin.Context) *redisSession {
+	if v, ok := c.Get(sessions.DefaultKey); ok {
+		s, _ := v.(*redisSession)
+		return s
+	}
+	return nil
+}
+
+// StartSession 登录成功后为用户开始新的 session
+// 总是分配新的 session id, 防止登录前被植入的 session id 在登录后继续有效(session fixation)
+func StartSession(c *gin.Context, claims *CustomClaims) error {
+	s := currentSession(c)
+	if s == nil {
+		return nil
+	}
+	if s.id != "" {
+		cache.RedisClient.Del(c, sessionKey(s.id))
+	}
+	s.id, s.maxAge = newSessionID(), 0
+	s.values = map[interface{}]interface{}{
+		sessionUserKey:   int(claims.ID),
+		sessionTenantKey: claims.Tenant,
+		sessionCSRFKey:   newSessionID(),
+	}
+	s.written = true
+	if err := s.Save(); err != nil {
+		return err
+	}
+	key := userSessionsKey(claims.Tenant, int(claims.ID))
+	if err := cache.RedisClient.SAdd(c, key, s.id).Err(); err != nil {
+		return err
+	}
+	// 索引保留到最后一个 session 过期
+	return cache.RedisClient.Expire(c, key, s.store.ttl).Err()
+}
+
+// EndSession 退出当前 session
+func EndSession(c *gin.Context) error {
+	s := currentSession(c)
+	if s == nil || s.id == "" {
+		return nil
+	}
+	return s.destroy()
+}
+
+// EndUserSessions 退出用户在所有设备上的 session
+// 逐个删除, 集群模式下这些键不在同一个 slot
+func EndUserSessions(ctx context.Context, tenantID, userID int) error {
+	key := userSessionsKey(tenantID, userID)
+	ids, err := cache.RedisClient.SMembers(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	_, err = cache.RedisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			pipe.Del(ctx, sessionKey(id))
+		}
+		pipe.Del(ctx, key)
+		return nil
+	})
+	return err
+}
+
+// SessionInfo 用户的一个 session, ID 为 session id 的摘要, 不能用于登录
+type SessionInfo struct {
+	ID        string    `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// UserSessions 用户在所有设备上尚未过期的 session
+func UserSessions(ctx context.Context, tenantID, userID int) ([]SessionInfo, error) {
+	ids, err := cache.RedisClient.SMembers(ctx, userSessionsKey(tenantID, userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]SessionInfo, 0, len(ids))
+	for _, id := range ids {
+		ttl, err := cache.RedisClient.TTL(ctx, sessionKey(id)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if ttl <= 0 {
+			continue
+		}
+		sum := sha256.Sum256([]byte(id))
+		sessions = append(sessions, SessionInfo{
+			ID:        hex.EncodeToString(sum[:8]),
+			ExpiresAt: util.Now().Add(ttl).Truncate(time.Second),
+		})
+	}
+	return sessions, nil
+}
+
+// sessionClaims 已登录 session 中的用户信息, 未登录时返回 nil
+// 角色与状态从数据库读取, 修改角色或停用后立即生效, 停用或删除的用户视为未登录; 结果在请求内缓存
+func sessionClaims(c *gin.Context) *CustomClaims {
+	if v, ok := c.Get(sessionClaimsKey); ok {
+		claims, _ := v.(*CustomClaims)
+		return claims
+	}
+	var claims *CustomClaims
+	if s := currentSession(c); s != nil {
+		if userID, ok := s.values[sessionUserKey].(int); ok {
+			claims = &CustomClaims{ID: uint(userID)}
+			claims.Tenant, _ = s.values[sessionTenantKey].(int)
+			if u, err := loadUser(claims); err == nil && u.Status == model.Active {
+				claims.Role, claims.Name = u.Role, u.Nickname
+			} else {
+				claims = nil
+			}
+		}
+	}
+	c.Set(sessionClaimsKey, claims)
+	return claims
+}
+
+// SessionAuthenticated 请求是否通过 session cookie 登录
+func SessionAuthenticated(c *gin.Context) bool {
+	return c.GetBool(sessionAuth)
+}
+
+// headerClaims 校验请求头中的 token, 没有 token 或校验失败时返回 nil; 结果在请求内缓存
+// CSRF 与 Idempotency 在 JWTAuth 之前执行, 需要自行确认 token 是否有效
+func headerClaims(c *gin.Context) *CustomClaims {
+	if v, ok := c.Get(headerClaimsKey); ok {
+		claims, _ := v.(*CustomClaims)
+		return claims
+	}
+	var claims *CustomClaims
+	if token := headerToken(c); token != "" {
+		if u, err := VerifyToken(token); err == nil {
+			claims = u
+		}
+	}
+	c.Set(headerClaimsKey, claims)
+	return claims
+}
+
+// headerToken 请求头中的 token, 包括 Authorization: Bearer
+func headerToken(c *gin.Context) string {
+	if token := c.Request.Header.Get("token"); token != "" {
+		return token
+	}
+	// 第三方应用按 RFC 6750 使用 Authorization: Bearer
+	if auth := c.Request.Header.Get("Authorization"); len(auth) > 7 && strings.EqualFold(auth[:7], "Bearer ") {
+		return auth[7:]
+	}
+	return ""
+}
+
+// CSRF 校验使用 session cookie 登录的写请求, 需要在请求头 X-CSRF-Token 中携带 csrf_token cookie 的值,
+// 与 session 中保存的 token 比较; 请求头中的 token 有效时由 token 登录, 不依赖 cookie, 不做校验,
+// token 无效时仍按 session 校验
+func CSRF() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		s := currentSession(c)
+		if headerClaims(c) != nil || s == nil || sessionClaims(c) == nil {
+			c.Next()
+			return
+		}
+		expected, _ := s.values[sessionCSRFKey].(string)
+		if expected == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader(CSRFHeader)), []byte(expected)) != 1 {
+			util.Log().Warning("CSRF 校验失败 %s %s", c.Request.Method, c.Request.URL.Path)
+			c.JSON(403, serializer.Err(serializer.CodeNoRightErr, "CSRF token 无效", nil))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
 }
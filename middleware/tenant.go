@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"go-api/ent"
+	"go-api/ent/predicate"
+	"go-api/ent/tenant"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/tenancy"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	localcache "github.com/patrickmn/go-cache"
+)
+
+const (
+	// TenantHeader 指定租户名称的请求头
+	TenantHeader = "X-Tenant"
+	// tenantExplicit 请求通过请求头或子域名明确指定了租户
+	tenantExplicit = "tenant_explicit"
+)
+
+// 租户信息缓存一分钟, 修改租户配置后最长一分钟生效
+var tenants = localcache.New(time.Minute, 10*time.Minute)
+
+// Tenant 解析请求所属的租户并放入请求的 context, 依次使用 X-Tenant 请求头, 子域名,
+// 都没有时为默认租户, 登录后的请求以 token 中的租户为准(见 JWTAuth)
+// TENANT_DOMAIN 子域名解析使用的根域名, 如 api.example.com 时 acme.api.example.com 属于 acme 租户
+func Tenant() gin.HandlerFunc {
+	domain := strings.ToLower(os.Getenv("TENANT_DOMAIN"))
+	return func(c *gin.Context) {
+		name, explicit := c.GetHeader(TenantHeader), true
+		if name == "" {
+			name = subdomain(c.Request.Host, domain)
+		}
+		if name == "" {
+			name, explicit = tenancy.DefaultName, false
+		}
+
+		t, err := TenantByName(c.Request.Context(), name)
+		if !bindTenant(c, t, err) {
+			return
+		}
+		c.Set(tenantExplicit, explicit)
+		c.Next()
+	}
+}
+
+// BindTenantClaims 使用 token 中的租户, token 不属于请求明确指定的租户时拒绝
+// 多租户之前签发的 token 没有租户信息, 属于默认租户; 自行校验 token 的接口(如 realtime)也需要调用
+func BindTenantClaims(c *gin.Context, claims *CustomClaims) bool {
+	id := claims.Tenant
+	if id == 0 {
+		id = tenancy.DefaultID
+	}
+	if tenancy.ID(c.Request.Context()) == id {
+		return true
+	}
+	if c.GetBool(tenantExplicit) {
+		c.JSON(403, serializer.Err(serializer.CodeNoRightErr, "token 不属于当前租户", nil))
+		c.Abort()
+		return false
+	}
+	t, err := TenantByID(c.Request.Context(), id)
+	return bindTenant(c, t, err)
+}
+
+// bindTenant 把租户放入请求的 context, 租户不存在或已停用时中止请求
+func bindTenant(c *gin.Context, t *tenancy.Tenant, err error) bool {
+	if ent.IsNotFound(err) {
+		c.JSON(404, serializer.Err(serializer.CodeNotFound, "租户不存在", nil))
+		c.Abort()
+		return false
+	}
+	if err != nil {
+		c.JSON(500, serializer.DBErr("", err))
+		c.Abort()
+		return false
+	}
+	if t == nil {
+		c.JSON(403, serializer.Err(serializer.CodeNoRightErr, "租户已停用", nil))
+		c.Abort()
+		return false
+	}
+	c.Request = c.Request.WithContext(tenancy.NewContext(c.Request.Context(), t))
+	return true
+}
+
+// subdomain host 在根域名下的第一级子域名
+func subdomain(host, domain string) string {
+	if domain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	if !strings.HasSuffix(host, "."+domain) {
+		return ""
+	}
+	sub := strings.TrimSuffix(host, "."+domain)
+	if i := strings.LastIndexByte(sub, '.'); i >= 0 {
+		sub = sub[i+1:]
+	}
+	return sub
+}
+
+// TenantByName 按名称查询租户, 停用的租户返回 nil
+func TenantByName(ctx context.Context, name string) (*tenancy.Tenant, error) {
+	return loadTenant(ctx, "name:"+name, tenant.Name(name))
+}
+
+// TenantByID 按 id 查询租户, 停用的租户返回 nil
+func TenantByID(ctx context.Context, id int) (*tenancy.Tenant, error) {
+	return loadTenant(ctx, "id:"+strconv.Itoa(id), tenant.ID(id))
+}
+
+func loadTenant(ctx context.Context, key string, where predicate.Tenant) (*tenancy.Tenant, error) {
+	if v, ok := tenants.Get(key); ok {
+		return v.(*tenancy.Tenant), nil
+	}
+	row, err := model.Client.Tenant.Query().Where(where).Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := buildTenant(row)
+	if err != nil {
+		return nil, err
+	}
+	tenants.SetDefault(key, t)
+	return t, nil
+}
+
+// FlushTenants 清空租户信息缓存
+func FlushTenants() {
+	tenants.Flush()
+}
+
+// buildTenant 转换为请求 context 中的租户
+func buildTenant(t *ent.Tenant) (*tenancy.Tenant, error) {
+	if t.Status != model.Active {
+		return nil, nil
+	}
+	config := make(map[string]string)
+	if t.Config != "" {
+		if err := json.Unmarshal([]byte(t.Config), &config); err != nil {
+			return nil, err
+		}
+	}
+	return &tenancy.Tenant{ID: t.ID, Name: t.Name, Config: config}, nil
+}
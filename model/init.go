@@ -1,10 +1,18 @@
 package model
 
 import (
+	"context"
+	"go-api/dbresolver"
 	"go-api/ent"
+	_ "go-api/ent/runtime"
 	"go-api/util"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	entsql "github.com/facebook/ent/dialect/sql"
+
 	"github.com/jinzhu/gorm"
 
 	_ "github.com/jinzhu/gorm/dialects/mysql"
@@ -36,11 +44,68 @@ func Database(connString string) {
 
 var Client *ent.Client
 
-// ent
+// Resolver Client 使用的读写分离驱动, 用于查看连接池与从库状态
+var Resolver *dbresolver.Driver
+
+// DatabaseEnt 连接主库与 MYSQL_REPLICA_DSNS 中逗号分隔的从库, 查询读写分离
+// DB_QUERY_TIMEOUT 单条语句超时秒数, 默认 10, 0 表示不限制
+// DB_REPLICA_CHECK_INTERVAL 从库健康检查间隔秒数, 默认 5; DB_AUTO_MIGRATE=true 时连接后执行 Migrate
 func DatabaseEnt(connString string) {
-	client, err := ent.Open("mysql", connString)
+	primary, err := openEnt(connString)
 	if err != nil {
 		util.Log().Panic("连接数据库不成功", err)
 	}
-	Client = client
+	var replicas []*entsql.Driver
+	for _, dsn := range strings.Split(os.Getenv("MYSQL_REPLICA_DSNS"), ",") {
+		if dsn = strings.TrimSpace(dsn); dsn == "" {
+			continue
+		}
+		replica, err := openEnt(dsn)
+		if err != nil {
+			util.Log().Panic("连接从库不成功", err)
+		}
+		replicas = append(replicas, replica)
+	}
+	Resolver = dbresolver.New(primary, replicas, dbresolver.Config{
+		QueryTimeout:  envSeconds("DB_QUERY_TIMEOUT", 10),
+		CheckInterval: envSeconds("DB_REPLICA_CHECK_INTERVAL", 5),
+	})
+	Client = ent.NewClient(ent.Driver(Resolver))
+
+	// 表结构由 migrate 子命令变更, 多个实例同时启动时自动迁移会并发执行 DDL
+	if os.Getenv("DB_AUTO_MIGRATE") == "true" {
+		if err := Migrate(context.Background()); err != nil {
+			util.Log().Panic("数据库迁移失败", err)
+		}
+	}
+}
+
+// openEnt 打开连接并设置连接池
+// DB_MAX_OPEN_CONNS 最大连接数, 默认 100; DB_MAX_IDLE_CONNS 最大空闲连接数, 默认 50;
+// DB_CONN_MAX_LIFETIME 连接最长使用秒数, 默认 30; DB_CONN_MAX_IDLE_TIME 连接最长空闲秒数, 默认不限制
+func openEnt(dsn string) (*entsql.Driver, error) {
+	drv, err := entsql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db := drv.DB()
+	db.SetMaxOpenConns(envInt("DB_MAX_OPEN_CONNS", 100))
+	db.SetMaxIdleConns(envInt("DB_MAX_IDLE_CONNS", 50))
+	db.SetConnMaxLifetime(envSeconds("DB_CONN_MAX_LIFETIME", 30))
+	db.SetConnMaxIdleTime(envSeconds("DB_CONN_MAX_IDLE_TIME", 0))
+	return drv, nil
+}
+
+// envInt 读取非负整数环境变量, 未设置或不合法时使用默认值
+func envInt(key string, def int) int {
+	n, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || n < 0 {
+		return def
+	}
+	return n
+}
+
+// envSeconds 读取以秒为单位的环境变量
+func envSeconds(key string, def int) time.Duration {
+	return time.Duration(envInt(key, def)) * time.Second
 }
@@ -1,8 +1,33 @@
 package model
 
+import (
+	"context"
+	"fmt"
+	"go-api/dbresolver"
+	"go-api/tenancy"
+	"go-api/util"
+)
+
 //执行数据迁移
 
 func migration() {
 	// 自动迁移模式
 	DB.AutoMigrate(&User{})
 }
+
+// Migrate ent 迁移, 只新增表和字段, 并创建默认租户
+// 由 migrate 子命令在部署时执行一次; DB_AUTO_MIGRATE=true 时每次启动执行, 只建议在单实例的开发环境中开启
+func Migrate(ctx context.Context) error {
+	ctx = dbresolver.Primary(ctx)
+	if err := Client.Schema.Create(ctx); err != nil {
+		return fmt.Errorf("数据库迁移失败: %w", err)
+	}
+	t, err := DefaultTenant(ctx, Client)
+	if err != nil {
+		return fmt.Errorf("创建默认租户失败: %w", err)
+	}
+	if t.ID != tenancy.DefaultID {
+		util.Log().Warning("默认租户 id 为 %d, 已有数据属于租户 %d", t.ID, tenancy.DefaultID)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"context"
+	"go-api/ent"
+	"go-api/ent/tenant"
+	"go-api/tenancy"
+)
+
+// DefaultTenant 查询默认租户, 不存在时创建
+// 新建的租户表中第一个租户即为默认租户, id 为 tenancy.DefaultID
+func DefaultTenant(ctx context.Context, client *ent.Client) (*ent.Tenant, error) {
+	t, err := client.Tenant.Query().Where(tenant.Name(tenancy.DefaultName)).Only(ctx)
+	if ent.IsNotFound(err) {
+		return client.Tenant.Create().SetName(tenancy.DefaultName).Save(ctx)
+	}
+	return t, err
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"go-api/ent"
+)
+
+// WithTx 在事务中执行 fn, fn 返回错误或 panic 时回滚
+func WithTx(ctx context.Context, fn func(tx *ent.Tx) error) error {
+	tx, err := Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			tx.Rollback()
+			panic(v)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
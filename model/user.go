@@ -15,15 +15,21 @@ type User struct {
 	Avatar         string `gorm:"size:1000"`
 }
 
+// PassWordCost 密码加密难度, 测试中可以调低以加快速度
+var PassWordCost = 12
+
 const (
-	// PassWordCost 密码加密难度
-	PassWordCost = 12
 	// Active 激活用户
 	Active string = "active"
 	// Inactive 未激活用户
 	Inactive string = "inactive"
 	// Suspend 被封禁用户
 	Suspend string = "suspend"
+
+	// RoleUser 普通用户
+	RoleUser string = "user"
+	// RoleAdmin 管理员
+	RoleAdmin string = "admin"
 )
 
 // GetUser 用ID获取用户
@@ -0,0 +1,15 @@
+package openapi
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Handler 输出由路由表实时生成的文档
+func Handler(r *gin.Engine) gin.HandlerFunc {
+	handler := func(c *gin.Context) {
+		doc, _ := Build(r.Routes())
+		c.JSON(200, doc)
+	}
+	Hide(handler)
+	return handler
+}
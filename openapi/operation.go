@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Param 查询参数, 请求头或路径参数
+type Param struct {
+	Name        string
+	In          string // query, header, path
+	Type        string // string, integer, boolean, 默认 string
+	Description string
+	Required    bool
+	Enum        []string
+}
+
+// Operation 接口的文档元数据, 与路由的处理函数关联
+type Operation struct {
+	Summary     string
+	Description string
+	Tags        []string
+	// Auth 需要在请求头 token 中携带登录凭证
+	Auth bool
+	// Request 请求结构体, 如 service.UserLoginService{}, 写操作作为请求体, 读操作作为查询参数
+	Request interface{}
+	Params  []Param
+	// Response 响应中 data 的结构, 如 serializer.UserToken{}, 为空时只有基础响应
+	Response interface{}
+	// List 响应为 serializer.ListResponse, Response 为列表元素
+	List bool
+	// Stream 非 JSON 的流式响应, 如 websocket, text/event-stream
+	Stream string
+}
+
+var (
+	mu         sync.RWMutex
+	operations = make(map[string]Operation)
+	hidden     = make(map[string]bool)
+)
+
+// Describe 为处理函数登记文档, 路由表中所有使用该处理函数的路由共用
+func Describe(handler gin.HandlerFunc, op Operation) {
+	mu.Lock()
+	defer mu.Unlock()
+	operations[handlerName(handler)] = op
+}
+
+// Hide 不出现在文档中的处理函数, 如文档页面自身
+func Hide(handler gin.HandlerFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	hidden[handlerName(handler)] = true
+}
+
+func lookup(name string) (Operation, bool, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	op, ok := operations[name]
+	return op, ok, hidden[name]
+}
+
+// handlerName 与 gin.RouteInfo.Handler 相同的函数名
+func handlerName(handler gin.HandlerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+}
+
+// operationID 取函数名最后一段, 如 go-api/api.UserLogin -> UserLogin
+func operationID(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
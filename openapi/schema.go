@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema JSON Schema, OpenAPI 3.1 与 JSON Schema 2020-12 一致
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+// types 类型名, 可为空的字段为 ["string", "null"]
+func (s *Schema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	}
+	return nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator 由 Go 结构体生成 schema, 具名结构体放入 components
+type generator struct {
+	schemas map[string]*Schema
+}
+
+// componentName 如 serializer.UserToken
+func componentName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	return pkg[strings.LastIndex(pkg, "/")+1:] + "." + t.Name()
+}
+
+func (g *generator) schemaOf(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := g.schemaOf(t.Elem())
+		if s.Ref == "" {
+			if typ, ok := s.Type.(string); ok {
+				s.Type = []string{typ, "null"}
+			}
+		}
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name := componentName(t)
+		if _, ok := g.schemas[name]; !ok {
+			// 先占位, 避免自引用的结构体无限递归
+			g.schemas[name] = &Schema{}
+			*g.schemas[name] = *g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	// interface{} 可以是任意值
+	return &Schema{}
+}
+
+// object 展开结构体字段, 匿名嵌入的结构体字段提升到外层
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.fields(t, s)
+	return s
+}
+
+func (g *generator) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := fieldName(f)
+		if !ok {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(ft, s)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop := g.schemaOf(f.Type)
+		if applyBinding(prop, f.Tag.Get("binding")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// fieldName 按 json 标签取字段名, 没有 json 标签时取 form 标签, 标签为 "-" 时忽略
+func fieldName(f reflect.StructField) (string, bool) {
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		tag = f.Tag.Get("form")
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	return name, true
+}
+
+// applyBinding 把 gin 的 binding 校验规则转换为 schema 约束, 返回是否必填
+func applyBinding(s *Schema, binding string) bool {
+	required := false
+	// omitempty 允许空值, 空字符串不受最小长度限制
+	omitempty := strings.Contains(","+binding+",", ",omitempty,")
+	types := s.types()
+	isString := len(types) > 0 && types[0] == "string"
+	for _, rule := range strings.Split(binding, ",") {
+		key, value := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			key, value = rule[:i], rule[i+1:]
+		}
+		switch key {
+		case "required":
+			required = true
+		case "min", "max":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			if isString {
+				if key == "min" {
+					if omitempty {
+						continue
+					}
+					s.MinLength = &n
+				} else {
+					s.MaxLength = &n
+				}
+			} else {
+				v := float64(n)
+				if key == "min" {
+					s.Minimum = &v
+				} else {
+					s.Maximum = &v
+				}
+			}
+		case "oneof":
+			for _, v := range strings.Fields(value) {
+				s.Enum = append(s.Enum, v)
+			}
+		}
+	}
+	return required
+}
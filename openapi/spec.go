@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go-api/serializer"
+)
+
+// Version 生成的 OpenAPI 版本
+const Version = "3.1.0"
+
+// Document OpenAPI 文档
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*OpObject `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info 文档信息
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components 可复用的 schema 与鉴权方式
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme 鉴权方式
+type SecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+// OpObject 一个接口
+type OpObject struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter 参数
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response 响应
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType 某种内容类型的结构
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Build 由路由表和登记的接口元数据生成文档, undocumented 为没有登记文档的路由, 如 "GET /api/v1/ping"
+func Build(routes gin.RoutesInfo) (doc *Document, undocumented []string) {
+	g := &generator{schemas: make(map[string]*Schema)}
+	doc = &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "go-api",
+			Description: "接口文档",
+			Version:     "1.0",
+		},
+		Paths: make(map[string]map[string]*OpObject),
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				"token": {Type: "apiKey", In: "header", Name: "token"},
+			},
+		},
+	}
+	for _, route := range routes {
+		op, ok, hide := lookup(route.Handler)
+		if hide {
+			continue
+		}
+		if !ok {
+			undocumented = append(undocumented, route.Method+" "+route.Path)
+			continue
+		}
+		path := specPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*OpObject)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = g.operation(route, op)
+	}
+	sort.Strings(undocumented)
+	return doc, undocumented
+}
+
+// specPath gin 的 /pets/:id 与 /swagger/*any 转换为 /pets/{id} 与 /swagger/{any}
+func specPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (g *generator) operation(route gin.RouteInfo, op Operation) *OpObject {
+	o := &OpObject{
+		OperationID: operationID(route.Handler),
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Responses:   make(map[string]*Response),
+	}
+	if op.Auth {
+		o.Security = []map[string][]string{{"token": {}}}
+	}
+
+	// 路径参数默认为字符串, 可以在 Params 中覆盖
+	declared := make(map[string]bool)
+	for _, p := range op.Params {
+		declared[p.In+":"+p.Name] = true
+	}
+	for _, s := range strings.Split(route.Path, "/") {
+		if (strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*")) && !declared["path:"+s[1:]] {
+			o.Parameters = append(o.Parameters, &Parameter{Name: s[1:], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	for _, p := range op.Params {
+		o.Parameters = append(o.Parameters, paramObject(p))
+	}
+
+	if op.Request != nil {
+		t := reflect.TypeOf(op.Request)
+		if hasBody(route.Method) {
+			schema := g.schemaOf(t)
+			o.RequestBody = &RequestBody{
+				Required: true,
+				Content: map[string]*MediaType{
+					"application/json":                  {Schema: schema},
+					"application/x-www-form-urlencoded": {Schema: schema},
+				},
+			}
+		} else {
+			object := g.object(t)
+			names := make([]string, 0, len(object.Properties))
+			for name := range object.Properties {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				o.Parameters = append(o.Parameters, &Parameter{
+					Name:     name,
+					In:       "query",
+					Required: contains(object.Required, name),
+					Schema:   object.Properties[name],
+				})
+			}
+		}
+	}
+
+	if op.Stream != "" {
+		o.Responses["200"] = &Response{
+			Description: "OK",
+			Content:     map[string]*MediaType{op.Stream: {}},
+		}
+		return o
+	}
+	o.Responses["200"] = &Response{
+		Description: "code 为 0 时成功, 否则为错误码",
+		Content:     map[string]*MediaType{"application/json": {Schema: g.envelope(op)}},
+	}
+	return o
+}
+
+// envelope 基础响应中的 data 替换为具体结构
+func (g *generator) envelope(op Operation) *Schema {
+	if op.List {
+		base := g.schemaOf(reflect.TypeOf(serializer.ListResponse{}))
+		if op.Response == nil {
+			return base
+		}
+		data := &Schema{Type: "array", Items: g.schemaOf(reflect.TypeOf(op.Response))}
+		return &Schema{AllOf: []*Schema{base, {Type: "object", Properties: map[string]*Schema{"data": data}}}}
+	}
+	base := g.schemaOf(reflect.TypeOf(serializer.Response{}))
+	if op.Response == nil {
+		return base
+	}
+	data := g.schemaOf(reflect.TypeOf(op.Response))
+	return &Schema{AllOf: []*Schema{base, {Type: "object", Properties: map[string]*Schema{"data": data}}}}
+}
+
+func paramObject(p Param) *Parameter {
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+	s := &Schema{Type: typ}
+	for _, v := range p.Enum {
+		s.Enum = append(s.Enum, v)
+	}
+	return &Parameter{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required || p.In == "path",
+		Schema:      s,
+	}
+}
+
+func hasBody(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
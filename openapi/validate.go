@@ -0,0 +1,246 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"go-api/serializer"
+)
+
+// Validate 按文档校验请求参数与请求体, 用于开发环境及早发现文档与实现不一致
+// 需要在注册路由之前 Use, 文档在第一次请求时生成
+func Validate(r *gin.Engine) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  *Document
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			doc, _ = Build(r.Routes())
+		})
+		op := doc.Paths[specPath(c.FullPath())][strings.ToLower(c.Request.Method)]
+		if op == nil {
+			c.Next()
+			return
+		}
+		v := &validator{schemas: doc.Components.Schemas}
+		if err := v.request(c, op); err != nil {
+			c.JSON(400, serializer.ParamErr(err.Error(), nil))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+type validator struct {
+	schemas map[string]*Schema
+}
+
+func (v *validator) request(c *gin.Context, op *OpObject) error {
+	for _, p := range op.Parameters {
+		var (
+			value   string
+			present bool
+		)
+		switch p.In {
+		case "query":
+			value, present = c.GetQuery(p.Name)
+		case "header":
+			value = c.GetHeader(p.Name)
+			present = value != ""
+		case "path":
+			value = c.Param(p.Name)
+			present = true
+		}
+		if !present {
+			if p.Required {
+				return fmt.Errorf("缺少参数 %s", p.Name)
+			}
+			continue
+		}
+		if err := v.check(p.Name, p.Schema, stringValue(p.Schema, value)); err != nil {
+			return err
+		}
+	}
+
+	if op.RequestBody == nil {
+		return nil
+	}
+	schema := op.RequestBody.Content["application/json"].Schema
+	if c.ContentType() == gin.MIMEJSON {
+		raw, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+		var body interface{}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &body); err != nil {
+				return fmt.Errorf("请求体不是合法的 JSON")
+			}
+		}
+		return v.check("body", schema, body)
+	}
+	if err := c.Request.ParseMultipartForm(32 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return err
+	}
+	return v.check("body", schema, v.formValue(schema, c.Request.PostForm))
+}
+
+// formValue 表单字段都是字符串, 按 schema 转换类型后再校验
+func (v *validator) formValue(schema *Schema, form url.Values) map[string]interface{} {
+	schema = v.resolve(schema)
+	body := make(map[string]interface{})
+	for name, values := range form {
+		if len(values) == 0 {
+			continue
+		}
+		prop := schema.Properties[name]
+		if prop == nil {
+			body[name] = values[0]
+			continue
+		}
+		body[name] = stringValue(v.resolve(prop), values[0])
+	}
+	return body
+}
+
+// stringValue 查询参数和表单值转换为 schema 要求的类型, 无法转换时保留字符串以便报错
+func stringValue(s *Schema, value string) interface{} {
+	for _, t := range s.types() {
+		switch t {
+		case "integer", "number":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				return n
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(value); err == nil {
+				return b
+			}
+		}
+	}
+	return value
+}
+
+func (v *validator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = v.schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+// check 校验 value 是否符合 schema, 只覆盖生成文档时会用到的约束
+func (v *validator) check(name string, s *Schema, value interface{}) error {
+	s = v.resolve(s)
+	if s == nil {
+		return nil
+	}
+	for _, sub := range s.AllOf {
+		if err := v.check(name, sub, value); err != nil {
+			return err
+		}
+	}
+	if types := s.types(); len(types) > 0 && !matchType(types, value) {
+		return fmt.Errorf("%s 应为 %s", name, strings.Join(types, "|"))
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		return fmt.Errorf("%s 的取值不合法", name)
+	}
+	switch val := value.(type) {
+	case string:
+		n := len([]rune(val))
+		if s.MinLength != nil && n < *s.MinLength {
+			return fmt.Errorf("%s 长度不能小于 %d", name, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return fmt.Errorf("%s 长度不能大于 %d", name, *s.MaxLength)
+		}
+	case float64:
+		if s.Minimum != nil && val < *s.Minimum {
+			return fmt.Errorf("%s 不能小于 %v", name, *s.Minimum)
+		}
+		if s.Maximum != nil && val > *s.Maximum {
+			return fmt.Errorf("%s 不能大于 %v", name, *s.Maximum)
+		}
+	case map[string]interface{}:
+		for _, field := range s.Required {
+			if _, ok := val[field]; !ok {
+				return fmt.Errorf("缺少字段 %s", field)
+			}
+		}
+		names := make([]string, 0, len(val))
+		for field := range val {
+			names = append(names, field)
+		}
+		sort.Strings(names)
+		for _, field := range names {
+			if prop, ok := s.Properties[field]; ok {
+				if err := v.check(field, prop, val[field]); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range val {
+				if err := v.check(fmt.Sprintf("%s[%d]", name, i), s.Items, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func matchType(types []string, value interface{}) bool {
+	for _, t := range types {
+		switch val := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && val == math.Trunc(val)) {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if e == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package ops
+
+import (
+	"go-api/serializer"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dumpFile 保存在磁盘上的 dump
+type dumpFile struct {
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// dumper 把 profile 写入 dir, 用于事后分析, 不需要在线拉取 pprof
+type dumper struct {
+	dir string
+}
+
+// write 写入 goroutine, heap 等 pprof 支持的 profile
+// goroutine 写为包含完整调用栈的文本, 其余为 go tool pprof 可以读取的格式
+func (d dumper) write(c *gin.Context) {
+	kind := c.Param("kind")
+	profile := pprof.Lookup(kind)
+	if profile == nil {
+		c.JSON(http.StatusNotFound, serializer.Err(serializer.CodeNotFound, "不支持的 profile "+kind, nil))
+		return
+	}
+	if err := os.MkdirAll(d.dir, 0750); err != nil {
+		c.JSON(http.StatusInternalServerError, serializer.Err(serializer.CodeDumpError, "创建 dump 目录失败", err))
+		return
+	}
+	debugLevel, ext := 0, ".pb.gz"
+	if kind == "goroutine" {
+		debugLevel, ext = 2, ".txt"
+	}
+	if kind == "heap" {
+		// 与 /debug/pprof/heap?gc=1 相同, 只统计仍然存活的对象
+		runtime.GC()
+	}
+	name := kind + "-" + time.Now().Format("20060102-150405.000") + ext
+	f, err := os.OpenFile(filepath.Join(d.dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, serializer.Err(serializer.CodeDumpError, "创建 dump 文件失败", err))
+		return
+	}
+	err = profile.WriteTo(f, debugLevel)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, serializer.Err(serializer.CodeDumpError, "写入 dump 失败", err))
+		return
+	}
+	info, err := os.Stat(filepath.Join(d.dir, name))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, serializer.Err(serializer.CodeDumpError, "读取 dump 失败", err))
+		return
+	}
+	c.JSON(http.StatusOK, serializer.Response{Data: dumpFile{Name: name, Size: info.Size(), CreatedAt: info.ModTime().Unix()}})
+}
+
+// list 已保存的 dump, 新的在前
+func (d dumper) list(c *gin.Context) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil && !os.IsNotExist(err) {
+		c.JSON(http.StatusInternalServerError, serializer.Err(serializer.CodeDumpError, "读取 dump 目录失败", err))
+		return
+	}
+	files := make([]dumpFile, 0, len(entries))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, dumpFile{Name: e.Name(), Size: info.Size(), CreatedAt: info.ModTime().Unix()})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].CreatedAt != files[j].CreatedAt {
+			return files[i].CreatedAt > files[j].CreatedAt
+		}
+		return files[i].Name > files[j].Name
+	})
+	c.JSON(http.StatusOK, serializer.Response{Data: files})
+}
@@ -0,0 +1,181 @@
+package ops
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"go-api/cache"
+	"go-api/jobs"
+	"go-api/model"
+	"go-api/serializer"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// metricWriter 以 Prometheus 文本格式输出指标
+type metricWriter struct {
+	w *bufio.Writer
+}
+
+// family 指标的说明与类型
+func (m metricWriter) family(name, typ, help string) {
+	fmt.Fprintf(m.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample 一个样本, labels 为 key="value" 形式
+func (m metricWriter) sample(name, labels string, value float64) {
+	if labels != "" {
+		name += "{" + labels + "}"
+	}
+	fmt.Fprintf(m.w, "%s %s\n", name, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// gauge 只有一个样本的指标
+func (m metricWriter) gauge(name, help string, value float64) {
+	m.family(name, "gauge", help)
+	m.sample(name, "", value)
+}
+
+// counter 只有一个样本的累计指标
+func (m metricWriter) counter(name, help string, value float64) {
+	m.family(name, "counter", help)
+	m.sample(name, "", value)
+}
+
+// Metrics 运行时, 数据库与 redis 连接池, 任务队列的指标
+func Metrics(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+	m := metricWriter{w: bufio.NewWriter(c.Writer)}
+	defer m.w.Flush()
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	m.gauge("go_goroutines", "Number of goroutines.", float64(runtime.NumGoroutine()))
+	m.gauge("go_memstats_heap_alloc_bytes", "Bytes of allocated heap objects.", float64(ms.HeapAlloc))
+	m.gauge("go_memstats_heap_inuse_bytes", "Bytes in in-use heap spans.", float64(ms.HeapInuse))
+	m.gauge("go_memstats_sys_bytes", "Bytes of memory obtained from the OS.", float64(ms.Sys))
+	m.gauge("go_memory_limit_bytes", "Soft memory limit (GOMEMLIMIT).", float64(debug.SetMemoryLimit(-1)))
+	m.counter("go_gc_cycles_total", "Completed GC cycles.", float64(ms.NumGC))
+	m.counter("go_gc_pause_seconds_total", "Total GC stop-the-world pause time.", time.Duration(ms.PauseTotalNs).Seconds())
+	m.counter("process_uptime_seconds", "Seconds since the process started.", time.Since(started).Seconds())
+
+	if model.Resolver != nil {
+		stats := model.Resolver.Stats()
+		families := []struct {
+			name, typ, help string
+			value           func(i int) float64
+		}{
+			{"db_pool_open_connections", "gauge", "Open connections.", func(i int) float64 { return float64(stats[i].OpenConnections) }},
+			{"db_pool_in_use_connections", "gauge", "Connections in use.", func(i int) float64 { return float64(stats[i].InUse) }},
+			{"db_pool_idle_connections", "gauge", "Idle connections.", func(i int) float64 { return float64(stats[i].Idle) }},
+			{"db_pool_wait_total", "counter", "Times a connection was waited for.", func(i int) float64 { return float64(stats[i].WaitCount) }},
+			{"db_pool_wait_seconds_total", "counter", "Time spent waiting for a connection.", func(i int) float64 { return stats[i].WaitDuration.Seconds() }},
+			{"db_node_healthy", "gauge", "Whether the node serves queries.", func(i int) float64 { return boolValue(stats[i].Healthy) }},
+		}
+		for _, f := range families {
+			m.family(f.name, f.typ, f.help)
+			for i, s := range stats {
+				m.sample(f.name, `node="`+s.Node+`"`, f.value(i))
+			}
+		}
+	}
+
+	rs := cache.PoolStats()
+	m.counter("redis_pool_hits_total", "Times a free connection was found in the pool.", float64(rs.Hits))
+	m.counter("redis_pool_misses_total", "Times a new connection was dialed.", float64(rs.Misses))
+	m.counter("redis_pool_timeouts_total", "Times waiting for a connection timed out.", float64(rs.Timeouts))
+	m.family("redis_pool_connections", "gauge", "Connections in the pool.")
+	m.sample("redis_pool_connections", `state="total"`, float64(rs.TotalConns))
+	m.sample("redis_pool_connections", `state="idle"`, float64(rs.IdleConns))
+	m.sample("redis_pool_connections", `state="stale"`, float64(rs.StaleConns))
+
+	if inspector := jobs.Inspector(); inspector != nil {
+		m.family("jobs_queue_tasks", "gauge", "Tasks in the queue by state.")
+		for _, queue := range []string{jobs.QueueCritical, jobs.QueueDefault, jobs.QueueLow} {
+			info, err := inspector.GetQueueInfo(queue)
+			if err != nil {
+				continue
+			}
+			for _, s := range []struct {
+				state string
+				n     int
+			}{
+				{"pending", info.Pending},
+				{"active", info.Active},
+				{"scheduled", info.Scheduled},
+				{"retry", info.Retry},
+				{"archived", info.Archived},
+			} {
+				m.sample("jobs_queue_tasks", `queue="`+queue+`",state="`+s.state+`"`, float64(s.n))
+			}
+		}
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// check 一项健康检查的结果
+type check struct {
+	Name    string  `json:"name"`
+	OK      bool    `json:"ok"`
+	Error   string  `json:"error,omitempty"`
+	Latency float64 `json:"latency_ms"`
+	// optional 失败时服务仍然可用, 如从库
+	optional bool
+}
+
+// runCheck 执行检查并记录耗时
+func runCheck(name string, fn func() error) check {
+	start := time.Now()
+	err := fn()
+	res := check{Name: name, OK: err == nil, Latency: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// Health 数据库与 redis 的详细健康状态, 主库或 redis 不可用时返回 503
+func Health(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	var checks []check
+	if model.Resolver != nil {
+		checks = append(checks, runCheck("db:primary", func() error { return model.Resolver.Ping(ctx) }))
+		for _, s := range model.Resolver.Stats()[1:] {
+			res := check{Name: "db:" + s.Node, OK: s.Healthy, optional: true}
+			if !s.Healthy {
+				res.Error = "健康检查失败, 查询使用其他节点"
+			}
+			checks = append(checks, res)
+		}
+	}
+	if cache.RedisClient != nil {
+		checks = append(checks, runCheck("redis", func() error { return cache.RedisClient.Ping(ctx).Err() }))
+	}
+
+	status := http.StatusOK
+	for _, res := range checks {
+		if !res.OK && !res.optional {
+			status = http.StatusServiceUnavailable
+		}
+	}
+	c.JSON(status, serializer.Response{Data: gin.H{
+		"checks":     checks,
+		"uptime":     int64(time.Since(started).Seconds()),
+		"goroutines": runtime.NumGoroutine(),
+		"go_version": runtime.Version(),
+	}})
+}
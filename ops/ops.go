@@ -0,0 +1,118 @@
+// Package ops 运维监听端口, 提供 pprof, 指标, 健康检查, 日志级别, 运行时状态与 dump
+//
+// 与 API 使用不同的端口, 所有请求都需要 ADMIN_TOKEN, 不要把端口暴露到公网
+package ops
+
+import (
+	"crypto/subtle"
+	"errors"
+	"go-api/serializer"
+	"go-api/util"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/pprof"
+	"github.com/gin-gonic/gin"
+)
+
+// Config 运维端口配置
+type Config struct {
+	// Addr 监听地址, 为空时不启动
+	Addr string
+	// Token 访问令牌, 放在 Authorization: Bearer 请求头中
+	Token string
+	// DumpDir goroutine 与 heap dump 的保存目录
+	DumpDir string
+}
+
+// ConfigFromEnv 从环境变量读取配置
+// ADMIN_ADDR 监听地址, 如 127.0.0.1:6060; ADMIN_TOKEN 访问令牌; ADMIN_DUMP_DIR dump 目录, 默认 dumps
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Addr:    os.Getenv("ADMIN_ADDR"),
+		Token:   os.Getenv("ADMIN_TOKEN"),
+		DumpDir: os.Getenv("ADMIN_DUMP_DIR"),
+	}
+	if cfg.DumpDir == "" {
+		cfg.DumpDir = "dumps"
+	}
+	return cfg
+}
+
+// started 进程启动时间
+var started = time.Now()
+
+// NewRouter 运维端口的路由
+func NewRouter(cfg Config) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery(), auth(cfg.Token))
+
+	pprof.Register(r)
+	r.GET("/metrics", Metrics)
+	r.GET("/health", Health)
+	r.GET("/log/level", LogLevel)
+	r.PUT("/log/level", SetLogLevel)
+	r.GET("/runtime", Runtime)
+	r.PUT("/runtime", SetRuntime)
+	r.POST("/runtime/gc", GC)
+	d := dumper{dir: cfg.DumpDir}
+	r.GET("/dumps", d.list)
+	r.POST("/dumps/:kind", d.write)
+	return r
+}
+
+// auth 校验访问令牌, 令牌为空时拒绝所有请求
+func auth(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+	return func(c *gin.Context) {
+		got := []byte(c.GetHeader("Authorization"))
+		if token == "" || subtle.ConstantTimeCompare(got, expected) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, serializer.Err(serializer.CodeCheckLogin, "需要运维令牌", nil))
+			return
+		}
+		c.Next()
+	}
+}
+
+// Server 运行中的运维端口
+type Server struct {
+	srv *http.Server
+	// Addr 实际监听的地址
+	Addr net.Addr
+}
+
+// Close 关闭运维端口, 没有启动时不做任何事
+func (s *Server) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.srv.Close()
+}
+
+// Start 在后台启动运维端口, 没有配置 ADMIN_ADDR 时不启动并返回 nil, 返回的 Server 由调用方关闭
+func Start(cfg Config) (*Server, error) {
+	if cfg.Addr == "" {
+		return nil, nil
+	}
+	if strings.TrimSpace(cfg.Token) == "" {
+		return nil, errors.New("设置了 ADMIN_ADDR 时必须设置 ADMIN_TOKEN")
+	}
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{
+		Handler:           NewRouter(cfg),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			util.Log().Error("运维端口退出: %v", err)
+		}
+	}()
+	util.Log().Info("运维端口监听 %s", ln.Addr())
+	return &Server{srv: srv, Addr: ln.Addr()}, nil
+}
@@ -0,0 +1,167 @@
+package ops
+
+import (
+	"encoding/json"
+	"go-api/dbresolver"
+	"go-api/model"
+	"go-api/util"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	entsql "github.com/facebook/ent/dialect/sql"
+	"github.com/gin-gonic/gin"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const token = "ops-test"
+
+// request 以运维令牌请求 r
+func request(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func newRouter(t *testing.T) (*gin.Engine, string) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	return NewRouter(Config{Token: token, DumpDir: dir}), dir
+}
+
+func TestAuth(t *testing.T) {
+	r, _ := newRouter(t)
+	for _, header := range []string{"", "Bearer", "Bearer wrong", token} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+		req.Header.Set("Authorization", header)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("%q: %d", header, w.Code)
+		}
+	}
+	if w := request(r, http.MethodGet, "/debug/pprof/", ""); w.Code != http.StatusOK {
+		t.Fatalf("pprof: %d", w.Code)
+	}
+	// 没有设置令牌时拒绝所有请求
+	empty := NewRouter(Config{})
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	w := httptest.NewRecorder()
+	empty.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("empty token: %d", w.Code)
+	}
+
+	if _, err := Start(Config{Addr: "127.0.0.1:0"}); err == nil {
+		t.Fatal("started without token")
+	}
+	srv, err := Start(Config{})
+	if srv != nil || err != nil || srv.Close() != nil {
+		t.Fatalf("started without addr: %v %v", srv, err)
+	}
+}
+
+func TestMetricsAndHealth(t *testing.T) {
+	r, _ := newRouter(t)
+	drv, err := entsql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := dbresolver.New(drv, nil, dbresolver.Config{})
+	defer resolver.Close()
+	defer func(r *dbresolver.Driver) { model.Resolver = r }(model.Resolver)
+	model.Resolver = resolver
+
+	w := request(r, http.MethodGet, "/metrics", "")
+	for _, want := range []string{"# TYPE go_goroutines gauge\n", "go_memory_limit_bytes ", `db_pool_open_connections{node="primary"} `, `redis_pool_connections{state="idle"} 0`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Fatalf("metrics missing %q:\n%s", want, w.Body.String())
+		}
+	}
+
+	w = request(r, http.MethodGet, "/health", "")
+	var res struct {
+		Data struct {
+			Checks []check `json:"checks"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if w.Code != http.StatusOK || len(res.Data.Checks) != 1 || !res.Data.Checks[0].OK {
+		t.Fatalf("health: %d %s", w.Code, w.Body.String())
+	}
+	drv.Close()
+	if w := request(r, http.MethodGet, "/health", ""); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("health with closed db: %d", w.Code)
+	}
+}
+
+func TestRuntimeControls(t *testing.T) {
+	r, _ := newRouter(t)
+	defer util.SetLevel(util.LogLevel())
+	defer debug.SetMemoryLimit(debug.SetMemoryLimit(-1))
+	defer setGCPercent(int(lastGCPercent.Load()))
+
+	if w := request(r, http.MethodPut, "/log/level", `{"level":"warning"}`); w.Code != http.StatusOK || util.LogLevel() != "warning" {
+		t.Fatalf("set level: %d %s", w.Code, w.Body.String())
+	}
+	if w := request(r, http.MethodPut, "/log/level", `{"level":"verbose"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown level: %d", w.Code)
+	}
+
+	w := request(r, http.MethodPut, "/runtime", `{"memory_limit":"512MiB","gc_percent":50}`)
+	var res struct {
+		Data runtimeStats `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if w.Code != http.StatusOK || res.Data.MemoryLimit != 512<<20 || res.Data.GCPercent != 50 {
+		t.Fatalf("set runtime: %d %s", w.Code, w.Body.String())
+	}
+	if w := request(r, http.MethodPut, "/runtime", `{"memory_limit":"1.5GB"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("bad memory limit: %d", w.Code)
+	}
+
+	for in, want := range map[string]int64{"1024": 1024, "8GiB": 8 << 30, "64KiB": 64 << 10, "off": math.MaxInt64} {
+		if got, err := parseBytes(in); err != nil || got != want {
+			t.Fatalf("parseBytes(%q) = %d, %v", in, got, err)
+		}
+	}
+}
+
+func TestDumps(t *testing.T) {
+	r, dir := newRouter(t)
+	for _, kind := range []string{"goroutine", "heap"} {
+		w := request(r, http.MethodPost, "/dumps/"+kind, "")
+		var res struct {
+			Data dumpFile `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &res)
+		if w.Code != http.StatusOK || !strings.HasPrefix(res.Data.Name, kind+"-") || res.Data.Size == 0 {
+			t.Fatalf("%s dump: %d %s", kind, w.Code, w.Body.String())
+		}
+		data, err := os.ReadFile(filepath.Join(dir, res.Data.Name))
+		if err != nil || int64(len(data)) != res.Data.Size {
+			t.Fatalf("%s dump file: %v", kind, err)
+		}
+	}
+	if w := request(r, http.MethodPost, "/dumps/unknown", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown profile: %d", w.Code)
+	}
+
+	w := request(r, http.MethodGet, "/dumps", "")
+	var res struct {
+		Data []dumpFile `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if len(res.Data) != 2 {
+		t.Fatalf("list: %s", w.Body.String())
+	}
+}
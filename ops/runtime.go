@@ -0,0 +1,189 @@
+package ops
+
+import (
+	"errors"
+	"go-api/serializer"
+	"go-api/util"
+	"math"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApplyMemoryLimit 按 GOMEMLIMIT 设置内存软上限, 接近上限时 GC 更积极, 替代以前的 ballast
+// 运行时只在启动时读取环境变量, 这里让 .env 中的配置同样生效
+func ApplyMemoryLimit() {
+	value := os.Getenv("GOMEMLIMIT")
+	if value == "" {
+		return
+	}
+	limit, err := parseBytes(value)
+	if err != nil {
+		util.Log().Warning("GOMEMLIMIT 格式错误: %v", err)
+		return
+	}
+	debug.SetMemoryLimit(limit)
+}
+
+// parseBytes 解析 GOMEMLIMIT 格式的字节数, 如 512MiB, 8GiB, off 表示不限制
+func parseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "off" {
+		return math.MaxInt64, nil
+	}
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10}, {"B", 1},
+	}
+	size := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			s, size = strings.TrimSuffix(s, u.suffix), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, errors.New("应为非负整数加上 B, KiB, MiB, GiB, TiB 或 off")
+	}
+	if n > math.MaxInt64/size {
+		return math.MaxInt64, nil
+	}
+	return n * size, nil
+}
+
+// lastGCPercent 最后一次设置的 GOGC, 运行时只能在设置时返回旧值, 没有只读取的接口
+var lastGCPercent atomic.Int64
+
+func init() {
+	lastGCPercent.Store(int64(envGCPercent()))
+}
+
+// envGCPercent 启动时运行时按 GOGC 环境变量设置的值, off 为 -1, 未设置或格式错误时为 100
+func envGCPercent() int {
+	value := os.Getenv("GOGC")
+	if value == "off" {
+		return -1
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 100
+	}
+	return n
+}
+
+// setGCPercent 设置 GOGC 并记录, 供状态接口读取
+func setGCPercent(percent int) {
+	debug.SetGCPercent(percent)
+	lastGCPercent.Store(int64(percent))
+}
+
+// LogLevel 当前日志级别
+func LogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, serializer.Response{Data: gin.H{"level": util.LogLevel()}})
+}
+
+// SetLogLevel 修改日志级别, 只影响当前进程, 重启后恢复为 LOG_LEVEL
+func SetLogLevel(c *gin.Context) {
+	var req struct {
+		Level string `json:"level" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, serializer.ParamErr("", err))
+		return
+	}
+	if err := util.SetLevel(req.Level); err != nil {
+		c.JSON(http.StatusBadRequest, serializer.ParamErr(err.Error(), err))
+		return
+	}
+	util.Log().Warning("日志级别已修改为 %s", req.Level)
+	LogLevel(c)
+}
+
+// runtimeStats 内存与 GC 状态
+type runtimeStats struct {
+	Goroutines   int     `json:"goroutines"`
+	GOMAXPROCS   int     `json:"gomaxprocs"`
+	MemoryLimit  int64   `json:"memory_limit"`
+	GCPercent    int     `json:"gc_percent"`
+	HeapAlloc    uint64  `json:"heap_alloc"`
+	HeapInuse    uint64  `json:"heap_inuse"`
+	HeapIdle     uint64  `json:"heap_idle"`
+	HeapReleased uint64  `json:"heap_released"`
+	HeapObjects  uint64  `json:"heap_objects"`
+	Sys          uint64  `json:"sys"`
+	NextGC       uint64  `json:"next_gc"`
+	NumGC        uint32  `json:"num_gc"`
+	LastGC       int64   `json:"last_gc"`
+	PauseTotal   float64 `json:"pause_total_ms"`
+	GCCPU        float64 `json:"gc_cpu_fraction"`
+}
+
+func readRuntimeStats() runtimeStats {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return runtimeStats{
+		Goroutines:   runtime.NumGoroutine(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		MemoryLimit:  debug.SetMemoryLimit(-1),
+		GCPercent:    int(lastGCPercent.Load()),
+		HeapAlloc:    ms.HeapAlloc,
+		HeapInuse:    ms.HeapInuse,
+		HeapIdle:     ms.HeapIdle,
+		HeapReleased: ms.HeapReleased,
+		HeapObjects:  ms.HeapObjects,
+		Sys:          ms.Sys,
+		NextGC:       ms.NextGC,
+		NumGC:        ms.NumGC,
+		LastGC:       time.Unix(0, int64(ms.LastGC)).Unix(),
+		PauseTotal:   float64(ms.PauseTotalNs) / 1e6,
+		GCCPU:        ms.GCCPUFraction,
+	}
+}
+
+// Runtime 内存与 GC 状态
+func Runtime(c *gin.Context) {
+	c.JSON(http.StatusOK, serializer.Response{Data: readRuntimeStats()})
+}
+
+// SetRuntime 修改内存上限与 GOGC, 只影响当前进程
+// memory_limit 格式同 GOMEMLIMIT; gc_percent 为负数时关闭按比例触发的 GC, 只在接近内存上限时回收
+func SetRuntime(c *gin.Context) {
+	var req struct {
+		MemoryLimit *string `json:"memory_limit"`
+		GCPercent   *int    `json:"gc_percent"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, serializer.ParamErr("", err))
+		return
+	}
+	if req.MemoryLimit != nil {
+		limit, err := parseBytes(*req.MemoryLimit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, serializer.ParamErr("memory_limit "+err.Error(), err))
+			return
+		}
+		debug.SetMemoryLimit(limit)
+		util.Log().Warning("内存上限已修改为 %s", *req.MemoryLimit)
+	}
+	if req.GCPercent != nil {
+		setGCPercent(*req.GCPercent)
+		util.Log().Warning("GOGC 已修改为 %d", *req.GCPercent)
+	}
+	Runtime(c)
+}
+
+// GC 立即执行 GC 并把空闲内存归还操作系统
+func GC(c *gin.Context) {
+	debug.FreeOSMemory()
+	Runtime(c)
+}
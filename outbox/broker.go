@@ -0,0 +1,96 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-api/cache"
+	"go-api/ent"
+	"os"
+	"strings"
+	"time"
+)
+
+// Message 投递给消息中间件的事件, 消费者应按 ID 去重
+type Message struct {
+	ID            int             `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Key 分区键, 同一聚合的事件落在同一分区以保证顺序
+func (m Message) Key() string {
+	return m.AggregateType + ":" + m.AggregateID
+}
+
+func newMessage(row *ent.Outbox) Message {
+	return Message{
+		ID:            row.ID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		EventType:     row.EventType,
+		Payload:       row.Payload,
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+// Broker 可插拔的消息中间件
+type Broker interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// NewBrokerFromEnv 根据 OUTBOX_BROKER 创建消息中间件
+// kafka: KAFKA_BROKERS 逗号分隔, OUTBOX_TOPIC 主题
+// redis: OUTBOX_STREAM stream 名称
+// memory: 进程内总线, 仅用于开发和测试
+func NewBrokerFromEnv() (Broker, error) {
+	switch os.Getenv("OUTBOX_BROKER") {
+	case "kafka":
+		topic := os.Getenv("OUTBOX_TOPIC")
+		if topic == "" {
+			topic = "go-api.events"
+		}
+		return NewKafkaBroker(strings.Split(os.Getenv("KAFKA_BROKERS"), ","), topic)
+	case "redis", "":
+		stream := os.Getenv("OUTBOX_STREAM")
+		if stream == "" {
+			stream = "outbox:events"
+		}
+		return NewRedisStreamBroker(nil, cache.Prefixed(stream)), nil
+	case "memory":
+		return NewMemoryBus(), nil
+	}
+	return nil, errors.New("outbox: unknown broker " + os.Getenv("OUTBOX_BROKER"))
+}
+
+// fanout 依次投递给多个消息中间件
+type fanout []Broker
+
+// Fanout 把事件投递给所有 brokers, 任一失败时整条消息稍后重新投递给所有 brokers,
+// 因此每个 broker 都需要能处理重复的消息
+func Fanout(brokers ...Broker) Broker {
+	return fanout(brokers)
+}
+
+// Publish 实现 Broker
+func (f fanout) Publish(ctx context.Context, msg Message) error {
+	for _, b := range f {
+		if err := b.Publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 关闭所有 brokers
+func (f fanout) Close() error {
+	var errs []error
+	for _, b := range f {
+		errs = append(errs, b.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,143 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"go-api/ent"
+	"strconv"
+	"time"
+)
+
+// AggregateUser 用户聚合
+const AggregateUser = "user"
+
+// Event 领域事件, 同一聚合的事件按写入顺序投递
+type Event interface {
+	EventType() string
+	AggregateType() string
+	AggregateID() string
+}
+
+// userAggregate 用户相关事件的公共部分
+type userAggregate struct {
+	UserID int `json:"user_id"`
+}
+
+func (e userAggregate) AggregateType() string { return AggregateUser }
+
+func (e userAggregate) AggregateID() string { return strconv.Itoa(e.UserID) }
+
+// UserRegistered 用户注册
+type UserRegistered struct {
+	userAggregate
+	Username string    `json:"username"`
+	Nickname string    `json:"nickname"`
+	At       time.Time `json:"at"`
+}
+
+func (UserRegistered) EventType() string { return "user.registered" }
+
+// UserLoggedIn 用户登录
+type UserLoggedIn struct {
+	userAggregate
+	IP string    `json:"ip"`
+	At time.Time `json:"at"`
+}
+
+func (UserLoggedIn) EventType() string { return "user.logged_in" }
+
+// UserSuspended 用户被封禁
+type UserSuspended struct {
+	userAggregate
+	From string    `json:"from"`
+	At   time.Time `json:"at"`
+}
+
+func (UserSuspended) EventType() string { return "user.suspended" }
+
+// UserActivated 用户被激活
+type UserActivated struct {
+	userAggregate
+	From string    `json:"from"`
+	At   time.Time `json:"at"`
+}
+
+func (UserActivated) EventType() string { return "user.activated" }
+
+// UserDeactivated 用户被置为未激活
+type UserDeactivated struct {
+	userAggregate
+	From string    `json:"from"`
+	At   time.Time `json:"at"`
+}
+
+func (UserDeactivated) EventType() string { return "user.deactivated" }
+
+// UserErased 用户注销, 个人数据已被匿名化, 下游系统应删除保存的副本
+type UserErased struct {
+	userAggregate
+	At time.Time `json:"at"`
+}
+
+func (UserErased) EventType() string { return "user.erased" }
+
+// NewUserRegistered 构造用户注册事件
+func NewUserRegistered(u *ent.User) UserRegistered {
+	return UserRegistered{
+		userAggregate: userAggregate{UserID: u.ID},
+		Username:      u.Username,
+		Nickname:      u.Nickname,
+		At:            time.Now(),
+	}
+}
+
+// NewUserLoggedIn 构造用户登录事件
+func NewUserLoggedIn(u *ent.User, ip string) UserLoggedIn {
+	return UserLoggedIn{
+		userAggregate: userAggregate{UserID: u.ID},
+		IP:            ip,
+		At:            time.Now(),
+	}
+}
+
+// NewUserStatusChanged 根据新状态构造对应的事件, 未知状态返回 nil
+func NewUserStatusChanged(userID int, from, to string) Event {
+	base := userAggregate{UserID: userID}
+	now := time.Now()
+	switch to {
+	case "suspend":
+		return UserSuspended{userAggregate: base, From: from, At: now}
+	case "active":
+		return UserActivated{userAggregate: base, From: from, At: now}
+	case "inactive":
+		return UserDeactivated{userAggregate: base, From: from, At: now}
+	}
+	return nil
+}
+
+// NewUserErased 构造用户注销事件
+func NewUserErased(userID int) UserErased {
+	return UserErased{userAggregate: userAggregate{UserID: userID}, At: time.Now()}
+}
+
+// Record 在业务事务中写入事件, 与业务数据一起提交或回滚
+func Record(ctx context.Context, tx *ent.Tx, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Outbox.Create().
+		SetAggregateType(e.AggregateType()).
+		SetAggregateID(e.AggregateID()).
+		SetEventType(e.EventType()).
+		SetPayload(payload).
+		Save(ctx)
+	return err
+}
+
+// Decode 把消息载荷解析为具体的事件类型
+func Decode[T Event](m Message) (T, error) {
+	var e T
+	err := json.Unmarshal(m.Payload, &e)
+	return e, err
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Shopify/sarama"
+)
+
+// KafkaBroker 通过 kafka 投递事件, 以聚合作为消息 key 保证同一聚合有序
+type KafkaBroker struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+// NewKafkaBroker 创建 kafka 同步生产者
+func NewKafkaBroker(hosts []string, topic string) (*KafkaBroker, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	// 单连接单飞行请求, 避免重试导致同一分区内乱序
+	config.Net.MaxOpenRequests = 1
+	config.Producer.Idempotent = true
+	config.Version = sarama.V2_1_0_0
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	producer, err := sarama.NewSyncProducer(hosts, config)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaBroker{
+		producer: producer,
+		topic:    topic,
+	}, nil
+}
+
+// Publish 实现 Broker
+func (b *KafkaBroker) Publish(ctx context.Context, msg Message) error {
+	_, _, err := b.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: b.topic,
+		Key:   sarama.StringEncoder(msg.Key()),
+		Value: sarama.ByteEncoder(msg.Payload),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("message_id"), Value: []byte(strconv.Itoa(msg.ID))},
+			{Key: []byte("event_type"), Value: []byte(msg.EventType)},
+			{Key: []byte("aggregate_type"), Value: []byte(msg.AggregateType)},
+			{Key: []byte("aggregate_id"), Value: []byte(msg.AggregateID)},
+		},
+		Timestamp: msg.CreatedAt,
+	})
+	return err
+}
+
+// Close 实现 Broker
+func (b *KafkaBroker) Close() error {
+	return b.producer.Close()
+}
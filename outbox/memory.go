@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBus 进程内消息总线, 用于测试
+type MemoryBus struct {
+	mu          sync.Mutex
+	messages    []Message
+	subscribers []func(Message) error
+}
+
+// NewMemoryBus 创建进程内消息总线
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{}
+}
+
+// Subscribe 订阅事件, 订阅者返回错误时本次投递失败, relay 会重试
+func (b *MemoryBus) Subscribe(fn func(Message) error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, fn)
+}
+
+// Publish 同步投递给所有订阅者
+func (b *MemoryBus) Publish(ctx context.Context, msg Message) error {
+	b.mu.Lock()
+	subscribers := append([]func(Message) error(nil), b.subscribers...)
+	b.mu.Unlock()
+	for _, fn := range subscribers {
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	b.mu.Lock()
+	b.messages = append(b.messages, msg)
+	b.mu.Unlock()
+	return nil
+}
+
+// Messages 返回已投递成功的消息
+func (b *MemoryBus) Messages() []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Message(nil), b.messages...)
+}
+
+// Close 实现 Broker
+func (b *MemoryBus) Close() error {
+	return nil
+}
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"go-api/cache"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisStreamBroker 通过 redis stream 投递事件, 单个 stream 保证全局有序
+type RedisStreamBroker struct {
+	client redis.UniversalClient
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamBroker client 为 nil 时使用 cache.RedisClient
+func NewRedisStreamBroker(client redis.UniversalClient, stream string) *RedisStreamBroker {
+	return &RedisStreamBroker{
+		client: client,
+		stream: stream,
+		maxLen: 100000,
+	}
+}
+
+// Publish 实现 Broker
+func (b *RedisStreamBroker) Publish(ctx context.Context, msg Message) error {
+	client := b.client
+	if client == nil {
+		client = cache.RedisClient
+	}
+	return client.XAdd(ctx, &redis.XAddArgs{
+		Stream: b.stream,
+		MaxLen: b.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"message_id":     strconv.Itoa(msg.ID),
+			"aggregate_type": msg.AggregateType,
+			"aggregate_id":   msg.AggregateID,
+			"event_type":     msg.EventType,
+			"payload":        string(msg.Payload),
+			"created_at":     msg.CreatedAt.Unix(),
+		},
+	}).Err()
+}
+
+// Close 实现 Broker
+func (b *RedisStreamBroker) Close() error {
+	return nil
+}
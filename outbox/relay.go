@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"context"
+	"go-api/cache"
+	"go-api/ent"
+	"go-api/ent/outbox"
+	"go-api/util"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const relayLockKey = "outbox:relay"
+
+// DefaultMaxAttempts 默认的最大投递次数
+const DefaultMaxAttempts = 10
+
+// Relay 把未发布的事件投递到消息中间件, 至少投递一次
+// 同一聚合的事件投递失败时, 该聚合后续的事件在本轮不再投递, 以保证顺序;
+// 失败次数达到上限的事件转入死信, 不再阻塞后续事件
+type Relay struct {
+	client *ent.Client
+	broker Broker
+	// locker 不为空时多个实例通过分布式锁选出一个进行投递
+	locker      redis.UniversalClient
+	batch       int
+	interval    time.Duration
+	maxAttempts int
+}
+
+// NewRelay 创建 relay
+// OUTBOX_MAX_ATTEMPTS 单个事件的最大投递次数, 默认为 10
+func NewRelay(client *ent.Client, broker Broker, locker redis.UniversalClient) *Relay {
+	maxAttempts := DefaultMaxAttempts
+	if n, err := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS")); err == nil && n > 0 {
+		maxAttempts = n
+	}
+	return &Relay{
+		client:      client,
+		broker:      broker,
+		locker:      locker,
+		batch:       100,
+		interval:    time.Second,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Run 持续投递直到 ctx 结束
+func (r *Relay) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		if r.locker == nil {
+			r.loop(ctx)
+			continue
+		}
+		lock := util.NewRedisLock(r.locker, cache.Prefixed(relayLockKey), 30*time.Second)
+		ok, err := lock.LockAndRenewal(ctx)
+		if err != nil {
+			util.Log().Warning("outbox relay 获取锁失败 %v", err)
+		}
+		if ok {
+			// 锁丢失时 lock.Context() 被取消, 停止投递让给新的持有者
+			r.loop(lock.Context())
+			lock.Unlock(context.Background())
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(r.interval * 5):
+		}
+	}
+}
+
+func (r *Relay) loop(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+			util.Log().Error("outbox relay 投递失败 %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush 投递一批未发布的事件, 返回成功投递的数量
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	rows, err := r.client.Outbox.Query().
+		Where(outbox.PublishedAtIsNil(), outbox.DeadAtIsNil()).
+		Order(ent.Asc(outbox.FieldID)).
+		Limit(r.batch).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	blocked := make(map[string]bool)
+	for _, row := range rows {
+		msg := newMessage(row)
+		if blocked[msg.Key()] {
+			continue
+		}
+		if err := r.broker.Publish(ctx, msg); err != nil {
+			update := r.client.Outbox.UpdateOne(row).
+				AddAttempts(1).
+				SetLastError(err.Error())
+			// 无法投递的事件转入死信, 同一聚合的后续事件继续投递, 顺序由死信的处理方自行保证
+			if row.Attempts+1 >= r.maxAttempts {
+				update.SetDeadAt(time.Now())
+				util.Log().Error("outbox 事件 %d 投递 %d 次失败, 转入死信: %v", row.ID, row.Attempts+1, err)
+			} else {
+				blocked[msg.Key()] = true
+			}
+			if uerr := update.Exec(ctx); uerr != nil {
+				return published, uerr
+			}
+			continue
+		}
+		// 标记失败时下次会重复投递, 由消费者按消息 ID 去重
+		if err := r.client.Outbox.UpdateOne(row).
+			SetPublishedAt(time.Now()).
+			AddAttempts(1).
+			Exec(ctx); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// Requeue 把死信事件重新放回待投递, 清零失败次数, 返回重新投递的数量
+func Requeue(ctx context.Context, client *ent.Client, ids ...int) (int, error) {
+	return client.Outbox.Update().
+		Where(outbox.IDIn(ids...), outbox.DeadAtNotNil()).
+		ClearDeadAt().
+		SetAttempts(0).
+		Save(ctx)
+}
+
+// Purge 删除发布时间早于 before 的事件, 死信事件保留
+func Purge(ctx context.Context, client *ent.Client, before time.Time) (int, error) {
+	return client.Outbox.Delete().
+		Where(outbox.PublishedAtNotNil(), outbox.PublishedAtLT(before)).
+		Exec(ctx)
+}
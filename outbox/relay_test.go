@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"go-api/ent"
+	"go-api/ent/enttest"
+	"go-api/ent/outbox"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func record(t *testing.T, client *ent.Client, e Event) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(ctx, tx, e); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRelayOrderingPerAggregate(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:outbox?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	record(t, client, NewUserStatusChanged(1, "active", "suspend"))
+	record(t, client, NewUserStatusChanged(2, "active", "suspend"))
+	record(t, client, NewUserStatusChanged(1, "suspend", "active"))
+
+	// 用户 1 的第一个事件投递失败, 其后续事件也不能先于它投递
+	bus := NewMemoryBus()
+	fail := true
+	bus.Subscribe(func(m Message) error {
+		if m.AggregateID == "1" && fail {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	})
+	relay := NewRelay(client, bus, nil)
+
+	n, err := relay.Flush(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || bus.Messages()[0].AggregateID != "2" {
+		t.Fatalf("published %d, messages %+v", n, bus.Messages())
+	}
+
+	fail = false
+	if n, err = relay.Flush(ctx); err != nil || n != 2 {
+		t.Fatalf("second flush: %d %v", n, err)
+	}
+	msgs := bus.Messages()
+	if msgs[1].EventType != "user.suspended" || msgs[2].EventType != "user.activated" {
+		t.Fatalf("out of order: %+v", msgs)
+	}
+	e, err := Decode[UserActivated](msgs[2])
+	if err != nil || e.UserID != 1 || e.From != "suspend" {
+		t.Fatalf("decode: %+v %v", e, err)
+	}
+
+	if n, _ = relay.Flush(ctx); n != 0 {
+		t.Fatalf("republished %d events", n)
+	}
+}
+
+func TestRelayDeadLetter(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:outbox_dead?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	record(t, client, NewUserStatusChanged(1, "active", "suspend"))
+	record(t, client, NewUserStatusChanged(1, "suspend", "active"))
+
+	// 第一个事件始终无法投递
+	bus := NewMemoryBus()
+	poison := true
+	bus.Subscribe(func(m Message) error {
+		if m.EventType == "user.suspended" && poison {
+			return errors.New("poison message")
+		}
+		return nil
+	})
+	relay := NewRelay(client, bus, nil)
+	relay.maxAttempts = 3
+
+	for i := 0; i < 2; i++ {
+		if n, err := relay.Flush(ctx); err != nil || n != 0 {
+			t.Fatalf("flush %d: %d %v", i, n, err)
+		}
+	}
+	// 达到上限后转入死信, 同一聚合的后续事件继续投递
+	if n, err := relay.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("flush after dead letter: %d %v", n, err)
+	}
+	dead := client.Outbox.Query().Where(outbox.DeadAtNotNil()).OnlyX(ctx)
+	if dead.Attempts != 3 || dead.LastError != "poison message" || dead.PublishedAt != nil {
+		t.Fatalf("unexpected dead letter %+v", dead)
+	}
+	if n, _ := relay.Flush(ctx); n != 0 {
+		t.Fatalf("dead letter republished: %d", n)
+	}
+
+	// 重新放回后再次投递
+	if n, err := Requeue(ctx, client, dead.ID); err != nil || n != 1 {
+		t.Fatalf("requeue: %d %v", n, err)
+	}
+	poison = false
+	if n, err := relay.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("flush after requeue: %d %v", n, err)
+	}
+	if row := client.Outbox.GetX(ctx, dead.ID); row.DeadAt != nil || row.PublishedAt == nil {
+		t.Fatalf("not republished %+v", row)
+	}
+}
@@ -0,0 +1,114 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"go-api/middleware"
+	"go-api/serializer"
+	"go-api/tenancy"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sendBuffer 每个连接的发送缓冲, 写满即视为慢连接
+const sendBuffer = 64
+
+// client 一个 WebSocket 或 SSE 连接
+type client struct {
+	// ctx 建立连接的请求的 context, 用于订阅授权
+	ctx    context.Context
+	userID int
+	// tenantID 连接所属的租户, 只能收到本租户内的主题事件
+	tenantID int
+	send     chan []byte
+	done     chan struct{}
+	once     sync.Once
+	// topics 客户端订阅的主题, 由 Hub 的锁保护
+	topics map[string]bool
+}
+
+func newClient(ctx context.Context, userID int) *client {
+	return &client{
+		ctx:      ctx,
+		userID:   userID,
+		tenantID: tenancy.ID(ctx),
+		send:     make(chan []byte, sendBuffer),
+		done:     make(chan struct{}),
+		topics:   make(map[string]bool),
+	}
+}
+
+// enqueue 非阻塞写入发送缓冲, 缓冲已满或连接已关闭时返回 false
+func (c *client) enqueue(msg []byte) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *client) close() {
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
+
+// authenticate 与 JWTAuth 使用同样的 token 校验, 不接受第三方应用的 token
+// 浏览器的 WebSocket 与 EventSource 无法设置请求头, 允许通过 query 参数传递
+func authenticate(c *gin.Context) (*middleware.CustomClaims, error) {
+	token := c.GetHeader("token")
+	if token == "" {
+		token = c.Query("token")
+	}
+	if token == "" {
+		if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimPrefix(auth, "Bearer ")
+		}
+	}
+	if token == "" {
+		return nil, errors.New("缺少token")
+	}
+	claims, err := middleware.VerifyToken(token)
+	if err == nil && claims.Delegated() {
+		return nil, middleware.TokenInvalid
+	}
+	return claims, err
+}
+
+// connect 校验 token 并注册连接, 连接属于 token 中的租户, 失败时已写入响应
+func connect(c *gin.Context, hub *Hub) (*client, bool) {
+	claims, err := authenticate(c)
+	if err != nil {
+		c.JSON(401, serializer.Err(serializer.CodeTokenError, "token失效, 重新获取", err))
+		return nil, false
+	}
+	if !middleware.BindTenantClaims(c, claims) {
+		return nil, false
+	}
+	cl := newClient(c.Request.Context(), int(claims.ID))
+	hub.register(cl)
+	if !subscribeQuery(c, hub, cl) {
+		return nil, false
+	}
+	return cl, true
+}
+
+// subscribeQuery 订阅 query 参数中的主题, 有主题不允许订阅时返回 403 并注销连接
+func subscribeQuery(c *gin.Context, hub *Hub, cl *client) bool {
+	for _, topic := range c.QueryArray("topic") {
+		if err := hub.subscribe(cl, topic); err != nil {
+			hub.unregister(cl)
+			c.JSON(403, serializer.Err(serializer.CodeNoRightErr, "不能订阅 "+topic, err))
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,279 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-api/cache"
+	"go-api/tenancy"
+	"go-api/util"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// userTopicPrefix 用户私有频道前缀, 客户端不能直接订阅
+const userTopicPrefix = "user:"
+
+// Authorizer 判断用户能否订阅主题, id 为主题中种类之后的部分, 如 "pet:12" 中的 "12"
+// ctx 为建立连接的请求的 context
+type Authorizer func(ctx context.Context, userID int, id string) bool
+
+var (
+	authorizersMu sync.RWMutex
+	authorizers   = make(map[string]Authorizer)
+)
+
+// Authorize 注册一种主题的订阅授权, 主题格式为 "<kind>:<id>", 如 Authorize("pet", ...) 对应 "pet:12"
+// 没有注册授权的种类不能订阅
+func Authorize(kind string, fn Authorizer) {
+	authorizersMu.Lock()
+	defer authorizersMu.Unlock()
+	authorizers[kind] = fn
+}
+
+// authorize 用户能否订阅 topic
+func authorize(ctx context.Context, userID int, topic string) error {
+	kind, id, ok := strings.Cut(topic, ":")
+	if !ok || strings.HasPrefix(topic, userTopicPrefix) {
+		return errors.New("invalid topic")
+	}
+	authorizersMu.RLock()
+	fn := authorizers[kind]
+	authorizersMu.RUnlock()
+	if fn == nil {
+		return errors.New("unknown topic")
+	}
+	if !fn(ctx, userID, id) {
+		return errors.New("forbidden topic")
+	}
+	return nil
+}
+
+// maxTopics 单个连接最多订阅的主题数
+const maxTopics = 32
+
+// topicKey 主题在 Hub 内的键, 同名主题在不同租户之间互相隔离
+func topicKey(tenantID int, topic string) string {
+	return strconv.Itoa(tenantID) + "/" + topic
+}
+
+// Event 推送给客户端的事件
+type Event struct {
+	Type  string      `json:"type"`
+	Topic string      `json:"topic,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+	At    int64       `json:"at"`
+}
+
+// NewEvent 创建事件
+func NewEvent(typ string, data interface{}) Event {
+	return Event{
+		Type: typ,
+		Data: data,
+		At:   time.Now().Unix(),
+	}
+}
+
+// envelope 在实例之间通过 redis pub/sub 转发的消息, Topic 为带租户的 topicKey
+type envelope struct {
+	UserID int             `json:"user_id,omitempty"`
+	Topic  string          `json:"topic,omitempty"`
+	Event  json.RawMessage `json:"event"`
+}
+
+// Hub 管理本实例上的连接, 并通过 redis pub/sub 接收其他实例发布的事件
+type Hub struct {
+	mu      sync.RWMutex
+	users   map[int]map[*client]struct{}
+	topics  map[string]map[*client]struct{}
+	redis   redis.UniversalClient
+	channel string
+}
+
+// NewHub 创建 Hub, rdb 为 nil 时只在本实例内投递
+func NewHub(rdb redis.UniversalClient, channel string) *Hub {
+	return &Hub{
+		users:   make(map[int]map[*client]struct{}),
+		topics:  make(map[string]map[*client]struct{}),
+		redis:   rdb,
+		channel: channel,
+	}
+}
+
+var defaultHub = NewHub(nil, "")
+
+// Init 初始化默认 Hub 并开始接收跨实例事件
+// REALTIME_CHANNEL redis 频道名称
+func Init() {
+	channel := os.Getenv("REALTIME_CHANNEL")
+	if channel == "" {
+		channel = "realtime:events"
+	}
+	defaultHub = NewHub(cache.RedisClient, cache.Prefixed(channel))
+	go defaultHub.Run(context.Background())
+}
+
+// Publish 向用户的所有连接推送事件, 可在任意实例调用
+func Publish(userID int, event Event) error {
+	return defaultHub.Publish(userID, event)
+}
+
+// PublishTopic 向 ctx 中的租户内订阅了 topic 的所有连接推送事件
+func PublishTopic(ctx context.Context, topic string, event Event) error {
+	return defaultHub.PublishTopic(ctx, topic, event)
+}
+
+// Publish 向用户的所有连接推送事件
+func (h *Hub) Publish(userID int, event Event) error {
+	return h.publish(envelope{UserID: userID}, event)
+}
+
+// PublishTopic 向 ctx 中的租户内订阅了 topic 的所有连接推送事件
+func (h *Hub) PublishTopic(ctx context.Context, topic string, event Event) error {
+	if topic == "" || strings.HasPrefix(topic, userTopicPrefix) {
+		return errors.New("realtime: invalid topic " + topic)
+	}
+	tenantID := tenancy.ID(ctx)
+	if tenantID == 0 {
+		return errors.New("realtime: missing tenant in context")
+	}
+	event.Topic = topic
+	return h.publish(envelope{Topic: topicKey(tenantID, topic)}, event)
+}
+
+func (h *Hub) publish(env envelope, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if h.redis == nil {
+		h.deliver(env.UserID, env.Topic, data)
+		return nil
+	}
+	env.Event = data
+	msg, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	// 本实例也通过订阅收到消息, 保证所有实例的投递路径一致
+	return h.redis.Publish(context.Background(), h.channel, msg).Err()
+}
+
+// Run 订阅 redis 频道, 把其他实例发布的事件投递到本实例的连接
+func (h *Hub) Run(ctx context.Context) {
+	if h.redis == nil {
+		return
+	}
+	sub := h.redis.Subscribe(ctx, h.channel)
+	defer sub.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-sub.Channel():
+			if !ok {
+				return
+			}
+			var env envelope
+			if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+				util.Log().Warning("realtime 消息格式错误 %v", err)
+				continue
+			}
+			h.deliver(env.UserID, env.Topic, env.Event)
+		}
+	}
+}
+
+// deliver 投递到本实例的连接, 发送缓冲已满的慢连接会被断开
+func (h *Hub) deliver(userID int, topic string, data []byte) {
+	h.mu.RLock()
+	var targets map[*client]struct{}
+	if topic != "" {
+		targets = h.topics[topic]
+	} else {
+		targets = h.users[userID]
+	}
+	clients := make([]*client, 0, len(targets))
+	for c := range targets {
+		clients = append(clients, c)
+	}
+	h.mu.RUnlock()
+
+	for _, c := range clients {
+		if !c.enqueue(data) {
+			util.Log().Warning("realtime 用户 %d 的连接消费过慢, 断开", c.userID)
+			h.unregister(c)
+		}
+	}
+}
+
+func (h *Hub) register(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.users[c.userID] == nil {
+		h.users[c.userID] = make(map[*client]struct{})
+	}
+	h.users[c.userID][c] = struct{}{}
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	if conns := h.users[c.userID]; conns != nil {
+		delete(conns, c)
+		if len(conns) == 0 {
+			delete(h.users, c.userID)
+		}
+	}
+	for topic := range c.topics {
+		key := topicKey(c.tenantID, topic)
+		if conns := h.topics[key]; conns != nil {
+			delete(conns, c)
+			if len(conns) == 0 {
+				delete(h.topics, key)
+			}
+		}
+	}
+	c.topics = nil
+	h.mu.Unlock()
+	c.close()
+}
+
+// subscribe 订阅连接所属租户内的主题, 需要通过该种类主题注册的授权
+func (h *Hub) subscribe(c *client, topic string) error {
+	if err := authorize(c.ctx, c.userID, topic); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if c.topics == nil {
+		return errors.New("connection closed")
+	}
+	if len(c.topics) >= maxTopics && !c.topics[topic] {
+		return errors.New("too many topics")
+	}
+	c.topics[topic] = true
+	key := topicKey(c.tenantID, topic)
+	if h.topics[key] == nil {
+		h.topics[key] = make(map[*client]struct{})
+	}
+	h.topics[key][c] = struct{}{}
+	return nil
+}
+
+func (h *Hub) unsubscribe(c *client, topic string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(c.topics, topic)
+	key := topicKey(c.tenantID, topic)
+	if conns := h.topics[key]; conns != nil {
+		delete(conns, c)
+		if len(conns) == 0 {
+			delete(h.topics, key)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"go-api/tenancy"
+)
+
+func receive(t *testing.T, cl *client) Event {
+	t.Helper()
+	select {
+	case msg := <-cl.send:
+		var e Event
+		if err := json.Unmarshal(msg, &e); err != nil {
+			t.Fatal(err)
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+	return Event{}
+}
+
+func init() {
+	Authorize("news", func(ctx context.Context, userID int, id string) bool { return true })
+	// 用户只能订阅自己的订单
+	Authorize("order", func(ctx context.Context, userID int, id string) bool { return id == strconv.Itoa(userID*100) })
+}
+
+func TestCrossInstanceFanOut(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = tenancy.NewContext(ctx, &tenancy.Tenant{ID: tenancy.DefaultID})
+	other := tenancy.NewContext(ctx, &tenancy.Tenant{ID: 2})
+
+	// 两个实例共用一个 redis
+	a := NewHub(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "realtime:test")
+	b := NewHub(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "realtime:test")
+	go a.Run(ctx)
+	go b.Run(ctx)
+
+	user := newClient(ctx, 7)
+	b.register(user)
+	watcher := newClient(ctx, 8)
+	b.register(watcher)
+	if err := b.subscribe(watcher, "news:sports"); err != nil {
+		t.Fatal(err)
+	}
+	// 其他租户的同名主题
+	outsider := newClient(other, 9)
+	b.register(outsider)
+	if err := b.subscribe(outsider, "news:sports"); err != nil {
+		t.Fatal(err)
+	}
+	// 等待订阅生效
+	time.Sleep(50 * time.Millisecond)
+
+	if err := a.Publish(7, NewEvent("hello", "world")); err != nil {
+		t.Fatal(err)
+	}
+	if e := receive(t, user); e.Type != "hello" || e.Data != "world" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+
+	if err := a.PublishTopic(ctx, "news:sports", NewEvent("headline", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if e := receive(t, watcher); e.Type != "headline" || e.Topic != "news:sports" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	select {
+	case <-user.send:
+		t.Fatal("topic event delivered to unsubscribed client")
+	case <-outsider.send:
+		t.Fatal("topic event delivered to another tenant")
+	default:
+	}
+	if err := a.PublishTopic(other, "news:sports", NewEvent("local", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if e := receive(t, outsider); e.Type != "local" || e.Topic != "news:sports" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if err := a.PublishTopic(context.Background(), "news:sports", NewEvent("headline", nil)); err == nil {
+		t.Fatal("published without tenant")
+	}
+
+	if err := b.subscribe(watcher, "user:7"); err == nil {
+		t.Fatal("subscribed to another user's private topic")
+	}
+}
+
+func TestSubscribeAuthorization(t *testing.T) {
+	h := NewHub(nil, "")
+	cl := newClient(context.Background(), 7)
+	h.register(cl)
+	if err := h.subscribe(cl, "order:700"); err != nil {
+		t.Fatalf("own order: %v", err)
+	}
+	for _, topic := range []string{"order:800", "unknown:1", "news", "", "user:7"} {
+		if err := h.subscribe(cl, topic); err == nil {
+			t.Fatalf("subscribed to %q", topic)
+		}
+	}
+	if len(cl.topics) != 1 {
+		t.Fatalf("topics = %v", cl.topics)
+	}
+}
+
+func TestSlowClientDisconnected(t *testing.T) {
+	h := NewHub(nil, "")
+	cl := newClient(context.Background(), 1)
+	h.register(cl)
+
+	for i := 0; i < sendBuffer+1; i++ {
+		h.Publish(1, NewEvent("tick", i))
+	}
+	select {
+	case <-cl.done:
+	default:
+		t.Fatal("slow client not disconnected")
+	}
+	if len(h.users) != 0 {
+		t.Fatal("slow client not unregistered")
+	}
+}
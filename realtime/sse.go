@@ -0,0 +1,51 @@
+package realtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// heartbeatPeriod SSE 心跳间隔, 防止代理断开空闲连接
+const heartbeatPeriod = 30 * time.Second
+
+// ServeSSE 不支持 WebSocket 时的降级方案, 通过 ?topic=a&topic=b 订阅主题
+func ServeSSE(c *gin.Context) {
+	hub := defaultHub
+	cl, ok := connect(c, hub)
+	if !ok {
+		return
+	}
+	defer hub.unregister(cl)
+
+	w := c.Writer
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// 关闭 nginx 缓冲
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(200)
+	w.Flush()
+
+	ticker := time.NewTicker(heartbeatPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-cl.done:
+			return
+		case msg := <-cl.send:
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", msg); err != nil {
+				return
+			}
+			w.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package realtime
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = pongWait * 9 / 10
+	maxMessageSize = 4096
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     checkOrigin,
+}
+
+// checkOrigin 非 release 模式允许跨域, 生产环境只允许同源或 REALTIME_ALLOWED_ORIGINS 中的域名
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || gin.Mode() != gin.ReleaseMode {
+		return true
+	}
+	if strings.TrimPrefix(strings.TrimPrefix(origin, "https://"), "http://") == r.Host {
+		return true
+	}
+	for _, allowed := range strings.Split(os.Getenv("REALTIME_ALLOWED_ORIGINS"), ",") {
+		if strings.TrimSpace(allowed) == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// command 客户端发送的控制消息
+type command struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+}
+
+// ServeWS WebSocket 连接, 客户端可发送 {"action":"subscribe","topic":"..."} 订阅主题
+func ServeWS(c *gin.Context) {
+	hub := defaultHub
+	cl, ok := connect(c, hub)
+	if !ok {
+		return
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		hub.unregister(cl)
+		return
+	}
+
+	go writePump(hub, cl, conn)
+	readPump(hub, cl, conn)
+}
+
+// readPump 处理控制消息与 pong, 连接断开时注销
+func readPump(hub *Hub, cl *client, conn *websocket.Conn) {
+	defer func() {
+		hub.unregister(cl)
+		conn.Close()
+	}()
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var cmd command
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			reply(cl, "error", "消息格式错误")
+			continue
+		}
+		switch cmd.Action {
+		case "subscribe":
+			if err := hub.subscribe(cl, cmd.Topic); err != nil {
+				reply(cl, "error", err.Error())
+				continue
+			}
+			reply(cl, "subscribed", cmd.Topic)
+		case "unsubscribe":
+			hub.unsubscribe(cl, cmd.Topic)
+			reply(cl, "unsubscribed", cmd.Topic)
+		case "ping":
+			reply(cl, "pong", nil)
+		default:
+			reply(cl, "error", "不支持的操作")
+		}
+	}
+}
+
+// writePump 写出事件并定时发送 ping 心跳
+func writePump(hub *Hub, cl *client, conn *websocket.Conn) {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+	}()
+	for {
+		select {
+		case <-cl.done:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "slow consumer"))
+			return
+		case msg := <-cl.send:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				hub.unregister(cl)
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				hub.unregister(cl)
+				return
+			}
+		}
+	}
+}
+
+func reply(cl *client, typ string, data interface{}) {
+	msg, _ := json.Marshal(NewEvent(typ, data))
+	cl.enqueue(msg)
+}
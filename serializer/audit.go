@@ -0,0 +1,36 @@
+package serializer
+
+import "go-api/ent"
+
+// AuditLog 审计记录序列化器, before/after 为变更前后的 json
+type AuditLog struct {
+	ID        int    `json:"id"`
+	ActorID   int    `json:"actor_id"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	Before    string `json:"before,omitempty"`
+	After     string `json:"after,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// BuildAuditLog 序列化审计记录
+func BuildAuditLog(item *ent.AuditLog) AuditLog {
+	return AuditLog{
+		ID:        item.ID,
+		ActorID:   item.ActorID,
+		Action:    item.Action,
+		Target:    item.Target,
+		Before:    item.Before,
+		After:     item.After,
+		CreatedAt: item.CreatedAt.Unix(),
+	}
+}
+
+// BuildAuditLogs 序列化审计记录列表
+func BuildAuditLogs(items []*ent.AuditLog) []AuditLog {
+	logs := make([]AuditLog, 0, len(items))
+	for _, item := range items {
+		logs = append(logs, BuildAuditLog(item))
+	}
+	return logs
+}
@@ -1,6 +1,11 @@
 package serializer
 
-import "github.com/gin-gonic/gin"
+import (
+	"errors"
+	"go-api/entx"
+
+	"github.com/gin-gonic/gin"
+)
 
 // Response 基础序列化器
 type Response struct {
@@ -10,6 +15,16 @@ type Response struct {
 	Error string      `json:"error,omitempty"`
 }
 
+// Responder 可以作为接口响应输出的序列化器, 扩展了 Response 的序列化器嵌入 Response 即可
+type Responder interface {
+	Base() Response
+}
+
+// Base 基础响应部分
+func (res Response) Base() Response {
+	return res
+}
+
 // TrackedErrorResponse 有追踪信息的错误响应
 type TrackedErrorResponse struct {
 	Response
@@ -25,16 +40,36 @@ const (
 	CodeCheckLogin = 401
 	// CodeNoRightErr 未授权访问
 	CodeNoRightErr = 403
+	// CodeNotFound 资源不存在
+	CodeNotFound = 404
+	// CodePreconditionFailed If-Match 与资源当前版本不一致
+	CodePreconditionFailed = 412
+	// CodeConflict 数据在读取之后已被修改
+	CodeConflict = 409
 	// CodeDBError 数据库操作失败
 	CodeDBError = 50001
 	// CodeEncryptError 加密失败
 	CodeEncryptError = 50002
+	// CodeJobError 任务队列操作失败
+	CodeJobError = 50003
+	// CodeFlagError 功能开关保存失败
+	CodeFlagError = 50004
+	// CodeOIDCError 第三方登录提供方请求失败
+	CodeOIDCError = 50005
+	// CodeDumpError 运维端口写入 dump 失败
+	CodeDumpError = 50006
+	// CodeCacheError 缓存读写失败
+	CodeCacheError = 50007
 	//CodeParamErr 各种奇奇怪怪的参数错误
 	CodeParamErr = 40001
 	//CodeTokenError token 获取失败
 	CodeTokenError = 40002
 	// 超频
 	CodeOverClock = 40003
+	// CodeIdempotencyMismatch 幂等键被不同的请求内容复用
+	CodeIdempotencyMismatch = 40020
+	// CodeIdempotencyInFlight 相同幂等键的请求仍在处理中
+	CodeIdempotencyInFlight = 40021
 )
 
 // CheckLogin 检查登录
@@ -58,8 +93,11 @@ func Err(errCode int, msg string, err error) Response {
 	return res
 }
 
-// DBErr 数据库操作失败
+// DBErr 数据库操作失败, 版本冲突时返回 CodeConflict
 func DBErr(msg string, err error) Response {
+	if errors.Is(err, entx.ErrVersionConflict) {
+		return Err(CodeConflict, "数据已被修改, 请刷新后重试", err)
+	}
 	if msg == "" {
 		msg = "数据库操作失败"
 	}
@@ -0,0 +1,35 @@
+package serializer
+
+import "go-api/ent"
+
+// Identity 第三方账号序列化器
+type Identity struct {
+	Provider  string `json:"provider"`
+	Subject   string `json:"subject"`
+	Email     string `json:"email"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// AuthURL 第三方授权地址
+type AuthURL struct {
+	URL string `json:"url"`
+}
+
+// BuildIdentity 序列化第三方账号
+func BuildIdentity(item *ent.Identity) Identity {
+	return Identity{
+		Provider:  item.Provider,
+		Subject:   item.Subject,
+		Email:     item.Email,
+		CreatedAt: item.CreatedAt.Unix(),
+	}
+}
+
+// BuildIdentities 序列化第三方账号列表
+func BuildIdentities(items []*ent.Identity) []Identity {
+	identities := make([]Identity, 0, len(items))
+	for _, item := range items {
+		identities = append(identities, BuildIdentity(item))
+	}
+	return identities
+}
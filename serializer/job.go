@@ -0,0 +1,92 @@
+package serializer
+
+import (
+	"github.com/hibiken/asynq"
+)
+
+// Queue 任务队列序列化器
+type Queue struct {
+	Queue     string `json:"queue"`
+	Paused    bool   `json:"paused"`
+	Size      int    `json:"size"`
+	Pending   int    `json:"pending"`
+	Active    int    `json:"active"`
+	Scheduled int    `json:"scheduled"`
+	Retry     int    `json:"retry"`
+	Archived  int    `json:"archived"`
+	Completed int    `json:"completed"`
+	Processed int    `json:"processed"`
+	Failed    int    `json:"failed"`
+	Latency   int64  `json:"latency_ms"`
+}
+
+// Task 任务序列化器
+type Task struct {
+	ID            string `json:"id"`
+	Queue         string `json:"queue"`
+	Type          string `json:"type"`
+	Payload       string `json:"payload"`
+	State         string `json:"state"`
+	MaxRetry      int    `json:"max_retry"`
+	Retried       int    `json:"retried"`
+	LastErr       string `json:"last_err"`
+	LastFailedAt  int64  `json:"last_failed_at"`
+	NextProcessAt int64  `json:"next_process_at"`
+}
+
+// BuildQueue 序列化队列
+func BuildQueue(q *asynq.QueueInfo) Queue {
+	return Queue{
+		Queue:     q.Queue,
+		Paused:    q.Paused,
+		Size:      q.Size,
+		Pending:   q.Pending,
+		Active:    q.Active,
+		Scheduled: q.Scheduled,
+		Retry:     q.Retry,
+		Archived:  q.Archived,
+		Completed: q.Completed,
+		Processed: q.Processed,
+		Failed:    q.Failed,
+		Latency:   q.Latency.Milliseconds(),
+	}
+}
+
+// BuildQueues 序列化队列列表
+func BuildQueues(items []*asynq.QueueInfo) []Queue {
+	queues := make([]Queue, 0, len(items))
+	for _, q := range items {
+		queues = append(queues, BuildQueue(q))
+	}
+	return queues
+}
+
+// BuildTask 序列化任务
+func BuildTask(t *asynq.TaskInfo) Task {
+	task := Task{
+		ID:       t.ID,
+		Queue:    t.Queue,
+		Type:     t.Type,
+		Payload:  string(t.Payload),
+		State:    t.State.String(),
+		MaxRetry: t.MaxRetry,
+		Retried:  t.Retried,
+		LastErr:  t.LastErr,
+	}
+	if !t.LastFailedAt.IsZero() {
+		task.LastFailedAt = t.LastFailedAt.Unix()
+	}
+	if !t.NextProcessAt.IsZero() {
+		task.NextProcessAt = t.NextProcessAt.Unix()
+	}
+	return task
+}
+
+// BuildTasks 序列化任务列表
+func BuildTasks(items []*asynq.TaskInfo) []Task {
+	tasks := make([]Task, 0, len(items))
+	for _, t := range items {
+		tasks = append(tasks, BuildTask(t))
+	}
+	return tasks
+}
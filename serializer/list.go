@@ -0,0 +1,24 @@
+package serializer
+
+// ListMeta 列表分页信息
+type ListMeta struct {
+	Size       int    `json:"size"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListResponse 列表响应, 在基础响应上附带分页信息
+type ListResponse struct {
+	Response
+	Meta ListMeta `json:"meta"`
+}
+
+// BuildList 序列化列表响应
+func BuildList(data interface{}, meta ListMeta) ListResponse {
+	return ListResponse{
+		Response: Response{
+			Data: data,
+		},
+		Meta: meta,
+	}
+}
@@ -0,0 +1,86 @@
+package serializer
+
+import (
+	"go-api/ent"
+	"strings"
+)
+
+// OAuthClient 第三方应用序列化器
+type OAuthClient struct {
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	GrantTypes   []string `json:"grant_types"`
+	// Public 公开客户端(如移动端, 单页应用)没有密钥, 只能使用授权码模式
+	Public    bool  `json:"public"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+// OAuthClientSecret 新注册的第三方应用, 密钥只在注册时返回一次
+type OAuthClientSecret struct {
+	OAuthClient
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// OAuthScope 授权范围
+type OAuthScope struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// OAuthConsent 授权确认页展示的内容
+type OAuthConsent struct {
+	ClientID    string       `json:"client_id"`
+	ClientName  string       `json:"client_name"`
+	Scopes      []OAuthScope `json:"scopes"`
+	RedirectURI string       `json:"redirect_uri"`
+}
+
+// OAuthRedirect 授权结果, 前端跳转到 redirect_uri
+type OAuthRedirect struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// OAuthToken RFC 6749 5.1 的 token 响应
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// OAuthIntrospection RFC 7662 的 token 信息, token 无效时只有 active
+type OAuthIntrospection struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	TokenID   string `json:"jti,omitempty"`
+}
+
+// BuildOAuthClient 序列化第三方应用
+func BuildOAuthClient(item *ent.OAuthClient) OAuthClient {
+	return OAuthClient{
+		ClientID:     item.ClientID,
+		Name:         item.Name,
+		RedirectURIs: strings.Fields(item.RedirectUris),
+		Scopes:       strings.Fields(item.Scopes),
+		GrantTypes:   strings.Fields(item.GrantTypes),
+		Public:       item.SecretHash == "",
+		CreatedAt:    item.CreatedAt.Unix(),
+	}
+}
+
+// BuildOAuthClients 序列化第三方应用列表
+func BuildOAuthClients(items []*ent.OAuthClient) []OAuthClient {
+	clients := make([]OAuthClient, 0, len(items))
+	for _, item := range items {
+		clients = append(clients, BuildOAuthClient(item))
+	}
+	return clients
+}
@@ -0,0 +1,53 @@
+package serializer
+
+import (
+	"go-api/ent"
+)
+
+// Pet 宠物序列化器
+type Pet struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Species   string  `json:"species"`
+	Birthday  *string `json:"birthday"`
+	OwnerID   int     `json:"owner_id,omitempty"`
+	CreatedAt int64   `json:"created_at"`
+	UpdatedAt int64   `json:"updated_at"`
+	version   `json:"-"`
+}
+
+// BuildPet 序列化宠物
+func BuildPet(p *ent.Pet) Pet {
+	pet := Pet{
+		ID:        p.ID,
+		Name:      p.Name,
+		Species:   p.Species,
+		CreatedAt: p.CreatedAt.Unix(),
+		UpdatedAt: p.UpdatedAt.Unix(),
+	}
+	if p.Birthday != nil {
+		birthday := p.Birthday.Format("2006-01-02")
+		pet.Birthday = &birthday
+	}
+	if p.Edges.Owner != nil {
+		pet.OwnerID = p.Edges.Owner.ID
+	}
+	pet.version = newVersion("pet", p.ID, p.UpdatedAt, pet)
+	return pet
+}
+
+// BuildPets 序列化宠物列表
+func BuildPets(items []*ent.Pet) []Pet {
+	pets := make([]Pet, 0, len(items))
+	for _, p := range items {
+		pets = append(pets, BuildPet(p))
+	}
+	return pets
+}
+
+// BuildPetResponse 序列化宠物响应
+func BuildPetResponse(p *ent.Pet) Response {
+	return Response{
+		Data: BuildPet(p),
+	}
+}
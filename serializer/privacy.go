@@ -0,0 +1,46 @@
+package serializer
+
+import "go-api/ent"
+
+// DataRequest 数据导出与注销申请序列化器
+// kind 为 export|erasure, status 为 pending|completed|cancelled|failed|expired
+type DataRequest struct {
+	ID          int    `json:"id"`
+	Kind        string `json:"kind"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+	ScheduledAt int64  `json:"scheduled_at,omitempty"`
+	ExpiresAt   int64  `json:"expires_at,omitempty"`
+	CompletedAt int64  `json:"completed_at,omitempty"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+// BuildDataRequest 序列化数据申请
+func BuildDataRequest(item *ent.DataRequest) DataRequest {
+	req := DataRequest{
+		ID:        item.ID,
+		Kind:      item.Kind.String(),
+		Status:    item.Status.String(),
+		Error:     item.Error,
+		CreatedAt: item.CreatedAt.Unix(),
+	}
+	if item.ScheduledAt != nil {
+		req.ScheduledAt = item.ScheduledAt.Unix()
+	}
+	if item.ExpiresAt != nil {
+		req.ExpiresAt = item.ExpiresAt.Unix()
+	}
+	if item.CompletedAt != nil {
+		req.CompletedAt = item.CompletedAt.Unix()
+	}
+	return req
+}
+
+// BuildDataRequests 序列化数据申请列表
+func BuildDataRequests(items []*ent.DataRequest) []DataRequest {
+	requests := make([]DataRequest, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, BuildDataRequest(item))
+	}
+	return requests
+}
@@ -0,0 +1,39 @@
+package serializer
+
+import (
+	"encoding/json"
+	"go-api/ent"
+)
+
+// Tenant 租户序列化器
+type Tenant struct {
+	ID        int               `json:"id"`
+	Name      string            `json:"name"`
+	Status    string            `json:"status"`
+	Config    map[string]string `json:"config"`
+	CreatedAt int64             `json:"created_at"`
+}
+
+// BuildTenant 序列化租户
+func BuildTenant(item *ent.Tenant) Tenant {
+	config := make(map[string]string)
+	if item.Config != "" {
+		json.Unmarshal([]byte(item.Config), &config)
+	}
+	return Tenant{
+		ID:        item.ID,
+		Name:      item.Name,
+		Status:    item.Status,
+		Config:    config,
+		CreatedAt: item.CreatedAt.Unix(),
+	}
+}
+
+// BuildTenants 序列化租户列表
+func BuildTenants(items []*ent.Tenant) []Tenant {
+	tenants := make([]Tenant, 0, len(items))
+	for _, item := range items {
+		tenants = append(tenants, BuildTenant(item))
+	}
+	return tenants
+}
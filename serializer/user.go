@@ -11,7 +11,9 @@ type User struct {
 	Nickname  string `json:"nickname"`
 	Status    string `json:"status"`
 	Avatar    string `json:"avatar"`
+	Role      string `json:"role"`
 	CreatedAt int64  `json:"created_at"`
+	version   `json:"-"`
 }
 
 //User 用户序列化器
@@ -33,14 +35,26 @@ type UserList struct {
 
 // BuildUser 序列化用户
 func BuildUser(user *ent.User) User {
-	return User{
+	u := User{
 		ID:        uint(user.ID),
 		Username:  user.Username,
 		Nickname:  user.Nickname,
 		Status:    user.Status,
 		Avatar:    user.Avatar,
+		Role:      user.Role,
 		CreatedAt: user.CreatedAt.Unix(),
 	}
+	u.version = newVersion("user", user.ID, user.UpdatedAt, u)
+	return u
+}
+
+// BuildUsers 序列化用户列表
+func BuildUsers(items []*ent.User) []User {
+	users := make([]User, 0, len(items))
+	for _, item := range items {
+		users = append(users, BuildUser(item))
+	}
+	return users
 }
 
 // BuildUserToken 序列化用户带token信息
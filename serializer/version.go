@@ -0,0 +1,43 @@
+package serializer
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Versioned 带版本信息的序列化器, 用于生成 ETag 与 Last-Modified
+type Versioned interface {
+	ETag() string
+	LastModified() time.Time
+}
+
+// version 实体版本, 嵌入序列化器中, 不参与 JSON 输出
+type version struct {
+	etag     string
+	modified time.Time
+}
+
+// ETag 强校验的实体标签
+func (v version) ETag() string {
+	return v.etag
+}
+
+// LastModified 实体最后修改时间
+func (v version) LastModified() time.Time {
+	return v.modified
+}
+
+// newVersion 由实体类型, 主键, 修改时间和序列化后的内容生成版本
+// 数据库时间精度为秒时, 同一秒内的两次修改依靠内容区分
+func newVersion(kind string, id int, updatedAt time.Time, data interface{}) version {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s:%d:%d:", kind, id, updatedAt.UnixNano())
+	json.NewEncoder(h).Encode(data)
+	return version{
+		etag:     `"` + kind + "-" + hex.EncodeToString(h.Sum(nil))[:16] + `"`,
+		modified: updatedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package serializer
+
+import (
+	"go-api/ent"
+	"strings"
+)
+
+// WebhookEndpoint webhook 地址序列化器
+type WebhookEndpoint struct {
+	ID          int      `json:"id"`
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Description string   `json:"description"`
+	Enabled     bool     `json:"enabled"`
+	// Failures 连续失败次数
+	Failures   int   `json:"failures"`
+	DisabledAt int64 `json:"disabled_at,omitempty"`
+	CreatedAt  int64 `json:"created_at"`
+}
+
+// WebhookEndpointSecret 新注册的地址, 签名密钥只在注册时返回一次
+type WebhookEndpointSecret struct {
+	WebhookEndpoint
+	Secret string `json:"secret"`
+}
+
+// WebhookEvent 可以订阅的事件
+type WebhookEvent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// WebhookDelivery 投递记录序列化器, status 为 pending|succeeded|failed
+type WebhookDelivery struct {
+	ID             int    `json:"id"`
+	EventID        int    `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"response_status"`
+	ResponseBody   string `json:"response_body"`
+	Error          string `json:"error,omitempty"`
+	DurationMs     int64  `json:"duration_ms"`
+	ReplayOf       int    `json:"replay_of,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	LastAttemptAt  int64  `json:"last_attempt_at,omitempty"`
+	DeliveredAt    int64  `json:"delivered_at,omitempty"`
+}
+
+// BuildWebhookEndpoint 序列化 webhook 地址
+func BuildWebhookEndpoint(item *ent.WebhookEndpoint) WebhookEndpoint {
+	endpoint := WebhookEndpoint{
+		ID:          item.ID,
+		URL:         item.URL,
+		Events:      strings.Fields(item.Events),
+		Description: item.Description,
+		Enabled:     item.Enabled,
+		Failures:    item.Failures,
+		CreatedAt:   item.CreatedAt.Unix(),
+	}
+	if item.DisabledAt != nil {
+		endpoint.DisabledAt = item.DisabledAt.Unix()
+	}
+	return endpoint
+}
+
+// BuildWebhookEndpoints 序列化 webhook 地址列表
+func BuildWebhookEndpoints(items []*ent.WebhookEndpoint) []WebhookEndpoint {
+	endpoints := make([]WebhookEndpoint, 0, len(items))
+	for _, item := range items {
+		endpoints = append(endpoints, BuildWebhookEndpoint(item))
+	}
+	return endpoints
+}
+
+// BuildWebhookDelivery 序列化投递记录
+func BuildWebhookDelivery(item *ent.WebhookDelivery) WebhookDelivery {
+	delivery := WebhookDelivery{
+		ID:             item.ID,
+		EventID:        item.EventID,
+		EventType:      item.EventType,
+		Status:         item.Status.String(),
+		Attempts:       item.Attempts,
+		ResponseStatus: item.ResponseStatus,
+		ResponseBody:   item.ResponseBody,
+		Error:          item.Error,
+		DurationMs:     item.DurationMs,
+		ReplayOf:       item.ReplayOf,
+		CreatedAt:      item.CreatedAt.Unix(),
+	}
+	if item.LastAttemptAt != nil {
+		delivery.LastAttemptAt = item.LastAttemptAt.Unix()
+	}
+	if item.DeliveredAt != nil {
+		delivery.DeliveredAt = item.DeliveredAt.Unix()
+	}
+	return delivery
+}
+
+// BuildWebhookDeliveries 序列化投递记录列表
+func BuildWebhookDeliveries(items []*ent.WebhookDelivery) []WebhookDelivery {
+	deliveries := make([]WebhookDelivery, 0, len(items))
+	for _, item := range items {
+		deliveries = append(deliveries, BuildWebhookDelivery(item))
+	}
+	return deliveries
+}
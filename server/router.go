@@ -6,24 +6,49 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go-api/api"
 	"go-api/middleware"
+	"go-api/openapi"
+	"go-api/realtime"
+	"os"
 )
 
 // NewRouter 路由配置
 func NewRouter() *gin.Engine {
 	r := gin.Default()
+	// 处理函数把 *gin.Context 作为 context 传给服务层, 需要能读取到请求 context 中的租户
+	r.ContextWithFallback = true
 
 	r.NoMethod(api.HandleNotFound)
 	r.NoRoute(api.HandleNotFound)
 
 	if gin.Mode() != gin.ReleaseMode {
-		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+		swagger := ginSwagger.WrapHandler(swaggerFiles.Handler)
+		openapi.Hide(swagger)
+		r.GET("/swagger/*any", swagger)
+		r.GET("/openapi.json", openapi.Handler(r))
 	}
+	// 跨域策略, 公开接口不需要登录凭证, 允许任意来源, 可以用 CORS_PUBLIC_* 覆盖
+	corsPolicy := middleware.CorsConfig("", middleware.DefaultCorsPolicy())
+	publicCors := corsPolicy
+	publicCors.AllowOrigins, publicCors.AllowCredentials = []string{"*"}, false
+
 	// 中间件, 顺序不能改
-	// cors zaplog time/rate
-	r.Use(middleware.Cors(),
+	// cors zaplog compress db tenant session csrf time/rate idempotency
+	r.Use(middleware.Cors(corsPolicy,
+		middleware.CorsRoute{Prefix: "/api/v1/ping", Policy: middleware.CorsConfig("PUBLIC", publicCors)},
+	),
 		middleware.GinLogger(),
+		middleware.Compress(),
+		middleware.ReadYourWrites(),
+		middleware.Tenant(),
+		middleware.Session(os.Getenv("SESSION_SECRET")),
+		middleware.CSRF(),
 		middleware.Rate(),
+		middleware.Idempotency(),
 	)
+	// 开发环境按接口文档校验请求
+	if gin.Mode() != gin.ReleaseMode {
+		r.Use(openapi.Validate(r))
+	}
 
 	// 路由
 	v1 := r.Group("/api/v1")
@@ -36,19 +61,101 @@ func NewRouter() *gin.Engine {
 		// 用户登录
 		v1.POST("user/login", api.UserLogin)
 
+		// 第三方登录
+		v1.GET("oauth/providers", api.OIDCProviders)
+		v1.GET("oauth/:provider/login", api.OIDCLogin)
+		v1.GET("oauth/:provider/callback", api.OIDCCallback)
+
 		// oss token
 		v1.GET("oss", api.GetOssToken)
 
+		// 实时推送, 自行校验 token 以支持通过 query 参数传递
+		v1.GET("realtime/ws", realtime.ServeWS)
+		v1.GET("realtime/sse", realtime.ServeSSE)
+
+		// 刷新 token, 允许使用宽限期内过期的 token
+		v1.PUT("user/token/refresh", middleware.JWTRefresh(), api.UserTokenRefresh)
+
+		// OAuth2 授权服务器, 应用在端点内自行认证
+		v1.POST("oauth2/token", api.OAuthToken)
+		v1.POST("oauth2/introspect", api.OAuthIntrospect)
+		v1.POST("oauth2/revoke", api.OAuthRevoke)
+
 		// 需要登录保护的
 		auth := v1.Group("")
 		auth.Use(middleware.JWTAuth())
 		{
-			// User Routing
-			auth.GET("user/me", api.UserMe)
-			auth.DELETE("user/logout", api.UserLogout)
+			// 第三方应用的 token 按授权范围访问
+			auth.GET("user/me", middleware.Scope("profile"), middleware.ETag("private, no-cache"), api.UserMe)
+
+			// 宠物
+			auth.POST("pets", middleware.Scope("pets:write"), api.PetCreate)
+			auth.GET("pets", middleware.Scope("pets:read"), middleware.ETag("private, no-cache"), api.PetList)
+			auth.GET("pets/:id", middleware.Scope("pets:read"), middleware.ETag("private, no-cache"), api.PetShow)
+			auth.PUT("pets/:id", middleware.Scope("pets:write"), api.PetUpdate)
+			auth.DELETE("pets/:id", middleware.Scope("pets:write"), api.PetDelete)
+
+			// 只允许用户自己登录的 token
+			own := auth.Group("")
+			own.Use(middleware.FirstParty())
+			{
+				own.DELETE("user/logout", api.UserLogout)
+				own.DELETE("user/sessions", api.UserLogoutEverywhere)
+
+				// 第三方账号绑定
+				own.GET("user/identities", api.IdentityList)
+				own.POST("user/identities/:provider", api.IdentityLink)
+				own.DELETE("user/identities/:provider", api.IdentityUnlink)
+
+				// 个人数据导出与注销
+				own.POST("user/me/export", api.UserExport)
+				own.POST("user/me/erasure", api.UserErasure)
+				own.DELETE("user/me/erasure", api.UserErasureCancel)
+				own.GET("user/me/data-requests", api.DataRequestList)
+				own.GET("user/me/data-requests/:id/download", api.DataRequestDownload)
+
+				// 授权第三方应用
+				own.GET("oauth2/authorize", api.OAuthConsent)
+				own.POST("oauth2/authorize", api.OAuthAuthorize)
+			}
+
+			// 管理后台
+			admin := auth.Group("admin")
+			admin.Use(middleware.AdminRequired())
+			{
+				// 不区分租户的全局资源, 只允许默认租户的管理员
+				platform := admin.Group("")
+				platform.Use(middleware.PlatformAdmin())
+				{
+					// 任务队列
+					platform.GET("jobs/queues", api.JobQueues)
+					platform.GET("jobs/queues/:queue/tasks", api.JobTasks)
+					platform.POST("jobs/queues/:queue/tasks/:id/run", api.JobTaskRun)
+					platform.DELETE("jobs/queues/:queue/tasks/:id", api.JobTaskDelete)
+
+					// 功能开关
+					platform.GET("flags", api.FlagList)
+					platform.PUT("flags/:name", api.FlagUpdate)
+					platform.GET("flags/:name/audits", api.FlagAudits)
+				}
+
+				// 用户管理
+				admin.PUT("users/:id/status", api.UserStatus)
+
+				// 第三方应用
+				admin.POST("oauth/clients", api.OAuthClientCreate)
+				admin.GET("oauth/clients", api.OAuthClientList)
+				admin.DELETE("oauth/clients/:client_id", api.OAuthClientDelete)
 
-			//refresh token
-			auth.PUT("user/token/refresh", api.UserTokenRefresh)
+				// webhook
+				admin.GET("webhooks/events", api.WebhookEvents)
+				admin.POST("webhooks", api.WebhookCreate)
+				admin.GET("webhooks", api.WebhookList)
+				admin.PUT("webhooks/:id", api.WebhookUpdate)
+				admin.DELETE("webhooks/:id", api.WebhookDelete)
+				admin.GET("webhooks/:id/deliveries", api.WebhookDeliveries)
+				admin.POST("webhooks/:id/deliveries/:delivery_id/replay", api.WebhookReplay)
+			}
 		}
 	}
 	return r
@@ -0,0 +1,51 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go-api/openapi"
+	"go-api/server"
+	"go-api/testutil"
+)
+
+func TestRoutesDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc, undocumented := openapi.Build(server.NewRouter().Routes())
+	if len(undocumented) > 0 {
+		t.Fatalf("路由缺少接口文档, 请在 api/openapi.go 中登记:\n%s", strings.Join(undocumented, "\n"))
+	}
+	if doc.Paths["/api/v1/pets/{id}"]["put"] == nil {
+		t.Fatal("PUT /api/v1/pets/{id} missing from spec")
+	}
+}
+
+func TestOpenAPIServedAndValidated(t *testing.T) {
+	r := testutil.New(t).Router
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var doc openapi.Document
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil || doc.OpenAPI != openapi.Version {
+		t.Fatalf("unexpected spec %d %s", w.Code, w.Body.String())
+	}
+	if _, ok := doc.Components.Schemas["service.UserLoginService"]; !ok {
+		t.Fatal("request schema missing")
+	}
+	if _, ok := doc.Components.Schemas["serializer.UserToken"]; !ok {
+		t.Fatal("response schema missing")
+	}
+
+	// 用户名过短, 在到达处理函数之前被拒绝
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/user/login", strings.NewReader(`{"username":"ab","password":"secret123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "username") {
+		t.Fatalf("expected validation error, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"go-api/ent"
+	"go-api/ent/auditlog"
+	"go-api/ent/predicate"
+	"go-api/listquery"
+	"go-api/model"
+	"go-api/serializer"
+	"net/url"
+)
+
+// auditListSchema 审计记录允许过滤与排序的字段
+var auditListSchema = &listquery.Schema{
+	Fields: []listquery.Field{
+		{Name: auditlog.FieldActorID, Type: listquery.Int},
+		{Name: auditlog.FieldAction, Type: listquery.String},
+		{Name: auditlog.FieldCreatedAt, Type: listquery.Time, Sortable: true},
+	},
+	DefaultSort: "-" + auditlog.FieldCreatedAt,
+}
+
+// ListAudits 对象的审计记录, 默认按时间倒序
+func ListAudits(ctx context.Context, target string, values url.Values) serializer.ListResponse {
+	q, err := listquery.Parse(auditListSchema, values)
+	if err != nil {
+		return serializer.ListResponse{Response: serializer.ParamErr(err.Error(), err)}
+	}
+
+	where := append([]predicate.AuditLog{auditlog.Target(target)}, listquery.Where[predicate.AuditLog](q)...)
+	items, err := model.Client.AuditLog.Query().
+		Where(where...).
+		Order(listquery.Order[ent.OrderFunc](q)...).
+		Limit(q.Limit()).
+		All(ctx)
+	if err != nil {
+		return serializer.ListResponse{Response: serializer.DBErr("", err)}
+	}
+	items, meta := listquery.Paginate(q, items)
+	return serializer.BuildList(serializer.BuildAuditLogs(items), meta)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api/jobs"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+// SendEmailPayload 发送邮件任务
+type SendEmailPayload struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+// SendEmailTask 通过 SMTP_ADDR 配置的服务器发送邮件
+var SendEmailTask = jobs.NewTask[SendEmailPayload]("email:send")
+
+func init() {
+	SendEmailTask.Handle(sendEmail)
+}
+
+func sendEmail(ctx context.Context, p SendEmailPayload) error {
+	addr := os.Getenv("SMTP_ADDR")
+	if addr == "" {
+		return fmt.Errorf("SMTP_ADDR 未配置: %w", jobs.SkipRetry)
+	}
+	if len(p.To) == 0 {
+		return fmt.Errorf("收件人为空: %w", jobs.SkipRetry)
+	}
+	from := os.Getenv("SMTP_FROM")
+
+	var auth smtp.Auth
+	if username := os.Getenv("SMTP_USER"); username != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return errors.New("SMTP_ADDR 格式错误")
+		}
+		auth = smtp.PlainAuth("", username, os.Getenv("SMTP_PW"), host)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("From: " + from + "\r\n")
+	msg.WriteString("To: " + strings.Join(p.To, ",") + "\r\n")
+	msg.WriteString("Subject: " + p.Subject + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(p.Body)
+	return smtp.SendMail(addr, auth, from, p.To, []byte(msg.String()))
+}
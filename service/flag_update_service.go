@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-api/ent"
+	"go-api/flags"
+	"go-api/model"
+	"go-api/serializer"
+)
+
+// FlagUpdateService 新增或修改功能开关的服务
+type FlagUpdateService struct {
+	Description string          `form:"description" json:"description" binding:"max=200"`
+	Enabled     bool            `form:"enabled" json:"enabled"`
+	Rollout     int             `form:"rollout" json:"rollout" binding:"min=0,max=100"`
+	Allow       []uint          `form:"allow" json:"allow"`
+	Variants    []flags.Variant `form:"variants" json:"variants" binding:"dive"`
+}
+
+// Update 保存开关, 审计记录与开关一同写入, 保存开关失败时审计记录回滚
+func (service *FlagUpdateService) Update(ctx context.Context, actorID int, name string) serializer.Response {
+	f := flags.Flag{
+		Name:        name,
+		Description: service.Description,
+		Enabled:     service.Enabled,
+		Rollout:     service.Rollout,
+		Allow:       service.Allow,
+		Variants:    service.Variants,
+	}
+	if err := f.Validate(); err != nil {
+		return serializer.ParamErr("功能开关定义不合法", err)
+	}
+
+	var before string
+	if old, ok := flags.Default.Get(name); ok {
+		data, _ := json.Marshal(old)
+		before = string(data)
+	}
+	after, _ := json.Marshal(f)
+
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		_, err := tx.AuditLog.Create().
+			SetActorID(actorID).
+			SetAction("flag.update").
+			SetTarget("flag:" + name).
+			SetBefore(before).
+			SetAfter(string(after)).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		return flags.Default.Save(ctx, f)
+	})
+	if errors.Is(err, flags.ErrReadOnly) {
+		return serializer.Err(serializer.CodeParamErr, "功能开关为只读配置", err)
+	}
+	if err != nil {
+		return serializer.Err(serializer.CodeFlagError, "保存功能开关失败", err)
+	}
+
+	f, _ = flags.Default.Get(name)
+	return serializer.Response{Data: f}
+}
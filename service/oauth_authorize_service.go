@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"go-api/auth/oauth"
+	"go-api/ent"
+	"go-api/ent/oauthclient"
+	"go-api/model"
+	"go-api/serializer"
+	"net/url"
+	"strings"
+)
+
+// OAuthAuthorizeService 授权码模式的授权请求, 由已登录用户在授权确认页发起
+type OAuthAuthorizeService struct {
+	ResponseType        string `form:"response_type" json:"response_type" binding:"required"`
+	ClientID            string `form:"client_id" json:"client_id" binding:"required"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri"`
+	Scope               string `form:"scope" json:"scope"`
+	State               string `form:"state" json:"state"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+	// Approve 用户是否同意授权, 只用于提交确认结果
+	Approve bool `form:"approve" json:"approve"`
+}
+
+// client 校验应用与回调地址, 回调地址不可信时不能跳转, 直接返回错误
+func (service *OAuthAuthorizeService) client(ctx context.Context) (*ent.OAuthClient, string, *serializer.Response) {
+	client, err := model.Client.OAuthClient.Query().Where(oauthclient.ClientID(service.ClientID)).Only(ctx)
+	if ent.IsNotFound(err) {
+		res := serializer.ParamErr("应用不存在", err)
+		return nil, "", &res
+	}
+	if err != nil {
+		res := serializer.DBErr("", err)
+		return nil, "", &res
+	}
+	if !oauth.Contains(client.GrantTypes, oauth.GrantAuthorizationCode) {
+		res := serializer.ParamErr("应用未开通授权码模式", nil)
+		return nil, "", &res
+	}
+
+	uris := strings.Fields(client.RedirectUris)
+	redirect := service.RedirectURI
+	// 只登记了一个回调地址时可以省略
+	if redirect == "" && len(uris) == 1 {
+		redirect = uris[0]
+	}
+	if !oauth.Contains(client.RedirectUris, redirect) {
+		res := serializer.ParamErr("回调地址与应用登记的不一致", nil)
+		return nil, "", &res
+	}
+	return client, redirect, nil
+}
+
+// scopes 校验授权请求, 返回授予的授权范围, 错误按 RFC 6749 4.1.2.1 通过回调地址返回
+func (service *OAuthAuthorizeService) scopes(client *ent.OAuthClient) ([]string, *oauth.Error) {
+	if service.ResponseType != "code" {
+		return nil, oauth.Errorf("unsupported_response_type", "only response_type=code is supported")
+	}
+	if service.CodeChallenge == "" || service.CodeChallengeMethod != "S256" {
+		return nil, oauth.Errorf("invalid_request", "code_challenge with code_challenge_method=S256 is required")
+	}
+	scopes := oauth.ParseScope(service.Scope)
+	if len(scopes) == 0 {
+		scopes = strings.Fields(client.Scopes)
+	}
+	for _, scope := range scopes {
+		if !oauth.Contains(client.Scopes, scope) {
+			return nil, oauth.Errorf("invalid_scope", "scope %s is not allowed for this client", scope)
+		}
+	}
+	return scopes, nil
+}
+
+// Consent 授权确认页需要展示的应用与授权范围
+func (service *OAuthAuthorizeService) Consent(ctx context.Context) serializer.Response {
+	client, redirect, res := service.client(ctx)
+	if res != nil {
+		return *res
+	}
+	scopes, oerr := service.scopes(client)
+	if oerr != nil {
+		return serializer.ParamErr(oerr.Description, oerr)
+	}
+	consent := serializer.OAuthConsent{
+		ClientID:    client.ClientID,
+		ClientName:  client.Name,
+		RedirectURI: redirect,
+	}
+	for _, scope := range scopes {
+		consent.Scopes = append(consent.Scopes, serializer.OAuthScope{Name: scope, Description: oauth.Scopes[scope]})
+	}
+	return serializer.Response{Data: consent}
+}
+
+// Authorize 用户确认授权, 返回带有授权码或错误的回调地址
+func (service *OAuthAuthorizeService) Authorize(ctx context.Context, userID int) serializer.Response {
+	client, redirect, res := service.client(ctx)
+	if res != nil {
+		return *res
+	}
+
+	q := url.Values{}
+	if service.State != "" {
+		q.Set("state", service.State)
+	}
+	scopes, oerr := service.scopes(client)
+	switch {
+	case oerr != nil:
+		q.Set("error", oerr.Code)
+		q.Set("error_description", oerr.Description)
+	case !service.Approve:
+		q.Set("error", "access_denied")
+	default:
+		code, err := oauth.SaveCode(ctx, oauth.Code{
+			ClientID:    client.ClientID,
+			UserID:      userID,
+			RedirectURI: service.RedirectURI,
+			Scope:       strings.Join(scopes, " "),
+			Challenge:   service.CodeChallenge,
+		})
+		if err != nil {
+			return serializer.Err(serializer.CodeTokenError, "授权失败", err)
+		}
+		q.Set("code", code)
+	}
+
+	u, _ := url.Parse(redirect)
+	if u.RawQuery != "" {
+		u.RawQuery += "&" + q.Encode()
+	} else {
+		u.RawQuery = q.Encode()
+	}
+	return serializer.Response{Data: serializer.OAuthRedirect{RedirectURI: u.String()}}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-api/auth/oauth"
+	"go-api/ent"
+	"go-api/ent/oauthclient"
+	"go-api/model"
+	"go-api/serializer"
+	"net/url"
+	"strings"
+)
+
+// OAuthClientCreateService 注册第三方应用的服务
+type OAuthClientCreateService struct {
+	Name         string   `form:"name" json:"name" binding:"required,max=100"`
+	RedirectURIs []string `form:"redirect_uris" json:"redirect_uris"`
+	Scopes       []string `form:"scopes" json:"scopes" binding:"required"`
+	GrantTypes   []string `form:"grant_types" json:"grant_types" binding:"required"`
+	// Public 公开客户端不签发密钥, 只能使用授权码模式
+	Public bool `form:"public" json:"public"`
+}
+
+// valid 校验回调地址, 授权范围与授权类型
+func (service *OAuthClientCreateService) valid() *serializer.Response {
+	for _, scope := range service.Scopes {
+		if _, ok := oauth.Scopes[scope]; !ok {
+			res := serializer.ParamErr("不支持的授权范围 "+scope, nil)
+			return &res
+		}
+	}
+	code := false
+	for _, grant := range service.GrantTypes {
+		switch grant {
+		case oauth.GrantAuthorizationCode:
+			code = true
+		case oauth.GrantClientCredentials:
+			if service.Public {
+				res := serializer.ParamErr("公开客户端不能使用客户端凭证模式", nil)
+				return &res
+			}
+		default:
+			res := serializer.ParamErr("不支持的授权类型 "+grant, nil)
+			return &res
+		}
+	}
+	if code && len(service.RedirectURIs) == 0 {
+		res := serializer.ParamErr("授权码模式需要回调地址", nil)
+		return &res
+	}
+	for _, uri := range service.RedirectURIs {
+		u, err := url.Parse(uri)
+		if err != nil || !u.IsAbs() || u.Fragment != "" || strings.ContainsAny(uri, " \t\n") {
+			res := serializer.ParamErr("回调地址不合法 "+uri, err)
+			return &res
+		}
+	}
+	return nil
+}
+
+// Create 注册第三方应用, 密钥只在此时返回
+func (service *OAuthClientCreateService) Create(ctx context.Context, actorID int) serializer.Response {
+	if err := service.valid(); err != nil {
+		return *err
+	}
+
+	var secret, hash string
+	if !service.Public {
+		secret = oauth.Random(32)
+		hash = oauth.HashSecret(secret)
+	}
+	var client *ent.OAuthClient
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		var err error
+		client, err = tx.OAuthClient.Create().
+			SetClientID(oauth.Random(16)).
+			SetSecretHash(hash).
+			SetName(service.Name).
+			SetRedirectUris(strings.Join(service.RedirectURIs, " ")).
+			SetScopes(strings.Join(oauth.ParseScope(strings.Join(service.Scopes, " ")), " ")).
+			SetGrantTypes(strings.Join(service.GrantTypes, " ")).
+			SetCreatedBy(actorID).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		after, _ := json.Marshal(serializer.BuildOAuthClient(client))
+		_, err = tx.AuditLog.Create().
+			SetActorID(actorID).
+			SetAction("oauth_client.create").
+			SetTarget("oauth_client:" + client.ClientID).
+			SetAfter(string(after)).
+			Save(ctx)
+		return err
+	})
+	if err != nil {
+		return serializer.DBErr("注册应用失败", err)
+	}
+	return serializer.Response{
+		Data: serializer.OAuthClientSecret{
+			OAuthClient:  serializer.BuildOAuthClient(client),
+			ClientSecret: secret,
+		},
+	}
+}
+
+// ListOAuthClients 全部第三方应用
+func ListOAuthClients(ctx context.Context) serializer.Response {
+	items, err := model.Client.OAuthClient.Query().Order(ent.Desc(oauthclient.FieldCreatedAt)).All(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildOAuthClients(items)}
+}
+
+// DeleteOAuthClient 删除第三方应用, 已签发的 token 随即失效, 吊销失败时不删除
+func DeleteOAuthClient(ctx context.Context, actorID int, clientID string) serializer.Response {
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		client, err := tx.OAuthClient.Query().Where(oauthclient.ClientID(clientID)).Only(ctx)
+		if err != nil {
+			return err
+		}
+		before, _ := json.Marshal(serializer.BuildOAuthClient(client))
+		if err := tx.OAuthClient.DeleteOne(client).Exec(ctx); err != nil {
+			return err
+		}
+		if err := oauth.RevokeClient(ctx, client.TenantID, clientID, OAuthTokenTTL()); err != nil {
+			return err
+		}
+		_, err = tx.AuditLog.Create().
+			SetActorID(actorID).
+			SetAction("oauth_client.delete").
+			SetTarget("oauth_client:" + clientID).
+			SetBefore(string(before)).
+			Save(ctx)
+		return err
+	})
+	if ent.IsNotFound(err) {
+		return serializer.Err(serializer.CodeNotFound, "应用不存在", err)
+	}
+	if err != nil {
+		return serializer.DBErr("删除应用失败", err)
+	}
+	return serializer.Response{Msg: "删除成功"}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"go-api/auth/oauth"
+	"go-api/ent"
+	"go-api/ent/oauthclient"
+	"go-api/middleware"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/util"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// OAuthTokenService token 端点, 客户端密钥可以放在 Basic 认证中或表单中
+type OAuthTokenService struct {
+	GrantType    string `form:"grant_type" json:"grant_type" binding:"required"`
+	Code         string `form:"code" json:"code"`
+	RedirectURI  string `form:"redirect_uri" json:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier" json:"code_verifier"`
+	Scope        string `form:"scope" json:"scope"`
+	ClientID     string `form:"client_id" json:"client_id"`
+	ClientSecret string `form:"client_secret" json:"client_secret"`
+}
+
+// OAuthTokenTTL 第三方应用 access token 的有效期, OAUTH_TOKEN_TTL 秒, 默认 1 小时
+func OAuthTokenTTL() time.Duration {
+	ttl, err := strconv.Atoi(os.Getenv("OAUTH_TOKEN_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 3600
+	}
+	return time.Duration(ttl) * time.Second
+}
+
+// RevokeOAuthGrants 吊销用户授权给第三方应用的全部 token, 用于封禁, 重置密码与注销
+func RevokeOAuthGrants(ctx context.Context, tenantID, userID int) error {
+	return oauth.RevokeUser(ctx, tenantID, userID, OAuthTokenTTL())
+}
+
+// authenticateClient 客户端认证, 公开客户端只需要 client_id
+func authenticateClient(ctx context.Context, id, secret string, requireSecret bool) (*ent.OAuthClient, *oauth.Error) {
+	if id == "" {
+		return nil, oauth.InvalidClient()
+	}
+	client, err := model.Client.OAuthClient.Query().Where(oauthclient.ClientID(id)).Only(ctx)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			util.Log().Error("查询应用失败 %v", err)
+		}
+		return nil, oauth.InvalidClient()
+	}
+	if client.SecretHash == "" {
+		if requireSecret || secret != "" {
+			return nil, oauth.InvalidClient()
+		}
+		return client, nil
+	}
+	if !oauth.CheckSecret(client.SecretHash, secret) {
+		return nil, oauth.InvalidClient()
+	}
+	return client, nil
+}
+
+// Token 按授权类型签发 access token
+func (service *OAuthTokenService) Token(ctx context.Context) (*serializer.OAuthToken, *oauth.Error) {
+	switch service.GrantType {
+	case oauth.GrantAuthorizationCode:
+		return service.authorizationCode(ctx)
+	case oauth.GrantClientCredentials:
+		return service.clientCredentials(ctx)
+	default:
+		return nil, oauth.Errorf("unsupported_grant_type", "grant_type %s is not supported", service.GrantType)
+	}
+}
+
+func (service *OAuthTokenService) authorizationCode(ctx context.Context) (*serializer.OAuthToken, *oauth.Error) {
+	client, oerr := authenticateClient(ctx, service.ClientID, service.ClientSecret, false)
+	if oerr != nil {
+		return nil, oerr
+	}
+	if !oauth.Contains(client.GrantTypes, oauth.GrantAuthorizationCode) {
+		return nil, oauth.Errorf("unauthorized_client", "client is not allowed to use %s", oauth.GrantAuthorizationCode)
+	}
+	code, err := oauth.TakeCode(ctx, service.Code)
+	if err != nil {
+		util.Log().Error("读取授权码失败 %v", err)
+		return nil, &oauth.Error{Status: 500, Code: "server_error"}
+	}
+	// 授权码只能由申请它的应用, 以相同的回调地址和 code_verifier 兑换
+	if code == nil || code.ClientID != client.ClientID || code.RedirectURI != service.RedirectURI {
+		return nil, oauth.Errorf("invalid_grant", "authorization code is invalid or expired")
+	}
+	if !oauth.VerifyChallenge(service.CodeVerifier, code.Challenge) {
+		return nil, oauth.Errorf("invalid_grant", "code_verifier does not match code_challenge")
+	}
+
+	member, err := model.Client.User.Get(ctx, code.UserID)
+	if err != nil || member.Status != model.Active {
+		return nil, oauth.Errorf("invalid_grant", "resource owner is not available")
+	}
+	return issueOAuthToken(client, member.ID, member.Nickname, code.Scope)
+}
+
+func (service *OAuthTokenService) clientCredentials(ctx context.Context) (*serializer.OAuthToken, *oauth.Error) {
+	client, oerr := authenticateClient(ctx, service.ClientID, service.ClientSecret, true)
+	if oerr != nil {
+		return nil, oerr
+	}
+	if !oauth.Contains(client.GrantTypes, oauth.GrantClientCredentials) {
+		return nil, oauth.Errorf("unauthorized_client", "client is not allowed to use %s", oauth.GrantClientCredentials)
+	}
+	// 客户端凭证的 token 不代表任何用户, 不能获得访问用户数据的授权范围
+	scopes := oauth.ParseScope(service.Scope)
+	if len(scopes) == 0 {
+		for _, scope := range strings.Fields(client.Scopes) {
+			if _, user := oauth.Scopes[scope]; !user {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	for _, scope := range scopes {
+		if _, user := oauth.Scopes[scope]; user {
+			return nil, oauth.Errorf("invalid_scope", "scope %s requires user authorization", scope)
+		}
+		if !oauth.Contains(client.Scopes, scope) {
+			return nil, oauth.Errorf("invalid_scope", "scope %s is not allowed for this client", scope)
+		}
+	}
+	return issueOAuthToken(client, 0, client.Name, strings.Join(scopes, " "))
+}
+
+// issueOAuthToken 签发第三方应用的 access token, 客户端凭证模式的 userID 为 0
+func issueOAuthToken(client *ent.OAuthClient, userID int, name, scope string) (*serializer.OAuthToken, *oauth.Error) {
+	ttl := OAuthTokenTTL()
+	now := util.Now()
+	token, err := middleware.NewJWT().CreateToken(middleware.CustomClaims{
+		ID:       uint(userID),
+		Name:     name,
+		Tenant:   client.TenantID,
+		ClientID: client.ClientID,
+		Scope:    scope,
+		StandardClaims: jwt.StandardClaims{
+			NotBefore: now.Unix() - 1000,
+			ExpiresAt: now.Add(ttl).Unix(),
+		},
+	})
+	if err != nil {
+		util.Log().Error("签发 token 失败 %v", err)
+		return nil, &oauth.Error{Status: 500, Code: "server_error"}
+	}
+	return &serializer.OAuthToken{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl / time.Second),
+		Scope:       scope,
+	}, nil
+}
+
+// OAuthTokenRequest 内省与吊销端点的请求
+type OAuthTokenRequest struct {
+	Token         string `form:"token" json:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint" json:"token_type_hint"`
+	ClientID      string `form:"client_id" json:"client_id"`
+	ClientSecret  string `form:"client_secret" json:"client_secret"`
+}
+
+// Introspect RFC 7662 token 内省, 只有有密钥的应用可以调用
+// 只返回同一租户内签发给第三方应用的 token 信息, 其他 token 一律视为无效
+func (service *OAuthTokenRequest) Introspect(ctx context.Context) (*serializer.OAuthIntrospection, *oauth.Error) {
+	client, oerr := authenticateClient(ctx, service.ClientID, service.ClientSecret, true)
+	if oerr != nil {
+		return nil, oerr
+	}
+	claims, err := middleware.VerifyToken(service.Token)
+	if err != nil || !claims.Delegated() || claims.Tenant != client.TenantID {
+		return &serializer.OAuthIntrospection{Active: false}, nil
+	}
+
+	info := &serializer.OAuthIntrospection{
+		Active:    true,
+		Scope:     claims.Scope,
+		ClientID:  claims.ClientID,
+		TokenType: "Bearer",
+		ExpiresAt: claims.ExpiresAt,
+		IssuedAt:  claims.IssuedAt,
+		TokenID:   claims.Id,
+	}
+	if claims.ID != 0 {
+		info.Subject = strconv.Itoa(int(claims.ID))
+		if member, err := model.Client.User.Get(ctx, int(claims.ID)); err == nil {
+			info.Username = member.Username
+		}
+	}
+	return info, nil
+}
+
+// Revoke RFC 7009 吊销 token, 应用只能吊销签发给自己的 token
+// 无效或不属于该应用的 token 同样返回成功
+func (service *OAuthTokenRequest) Revoke(ctx context.Context) *oauth.Error {
+	client, oerr := authenticateClient(ctx, service.ClientID, service.ClientSecret, false)
+	if oerr != nil {
+		return oerr
+	}
+	claims, err := middleware.NewJWT().ParseToken(service.Token)
+	if err != nil || claims.ClientID != client.ClientID || claims.Tenant != client.TenantID {
+		return nil
+	}
+	if err := oauth.Revoke(ctx, claims.Tenant, claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+		util.Log().Error("吊销 token 失败 %v", err)
+		return &oauth.Error{Status: 500, Code: "server_error"}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"go-api/jobs"
+	"go-api/model"
+	"go-api/outbox"
+	"go-api/util"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// PurgeOutboxPayload 清理已发布事件任务
+type PurgeOutboxPayload struct {
+	// RetainDays 发布后保留的天数
+	RetainDays int `json:"retain_days"`
+}
+
+// PurgeOutboxTask 删除已发布超过保留期的 outbox 事件
+var PurgeOutboxTask = jobs.NewTask[PurgeOutboxPayload]("outbox:purge",
+	asynq.Queue(jobs.QueueLow), asynq.Unique(time.Hour))
+
+func init() {
+	PurgeOutboxTask.Handle(purgeOutbox)
+	PurgeOutboxTask.Schedule("@daily", PurgeOutboxPayload{RetainDays: 7})
+}
+
+func purgeOutbox(ctx context.Context, p PurgeOutboxPayload) error {
+	n, err := outbox.Purge(ctx, model.Client, time.Now().AddDate(0, 0, -p.RetainDays))
+	if err != nil {
+		return err
+	}
+	util.Log().Info("清理已发布事件 %d 条", n)
+	return nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"go-api/ent/pet"
+	"go-api/model"
+	"go-api/serializer"
+)
+
+// PetCreateService 创建宠物的服务
+type PetCreateService struct {
+	Name     string `form:"name" json:"name" binding:"required,min=1,max=30"`
+	Species  string `form:"species" json:"species" binding:"required,min=1,max=30"`
+	Birthday string `form:"birthday" json:"birthday" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// Create 为当前用户创建宠物
+func (service *PetCreateService) Create(ctx context.Context, ownerID int) serializer.Response {
+	birthday, err := parseBirthday(service.Birthday)
+	if err != nil {
+		return serializer.ParamErr("生日格式错误", err)
+	}
+	p, err := model.Client.Pet.Create().
+		SetName(service.Name).
+		SetSpecies(service.Species).
+		SetNillableBirthday(birthday).
+		SetOwnerID(ownerID).
+		Save(ctx)
+	if err != nil {
+		return serializer.DBErr("创建失败", err)
+	}
+	p, err = model.Client.Pet.Query().Where(pet.ID(p.ID)).WithOwner().Only(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.BuildPetResponse(p)
+}
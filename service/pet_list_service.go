@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"go-api/ent"
+	"go-api/ent/pet"
+	"go-api/ent/predicate"
+	"go-api/ent/user"
+	"go-api/listquery"
+	"go-api/model"
+	"go-api/serializer"
+	"net/url"
+)
+
+// petListSchema 宠物列表允许过滤与排序的字段
+var petListSchema = &listquery.Schema{
+	Fields: []listquery.Field{
+		{Name: pet.FieldName, Type: listquery.String, Sortable: true},
+		{Name: pet.FieldSpecies, Type: listquery.String, Sortable: true},
+		{Name: pet.FieldBirthday, Type: listquery.Time},
+		{Name: pet.FieldCreatedAt, Type: listquery.Time, Sortable: true},
+		{Name: pet.FieldUpdatedAt, Type: listquery.Time, Sortable: true},
+	},
+	DefaultSort: "-" + pet.FieldCreatedAt,
+}
+
+// ListPets 当前用户的宠物列表, 默认按创建时间倒序
+func ListPets(ctx context.Context, ownerID int, values url.Values) serializer.ListResponse {
+	q, err := listquery.Parse(petListSchema, values)
+	if err != nil {
+		return serializer.ListResponse{Response: serializer.ParamErr(err.Error(), err)}
+	}
+
+	where := append([]predicate.Pet{pet.HasOwnerWith(user.ID(ownerID))}, listquery.Where[predicate.Pet](q)...)
+	items, err := model.Client.Pet.Query().
+		Where(where...).
+		WithOwner().
+		Order(listquery.Order[ent.OrderFunc](q)...).
+		Limit(q.Limit()).
+		All(ctx)
+	if err != nil {
+		return serializer.ListResponse{Response: serializer.DBErr("", err)}
+	}
+	items, meta := listquery.Paginate(q, items)
+	return serializer.BuildList(serializer.BuildPets(items), meta)
+}
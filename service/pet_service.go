@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"go-api/ent"
+	"go-api/ent/pet"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/util"
+	"time"
+)
+
+// birthdayLayout 宠物生日格式
+const birthdayLayout = "2006-01-02"
+
+// findOwnedPet 查询宠物并校验归属
+func findOwnedPet(ctx context.Context, ownerID, id int) (*ent.Pet, *serializer.Response) {
+	p, err := model.Client.Pet.Query().
+		Where(pet.ID(id)).
+		WithOwner().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		res := serializer.Err(serializer.CodeNotFound, "宠物不存在", err)
+		return nil, &res
+	}
+	if err != nil {
+		res := serializer.DBErr("", err)
+		return nil, &res
+	}
+	if p.Edges.Owner == nil || p.Edges.Owner.ID != ownerID {
+		res := serializer.Err(serializer.CodeNoRightErr, "无权操作该宠物", nil)
+		return nil, &res
+	}
+	return p, nil
+}
+
+func parseBirthday(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(birthdayLayout, s, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetPet 宠物详情
+func GetPet(ctx context.Context, ownerID, id int) serializer.Response {
+	p, res := findOwnedPet(ctx, ownerID, id)
+	if res != nil {
+		return *res
+	}
+	return serializer.BuildPetResponse(p)
+}
+
+// DeletePet 删除宠物, 软删除后由 CleanDeletedUserTask 到期物理删除
+func DeletePet(ctx context.Context, ownerID, id int) serializer.Response {
+	p, res := findOwnedPet(ctx, ownerID, id)
+	if res != nil {
+		return *res
+	}
+	if err := model.Client.Pet.UpdateOne(p).SetDeletedAt(util.Now()).Exec(ctx); err != nil {
+		return serializer.DBErr("删除失败", err)
+	}
+	return serializer.Response{
+		Msg: "删除成功",
+	}
+}
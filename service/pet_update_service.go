@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"go-api/ent"
+	"go-api/ent/pet"
+	"go-api/middleware"
+	"go-api/model"
+	"go-api/serializer"
+)
+
+// PetUpdateService 修改宠物的服务, 未传的字段保持不变
+type PetUpdateService struct {
+	Name     *string `form:"name" json:"name" binding:"omitempty,min=1,max=30"`
+	Species  *string `form:"species" json:"species" binding:"omitempty,min=1,max=30"`
+	Birthday *string `form:"birthday" json:"birthday" binding:"omitempty"`
+	// IfMatch 请求头 If-Match, 不为空时只在宠物未被他人修改过时更新
+	IfMatch string `form:"-" json:"-"`
+}
+
+// apply 把要修改的字段写入 mutation
+func (service *PetUpdateService) apply(m *ent.PetMutation) *serializer.Response {
+	if service.Name != nil {
+		m.SetName(*service.Name)
+	}
+	if service.Species != nil {
+		m.SetSpecies(*service.Species)
+	}
+	if service.Birthday != nil {
+		birthday, err := parseBirthday(*service.Birthday)
+		if err != nil {
+			res := serializer.ParamErr("生日格式错误", err)
+			return &res
+		}
+		if birthday == nil {
+			m.ClearBirthday()
+		} else {
+			m.SetBirthday(*birthday)
+		}
+	}
+	return nil
+}
+
+// Update 修改当前用户的宠物, birthday 传空字符串时清除
+func (service *PetUpdateService) Update(ctx context.Context, ownerID, id int) serializer.Response {
+	p, res := findOwnedPet(ctx, ownerID, id)
+	if res != nil {
+		return *res
+	}
+
+	if service.IfMatch == "" {
+		// 读取之后被他人修改时返回版本冲突
+		var res *serializer.Response
+		err := model.WithTx(ctx, func(tx *ent.Tx) error {
+			update := tx.Pet.UpdateOne(p).SetVersion(p.Version)
+			if res = service.apply(update.Mutation()); res != nil {
+				return nil
+			}
+			_, err := update.Save(ctx)
+			return err
+		})
+		if res != nil {
+			return *res
+		}
+		if err != nil {
+			return serializer.DBErr("修改失败", err)
+		}
+	} else {
+		if !middleware.MatchETag(service.IfMatch, serializer.BuildPet(p).ETag(), false) {
+			return preconditionFailed()
+		}
+		// 以读取时的版本为条件更新, 防止校验之后被并发修改
+		update := model.Client.Pet.Update().Where(pet.ID(p.ID), pet.Version(p.Version))
+		if res := service.apply(update.Mutation()); res != nil {
+			return *res
+		}
+		n, err := update.Save(ctx)
+		if err != nil {
+			return serializer.DBErr("修改失败", err)
+		}
+		if n == 0 {
+			return preconditionFailed()
+		}
+	}
+
+	// 重新读取, 保证返回的版本与之后查询得到的一致
+	return GetPet(ctx, ownerID, id)
+}
+
+func preconditionFailed() serializer.Response {
+	return serializer.Err(serializer.CodePreconditionFailed, "宠物已被修改, 请刷新后重试", nil)
+}
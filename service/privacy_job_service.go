@@ -0,0 +1,333 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-api/cache"
+	"go-api/ent"
+	"go-api/ent/auditlog"
+	"go-api/ent/datarequest"
+	"go-api/ent/identity"
+	"go-api/ent/outbox"
+	"go-api/ent/pet"
+	"go-api/ent/user"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"go-api/entx"
+	"go-api/jobs"
+	"go-api/middleware"
+	"go-api/model"
+	events "go-api/outbox"
+	"go-api/serializer"
+	"go-api/storage"
+	"go-api/tenancy"
+	"go-api/util"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// exportTTL 导出文件的保留时间
+const exportTTL = 7 * 24 * time.Hour
+
+// ExportUserDataPayload 导出个人数据任务
+type ExportUserDataPayload struct {
+	RequestID int `json:"request_id"`
+}
+
+// PrivacySweepPayload 执行到期注销与清理过期导出的任务
+type PrivacySweepPayload struct{}
+
+// ExportUserDataTask 把用户的数据打包为 zip 保存到对象存储
+var ExportUserDataTask = jobs.NewTask[ExportUserDataPayload]("privacy:export")
+
+// PrivacySweepTask 每小时执行宽限期已过的注销申请, 并删除过期的导出文件
+var PrivacySweepTask = jobs.NewTask[PrivacySweepPayload]("privacy:sweep",
+	asynq.Queue(jobs.QueueLow), asynq.Unique(time.Hour))
+
+func init() {
+	ExportUserDataTask.Handle(exportUserData)
+	PrivacySweepTask.Handle(sweepDataRequests)
+	PrivacySweepTask.Schedule("@hourly", PrivacySweepPayload{})
+}
+
+// userContext 不受租户限制, 创建的数据属于用户所在的租户
+func userContext(ctx context.Context, tenantID int) context.Context {
+	return tenancy.NewContext(tenancy.System(ctx), &tenancy.Tenant{ID: tenantID})
+}
+
+// lastAttempt 是否为最后一次重试, 同步执行时没有重试
+func lastAttempt(ctx context.Context) bool {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, ok := asynq.GetMaxRetry(ctx)
+	return !ok || retried >= maxRetry
+}
+
+func exportUserData(ctx context.Context, p ExportUserDataPayload) error {
+	req, err := model.Client.DataRequest.Get(tenancy.System(ctx), p.RequestID)
+	if ent.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if req.Status != datarequest.StatusPending {
+		return nil
+	}
+	ctx = userContext(ctx, req.TenantID)
+
+	key := storage.UserPrefix(req.TenantID, req.UserID) + "exports/" + strconv.Itoa(req.ID) + ".zip"
+	err = writeUserExport(ctx, req.TenantID, req.UserID, key)
+	if err != nil {
+		update := model.Client.DataRequest.UpdateOne(req).SetError(err.Error())
+		if lastAttempt(ctx) {
+			update.SetStatus(datarequest.StatusFailed)
+		}
+		update.Exec(ctx)
+		return err
+	}
+	now := util.Now()
+	return model.Client.DataRequest.UpdateOne(req).
+		SetStatus(datarequest.StatusCompleted).
+		SetObjectKey(key).
+		SetError("").
+		SetCompletedAt(now).
+		SetExpiresAt(now.Add(exportTTL)).
+		Exec(ctx)
+}
+
+// writeUserExport 把与用户相关的全部数据写入 zip, 每类数据一个 json 文件
+func writeUserExport(ctx context.Context, tenantID, userID int, key string) error {
+	u, err := model.Client.User.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	identities, err := model.Client.Identity.Query().Where(identity.HasUserWith(user.ID(userID))).All(ctx)
+	if err != nil {
+		return err
+	}
+	pets, err := model.Client.Pet.Query().Where(pet.HasOwnerWith(user.ID(userID))).All(ctx)
+	if err != nil {
+		return err
+	}
+	audits, err := model.Client.AuditLog.Query().
+		Where(auditlog.Or(auditlog.ActorID(userID), auditlog.Target("user:"+strconv.Itoa(userID)))).
+		Order(ent.Asc(auditlog.FieldID)).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	webhooks, err := model.Client.WebhookEndpoint.Query().Where(webhookendpoint.OwnerID(userID)).All(ctx)
+	if err != nil {
+		return err
+	}
+	requests, err := model.Client.DataRequest.Query().Where(datarequest.UserID(userID)).All(ctx)
+	if err != nil {
+		return err
+	}
+	sessions, err := middleware.UserSessions(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	prefix := storage.UserPrefix(tenantID, userID)
+	objects, err := storage.Default.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	// 之前的导出文件不算用户上传的数据
+	uploaded := make([]storage.Object, 0, len(objects))
+	for _, o := range objects {
+		if !strings.HasPrefix(o.Key, prefix+"exports/") {
+			uploaded = append(uploaded, o)
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", serializer.BuildUser(u)},
+		{"identities.json", serializer.BuildIdentities(identities)},
+		{"pets.json", serializer.BuildPets(pets)},
+		{"sessions.json", sessions},
+		{"audit_logs.json", serializer.BuildAuditLogs(audits)},
+		{"webhooks.json", serializer.BuildWebhookEndpoints(webhooks)},
+		{"data_requests.json", serializer.BuildDataRequests(requests)},
+		{"objects.json", uploaded},
+	}
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return storage.Default.Put(ctx, key, &buf, int64(buf.Len()), "application/zip")
+}
+
+func sweepDataRequests(ctx context.Context, _ PrivacySweepPayload) error {
+	sys := tenancy.System(ctx)
+	now := util.Now()
+	var failed error
+
+	due, err := model.Client.DataRequest.Query().
+		Where(
+			datarequest.KindEQ(datarequest.KindErasure),
+			datarequest.StatusEQ(datarequest.StatusPending),
+			datarequest.ScheduledAtLTE(now),
+		).
+		All(sys)
+	if err != nil {
+		return err
+	}
+	for _, req := range due {
+		// 失败的申请保持待执行, 下次继续
+		if err := eraseUser(ctx, req); err != nil {
+			util.Log().Error("注销用户 %d 失败: %v", req.UserID, err)
+			model.Client.DataRequest.UpdateOne(req).SetError(err.Error()).Exec(sys)
+			failed = err
+		}
+	}
+
+	expired, err := model.Client.DataRequest.Query().
+		Where(
+			datarequest.KindEQ(datarequest.KindExport),
+			datarequest.StatusEQ(datarequest.StatusCompleted),
+			datarequest.ExpiresAtLTE(now),
+		).
+		All(sys)
+	if err != nil {
+		return err
+	}
+	for _, req := range expired {
+		if err := storage.Default.Delete(ctx, req.ObjectKey); err != nil {
+			failed = err
+			continue
+		}
+		if err := model.Client.DataRequest.UpdateOne(req).
+			SetStatus(datarequest.StatusExpired).
+			SetObjectKey("").
+			Exec(sys); err != nil {
+			failed = err
+		}
+	}
+	if len(due) > 0 || len(expired) > 0 {
+		util.Log().Info("执行注销 %d 个, 清理过期导出 %d 个", len(due), len(expired))
+	}
+	return failed
+}
+
+// eraseUser 删除用户的文件与关联数据, 匿名化用户记录, 并使登录态失效
+// 用户记录保留为软删除状态, 由 CleanDeletedUserTask 到期后物理删除
+func eraseUser(ctx context.Context, req *ent.DataRequest) error {
+	tenantID, userID := req.TenantID, req.UserID
+	// 已被管理员删除的用户与宠物同样需要清除
+	ctx = entx.IncludeDeleted(userContext(ctx, tenantID))
+	if _, err := storage.DeletePrefix(ctx, storage.Default, storage.UserPrefix(tenantID, userID)); err != nil {
+		return err
+	}
+
+	id := strconv.Itoa(userID)
+	now := util.Now()
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		if _, err := tx.Pet.Delete().Where(pet.HasOwnerWith(user.ID(userID))).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.Identity.Delete().Where(identity.HasUserWith(user.ID(userID))).Exec(ctx); err != nil {
+			return err
+		}
+		// 用户自己的地址收到的投递, 以及其他地址收到的与用户有关的事件
+		if _, err := tx.WebhookDelivery.Delete().
+			Where(webhookdelivery.Or(
+				webhookdelivery.HasEndpointWith(webhookendpoint.OwnerID(userID)),
+				webhookdelivery.UserID(userID),
+			)).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.WebhookEndpoint.Delete().Where(webhookendpoint.OwnerID(userID)).Exec(ctx); err != nil {
+			return err
+		}
+		// 事件中有用户名, 登录 ip 等资料, 未发送的事件也不再发送, 注销事件在之后写入
+		if _, err := tx.Outbox.Delete().
+			Where(
+				outbox.AggregateType(events.AggregateUser),
+				outbox.AggregateID(id),
+			).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.AuditLog.Update().
+			Where(auditlog.Target("user:" + id)).
+			ClearBefore().
+			ClearAfter().
+			Save(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.DataRequest.Update().
+			Where(
+				datarequest.UserID(userID),
+				datarequest.KindEQ(datarequest.KindExport),
+				datarequest.StatusIn(datarequest.StatusPending, datarequest.StatusCompleted),
+			).
+			SetStatus(datarequest.StatusExpired).
+			SetObjectKey("").
+			Save(ctx); err != nil {
+			return err
+		}
+
+		err := tx.User.UpdateOneID(userID).
+			SetUsername("deleted_" + id).
+			SetNickname("deleted_" + id).
+			SetPasswordDigest("").
+			SetAvatar("").
+			SetStatus(model.Inactive).
+			SetDeletedAt(now).
+			Exec(ctx)
+		if ent.IsNotFound(err) {
+			// 用户已被删除, 只需完成申请
+			err = nil
+		} else if err == nil {
+			err = events.Record(ctx, tx, events.NewUserErased(userID))
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.AuditLog.Create().
+			SetActorID(userID).
+			SetAction("user.erase").
+			SetTarget("user:" + id).
+			Save(ctx); err != nil {
+			return err
+		}
+		return tx.DataRequest.UpdateOne(req).
+			SetStatus(datarequest.StatusCompleted).
+			SetError("").
+			SetCompletedAt(now).
+			Exec(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	cache.RedisClient.Del(ctx, cache.TenantKey(tenantID, "user:"+id))
+	cache.RedisClient.Del(ctx, cache.TenantKey(tenantID, "member:"+id))
+	cache.LocalCacheClient.Delete(cache.TenantKey(tenantID, "member:"+id))
+	if err := RevokeOAuthGrants(ctx, tenantID, userID); err != nil {
+		return err
+	}
+	return middleware.EndUserSessions(ctx, tenantID, userID)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-api/ent"
+	"go-api/ent/datarequest"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/storage"
+	"go-api/tenancy"
+	"go-api/util"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// erasureGrace 注销申请的宽限期, 期间可以撤销, 租户可以通过 ERASURE_GRACE_DAYS 配置, 默认 14 天
+func erasureGrace(ctx context.Context) time.Duration {
+	days, err := strconv.Atoi(tenancy.Config(ctx, "ERASURE_GRACE_DAYS"))
+	if err != nil || days < 0 {
+		days = 14
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// pendingDataRequest 用户待处理的申请, 没有时返回 nil
+func pendingDataRequest(ctx context.Context, userID int, kind datarequest.Kind) (*ent.DataRequest, error) {
+	req, err := model.Client.DataRequest.Query().
+		Where(
+			datarequest.UserID(userID),
+			datarequest.KindEQ(kind),
+			datarequest.StatusEQ(datarequest.StatusPending),
+		).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	return req, err
+}
+
+// RequestExport 申请导出个人数据, 由任务异步打包, 已有处理中的申请时直接返回
+func RequestExport(ctx context.Context, userID int) serializer.Response {
+	req, err := pendingDataRequest(ctx, userID, datarequest.KindExport)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	if req != nil {
+		return serializer.Response{Data: serializer.BuildDataRequest(req)}
+	}
+
+	req, err = model.Client.DataRequest.Create().
+		SetUserID(userID).
+		SetKind(datarequest.KindExport).
+		Save(ctx)
+	if err != nil {
+		return serializer.DBErr("申请失败", err)
+	}
+	_, err = ExportUserDataTask.Enqueue(ctx, ExportUserDataPayload{RequestID: req.ID},
+		asynq.TaskID("privacy:export:"+strconv.Itoa(req.ID)))
+	if err != nil {
+		return serializer.Err(serializer.CodeJobError, "申请失败", err)
+	}
+	// 同步执行时导出已经完成, 重新读取结果
+	if req, err = model.Client.DataRequest.Get(ctx, req.ID); err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildDataRequest(req)}
+}
+
+// RequestErasure 申请注销, 宽限期结束后由定时任务执行, 已有待执行的申请时直接返回
+func RequestErasure(ctx context.Context, userID int) serializer.Response {
+	req, err := pendingDataRequest(ctx, userID, datarequest.KindErasure)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	if req == nil {
+		req, err = model.Client.DataRequest.Create().
+			SetUserID(userID).
+			SetKind(datarequest.KindErasure).
+			SetScheduledAt(util.Now().Add(erasureGrace(ctx))).
+			Save(ctx)
+		if err != nil {
+			return serializer.DBErr("申请失败", err)
+		}
+	}
+	return serializer.Response{Data: serializer.BuildDataRequest(req)}
+}
+
+// CancelErasure 在宽限期内撤销注销申请
+func CancelErasure(ctx context.Context, userID int) serializer.Response {
+	n, err := model.Client.DataRequest.Update().
+		Where(
+			datarequest.UserID(userID),
+			datarequest.KindEQ(datarequest.KindErasure),
+			datarequest.StatusEQ(datarequest.StatusPending),
+		).
+		SetStatus(datarequest.StatusCancelled).
+		Save(ctx)
+	if err != nil {
+		return serializer.DBErr("撤销失败", err)
+	}
+	if n == 0 {
+		return serializer.Err(serializer.CodeNotFound, "没有待执行的注销申请", nil)
+	}
+	return serializer.Response{Msg: "已撤销注销申请"}
+}
+
+// ListDataRequests 用户的导出与注销申请
+func ListDataRequests(ctx context.Context, userID int) serializer.Response {
+	items, err := model.Client.DataRequest.Query().
+		Where(datarequest.UserID(userID)).
+		Order(ent.Desc(datarequest.FieldCreatedAt), ent.Desc(datarequest.FieldID)).
+		All(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildDataRequests(items)}
+}
+
+// OpenDataExport 打开已完成且未过期的导出文件, 由调用方关闭
+func OpenDataExport(ctx context.Context, userID, id int) (io.ReadCloser, *serializer.Response) {
+	req, err := model.Client.DataRequest.Query().
+		Where(
+			datarequest.ID(id),
+			datarequest.UserID(userID),
+			datarequest.KindEQ(datarequest.KindExport),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		res := serializer.Err(serializer.CodeNotFound, "导出申请不存在", err)
+		return nil, &res
+	}
+	if err != nil {
+		res := serializer.DBErr("", err)
+		return nil, &res
+	}
+	if req.Status == datarequest.StatusExpired || (req.ExpiresAt != nil && !util.Now().Before(*req.ExpiresAt)) {
+		res := serializer.Err(serializer.CodeNotFound, "导出文件已过期, 请重新申请", nil)
+		return nil, &res
+	}
+	if req.Status != datarequest.StatusCompleted {
+		res := serializer.ParamErr("导出尚未完成", nil)
+		return nil, &res
+	}
+
+	r, err := storage.Default.Open(ctx, req.ObjectKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		res := serializer.Err(serializer.CodeNotFound, "导出文件不存在, 请重新申请", err)
+		return nil, &res
+	}
+	if err != nil {
+		res := serializer.Err(serializer.CodeDBError, "读取导出文件失败", err)
+		return nil, &res
+	}
+	return r, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-api/ent"
+	"go-api/ent/tenant"
+	"go-api/model"
+	"go-api/serializer"
+)
+
+// TenantCreateService 创建租户的服务
+type TenantCreateService struct {
+	Name   string            `form:"name" json:"name" binding:"required,max=63"`
+	Config map[string]string `form:"config" json:"config"`
+}
+
+// Create 创建租户, 名称只能包含小写字母, 数字和 -
+func (service *TenantCreateService) Create(ctx context.Context) serializer.Response {
+	config, err := json.Marshal(service.Config)
+	if err != nil {
+		return serializer.ParamErr("", err)
+	}
+	t, err := model.Client.Tenant.Create().
+		SetName(service.Name).
+		SetConfig(string(config)).
+		Save(ctx)
+	if ent.IsValidationError(err) {
+		return serializer.ParamErr("租户名称只能包含小写字母, 数字和 -", err)
+	}
+	if ent.IsConstraintError(err) {
+		return serializer.ParamErr("租户已存在", err)
+	}
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildTenant(t)}
+}
+
+// TenantConfigService 修改租户配置的服务
+type TenantConfigService struct {
+	// Set 新增或覆盖的配置项
+	Set map[string]string `form:"set" json:"set"`
+	// Unset 删除的配置项, 删除后使用环境变量
+	Unset []string `form:"unset" json:"unset"`
+}
+
+// Update 修改租户配置, 各实例缓存的租户信息最长一分钟后生效
+func (service *TenantConfigService) Update(ctx context.Context, name string) serializer.Response {
+	t, err := model.Client.Tenant.Query().Where(tenant.Name(name)).Only(ctx)
+	if ent.IsNotFound(err) {
+		return serializer.Err(serializer.CodeNotFound, "租户不存在", err)
+	}
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+
+	config := serializer.BuildTenant(t).Config
+	for k, v := range service.Set {
+		config[k] = v
+	}
+	for _, k := range service.Unset {
+		delete(config, k)
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return serializer.ParamErr("", err)
+	}
+	t, err = model.Client.Tenant.UpdateOne(t).SetConfig(string(data)).Save(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildTenant(t)}
+}
+
+// ListTenants 全部租户
+func ListTenants(ctx context.Context) serializer.Response {
+	items, err := model.Client.Tenant.Query().Order(ent.Asc(tenant.FieldID)).All(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildTenants(items)}
+}
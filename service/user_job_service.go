@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-api/cache"
+	"go-api/ent/pet"
+	"go-api/ent/user"
+	"go-api/entx"
+	"go-api/jobs"
+	"go-api/model"
+	"go-api/tenancy"
+	"go-api/util"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// WarmUserCachePayload 预热用户缓存任务
+type WarmUserCachePayload struct {
+	UserID int `json:"user_id"`
+}
+
+// CleanDeletedUserPayload 清理软删除用户与宠物任务
+type CleanDeletedUserPayload struct {
+	// RetainDays 软删除后保留的天数
+	RetainDays int `json:"retain_days"`
+}
+
+// WarmUserCacheTask 预热 member:<id> 缓存, 同一用户一分钟内只投递一次
+var WarmUserCacheTask = jobs.NewTask[WarmUserCachePayload]("user:cache:warm", asynq.Unique(time.Minute))
+
+// CleanDeletedUserTask 物理删除软删除超过保留期的用户与宠物
+var CleanDeletedUserTask = jobs.NewTask[CleanDeletedUserPayload]("user:deleted:clean",
+	asynq.Queue(jobs.QueueLow), asynq.Unique(time.Hour))
+
+func init() {
+	WarmUserCacheTask.Handle(warmUserCache)
+	CleanDeletedUserTask.Handle(cleanDeletedUser)
+	CleanDeletedUserTask.Schedule("@daily", CleanDeletedUserPayload{RetainDays: 30})
+}
+
+func warmUserCache(ctx context.Context, p WarmUserCachePayload) error {
+	ctx = tenancy.System(ctx)
+	m, err := model.Client.User.Get(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+	mJson, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return cache.RedisClient.Set(ctx, cache.TenantKey(m.TenantID, "member:"+strconv.Itoa(m.ID)), string(mJson), 3600*time.Second).Err()
+}
+
+func cleanDeletedUser(ctx context.Context, p CleanDeletedUserPayload) error {
+	cutoff := util.Now().AddDate(0, 0, -p.RetainDays)
+	// 清理所有租户中已软删除的数据
+	ctx = entx.IncludeDeleted(tenancy.System(ctx))
+	pets, err := model.Client.Pet.Delete().
+		Where(pet.DeletedAtNotNil(), pet.DeletedAtLT(cutoff)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := model.Client.User.Delete().
+		Where(user.DeletedAtNotNil(), user.DeletedAtLT(cutoff)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	util.Log().Info("清理软删除用户 %d 个, 宠物 %d 个", n, pets)
+	return nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"go-api/ent"
+	"go-api/ent/predicate"
+	"go-api/ent/user"
+	"go-api/listquery"
+	"go-api/model"
+	"go-api/serializer"
+	"net/url"
+)
+
+// userListSchema 用户列表允许过滤与排序的字段
+var userListSchema = &listquery.Schema{
+	Fields: []listquery.Field{
+		{Name: user.FieldUsername, Type: listquery.String, Sortable: true},
+		{Name: user.FieldNickname, Type: listquery.String, Sortable: true},
+		{Name: user.FieldStatus, Type: listquery.String},
+		{Name: user.FieldRole, Type: listquery.String},
+		{Name: user.FieldCreatedAt, Type: listquery.Time, Sortable: true},
+	},
+	DefaultSort: user.FieldID,
+	MaxSize:     1000,
+}
+
+// ListUsers 用户列表, 默认按 id 排序
+func ListUsers(ctx context.Context, values url.Values) serializer.ListResponse {
+	q, err := listquery.Parse(userListSchema, values)
+	if err != nil {
+		return serializer.ListResponse{Response: serializer.ParamErr(err.Error(), err)}
+	}
+
+	items, err := model.Client.User.Query().
+		Where(listquery.Where[predicate.User](q)...).
+		Order(listquery.Order[ent.OrderFunc](q)...).
+		Limit(q.Limit()).
+		All(ctx)
+	if err != nil {
+		return serializer.ListResponse{Response: serializer.DBErr("", err)}
+	}
+	items, meta := listquery.Paginate(q, items)
+	return serializer.BuildList(serializer.BuildUsers(items), meta)
+}
@@ -8,6 +8,7 @@ import (
 	"go-api/ent/user"
 	"go-api/middleware"
 	"go-api/model"
+	"go-api/outbox"
 	"go-api/serializer"
 	"go-api/util"
 	"os"
@@ -27,57 +28,75 @@ type UserLoginService struct {
 	Password string `form:"password" json:"password" binding:"required,min=6,max=40"`
 }
 
-// setToken 设置token
-func (service *UserLoginService) setToken(user *ent.User, ttl int64) (string, int64, error) {
-	j := middleware.NewJWT()
-	notBefore := time.Now().Unix() - 1000
-	expiresAt := time.Now().Unix() + ttl
+// IssueToken 为用户签发 token 并登记为该用户当前有效的 token, 之前签发的 token 随即失效
+func IssueToken(member *ent.User) (string, int64, error) {
+	ttl, err := strconv.Atoi(os.Getenv("TOKEN_TTL"))
+	if err != nil {
+		return "", 0, err
+	}
+	now := util.Now()
+	expiresAt := now.Unix() + int64(ttl)
 	claims := middleware.CustomClaims{
-		ID:    uint(user.ID),
-		Name:  user.Nickname,
-		Phone: "",
+		ID:     uint(member.ID),
+		Name:   member.Nickname,
+		Phone:  "",
+		Role:   member.Role,
+		Tenant: member.TenantID,
 		StandardClaims: jwt.StandardClaims{
-			NotBefore: notBefore,
+			NotBefore: now.Unix() - 1000,
 			ExpiresAt: expiresAt,
 		},
 	}
-	token, err := j.CreateToken(claims)
-	return token, expiresAt, err
+	token, err := middleware.NewJWT().CreateToken(claims)
+	if err != nil {
+		return "", 0, err
+	}
+
+	tokenMD5 := util.StringToMD5(token)
+	key := strconv.Itoa(member.ID)
+	if err := cache.RedisClient.Set(context.Background(), cache.TenantKey(member.TenantID, "user:"+key), tokenMD5, time.Duration(ttl)*time.Second+middleware.RefreshGrace()).Err(); err != nil {
+		return "", 0, err
+	}
+	return token, expiresAt, nil
 }
 
 // Login 用户登录函数
 func (service *UserLoginService) Login(c *gin.Context) serializer.Response {
-	member, err := model.Client.User.Query().Where(user.Username(service.Username)).First(context.TODO())
+	member, err := model.Client.User.Query().Where(user.Username(service.Username)).First(c)
 	if err != nil {
 		return serializer.ParamErr("账号或d密码错误", err)
 	}
 
-	if checkPassword(member, service) {
+	if !checkPassword(member, service) {
 		return serializer.ParamErr("账号或密码错误", err)
 	}
+	if member.Status != model.Active {
+		return serializer.Err(serializer.CodeNoRightErr, "账号已停用", nil)
+	}
 
 	// 设置token
-	ttl, err := strconv.Atoi(os.Getenv("TOKEN_TTL"))
-	if err != nil {
-		return serializer.Err(serializer.CodeTokenError, "token 获取失败", err)
-	}
-	token, expiresAt, err := service.setToken(member, int64(ttl))
+	token, expiresAt, err := IssueToken(member)
 	if err != nil {
 		return serializer.Err(serializer.CodeTokenError, "token 获取失败", err)
 	}
 
-	tokenMD5 := util.StringToMD5(token)
 	key := strconv.Itoa(member.ID)
-	if err := cache.RedisClient.Set("user:"+key, tokenMD5, time.Duration(ttl)*time.Second).Err(); err != nil {
-		panic(err)
+	mJson, err := json.Marshal(member)
+	if err != nil {
+		return serializer.Err(serializer.CodeCacheError, "缓存用户信息失败", err)
 	}
-	mJson,err:=json.Marshal(member)
-	if err!=nil {
-		panic(err)
+
+	if err := cache.RedisClient.Set(c, cache.Key(c, "member:"+key), string(mJson), 0).Err(); err != nil {
+		return serializer.Err(serializer.CodeCacheError, "缓存用户信息失败", err)
 	}
 
-	if err:=cache.RedisClient.Set("member:"+key, string(mJson),0).Err();err!=nil {
-		panic(err)
+	startSession(c, member)
+
+	// 登录不修改用户数据, 事件写入失败不影响登录
+	if err := model.WithTx(c, func(tx *ent.Tx) error {
+		return outbox.Record(c, tx, outbox.NewUserLoggedIn(member, c.ClientIP()))
+	}); err != nil {
+		util.Log().Error("记录登录事件失败 %v", err)
 	}
 	return serializer.BuildToken(member, token, expiresAt)
 }
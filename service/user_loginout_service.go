@@ -1 +1,37 @@
 package service
+
+import (
+	"context"
+	"go-api/cache"
+	"go-api/ent"
+	"go-api/middleware"
+	"go-api/tenancy"
+	"go-api/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RevokeToken 吊销用户当前的 token, 之后需要重新登录
+func RevokeToken(ctx context.Context, id int) error {
+	return cache.RedisClient.Del(ctx, cache.Key(ctx, "user:"+strconv.Itoa(id))).Err()
+}
+
+// SignOutEverywhere 吊销用户的 token 并退出所有设备上的 session
+func SignOutEverywhere(ctx context.Context, id int) error {
+	if err := RevokeToken(ctx, id); err != nil {
+		return err
+	}
+	return middleware.EndUserSessions(ctx, tenancy.ID(ctx), id)
+}
+
+// startSession 登录成功后开始浏览器 session, 失败不影响通过 token 登录
+func startSession(c *gin.Context, member *ent.User) {
+	err := middleware.StartSession(c, &middleware.CustomClaims{
+		ID:     uint(member.ID),
+		Tenant: member.TenantID,
+	})
+	if err != nil {
+		util.Log().Error("创建 session 失败 %v", err)
+	}
+}
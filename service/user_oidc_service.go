@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"go-api/auth/oidc"
+	"go-api/ent"
+	"go-api/ent/identity"
+	"go-api/ent/user"
+	"go-api/entx"
+	"go-api/model"
+	"go-api/outbox"
+	"go-api/serializer"
+	"go-api/util"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OIDCCallbackService 第三方登录回调
+type OIDCCallbackService struct {
+	Code  string `form:"code" json:"code" binding:"required"`
+	State string `form:"state" json:"state" binding:"required"`
+}
+
+// OIDCBindingCookie 保存 state 绑定串的 cookie
+const OIDCBindingCookie = "oidc_binding"
+
+// OIDCAuthURL 生成第三方授权地址, userID 不为 0 时授权完成后为该用户绑定账号
+// state 绑定发起请求的浏览器, 回调只能在同一个浏览器中完成
+func OIDCAuthURL(c *gin.Context, provider string, userID int) serializer.Response {
+	url, binding, err := oidc.Default.AuthURL(c, provider, userID)
+	if err != nil {
+		return oidcErr(err)
+	}
+	setBindingCookie(c, binding, int(oidc.StateTTL/time.Second))
+	return serializer.Response{
+		Data: serializer.AuthURL{URL: url},
+	}
+}
+
+// setBindingCookie 写入或删除 state 绑定串
+// 从提供方跳转回来是跨站的顶层导航, Lax 的 cookie 仍会发送
+func setBindingCookie(c *gin.Context, binding string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     OIDCBindingCookie,
+		Value:    binding,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   gin.Mode() == gin.ReleaseMode,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// Callback 处理授权回调
+// 登录时按第三方账号查找用户, 没有绑定过的创建新用户, 最后签发 token;
+// 绑定时把第三方账号关联到发起绑定的用户
+func (service *OIDCCallbackService) Callback(c *gin.Context, provider string) serializer.Response {
+	binding, _ := c.Cookie(OIDCBindingCookie)
+	claims, state, err := oidc.Default.Exchange(c, provider, service.Code, service.State, binding)
+	if err != nil {
+		return oidcErr(err)
+	}
+	setBindingCookie(c, "", -1)
+	if state.UserID != 0 {
+		return linkIdentity(c, state.UserID, provider, claims)
+	}
+
+	member, err := oidcUser(c, provider, claims)
+	if err != nil {
+		return serializer.DBErr("第三方登录失败", err)
+	}
+	if member.Status != model.Active || member.DeletedAt != nil {
+		return serializer.Err(serializer.CodeNoRightErr, "账号已停用", nil)
+	}
+
+	token, expiresAt, err := IssueToken(member)
+	if err != nil {
+		return serializer.Err(serializer.CodeTokenError, "token 获取失败", err)
+	}
+	startSession(c, member)
+	if err := model.WithTx(c, func(tx *ent.Tx) error {
+		return outbox.Record(c, tx, outbox.NewUserLoggedIn(member, c.ClientIP()))
+	}); err != nil {
+		util.Log().Error("记录登录事件失败 %v", err)
+	}
+	return serializer.BuildToken(member, token, expiresAt)
+}
+
+// oidcUser 查找第三方账号绑定的用户, 没有时创建用户并绑定
+// 不按邮箱关联已有用户, 已有账号的用户需要登录后主动绑定
+// 绑定的用户已被软删除时也返回该用户, 由调用方拒绝登录, 不能为同一个第三方账号再创建用户
+func oidcUser(ctx context.Context, provider string, claims *oidc.Claims) (*ent.User, error) {
+	find := func() (*ent.User, error) {
+		return model.Client.User.Query().
+			Where(user.HasIdentitiesWith(identity.Provider(provider), identity.Subject(claims.Subject))).
+			Only(entx.IncludeDeleted(ctx))
+	}
+	member, err := find()
+	if !ent.IsNotFound(err) {
+		return member, err
+	}
+
+	username := oidcUsername(provider, claims.Subject)
+	err = model.WithTx(ctx, func(tx *ent.Tx) error {
+		member, err = tx.User.Create().
+			SetNickname(oidcNickname(ctx, username, claims)).
+			SetUsername(username).
+			SetPasswordDigest("").
+			SetStatus(model.Active).
+			SetAvatar("").
+			SetRole(model.RoleUser).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Identity.Create().
+			SetProvider(provider).
+			SetSubject(claims.Subject).
+			SetEmail(claims.Email).
+			SetUser(member).
+			Save(ctx); err != nil {
+			return err
+		}
+		return outbox.Record(ctx, tx, outbox.NewUserRegistered(member))
+	})
+	// 同一账号并发首次登录, 另一个请求已经创建了用户
+	if ent.IsConstraintError(err) {
+		return find()
+	}
+	return member, err
+}
+
+// oidcUsername 由提供方与第三方账号 id 生成用户名, 第三方用户没有密码, 用户名只用于展示
+func oidcUsername(provider, subject string) string {
+	sum := sha256.Sum256([]byte(subject))
+	suffix := fmt.Sprintf("_%x", sum[:6])
+	if len(provider) > 30-len(suffix) {
+		provider = provider[:30-len(suffix)]
+	}
+	return provider + suffix
+}
+
+// oidcNickname 使用第三方账号的名称作为昵称, 被占用或为空时使用用户名
+func oidcNickname(ctx context.Context, username string, claims *oidc.Claims) string {
+	nickname := claims.Name
+	if nickname == "" {
+		nickname, _, _ = strings.Cut(claims.Email, "@")
+	}
+	if r := []rune(nickname); len(r) > 30 {
+		nickname = string(r[:30])
+	}
+	if len([]rune(nickname)) < 2 {
+		return username
+	}
+	if count, _ := model.Client.User.Query().Where(user.Nickname(nickname)).Count(ctx); count > 0 {
+		return username
+	}
+	return nickname
+}
+
+// linkIdentity 为用户绑定第三方账号, 每个提供方只能绑定一个账号
+func linkIdentity(ctx context.Context, userID int, provider string, claims *oidc.Claims) serializer.Response {
+	existing, err := model.Client.Identity.Query().
+		Where(identity.Provider(provider), identity.Subject(claims.Subject)).
+		WithUser().
+		Only(ctx)
+	if err == nil {
+		if existing.Edges.User != nil && existing.Edges.User.ID == userID {
+			return serializer.Response{Data: serializer.BuildIdentity(existing)}
+		}
+		return serializer.ParamErr("该账号已绑定其他用户", nil)
+	}
+	if !ent.IsNotFound(err) {
+		return serializer.DBErr("", err)
+	}
+
+	count, err := model.Client.Identity.Query().
+		Where(identity.Provider(provider), identity.HasUserWith(user.ID(userID))).
+		Count(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	if count > 0 {
+		return serializer.ParamErr("已绑定该登录方式, 请先解除绑定", nil)
+	}
+
+	item, err := model.Client.Identity.Create().
+		SetProvider(provider).
+		SetSubject(claims.Subject).
+		SetEmail(claims.Email).
+		SetUserID(userID).
+		Save(ctx)
+	if ent.IsConstraintError(err) {
+		return serializer.ParamErr("该账号已绑定其他用户", err)
+	}
+	if err != nil {
+		return serializer.DBErr("绑定失败", err)
+	}
+	return serializer.Response{Data: serializer.BuildIdentity(item)}
+}
+
+// ListIdentities 用户绑定的第三方账号
+func ListIdentities(ctx context.Context, userID int) serializer.Response {
+	items, err := model.Client.Identity.Query().
+		Where(identity.HasUserWith(user.ID(userID))).
+		Order(ent.Asc(identity.FieldProvider)).
+		All(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildIdentities(items)}
+}
+
+// UnlinkIdentity 解除绑定, 没有设置密码的用户需要至少保留一个第三方账号
+func UnlinkIdentity(ctx context.Context, userID int, provider string) serializer.Response {
+	member, err := model.Client.User.Get(ctx, userID)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	items, err := member.QueryIdentities().All(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	var target *ent.Identity
+	for _, item := range items {
+		if item.Provider == provider {
+			target = item
+		}
+	}
+	if target == nil {
+		return serializer.Err(serializer.CodeNotFound, "未绑定该登录方式", nil)
+	}
+	if member.PasswordDigest == "" && len(items) == 1 {
+		return serializer.ParamErr("至少需要保留一种登录方式", nil)
+	}
+	if err := model.Client.Identity.DeleteOne(target).Exec(ctx); err != nil {
+		return serializer.DBErr("解除绑定失败", err)
+	}
+	return serializer.Response{Msg: "解除绑定成功"}
+}
+
+// oidcErr 第三方登录错误转换为响应
+func oidcErr(err error) serializer.Response {
+	switch {
+	case errors.Is(err, oidc.ErrUnknownProvider):
+		return serializer.Err(serializer.CodeNotFound, "登录方式不存在", err)
+	case errors.Is(err, oidc.ErrInvalidState):
+		return serializer.ParamErr("登录请求已失效, 请重新发起", err)
+	default:
+		return serializer.Err(serializer.CodeOIDCError, "第三方登录失败", err)
+	}
+}
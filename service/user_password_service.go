@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"go-api/cache"
+	"go-api/ent"
+	"go-api/model"
+	"go-api/serializer"
+	"strconv"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UserPasswordResetService 重置用户密码的服务
+type UserPasswordResetService struct {
+	Password string `form:"password" json:"password" binding:"required,min=6,max=40"`
+}
+
+// Reset 重置密码, 用户当前的 token 随即失效
+func (service *UserPasswordResetService) Reset(ctx context.Context, id int) serializer.Response {
+	digest, err := bcrypt.GenerateFromPassword([]byte(service.Password), model.PassWordCost)
+	if err != nil {
+		return serializer.Err(serializer.CodeEncryptError, "密码加密失败", err)
+	}
+
+	member, err := model.Client.User.UpdateOneID(id).SetPasswordDigest(string(digest)).Save(ctx)
+	if ent.IsNotFound(err) {
+		return serializer.Err(serializer.CodeNotFound, "用户不存在", err)
+	}
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+
+	if err := SignOutEverywhere(ctx, id); err != nil {
+		return serializer.Err(serializer.CodeTokenError, "吊销 token 失败", err)
+	}
+	if err := RevokeOAuthGrants(ctx, member.TenantID, id); err != nil {
+		return serializer.Err(serializer.CodeTokenError, "吊销 token 失败", err)
+	}
+	key := cache.Key(ctx, "member:"+strconv.Itoa(id))
+	cache.RedisClient.Del(ctx, key)
+	cache.LocalCacheClient.Delete(key)
+
+	return serializer.BuildUserResponse(member)
+}
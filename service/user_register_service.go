@@ -1,8 +1,15 @@
 package service
 
 import (
+	"context"
+	"go-api/ent"
+	"go-api/ent/user"
+	"go-api/entx"
 	"go-api/model"
+	"go-api/outbox"
 	"go-api/serializer"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 // UserRegisterService 管理用户注册服务
@@ -11,10 +18,12 @@ type UserRegisterService struct {
 	Username        string `form:"username" json:"username" binding:"required,min=5,max=30"`
 	Password        string `form:"password" json:"password" binding:"required,min=6,max=40"`
 	PasswordConfirm string `form:"password_confirm" json:"password_confirm" binding:"required,min=6,max=40"`
+	// Role 用户角色, 只能由命令行等内部调用指定, 默认为普通用户
+	Role string `form:"-" json:"-"`
 }
 
 // valid 验证表单
-func (service *UserRegisterService) valid() *serializer.Response {
+func (service *UserRegisterService) valid(ctx context.Context) *serializer.Response {
 	if service.PasswordConfirm != service.Password {
 		return &serializer.Response{
 			Code: 40001,
@@ -22,8 +31,7 @@ func (service *UserRegisterService) valid() *serializer.Response {
 		}
 	}
 
-	count := 0
-	model.DB.Model(&model.User{}).Where("nickname = ?", service.Nickname).Count(&count)
+	count, _ := model.Client.User.Query().Where(user.Nickname(service.Nickname)).Count(ctx)
 	if count > 0 {
 		return &serializer.Response{
 			Code: 40001,
@@ -31,12 +39,12 @@ func (service *UserRegisterService) valid() *serializer.Response {
 		}
 	}
 
-	count = 0
-	model.DB.Model(&model.User{}).Where("username = ?", service.Username).Count(&count)
+	// 软删除的用户仍然占用唯一索引, 到期物理删除后用户名才能再次注册
+	count, _ = model.Client.User.Query().Where(user.Username(service.Username)).Count(entx.IncludeDeleted(ctx))
 	if count > 0 {
 		return &serializer.Response{
 			Code: 40001,
-			Msg:  "用户名已经注册",
+			Msg:  "用户名已占用",
 		}
 	}
 
@@ -44,20 +52,15 @@ func (service *UserRegisterService) valid() *serializer.Response {
 }
 
 // Register 用户注册
-func (service *UserRegisterService) Register() serializer.Response {
-	user := model.User{
-		Nickname: service.Nickname,
-		Username: service.Username,
-		Status:   model.Active,
-	}
-
+func (service *UserRegisterService) Register(ctx context.Context) serializer.Response {
 	// 表单验证
-	if err := service.valid(); err != nil {
+	if err := service.valid(ctx); err != nil {
 		return *err
 	}
 
 	// 加密密码
-	if err := user.SetPassword(service.Password); err != nil {
+	digest, err := bcrypt.GenerateFromPassword([]byte(service.Password), model.PassWordCost)
+	if err != nil {
 		return serializer.Err(
 			serializer.CodeEncryptError,
 			"密码加密失败",
@@ -65,10 +68,34 @@ func (service *UserRegisterService) Register() serializer.Response {
 		)
 	}
 
-	// 创建用户
-	if err := model.DB.Create(&user).Error; err != nil {
+	role := service.Role
+	if role == "" {
+		role = model.RoleUser
+	}
+
+	// 创建用户, 注册事件与用户在同一事务内写入
+	var member *ent.User
+	err = model.WithTx(ctx, func(tx *ent.Tx) error {
+		member, err = tx.User.Create().
+			SetNickname(service.Nickname).
+			SetUsername(service.Username).
+			SetPasswordDigest(string(digest)).
+			SetStatus(model.Active).
+			SetAvatar("").
+			SetRole(role).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		return outbox.Record(ctx, tx, outbox.NewUserRegistered(member))
+	})
+	if ent.IsConstraintError(err) {
+		// 并发注册了相同的用户名
+		return serializer.ParamErr("用户名已占用", err)
+	}
+	if err != nil {
 		return serializer.ParamErr("注册失败", err)
 	}
 
-	return serializer.BuildUserResponse(nil)
+	return serializer.BuildUserResponse(member)
 }
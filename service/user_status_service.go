@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"go-api/cache"
+	"go-api/ent"
+	"go-api/model"
+	"go-api/outbox"
+	"go-api/realtime"
+	"go-api/serializer"
+	"strconv"
+)
+
+// UserStatusService 修改用户状态的服务
+type UserStatusService struct {
+	Status string `form:"status" json:"status" binding:"required,oneof=active inactive suspend"`
+}
+
+// Change 修改用户状态, 状态变更事件与用户在同一事务内写入
+func (service *UserStatusService) Change(ctx context.Context, id int) serializer.Response {
+	var member *ent.User
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		u, err := tx.User.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		member = u
+		if u.Status == service.Status {
+			return nil
+		}
+		member, err = tx.User.UpdateOne(u).SetStatus(service.Status).SetVersion(u.Version).Save(ctx)
+		if err != nil {
+			return err
+		}
+		return outbox.Record(ctx, tx, outbox.NewUserStatusChanged(id, u.Status, service.Status))
+	})
+	if ent.IsNotFound(err) {
+		return serializer.Err(serializer.CodeNotFound, "用户不存在", err)
+	}
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+
+	// 非激活用户的登录态立即失效
+	key := strconv.Itoa(id)
+	if service.Status != model.Active {
+		SignOutEverywhere(ctx, id)
+		RevokeOAuthGrants(ctx, member.TenantID, id)
+	}
+	cache.RedisClient.Del(ctx, cache.Key(ctx, "member:"+key))
+	cache.LocalCacheClient.Delete(cache.Key(ctx, "member:"+key))
+
+	realtime.Publish(id, realtime.NewEvent("user.status", serializer.BuildUser(member)))
+
+	return serializer.BuildUserResponse(member)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-api/jobs"
+	"go-api/model"
+	"go-api/webhook"
+	"strconv"
+
+	"github.com/hibiken/asynq"
+)
+
+// DeliverWebhookPayload 发送 webhook 任务
+type DeliverWebhookPayload struct {
+	DeliveryID int `json:"delivery_id"`
+}
+
+// DeliverWebhookTask 发送一次 webhook 投递, 失败时按 jobs.RetryDelay 指数退避重试
+var DeliverWebhookTask = jobs.NewTask[DeliverWebhookPayload]("webhook:deliver",
+	asynq.MaxRetry(webhook.MaxAttempts-1))
+
+func init() {
+	DeliverWebhookTask.Handle(deliverWebhook)
+}
+
+func deliverWebhook(ctx context.Context, p DeliverWebhookPayload) error {
+	return webhook.Deliver(ctx, model.Client, p.DeliveryID)
+}
+
+// enqueueWebhookDelivery 把投递放入队列, 同一投递已在队列中时忽略
+func enqueueWebhookDelivery(ctx context.Context, id int) error {
+	_, err := DeliverWebhookTask.Enqueue(ctx, DeliverWebhookPayload{DeliveryID: id},
+		asynq.TaskID("webhook:delivery:"+strconv.Itoa(id)))
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	return err
+}
+
+// WebhookDispatcher 由 outbox relay 调用, 把领域事件分发到订阅的 webhook 地址
+func WebhookDispatcher() *webhook.Dispatcher {
+	return webhook.NewDispatcher(model.Client, enqueueWebhookDelivery)
+}
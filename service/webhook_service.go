@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-api/ent"
+	"go-api/ent/predicate"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"go-api/listquery"
+	"go-api/model"
+	"go-api/serializer"
+	"go-api/webhook"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WebhookCreateService 注册 webhook 地址的服务
+type WebhookCreateService struct {
+	URL         string   `form:"url" json:"url" binding:"required,max=2048"`
+	Events      []string `form:"events" json:"events" binding:"required"`
+	Description string   `form:"description" json:"description" binding:"max=200"`
+}
+
+// WebhookUpdateService 修改 webhook 地址的服务, 未传的字段保持不变
+// 重新启用自动停用的地址时清零失败次数
+type WebhookUpdateService struct {
+	URL         *string  `form:"url" json:"url" binding:"omitempty,max=2048"`
+	Events      []string `form:"events" json:"events"`
+	Description *string  `form:"description" json:"description" binding:"omitempty,max=200"`
+	Enabled     *bool    `form:"enabled" json:"enabled"`
+}
+
+// validWebhookURL 只允许 http 与 https 地址, 不允许内网与回环地址
+func validWebhookURL(raw string) *serializer.Response {
+	if err := webhook.CheckURL(raw); err != nil {
+		res := serializer.ParamErr("地址不合法 "+raw, err)
+		return &res
+	}
+	return nil
+}
+
+// webhookEvents 校验并去重订阅的事件
+func webhookEvents(events []string) (string, *serializer.Response) {
+	set := make(map[string]bool)
+	for _, event := range events {
+		if _, ok := webhook.Events[event]; !ok {
+			res := serializer.ParamErr("不支持的事件 "+event, nil)
+			return "", &res
+		}
+		set[event] = true
+	}
+	if len(set) == 0 {
+		res := serializer.ParamErr("至少订阅一个事件", nil)
+		return "", &res
+	}
+	list := make([]string, 0, len(set))
+	for event := range set {
+		list = append(list, event)
+	}
+	sort.Strings(list)
+	return strings.Join(list, " "), nil
+}
+
+// ListWebhookEvents 可以订阅的事件
+func ListWebhookEvents() serializer.Response {
+	events := make([]serializer.WebhookEvent, 0, len(webhook.Events))
+	for name, description := range webhook.Events {
+		events = append(events, serializer.WebhookEvent{Name: name, Description: description})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	return serializer.Response{Data: events}
+}
+
+// webhookAudit 记录地址的变更
+func webhookAudit(ctx context.Context, tx *ent.Tx, actorID int, action string, id int, before, after *ent.WebhookEndpoint) error {
+	create := tx.AuditLog.Create().
+		SetActorID(actorID).
+		SetAction(action).
+		SetTarget("webhook:" + strconv.Itoa(id))
+	if before != nil {
+		data, _ := json.Marshal(serializer.BuildWebhookEndpoint(before))
+		create.SetBefore(string(data))
+	}
+	if after != nil {
+		data, _ := json.Marshal(serializer.BuildWebhookEndpoint(after))
+		create.SetAfter(string(data))
+	}
+	_, err := create.Save(ctx)
+	return err
+}
+
+// Create 注册 webhook 地址, 签名密钥只在此时返回
+func (service *WebhookCreateService) Create(ctx context.Context, ownerID int) serializer.Response {
+	if res := validWebhookURL(service.URL); res != nil {
+		return *res
+	}
+	events, res := webhookEvents(service.Events)
+	if res != nil {
+		return *res
+	}
+
+	var endpoint *ent.WebhookEndpoint
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		var err error
+		endpoint, err = tx.WebhookEndpoint.Create().
+			SetOwnerID(ownerID).
+			SetURL(service.URL).
+			SetSecret(webhook.NewSecret()).
+			SetEvents(events).
+			SetDescription(service.Description).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		return webhookAudit(ctx, tx, ownerID, "webhook.create", endpoint.ID, nil, endpoint)
+	})
+	if err != nil {
+		return serializer.DBErr("注册失败", err)
+	}
+	return serializer.Response{
+		Data: serializer.WebhookEndpointSecret{
+			WebhookEndpoint: serializer.BuildWebhookEndpoint(endpoint),
+			Secret:          endpoint.Secret,
+		},
+	}
+}
+
+// findOwnedWebhook 查询用户自己注册的地址
+func findOwnedWebhook(ctx context.Context, ownerID, id int) (*ent.WebhookEndpoint, *serializer.Response) {
+	endpoint, err := model.Client.WebhookEndpoint.Query().
+		Where(webhookendpoint.ID(id), webhookendpoint.OwnerID(ownerID)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		res := serializer.Err(serializer.CodeNotFound, "地址不存在", err)
+		return nil, &res
+	}
+	if err != nil {
+		res := serializer.DBErr("", err)
+		return nil, &res
+	}
+	return endpoint, nil
+}
+
+// Update 修改 webhook 地址
+func (service *WebhookUpdateService) Update(ctx context.Context, ownerID, id int) serializer.Response {
+	endpoint, res := findOwnedWebhook(ctx, ownerID, id)
+	if res != nil {
+		return *res
+	}
+
+	if service.URL != nil {
+		if res := validWebhookURL(*service.URL); res != nil {
+			return *res
+		}
+	}
+	var events string
+	if service.Events != nil {
+		if events, res = webhookEvents(service.Events); res != nil {
+			return *res
+		}
+	}
+
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		update := tx.WebhookEndpoint.UpdateOne(endpoint)
+		if service.URL != nil {
+			update.SetURL(*service.URL)
+		}
+		if service.Events != nil {
+			update.SetEvents(events)
+		}
+		if service.Description != nil {
+			update.SetDescription(*service.Description)
+		}
+		if service.Enabled != nil {
+			update.SetEnabled(*service.Enabled)
+			if *service.Enabled && !endpoint.Enabled {
+				update.SetFailures(0).ClearDisabledAt()
+			}
+		}
+		after, err := update.Save(ctx)
+		if err != nil {
+			return err
+		}
+		err = webhookAudit(ctx, tx, ownerID, "webhook.update", id, endpoint, after)
+		endpoint = after
+		return err
+	})
+	if err != nil {
+		return serializer.DBErr("修改失败", err)
+	}
+	return serializer.Response{Data: serializer.BuildWebhookEndpoint(endpoint)}
+}
+
+// ListWebhooks 用户注册的全部地址
+func ListWebhooks(ctx context.Context, ownerID int) serializer.Response {
+	items, err := model.Client.WebhookEndpoint.Query().
+		Where(webhookendpoint.OwnerID(ownerID)).
+		Order(ent.Desc(webhookendpoint.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildWebhookEndpoints(items)}
+}
+
+// DeleteWebhook 删除地址及其投递记录, 队列中尚未发送的投递随之取消
+func DeleteWebhook(ctx context.Context, ownerID, id int) serializer.Response {
+	endpoint, res := findOwnedWebhook(ctx, ownerID, id)
+	if res != nil {
+		return *res
+	}
+	err := model.WithTx(ctx, func(tx *ent.Tx) error {
+		if _, err := tx.WebhookDelivery.Delete().
+			Where(webhookdelivery.HasEndpointWith(webhookendpoint.ID(id))).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if err := tx.WebhookEndpoint.DeleteOne(endpoint).Exec(ctx); err != nil {
+			return err
+		}
+		return webhookAudit(ctx, tx, ownerID, "webhook.delete", id, endpoint, nil)
+	})
+	if err != nil {
+		return serializer.DBErr("删除失败", err)
+	}
+	return serializer.Response{Msg: "删除成功"}
+}
+
+// webhookDeliveryListSchema 投递记录允许过滤与排序的字段
+var webhookDeliveryListSchema = &listquery.Schema{
+	Fields: []listquery.Field{
+		{Name: webhookdelivery.FieldStatus, Type: listquery.String},
+		{Name: webhookdelivery.FieldEventType, Type: listquery.String},
+		{Name: webhookdelivery.FieldEventID, Type: listquery.Int},
+		{Name: webhookdelivery.FieldCreatedAt, Type: listquery.Time, Sortable: true},
+	},
+	DefaultSort: "-" + webhookdelivery.FieldCreatedAt,
+}
+
+// ListWebhookDeliveries 地址的投递记录, 默认按时间倒序
+func ListWebhookDeliveries(ctx context.Context, ownerID, id int, values url.Values) serializer.ListResponse {
+	if _, res := findOwnedWebhook(ctx, ownerID, id); res != nil {
+		return serializer.ListResponse{Response: *res}
+	}
+	q, err := listquery.Parse(webhookDeliveryListSchema, values)
+	if err != nil {
+		return serializer.ListResponse{Response: serializer.ParamErr(err.Error(), err)}
+	}
+
+	where := append([]predicate.WebhookDelivery{webhookdelivery.HasEndpointWith(webhookendpoint.ID(id))},
+		listquery.Where[predicate.WebhookDelivery](q)...)
+	items, err := model.Client.WebhookDelivery.Query().
+		Where(where...).
+		Order(listquery.Order[ent.OrderFunc](q)...).
+		Limit(q.Limit()).
+		All(ctx)
+	if err != nil {
+		return serializer.ListResponse{Response: serializer.DBErr("", err)}
+	}
+	items, meta := listquery.Paginate(q, items)
+	return serializer.BuildList(serializer.BuildWebhookDeliveries(items), meta)
+}
+
+// ReplayWebhookDelivery 以相同的请求体重新投递, 创建新的投递记录
+func ReplayWebhookDelivery(ctx context.Context, ownerID, id, deliveryID int) serializer.Response {
+	endpoint, res := findOwnedWebhook(ctx, ownerID, id)
+	if res != nil {
+		return *res
+	}
+	if !endpoint.Enabled {
+		return serializer.ParamErr("地址已停用, 请先启用", nil)
+	}
+	original, err := endpoint.QueryDeliveries().Where(webhookdelivery.ID(deliveryID)).Only(ctx)
+	if ent.IsNotFound(err) {
+		return serializer.Err(serializer.CodeNotFound, "投递记录不存在", err)
+	}
+	if err != nil {
+		return serializer.DBErr("", err)
+	}
+
+	replayOf := original.ID
+	if original.ReplayOf != 0 {
+		replayOf = original.ReplayOf
+	}
+	delivery, err := model.Client.WebhookDelivery.Create().
+		SetEndpoint(endpoint).
+		SetEventID(original.EventID).
+		SetEventType(original.EventType).
+		SetUserID(original.UserID).
+		SetPayload(original.Payload).
+		SetReplayOf(replayOf).
+		Save(ctx)
+	if err != nil {
+		return serializer.DBErr("重放失败", err)
+	}
+	if err := enqueueWebhookDelivery(ctx, delivery.ID); err != nil {
+		return serializer.Err(serializer.CodeJobError, "重放失败", err)
+	}
+	// 同步执行时投递已经完成, 重新读取结果
+	if delivery, err = model.Client.WebhookDelivery.Get(ctx, delivery.ID); err != nil {
+		return serializer.DBErr("", err)
+	}
+	return serializer.Response{Data: serializer.BuildWebhookDelivery(delivery)}
+}
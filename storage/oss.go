@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OSS 阿里云 OSS, 使用 V1 签名直接调用 REST 接口
+type OSS struct {
+	endpoint  string
+	bucket    string
+	keyID     string
+	keySecret string
+	client    *http.Client
+}
+
+// NewOSSFromEnv 使用 OSS_BUCKET, OSS_END_POINT, OSS_ACCESS_KEY_ID, OSS_ACCESS_KEY_SECRET 创建 OSS
+func NewOSSFromEnv() *OSS {
+	endpoint := strings.TrimPrefix(strings.TrimPrefix(os.Getenv("OSS_END_POINT"), "https://"), "http://")
+	return &OSS{
+		endpoint:  endpoint,
+		bucket:    os.Getenv("OSS_BUCKET"),
+		keyID:     os.Getenv("OSS_ACCESS_KEY_ID"),
+		keySecret: os.Getenv("OSS_ACCESS_KEY_SECRET"),
+		client:    &http.Client{Timeout: time.Minute},
+	}
+}
+
+// do 签名并发送请求, key 为空时请求 bucket
+func (o *OSS) do(ctx context.Context, method, key string, query url.Values, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     o.bucket + "." + o.endpoint,
+		Path:     "/" + key,
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	date := time.Now().UTC().Format(http.TimeFormat)
+	req.Header.Set("Date", date)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	mac := hmac.New(sha1.New, []byte(o.keySecret))
+	mac.Write([]byte(method + "\n\n" + contentType + "\n" + date + "\n/" + o.bucket + "/" + key))
+	req.Header.Set("Authorization", "OSS "+o.keyID+":"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	return o.client.Do(req)
+}
+
+// check 非 2xx 响应转换为错误
+func check(resp *http.Response, err error) (*http.Response, error) {
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("storage: oss %s: %s", resp.Status, data)
+	}
+	return resp, nil
+}
+
+// Put 实现 Store
+func (o *OSS) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	resp, err := check(o.do(ctx, http.MethodPut, key, nil, r, size, contentType))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Open 实现 Store
+func (o *OSS) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := check(o.do(ctx, http.MethodGet, key, nil, nil, 0, ""))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete 实现 Store
+func (o *OSS) Delete(ctx context.Context, key string) error {
+	resp, err := check(o.do(ctx, http.MethodDelete, key, nil, nil, 0, ""))
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// List 实现 Store, 按 marker 分页读取全部对象
+func (o *OSS) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	marker := ""
+	for {
+		query := url.Values{"prefix": {prefix}, "max-keys": {"1000"}}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+		resp, err := check(o.do(ctx, http.MethodGet, "", query, nil, 0, ""))
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			IsTruncated bool   `xml:"IsTruncated"`
+			NextMarker  string `xml:"NextMarker"`
+			Contents    []struct {
+				Key          string `xml:"Key"`
+				Size         string `xml:"Size"`
+				LastModified string `xml:"LastModified"`
+			} `xml:"Contents"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range result.Contents {
+			size, _ := strconv.ParseInt(c.Size, 10, 64)
+			modified, _ := time.Parse(time.RFC3339, c.LastModified)
+			objects = append(objects, Object{Key: c.Key, Size: size, ModifiedAt: modified})
+		}
+		if !result.IsTruncated || len(result.Contents) == 0 {
+			return objects, nil
+		}
+		marker = result.NextMarker
+		if marker == "" {
+			marker = result.Contents[len(result.Contents)-1].Key
+		}
+	}
+}
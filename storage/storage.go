@@ -0,0 +1,76 @@
+// Package storage 对象存储, 保存导出的数据等文件
+//
+// STORAGE_DRIVER 为 oss 时使用 OSS_* 配置的阿里云 OSS, 否则保存在本地目录 STORAGE_DIR(默认 data)中;
+// 用户的文件放在 UserPrefix 下, 注销时按前缀整体删除
+package storage
+
+import (
+	"context"
+	"errors"
+	"go-api/tenancy"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("storage: object not found")
+
+// Object 对象的元数据
+type Object struct {
+	Key        string    `json:"key"`
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+// Store 对象存储
+type Store interface {
+	// Put 写入对象, 已存在时覆盖
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open 读取对象, 不存在时返回 ErrNotFound
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete 删除对象, 不存在时不报错
+	Delete(ctx context.Context, key string) error
+	// List 列出 prefix 下的全部对象
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Default 默认的对象存储
+var Default Store
+
+// Init 按环境变量初始化 Default
+func Init() {
+	if os.Getenv("STORAGE_DRIVER") == "oss" {
+		Default = NewOSSFromEnv()
+		return
+	}
+	dir := os.Getenv("STORAGE_DIR")
+	if dir == "" {
+		dir = "data"
+	}
+	Default = NewLocal(dir)
+}
+
+// UserPrefix 用户文件的前缀, 默认租户之外的租户带有租户 id
+func UserPrefix(tenantID, userID int) string {
+	prefix := "users/" + strconv.Itoa(userID) + "/"
+	if tenantID != 0 && tenantID != tenancy.DefaultID {
+		prefix = "tenants/" + strconv.Itoa(tenantID) + "/" + prefix
+	}
+	return prefix
+}
+
+// DeletePrefix 删除 prefix 下的全部对象, 返回删除的数量
+func DeletePrefix(ctx context.Context, s Store, prefix string) (int, error) {
+	objects, err := s.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	for i, o := range objects {
+		if err := s.Delete(ctx, o.Key); err != nil {
+			return i, err
+		}
+	}
+	return len(objects), nil
+}
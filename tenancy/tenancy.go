@@ -0,0 +1,69 @@
+// Package tenancy 租户上下文
+//
+// 请求的租户由 middleware.Tenant 解析后放入 context, ent 的隐私规则据此自动限定
+// User, Pet 等租户数据的查询与修改范围; context 中没有租户时拒绝访问,
+// 定时任务, 迁移等需要跨租户访问的代码使用 System 显式声明
+package tenancy
+
+import (
+	"context"
+	"os"
+)
+
+// 默认租户, 没有指定租户的请求以及多租户改造前的数据都属于默认租户
+const (
+	DefaultID   = 1
+	DefaultName = "default"
+)
+
+// Tenant 请求所属的租户
+type Tenant struct {
+	ID   int
+	Name string
+	// Config 租户级别的配置, 覆盖同名的环境变量
+	Config map[string]string
+}
+
+type tenantKey struct{}
+
+type systemKey struct{}
+
+// NewContext 返回携带租户的 context
+func NewContext(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// FromContext context 中的租户, 没有时返回 nil
+func FromContext(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(tenantKey{}).(*Tenant)
+	return t
+}
+
+// ID context 中的租户 id, 没有时返回 0
+func ID(ctx context.Context) int {
+	if t := FromContext(ctx); t != nil {
+		return t.ID
+	}
+	return 0
+}
+
+// System 返回不受租户限制的 context, 只用于定时任务, 迁移, 命令行等内部代码
+func System(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemKey{}, true)
+}
+
+// IsSystem 是否为不受租户限制的 context
+func IsSystem(ctx context.Context) bool {
+	system, _ := ctx.Value(systemKey{}).(bool)
+	return system
+}
+
+// Config 读取配置, 优先使用租户的配置, 没有时读取环境变量
+func Config(ctx context.Context, key string) string {
+	if t := FromContext(ctx); t != nil {
+		if v, ok := t.Config[key]; ok {
+			return v
+		}
+	}
+	return os.Getenv(key)
+}
@@ -0,0 +1,36 @@
+package testutil
+
+import (
+	"sync"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// Clock 可控的时钟, 推进时同步推进 redis 中的过期时间
+type Clock struct {
+	mu    sync.Mutex
+	now   time.Time
+	redis *miniredis.Miniredis
+}
+
+// Epoch 测试时钟的起始时间
+var Epoch = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Now 当前时间
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance 推进时间
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+	if c.redis != nil {
+		c.redis.SetTime(c.Now())
+		c.redis.FastForward(d)
+	}
+}
@@ -0,0 +1,109 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"go-api/ent"
+	"go-api/model"
+	"go-api/service"
+	"go-api/tenancy"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password fixture 用户的默认密码
+const Password = "password"
+
+// UserBuilder 构造测试用户
+type UserBuilder struct {
+	env      *Env
+	username string
+	nickname string
+	password string
+	status   string
+	role     string
+	tenant   int
+}
+
+// User 新建用户构造器, 默认为 active 状态, 用户名与昵称按序号生成
+func (e *Env) User() *UserBuilder {
+	n := atomic.AddInt32(&e.users, 1)
+	return &UserBuilder{
+		env:      e,
+		username: fmt.Sprintf("user%04d", n),
+		nickname: fmt.Sprintf("nick%04d", n),
+		password: Password,
+		status:   model.Active,
+		role:     model.RoleUser,
+		tenant:   tenancy.DefaultID,
+	}
+}
+
+// Username 设置用户名
+func (b *UserBuilder) Username(username string) *UserBuilder {
+	b.username = username
+	return b
+}
+
+// Nickname 设置昵称
+func (b *UserBuilder) Nickname(nickname string) *UserBuilder {
+	b.nickname = nickname
+	return b
+}
+
+// Password 设置密码
+func (b *UserBuilder) Password(password string) *UserBuilder {
+	b.password = password
+	return b
+}
+
+// Status 设置状态
+func (b *UserBuilder) Status(status string) *UserBuilder {
+	b.status = status
+	return b
+}
+
+// Admin 设置为管理员
+func (b *UserBuilder) Admin() *UserBuilder {
+	b.role = model.RoleAdmin
+	return b
+}
+
+// Tenant 设置所属租户, 默认为默认租户
+func (b *UserBuilder) Tenant(t *ent.Tenant) *UserBuilder {
+	b.tenant = t.ID
+	return b
+}
+
+// Create 写入数据库
+func (b *UserBuilder) Create() *ent.User {
+	b.env.T.Helper()
+	digest, err := bcrypt.GenerateFromPassword([]byte(b.password), model.PassWordCost)
+	if err != nil {
+		b.env.T.Fatal(err)
+	}
+	u, err := b.env.DB.User.Create().
+		SetUsername(b.username).
+		SetNickname(b.nickname).
+		SetPasswordDigest(string(digest)).
+		SetStatus(b.status).
+		SetRole(b.role).
+		SetAvatar("").
+		Save(tenancy.NewContext(context.Background(), &tenancy.Tenant{ID: b.tenant}))
+	if err != nil {
+		b.env.T.Fatal(err)
+	}
+	return u
+}
+
+// Token 不经过登录接口直接为用户签发 token
+func (e *Env) Token(u *ent.User) string {
+	e.T.Helper()
+	token, _, err := service.IssueToken(u)
+	if err != nil {
+		e.T.Fatal(err)
+	}
+	return token
+}
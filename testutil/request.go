@@ -0,0 +1,66 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"go-api/serializer"
+)
+
+// Request 直接调用路由, body 不为空时以 JSON 发送, token 不为空时放在请求头中
+func (e *Env) Request(method, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	e.T.Helper()
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			e.T.Fatal(err)
+		}
+		r = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, path, r)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("token", token)
+	}
+	return e.Do(req)
+}
+
+// Do 调用路由, 用于需要自定义请求头的场景
+func (e *Env) Do(req *http.Request) *httptest.ResponseRecorder {
+	for k, v := range e.Header {
+		if req.Header.Get(k) == "" {
+			req.Header[k] = v
+		}
+	}
+	w := httptest.NewRecorder()
+	e.Router.ServeHTTP(w, req)
+	return w
+}
+
+// Call 调用接口并解析基础响应, data 不为 nil 时解析响应中的 data
+func (e *Env) Call(method, path string, body interface{}, token string, data interface{}) serializer.Response {
+	e.T.Helper()
+	w := e.Request(method, path, body, token)
+	var res struct {
+		serializer.Response
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		e.T.Fatalf("%s %s: %d %s", method, path, w.Code, w.Body.String())
+	}
+	if data != nil && len(res.Data) > 0 {
+		if err := json.Unmarshal(res.Data, data); err != nil {
+			e.T.Fatal(err)
+		}
+	}
+	if w.Code != http.StatusOK && res.Code == 0 {
+		res.Code = w.Code
+	}
+	return res.Response
+}
@@ -0,0 +1,169 @@
+// Package testutil 启动完整的路由用于集成测试, 数据库使用 sqlite, redis 使用 miniredis
+//
+// 被替换的都是包级变量, 使用 testutil 的测试不能并行执行
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"go-api/auth/oidc"
+	"go-api/auth/oidc/oidctest"
+	"go-api/cache"
+	"go-api/ent"
+	"go-api/ent/enttest"
+	"go-api/flags"
+	"go-api/jobs"
+	"go-api/middleware"
+	"go-api/model"
+	"go-api/server"
+	"go-api/storage"
+	"go-api/tenancy"
+	"go-api/util"
+	"go-api/webhook"
+
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SignKey 测试中签发 token 使用的密钥
+const SignKey = "go-api-test"
+
+var databases int64
+
+// Env 测试环境
+type Env struct {
+	T      testing.TB
+	Redis  *miniredis.Miniredis
+	DB     *ent.Client
+	Clock  *Clock
+	Router *gin.Engine
+	Server *httptest.Server
+	// Header 每个请求都携带的请求头, 如 X-Tenant
+	Header http.Header
+
+	users int32
+}
+
+// New 启动测试环境, 测试结束时自动恢复被替换的全局变量
+func New(t testing.TB) *Env {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	// 请求日志写在 logs 目录下, 放到临时目录中
+	t.Chdir(t.TempDir())
+	if err := os.Mkdir("logs", 0755); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]string{
+		"TOKEN_TTL":           "3600",
+		"TOKEN_REFRESH_GRACE": "86400",
+		"RATE_R":              "1000",
+		"RATE_B":              "1000",
+		"SESSION_SECRET":      "go-api-test",
+	} {
+		t.Setenv(k, v)
+	}
+
+	e := &Env{T: t, Header: http.Header{}}
+	e.Redis = miniredis.RunT(t)
+	e.Clock = &Clock{now: Epoch, redis: e.Redis}
+	e.Redis.SetTime(Epoch)
+	t.Setenv("REDIS_ADDR", e.Redis.Addr())
+
+	// 每个环境使用独立的内存数据库
+	name := fmt.Sprintf("%s_%d", strings.NewReplacer("/", "_", " ", "_").Replace(t.Name()), atomic.AddInt64(&databases, 1))
+	e.DB = enttest.Open(t, "sqlite3", "file:"+name+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { e.DB.Close() })
+	if _, err := model.DefaultTenant(context.Background(), e.DB); err != nil {
+		t.Fatal(err)
+	}
+	middleware.FlushTenants()
+	t.Cleanup(middleware.FlushTenants)
+
+	// 确定的时钟, 签名密钥与 jti, 相同的操作签发相同的 token
+	var seq int64
+	replace(t, &model.Client, e.DB)
+	replace(t, &util.Now, e.Clock.Now)
+	replace(t, &middleware.SignKey, SignKey)
+	replace(t, &middleware.TokenID, func() string { return fmt.Sprintf("jti-%d", atomic.AddInt64(&seq, 1)) })
+	replace(t, &model.PassWordCost, bcrypt.MinCost)
+	// 后台任务在投递时同步执行
+	replace(t, &jobs.Default, jobs.Enqueuer(syncEnqueuer{}))
+
+	redisClient, localCache := cache.RedisClient, cache.LocalCacheClient
+	cache.Redis()
+	cache.LocalCache()
+	t.Cleanup(func() {
+		cache.RedisClient.Close()
+		cache.RedisClient, cache.LocalCacheClient = redisClient, localCache
+	})
+	replace(t, &flags.Default, flags.NewManager(flags.NewRedisStore(cache.RedisClient, "flags"), cache.RedisClient, "flags:changed"))
+	replace(t, &oidc.Default, oidc.NewRegistry(cache.RedisClient))
+	replace(t, &storage.Default, storage.Store(storage.NewLocal(t.TempDir())))
+	// 测试中 webhook 推送到本机的 httptest 服务
+	replace(t, &webhook.AllowPrivate, true)
+
+	e.Router = server.NewRouter()
+	e.Server = httptest.NewServer(e.Router)
+	t.Cleanup(e.Server.Close)
+	return e
+}
+
+// Context 默认租户的 context, 用于在测试中直接访问租户数据
+func (e *Env) Context() context.Context {
+	return tenancy.NewContext(context.Background(), &tenancy.Tenant{ID: tenancy.DefaultID, Name: tenancy.DefaultName})
+}
+
+// Tenant 创建租户, config 覆盖同名的环境变量
+func (e *Env) Tenant(name string, config map[string]string) *ent.Tenant {
+	e.T.Helper()
+	data, _ := json.Marshal(config)
+	t, err := e.DB.Tenant.Create().SetName(name).SetConfig(string(data)).Save(context.Background())
+	if err != nil {
+		e.T.Fatal(err)
+	}
+	return t
+}
+
+// OIDC 启动测试用的 OIDC 提供方并以 name 注册, 回调地址指向测试服务
+func (e *Env) OIDC(name string) *oidctest.Provider {
+	e.T.Helper()
+	p := oidctest.New(e.T)
+	oidc.Default.Register(p.Config(name, e.Server.URL+"/api/v1/oauth/"+name+"/callback"))
+	return p
+}
+
+// Queue 后台任务投递到 miniredis 中的真实队列, 不再同步执行, 用于测试队列管理
+func (e *Env) Queue() {
+	replace(e.T, &jobs.Default, jobs.Asynq)
+	jobs.Init()
+	e.T.Cleanup(func() { jobs.Close() })
+}
+
+// syncEnqueuer 投递时直接执行处理函数, 忽略延迟与重试
+// 处理函数的错误只记录日志, 与真实队列中任务失败时一样不影响投递方
+type syncEnqueuer struct{}
+
+func (syncEnqueuer) Enqueue(ctx context.Context, task *asynq.Task, _ ...asynq.Option) (*asynq.TaskInfo, error) {
+	if err := jobs.Process(ctx, task); err != nil {
+		util.Log().Warning("任务 %s 执行失败: %v", task.Type(), err)
+	}
+	return &asynq.TaskInfo{Type: task.Type(), Payload: task.Payload(), State: asynq.TaskStateCompleted}, nil
+}
+
+// replace 替换全局变量, 测试结束时恢复
+func replace[T any](t testing.TB, p *T, v T) {
+	old := *p
+	*p = v
+	t.Cleanup(func() { *p = old })
+}
@@ -8,6 +8,9 @@ import (
 	"time"
 )
 
+// Now 返回当前时间, 签发与校验 token 等需要在测试中控制时间的地方使用, 测试时替换为固定时钟
+var Now = time.Now
+
 // RandStringRunes 返回随机字符串
 func RandStringRunes(n int) string {
 	var letterRunes = []rune("1234567890abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
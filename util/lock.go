@@ -2,122 +2,425 @@ package util
 
 import (
 	"context"
+	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 )
 
+const (
+	fencingSuffix = ":fencing"
+	// 阻塞加锁的退避区间
+	minBackoff = 10 * time.Millisecond
+	maxBackoff = 500 * time.Millisecond
+)
+
+// 锁以 hash 保存: owner 持有者, count 重入次数, token 栅栏令牌
+// KEYS[1] 锁 key, KEYS[2] 栅栏计数器 key, 两者使用相同的 hash tag, 集群模式下位于同一个 slot
+// ARGV[1] owner, ARGV[2] 过期毫秒数, ARGV[3] 是否可重入
+// 返回 0 代表加锁失败, 否则返回本次持有的栅栏令牌
+var acquireScript = redis.NewScript(`
+local owner = redis.call("HGET", KEYS[1], "owner")
+if owner == false then
+	local token = redis.call("INCR", KEYS[2])
+	redis.call("HSET", KEYS[1], "owner", ARGV[1], "count", 1, "token", token)
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return token
+end
+if owner == ARGV[1] and ARGV[3] == "1" then
+	redis.call("HINCRBY", KEYS[1], "count", 1)
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return tonumber(redis.call("HGET", KEYS[1], "token"))
+end
+return 0
+`)
+
+// 如果 Redis 中的持有者等于传入的 owner, 重入次数减一, 归零时删除
+var releaseScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "owner") ~= ARGV[1] then
+	return 0
+end
+if redis.call("HINCRBY", KEYS[1], "count", -1) <= 0 then
+	redis.call("DEL", KEYS[1])
+end
+return 1
+`)
+
+// 只有锁的持有者才能续期, PEXPIRE 重新设置为固定毫秒数
+var renewalScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "owner") == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
 // RedisLock Redis锁结构
 type RedisLock struct {
-	client        *redis.Client
-	key           string
-	value         string // 通常使用UUID，用于安全释放
-	expiration    time.Duration
+	client     redis.UniversalClient
+	key        string
+	value      string // 持有者标识, 默认使用UUID, 用于安全释放和重入
+	expiration time.Duration
+	reentrant  bool
+	token      int64
+
+	mu            sync.Mutex
+	renewals      int // KeepAlive 尚未被 Unlock 抵消的次数
 	cancelRenewal context.CancelFunc
+	lost          chan struct{}
+	lockCtx       context.Context
+	cancelLockCtx context.CancelFunc
+}
+
+// LockOption 锁的可选配置
+type LockOption func(lock *RedisLock)
+
+// WithOwner 指定持有者标识, 相同持有者在可重入模式下可以重复加锁
+func WithOwner(owner string) LockOption {
+	return func(lock *RedisLock) {
+		lock.value = owner
+	}
+}
+
+// WithReentrant 开启可重入
+func WithReentrant() LockOption {
+	return func(lock *RedisLock) {
+		lock.reentrant = true
+	}
 }
 
 // NewRedisLock 创建一个锁实例
-func NewRedisLock(client *redis.Client, key string, expiration time.Duration) *RedisLock {
-	return &RedisLock{
+func NewRedisLock(client redis.UniversalClient, key string, expiration time.Duration, opts ...LockOption) *RedisLock {
+	lock := &RedisLock{
 		client:     client,
 		key:        key,
 		value:      uuid.New().String(),
 		expiration: expiration,
 	}
+	for _, opt := range opts {
+		opt(lock)
+	}
+	return lock
+}
+
+// slotKey 锁在 redis 中的 key, 以锁名作为 hash tag, 栅栏计数器 slotKey()+fencingSuffix 与它位于同一个 slot
+func (lock *RedisLock) slotKey() string {
+	return "{" + lock.key + "}"
+}
+
+// Token 返回最近一次加锁得到的栅栏令牌, 令牌随每次新的持有单调递增
+// 下游存储应拒绝比已见过的令牌更小的写入
+// 计数器保存在锁所在的 redis 节点上, 只在该节点内单调, 主从切换丢失数据时也可能回退
+func (lock *RedisLock) Token() int64 {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+	return lock.token
 }
 
+// Acquire 尝试加锁一次
 func (lock *RedisLock) Acquire(ctx context.Context) (bool, error) {
-	result, err := lock.client.SetNX(ctx, lock.key, lock.value, lock.expiration).Result()
+	reentrant := "0"
+	if lock.reentrant {
+		reentrant = "1"
+	}
+	token, err := acquireScript.Run(ctx, lock.client, []string{lock.slotKey(), lock.slotKey() + fencingSuffix},
+		lock.value, lock.expiration.Milliseconds(), reentrant).Int64()
 	if err != nil {
 		return false, err
 	}
-	return result, nil
+	if token == 0 {
+		return false, nil
+	}
+	lock.mu.Lock()
+	lock.token = token
+	lock.mu.Unlock()
+	return true, nil
 }
 
+// Lock 阻塞加锁, 失败后指数退避重试直到 ctx 结束
+func (lock *RedisLock) Lock(ctx context.Context) error {
+	backoff := minBackoff
+	for {
+		ok, err := lock.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		// 加入随机抖动, 避免竞争者同时醒来
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// Release 释放锁, 可重入模式下每次释放抵消一次加锁
 func (lock *RedisLock) Release(ctx context.Context) (bool, error) {
-	// 1. Lua脚本：如果Redis中存储的值等于传入的 value，则执行删除操作
-	cadScript := `
-	if redis.call("GET", KEYS[1]) == ARGV[1] then
-		return redis.call("DEL", KEYS[1])
-	else
-		return 0
-	end
-	`
-	script := redis.NewScript(cadScript)
-	// 2. 执行Lua脚本，传入锁的 key 和唯一的 value
-	result, err := script.Run(ctx, lock.client, []string{lock.key}, lock.value).Int64()
+	result, err := releaseScript.Run(ctx, lock.client, []string{lock.slotKey()}, lock.value).Int64()
 	if err != nil {
 		return false, err
 	}
-	// 如果返回结果 > 0，代表删除成功（释放了锁）
+	// 如果返回结果 > 0，代表释放成功
 	return result > 0, nil
 }
 
 // LockAndRenewal 获取锁并启动协程自动续期
+// 续期失败时 Lost 返回的 channel 会被关闭, Context 返回的 context 会被取消,
+// 持有者应当据此停止受锁保护的工作
 func (lock *RedisLock) LockAndRenewal(ctx context.Context) (bool, error) {
-	// 1. 尝试获取锁
-	result, err := lock.client.SetNX(ctx, lock.key, lock.value, lock.expiration).Result()
+	result, err := lock.Acquire(ctx)
 	if err != nil || !result {
-		// 2. 获取锁失败
 		return result, err
 	}
-	// 3. 成功获取锁，启动协程进行自动续期
+	lock.KeepAlive(ctx)
+	return true, nil
+}
+
+// KeepAlive 为已经持有的锁启动协程自动续期, 由 Unlock 停止
+// 可重入时只有第一次调用启动续期, 之后的调用只计数, 相同次数的 Unlock 之后才停止续期
+func (lock *RedisLock) KeepAlive(ctx context.Context) {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+	lock.renewals++
+	if lock.cancelRenewal != nil {
+		return
+	}
 	renewalCtx, cancelFunc := context.WithCancel(ctx)
+	lockCtx, cancelLockCtx := context.WithCancel(ctx)
+	lost := make(chan struct{})
 	lock.cancelRenewal = cancelFunc
-	go lock.renewal(renewalCtx)
+	lock.lost = lost
+	lock.lockCtx = lockCtx
+	lock.cancelLockCtx = cancelLockCtx
+	go lock.renewal(renewalCtx, lost, cancelLockCtx)
+}
 
-	return true, nil
+// Lost 返回锁丢失时关闭的 channel, 未调用 LockAndRenewal 时返回 nil
+func (lock *RedisLock) Lost() <-chan struct{} {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+	return lock.lost
 }
 
-func (lock *RedisLock) renewal(ctx context.Context) {
+// Context 返回在锁丢失或解锁时取消的 context
+func (lock *RedisLock) Context() context.Context {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+	if lock.lockCtx == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx
+	}
+	return lock.lockCtx
+}
+
+func (lock *RedisLock) renewal(ctx context.Context, lost chan struct{}, cancelLockCtx context.CancelFunc) {
 	ticker := time.NewTicker(lock.expiration / 3) // 在过期时间 1/3 时续期
 	defer ticker.Stop()
+	// 最后一次续期成功后锁的过期时间, 网络抖动导致的续期失败在此之前可以重试
+	deadline := time.Now().Add(lock.expiration)
 	for {
 		select {
 		case <-ctx.Done():
 			// 主上下文已结束，停止续期
 			return
 		case <-ticker.C:
-			// 续期 lua 脚本：只有锁的持有者才能续期
-			// PEXPIRE 重新设置为固定毫秒数
-			renewalScript := `
-			if redis.call("GET", KEYS[1]) == ARGV[1] then
-				return redis.call("PEXPIRE", KEYS[1], ARGV[2]) 
-			else
-				return 0
-			end
-			`
-			script := redis.NewScript(renewalScript)
 			// 每次续期都将其重置为原过期时间 lock.expiration
-			err := script.Run(ctx, lock.client, []string{lock.key}, lock.value, lock.expiration.Milliseconds()).Err()
-			if err != nil {
-				// 续期失败，记录日志并推出
+			start := time.Now()
+			result, err := renewalScript.Run(ctx, lock.client, []string{lock.slotKey()}, lock.value, lock.expiration.Milliseconds()).Int64()
+			if ctx.Err() != nil {
 				return
 			}
-			// fmt.Printf("续期成功\n")
+			if err == nil && result > 0 {
+				deadline = start.Add(lock.expiration)
+				continue
+			}
+			if err != nil && time.Now().Before(deadline) {
+				Log().Warning("锁 %s 续期失败 %v", lock.key, err)
+				continue
+			}
+			// 锁已被他人持有或已过期, 之后重新加锁时再次启动续期
+			Log().Error("锁 %s 已丢失", lock.key)
+			lock.mu.Lock()
+			if lock.lost == lost {
+				lock.cancelRenewal = nil
+				lock.renewals = 0
+			}
+			lock.mu.Unlock()
+			close(lost)
+			cancelLockCtx()
+			return
 		}
 	}
 }
 
+// Unlock 停止续期并释放锁, 可重入时最后一次 Unlock 才停止续期
 func (lock *RedisLock) Unlock(ctx context.Context) (bool, error) {
 	// 1. 先停止续期
-	if lock.cancelRenewal != nil {
-		lock.cancelRenewal()
+	lock.mu.Lock()
+	if lock.renewals > 0 {
+		lock.renewals--
+	}
+	if lock.renewals == 0 {
+		if lock.cancelRenewal != nil {
+			lock.cancelRenewal()
+			lock.cancelRenewal = nil
+		}
+		if lock.cancelLockCtx != nil {
+			lock.cancelLockCtx()
+		}
 	}
+	lock.mu.Unlock()
 
 	// 2. 释放锁，使用 lua 脚本保证原子性
-	cadScript := `
-	if redis.call("GET", KEYS[1]) == ARGV[1] then
-		return redis.call("DEL", KEYS[1])
-	else
-		return 0
-	end
-	`
-	script := redis.NewScript(cadScript)
-	result, err := script.Run(ctx, lock.client, []string{lock.key}, lock.value).Int64()
-	if err != nil {
-		return false, nil
+	return lock.Release(ctx)
+}
+
+// Redlock 基于多个相互独立的 redis 节点实现的分布式锁
+// 超过半数节点加锁成功, 且扣除耗时与时钟漂移后仍在有效期内才算成功
+// 不提供栅栏令牌: 各节点的计数器相互独立, 取最大值也不能保证单调递增,
+// 需要栅栏令牌时使用单节点的 RedisLock, 或由下游存储自行维护线性一致的计数器
+type Redlock struct {
+	locks      []*RedisLock
+	expiration time.Duration
+	quorum     int
+
+	mu       sync.Mutex
+	validity time.Time
+}
+
+// 时钟漂移系数, 参考 redis 官方 Redlock 算法
+const clockDriftFactor = 0.01
+
+// NewRedlock 创建 Redlock, 所有节点使用相同的持有者标识
+func NewRedlock(clients []redis.UniversalClient, key string, expiration time.Duration, opts ...LockOption) *Redlock {
+	owner := uuid.New().String()
+	opts = append([]LockOption{WithOwner(owner)}, opts...)
+	locks := make([]*RedisLock, 0, len(clients))
+	for _, client := range clients {
+		locks = append(locks, NewRedisLock(client, key, expiration, opts...))
 	}
-	return result > 0, nil
+	return &Redlock{
+		locks:      locks,
+		expiration: expiration,
+		quorum:     len(clients)/2 + 1,
+	}
+}
+
+// Acquire 尝试在所有节点加锁一次, 未达到法定数量时释放已加的锁
+func (rl *Redlock) Acquire(ctx context.Context) (bool, error) {
+	start := time.Now()
+	// 单个节点的超时远小于锁有效期, 避免在故障节点上耗尽有效期
+	nodeTimeout := rl.expiration / 10
+	if nodeTimeout < 5*time.Millisecond {
+		nodeTimeout = 5 * time.Millisecond
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+		// acquired 本次加锁成功的节点, undo 失败时需要释放的节点
+		acquired, undo []*RedisLock
+	)
+	for _, lock := range rl.locks {
+		wg.Add(1)
+		go func(lock *RedisLock) {
+			defer wg.Done()
+			nodeCtx, cancel := context.WithTimeout(ctx, nodeTimeout)
+			defer cancel()
+			ok, err := lock.Acquire(nodeCtx)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil && ok:
+				acquired = append(acquired, lock)
+				undo = append(undo, lock)
+			case err != nil && !lock.reentrant:
+				// 超时的节点可能已经加锁成功; 可重入时释放会抵消外层的持有, 只能等待过期
+				undo = append(undo, lock)
+			}
+		}(lock)
+	}
+	wg.Wait()
+
+	drift := time.Duration(float64(rl.expiration)*clockDriftFactor) + 2*time.Millisecond
+	validity := rl.expiration - time.Since(start) - drift
+	if len(acquired) >= rl.quorum && validity > 0 {
+		rl.mu.Lock()
+		rl.validity = start.Add(rl.expiration - drift)
+		rl.mu.Unlock()
+		return true, nil
+	}
+	// 只释放本次加上的锁, 可重入时不影响外层已经持有的锁
+	release(context.Background(), undo)
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// Lock 阻塞加锁, 失败后指数退避重试直到 ctx 结束
+func (rl *Redlock) Lock(ctx context.Context) error {
+	backoff := minBackoff
+	for {
+		ok, err := rl.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// Unlock 在所有节点上释放锁
+func (rl *Redlock) Unlock(ctx context.Context) (bool, error) {
+	released := release(ctx, rl.locks)
+	return released >= rl.quorum, nil
+}
+
+// release 并发释放 locks, 返回释放成功的数量
+func release(ctx context.Context, locks []*RedisLock) int {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		released int
+	)
+	for _, lock := range locks {
+		wg.Add(1)
+		go func(lock *RedisLock) {
+			defer wg.Done()
+			if ok, err := lock.Release(ctx); err == nil && ok {
+				mu.Lock()
+				released++
+				mu.Unlock()
+			}
+		}(lock)
+	}
+	wg.Wait()
+	return released
+}
+
+// Validity 返回锁的有效截止时间, 超过之后受保护的工作不再安全
+func (rl *Redlock) Validity() time.Time {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.validity
 }
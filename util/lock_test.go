@@ -2,102 +2,343 @@ package util
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-redis/redis/v8"
 )
 
-var wg sync.WaitGroup
+// newTestRedis 启动进程内的 redis 替身
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return mr, client
+}
 
 func TestBaseLock(t *testing.T) {
-
+	_, client := newTestRedis(t)
 	ctx := context.Background()
 	lockKey := "my_service_name_" + "lock"
 	expiration := 10 * time.Second
 
-	// lock.Acquire(ctx)
+	var wg sync.WaitGroup
 	count := 0
-	// 50 个携程对 count 进行 +1 操作 100 次
-	for i := 0; i < 50; i++ {
-		client := redis.NewClient(&redis.Options{
-			Addr: "localhost:6379",
-		})
+	// 10 个携程对 count 进行 +1 操作 20 次
+	for i := 0; i < 10; i++ {
 		// 1. 创建锁实例
 		lock := NewRedisLock(client, lockKey, expiration)
-		t.Logf("启动携程：%d\n", i+1)
-		time.Sleep(100)
 		wg.Add(1)
 		goroutineId := i
 		go func() {
 			defer wg.Done()
-			j := 0
-			for j < 100 {
-				// 2.1 尝试获取锁
-				success, err := lock.Acquire(ctx)
-				if err != nil {
-					// 2.2 获取锁出错，抛出异常
-					panic(err)
-				}
-				if !success {
-					// 2.3 没有获取到锁，重新尝试获取锁
-					continue
+			for j := 0; j < 20; j++ {
+				// 2. 阻塞获取锁
+				if err := lock.Lock(ctx); err != nil {
+					t.Error(err)
+					return
 				}
 				count++
-				time.Sleep(1 * time.Millisecond)
-				j++
 				// 3. 释放锁
 				release, err := lock.Release(ctx)
 				if err != nil {
-					panic(err)
+					t.Error(err)
+					return
 				}
 				if !release {
-					t.Logf("协程 %d 解锁失败\n", goroutineId)
-				}
-				if count%100 == 0 {
-					t.Logf("count = %d", count)
+					t.Errorf("协程 %d 解锁失败", goroutineId)
 				}
 			}
 		}()
 	}
 	wg.Wait()
-	t.Logf("count = %d", count)
+	if count != 200 {
+		t.Fatalf("count = %d, want 200", count)
+	}
+}
+
+func TestFencingToken(t *testing.T) {
+	_, client := newTestRedis(t)
+	ctx := context.Background()
+
+	var last int64
+	for i := 0; i < 3; i++ {
+		lock := NewRedisLock(client, "fencing_lock", time.Second)
+		if ok, err := lock.Acquire(ctx); err != nil || !ok {
+			t.Fatalf("acquire: %v %v", ok, err)
+		}
+		if lock.Token() <= last {
+			t.Fatalf("token %d not greater than %d", lock.Token(), last)
+		}
+		last = lock.Token()
+		if _, err := lock.Release(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// hashTag 按 redis 集群的规则取出 key 中计算 slot 的部分
+func hashTag(key string) string {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			return key[start+1 : start+1+end]
+		}
+	}
+	return key
+}
+
+func TestClusterLock(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	// 锁名中已有 hash tag 时同样适用
+	for _, key := range []string{"cluster_lock", "{tenant:1}:cluster_lock"} {
+		lock := NewRedisLock(client, key, time.Second)
+		if ok, err := lock.LockAndRenewal(ctx); err != nil || !ok {
+			t.Fatalf("%s acquire: %v %v", key, ok, err)
+		}
+		// 脚本访问的锁与栅栏计数器位于同一个 slot, 否则集群返回 CROSSSLOT
+		if keys := mr.Keys(); len(keys) != 2 || hashTag(keys[0]) != hashTag(keys[1]) {
+			t.Fatalf("%s keys in different slots: %v", key, keys)
+		}
+		if ok, _ := NewRedisLock(client, key, time.Second).Acquire(ctx); ok {
+			t.Fatalf("%s acquired twice", key)
+		}
+		if ok, err := lock.Unlock(ctx); err != nil || !ok {
+			t.Fatalf("%s unlock: %v %v", key, ok, err)
+		}
+		if ok, _ := NewRedisLock(client, key, time.Second).Acquire(ctx); !ok {
+			t.Fatalf("%s not released", key)
+		}
+		mr.FlushAll()
+	}
+}
+
+func TestReentrantLock(t *testing.T) {
+	_, client := newTestRedis(t)
+	ctx := context.Background()
+
+	lock := NewRedisLock(client, "reentrant_lock", time.Second, WithOwner("worker-1"), WithReentrant())
+	for i := 0; i < 2; i++ {
+		if ok, err := lock.Acquire(ctx); err != nil || !ok {
+			t.Fatalf("acquire %d: %v %v", i, ok, err)
+		}
+	}
+	token := lock.Token()
+
+	other := NewRedisLock(client, "reentrant_lock", time.Second, WithOwner("worker-2"), WithReentrant())
+	if ok, _ := other.Acquire(ctx); ok {
+		t.Fatal("other owner acquired a held lock")
+	}
+
+	// 同一持有者重入不会产生新令牌, 需要释放相同次数
+	if _, err := lock.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := other.Acquire(ctx); ok {
+		t.Fatal("lock released before reentrant count reached zero")
+	}
+	if _, err := lock.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := other.Acquire(ctx); !ok {
+		t.Fatal("other owner could not acquire a released lock")
+	}
+	if other.Token() <= token {
+		t.Fatalf("token %d not greater than %d", other.Token(), token)
+	}
+
+	// 未开启可重入时同一持有者也不能重复加锁
+	plain := NewRedisLock(client, "plain_lock", time.Second, WithOwner("worker-1"))
+	if ok, _ := plain.Acquire(ctx); !ok {
+		t.Fatal("acquire plain lock failed")
+	}
+	if ok, _ := plain.Acquire(ctx); ok {
+		t.Fatal("non reentrant lock acquired twice")
+	}
+}
+
+func TestLockBlocksUntilReleased(t *testing.T) {
+	_, client := newTestRedis(t)
+	ctx := context.Background()
+
+	holder := NewRedisLock(client, "blocking_lock", time.Second)
+	if ok, _ := holder.Acquire(ctx); !ok {
+		t.Fatal("acquire failed")
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		holder.Release(ctx)
+	}()
+
+	waiter := NewRedisLock(client, "blocking_lock", time.Second)
+	timeout, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := waiter.Lock(timeout); err != nil {
+		t.Fatal(err)
+	}
+
+	short, cancel2 := context.WithTimeout(ctx, 30*time.Millisecond)
+	defer cancel2()
+	if err := NewRedisLock(client, "blocking_lock", time.Second).Lock(short); err == nil {
+		t.Fatal("lock acquired while held")
+	}
 }
 
 func TestLockRenewal(t *testing.T) {
+	mr, client := newTestRedis(t)
 	ctx := context.Background()
 	lockKey := "my_service_name_" + "lock"
-	expiration := 6 * time.Second
-	client := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
-	})
+	expiration := 300 * time.Millisecond
+
 	// 1. 创建锁实例
 	lock := NewRedisLock(client, lockKey, expiration)
 	success, err := lock.LockAndRenewal(ctx)
-	if err != nil {
-		panic(err)
+	if err != nil || !success {
+		t.Fatalf("加锁失败 %v %v", success, err)
 	}
-	if !success {
-		t.Log("加锁失败")
+	defer lock.Unlock(ctx)
+
+	// 模拟时间流逝, 续期协程会把过期时间重置
+	mr.FastForward(250 * time.Millisecond)
+	time.Sleep(150 * time.Millisecond)
+	if ttl := mr.TTL("{" + lockKey + "}"); ttl <= 100*time.Millisecond {
+		t.Fatalf("lock not renewed, ttl = %s", ttl)
 	}
 
 	// 新建一个客户端尝试去获取锁
-	client1 := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
-	})
-	ctx2 := context.Background()
-	rLock := NewRedisLock(client1, lockKey, expiration)
-	t.Log("新建一个客户端尝试获取锁")
-	for {
-		success, err := rLock.Acquire(ctx2)
-		if err != nil {
-			panic(err)
-		} else if !success {
-			t.Log("新客户端获取锁失败")
-		} else {
-			t.Log("获取锁成功")
+	rLock := NewRedisLock(client, lockKey, expiration)
+	if ok, _ := rLock.Acquire(ctx); ok {
+		t.Fatal("新客户端获取了已被持有的锁")
+	}
+	select {
+	case <-lock.Lost():
+		t.Fatal("lock reported lost while renewed")
+	case <-lock.Context().Done():
+		t.Fatal("lock context canceled while renewed")
+	default:
+	}
+}
+
+func TestLockLost(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+
+	lock := NewRedisLock(client, "lost_lock", 150*time.Millisecond)
+	if ok, err := lock.LockAndRenewal(ctx); err != nil || !ok {
+		t.Fatalf("加锁失败 %v %v", ok, err)
+	}
+	// 锁被其他持有者抢占
+	mr.Del("{lost_lock}")
+	if ok, _ := NewRedisLock(client, "lost_lock", time.Second).Acquire(ctx); !ok {
+		t.Fatal("steal lock failed")
+	}
+
+	select {
+	case <-lock.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("lost lock not reported")
+	}
+	if lock.Context().Err() == nil {
+		t.Fatal("lock context not canceled")
+	}
+	if ok, _ := lock.Unlock(ctx); ok {
+		t.Fatal("unlocked a lock held by another owner")
+	}
+}
+
+func TestRedlock(t *testing.T) {
+	ctx := context.Background()
+	nodes := make([]*miniredis.Miniredis, 3)
+	clients := make([]redis.UniversalClient, 3)
+	for i := range nodes {
+		nodes[i], clients[i] = newTestRedis(t)
+	}
+
+	// 一个节点宕机时仍能达到法定数量
+	nodes[2].Close()
+	rl := NewRedlock(clients, "red_lock", time.Second)
+	if ok, err := rl.Acquire(ctx); err != nil || !ok {
+		t.Fatalf("acquire with quorum: %v %v", ok, err)
+	}
+	if rl.Validity().Before(time.Now()) {
+		t.Fatalf("unexpected validity %s", rl.Validity())
+	}
+	if ok, _ := NewRedlock(clients, "red_lock", time.Second).Acquire(ctx); ok {
+		t.Fatal("second redlock acquired a held lock")
+	}
+	if ok, _ := rl.Unlock(ctx); !ok {
+		t.Fatal("unlock failed")
+	}
+
+	// 超过半数节点宕机时加锁失败, 且不残留部分加锁
+	nodes[1].Close()
+	rl = NewRedlock(clients, "red_lock", time.Second)
+	if ok, _ := rl.Acquire(ctx); ok {
+		t.Fatal("acquired without quorum")
+	}
+	if nodes[0].Exists("{red_lock}") {
+		t.Fatal("partial lock not released")
+	}
+}
+
+func TestReentrantRedlock(t *testing.T) {
+	ctx := context.Background()
+	nodes := make([]*miniredis.Miniredis, 3)
+	clients := make([]redis.UniversalClient, 3)
+	for i := range nodes {
+		nodes[i], clients[i] = newTestRedis(t)
+	}
+
+	rl := NewRedlock(clients, "red_lock", time.Second, WithReentrant())
+	if ok, err := rl.Acquire(ctx); err != nil || !ok {
+		t.Fatalf("acquire: %v %v", ok, err)
+	}
+	// 内层加锁失败时结果未知的节点不释放, 外层的持有不受影响
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if ok, _ := rl.Acquire(canceled); ok {
+		t.Fatal("acquired with canceled context")
+	}
+	for i, node := range nodes {
+		if node.HGet("{red_lock}", "count") != "1" {
+			t.Fatalf("node %d lost the outer hold", i)
+		}
+	}
+}
+
+func TestReentrantRenewal(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+
+	lock := NewRedisLock(client, "renewal_lock", 300*time.Millisecond, WithReentrant())
+	for i := 0; i < 2; i++ {
+		if ok, err := lock.LockAndRenewal(ctx); err != nil || !ok {
+			t.Fatalf("acquire %d: %v %v", i, ok, err)
 		}
+	}
+	// 内层解锁后外层仍然持有, 续期继续
+	if ok, err := lock.Unlock(ctx); err != nil || !ok {
+		t.Fatalf("inner unlock: %v %v", ok, err)
+	}
+	if lock.Context().Err() != nil {
+		t.Fatal("lock context canceled by inner unlock")
+	}
+	mr.FastForward(250 * time.Millisecond)
+	time.Sleep(150 * time.Millisecond)
+	if ttl := mr.TTL("{renewal_lock}"); ttl <= 100*time.Millisecond {
+		t.Fatalf("lock not renewed after inner unlock, ttl = %s", ttl)
+	}
 
+	if ok, err := lock.Unlock(ctx); err != nil || !ok {
+		t.Fatalf("outer unlock: %v %v", ok, err)
+	}
+	if lock.Context().Err() == nil || mr.Exists("{renewal_lock}") {
+		t.Fatal("lock still held after outer unlock")
 	}
 }
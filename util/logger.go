@@ -3,6 +3,8 @@ package util
 import (
 	"fmt"
 	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,9 +21,12 @@ const (
 
 var logger *Logger
 
+// levelNames 日志级别的名称, 下标为级别
+var levelNames = []string{"error", "warning", "info", "debug"}
+
 // Logger 日志
 type Logger struct {
-	level int
+	level atomic.Int32
 }
 
 // Println 打印
@@ -31,17 +36,17 @@ func (ll *Logger) Println(msg string) {
 
 // Panic 极端错误
 func (ll *Logger) Panic(format string, v ...interface{}) {
-	if LevelError > ll.level {
+	if LevelError > int(ll.level.Load()) {
 		return
 	}
 	msg := fmt.Sprintf("[Panic] "+format, v...)
 	ll.Println(msg)
-	os.Exit(0)
+	os.Exit(1)
 }
 
 // Error 错误
 func (ll *Logger) Error(format string, v ...interface{}) {
-	if LevelError > ll.level {
+	if LevelError > int(ll.level.Load()) {
 		return
 	}
 	msg := fmt.Sprintf("[E] "+format, v...)
@@ -50,7 +55,7 @@ func (ll *Logger) Error(format string, v ...interface{}) {
 
 // Warning 警告
 func (ll *Logger) Warning(format string, v ...interface{}) {
-	if LevelWarning > ll.level {
+	if LevelWarning > int(ll.level.Load()) {
 		return
 	}
 	msg := fmt.Sprintf("[W] "+format, v...)
@@ -59,7 +64,7 @@ func (ll *Logger) Warning(format string, v ...interface{}) {
 
 // Info 信息
 func (ll *Logger) Info(format string, v ...interface{}) {
-	if LevelInformational > ll.level {
+	if LevelInformational > int(ll.level.Load()) {
 		return
 	}
 	msg := fmt.Sprintf("[I] "+format, v...)
@@ -68,7 +73,7 @@ func (ll *Logger) Info(format string, v ...interface{}) {
 
 // Debug 校验
 func (ll *Logger) Debug(format string, v ...interface{}) {
-	if LevelDebug > ll.level {
+	if LevelDebug > int(ll.level.Load()) {
 		return
 	}
 	msg := fmt.Sprintf("[D] "+format, v...)
@@ -77,30 +82,44 @@ func (ll *Logger) Debug(format string, v ...interface{}) {
 
 // BuildLogger 构建logger
 func BuildLogger(level string) {
-	intLevel := LevelError
-	switch level {
-	case "error":
-		intLevel = LevelError
-	case "warning":
-		intLevel = LevelWarning
-	case "info":
-		intLevel = LevelInformational
-	case "debug":
-		intLevel = LevelDebug
+	// 未知的名称为 LevelError
+	intLevel, _ := parseLevel(level)
+	l := &Logger{}
+	l.level.Store(int32(intLevel))
+	logger = l
+}
+
+// parseLevel 级别名称对应的级别
+func parseLevel(level string) (int, bool) {
+	for i, name := range levelNames {
+		if name == level {
+			return i, true
+		}
 	}
-	l := Logger{
-		level: intLevel,
+	return LevelError, false
+}
+
+// SetLevel 运行时修改日志级别
+func SetLevel(level string) error {
+	intLevel, ok := parseLevel(level)
+	if !ok {
+		return fmt.Errorf("未知的日志级别 %q, 可选 %s", level, strings.Join(levelNames, ", "))
 	}
-	logger = &l
+	Log().level.Store(int32(intLevel))
+	return nil
+}
+
+// LogLevel 当前日志级别的名称
+func LogLevel() string {
+	return levelNames[Log().level.Load()]
 }
 
 // Log 返回日志对象
 func Log() *Logger {
 	if logger == nil {
-		l := Logger{
-			level: LevelDebug,
-		}
-		logger = &l
+		l := &Logger{}
+		l.level.Store(LevelDebug)
+		logger = l
 	}
 	return logger
 }
@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-api/ent"
+	"go-api/ent/webhookdelivery"
+	"go-api/tenancy"
+	"go-api/util"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// MaxAttempts 一次投递最多发送的次数, 包括第一次
+const MaxAttempts = 9
+
+// maxResponseBody 投递记录中保存的响应体长度
+const maxResponseBody = 1024
+
+// AllowPrivate 允许推送到内网与回环地址, 只用于测试
+var AllowPrivate = false
+
+// ErrForbiddenAddress 推送地址指向内网, 回环, 链路本地或云服务器元数据地址
+var ErrForbiddenAddress = errors.New("webhook: forbidden address")
+
+// 除 netip 能判断的类别之外还要拒绝的地址段
+var (
+	// sharedAddressSpace 运营商级 NAT 地址段, 部分云厂商的元数据服务位于其中
+	sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+	// nat64 NAT64 地址段(RFC 6052), 后 32 位是转换后访问的 ipv4 地址
+	nat64 = netip.MustParsePrefix("64:ff9b::/96")
+)
+
+// Forbidden 地址不能作为推送目标, 169.254.169.254 等元数据地址属于链路本地地址
+// ipv4 映射地址(::ffff:a.b.c.d)与 NAT64 地址按其中的 ipv4 地址判断
+func Forbidden(addr netip.Addr) bool {
+	if AllowPrivate {
+		return false
+	}
+	addr = addr.Unmap()
+	if nat64.Contains(addr) {
+		b := addr.As16()
+		addr = netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]})
+	}
+	return !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() || sharedAddressSpace.Contains(addr)
+}
+
+// CheckURL 只允许 http 与 https 地址, 主机是 ip 时不能是 Forbidden 的地址
+// 域名在每次连接时按解析出的地址检查, 解析结果变化也无法绕过
+func CheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("webhook: only http and https urls are supported")
+	}
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && Forbidden(addr) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// dialControl 在建立连接前检查解析后的地址
+func dialControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || Forbidden(addrPort.Addr()) {
+		return fmt.Errorf("%w %s", ErrForbiddenAddress, address)
+	}
+	return nil
+}
+
+// HTTPClient 发送请求的客户端, 不跟随跳转, 不经过代理, 拒绝连接 Forbidden 的地址
+var HTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   dialControl,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// DisableAfter 连续失败多少次后停用地址, WEBHOOK_DISABLE_AFTER, 默认 20
+func DisableAfter() int {
+	n, err := strconv.Atoi(os.Getenv("WEBHOOK_DISABLE_AFTER"))
+	if err != nil || n <= 0 {
+		n = 20
+	}
+	return n
+}
+
+// result 一次请求的结果
+type result struct {
+	status   int
+	body     string
+	err      error
+	duration time.Duration
+}
+
+func (r result) ok() bool {
+	return r.err == nil && r.status >= 200 && r.status < 300
+}
+
+func (r result) error() string {
+	if r.err != nil {
+		return r.err.Error()
+	}
+	if !r.ok() {
+		return "unexpected status " + strconv.Itoa(r.status)
+	}
+	return ""
+}
+
+// send 签名并发送请求体
+func send(ctx context.Context, endpoint *ent.WebhookEndpoint, delivery *ent.WebhookDelivery) result {
+	start := time.Now()
+	timestamp := util.Now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return result{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-api-webhook/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderEventID, strconv.Itoa(delivery.EventID))
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return result{err: err, duration: time.Since(start)}
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	// 读完剩余内容以复用连接
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return result{status: resp.StatusCode, body: string(body), duration: time.Since(start)}
+}
+
+// Deliver 发送一次投递并记录结果
+// 返回错误表示需要稍后重试; 成功, 达到 MaxAttempts 或地址已停用时返回 nil, 结果记录在投递的 status 中
+func Deliver(ctx context.Context, client *ent.Client, id int) error {
+	delivery, err := client.WebhookDelivery.Query().
+		Where(webhookdelivery.ID(id)).
+		WithEndpoint().
+		Only(tenancy.System(ctx))
+	if ent.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if delivery.Status != webhookdelivery.StatusPending {
+		return nil
+	}
+	ctx = tenancy.NewContext(ctx, &tenancy.Tenant{ID: delivery.TenantID})
+	endpoint := delivery.Edges.Endpoint
+	if !endpoint.Enabled {
+		return client.WebhookDelivery.UpdateOne(delivery).
+			SetStatus(webhookdelivery.StatusFailed).
+			SetError("endpoint disabled").
+			Exec(ctx)
+	}
+
+	res := send(ctx, endpoint, delivery)
+	now := util.Now()
+	attempts := delivery.Attempts + 1
+	update := client.WebhookDelivery.UpdateOne(delivery).
+		SetAttempts(attempts).
+		SetResponseStatus(res.status).
+		SetResponseBody(res.body).
+		SetError(res.error()).
+		SetDurationMs(res.duration.Milliseconds()).
+		SetLastAttemptAt(now)
+	switch {
+	case res.ok():
+		update.SetStatus(webhookdelivery.StatusSucceeded).SetDeliveredAt(now)
+	case attempts >= MaxAttempts:
+		update.SetStatus(webhookdelivery.StatusFailed)
+	}
+	if err := update.Exec(ctx); err != nil {
+		return err
+	}
+
+	if res.ok() {
+		if endpoint.Failures > 0 {
+			return client.WebhookEndpoint.UpdateOne(endpoint).SetFailures(0).Exec(ctx)
+		}
+		return nil
+	}
+	endpoint, err = client.WebhookEndpoint.UpdateOne(endpoint).AddFailures(1).Save(ctx)
+	if err != nil {
+		return err
+	}
+	if endpoint.Failures >= DisableAfter() {
+		util.Log().Warning("webhook 地址 %d 连续失败 %d 次, 已停用", endpoint.ID, endpoint.Failures)
+		if err := client.WebhookEndpoint.UpdateOne(endpoint).
+			SetEnabled(false).
+			SetDisabledAt(now).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+	if attempts >= MaxAttempts {
+		util.Log().Error("webhook 投递 %d 重试耗尽: %s", id, res.error())
+		return nil
+	}
+	return fmt.Errorf("webhook: delivery %d attempt %d: %s", id, attempts, res.error())
+}
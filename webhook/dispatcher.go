@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"go-api/ent"
+	"go-api/ent/user"
+	"go-api/ent/webhookdelivery"
+	"go-api/ent/webhookendpoint"
+	"go-api/entx"
+	"go-api/outbox"
+	"go-api/tenancy"
+	"strconv"
+)
+
+// Dispatcher 实现 outbox.Broker, 为订阅了事件的地址创建投递记录并交给 enqueue 发送
+// 重复投递同一事件时不会重复创建记录, 可以与其他消息中间件一起用 outbox.Fanout 组合
+type Dispatcher struct {
+	client  *ent.Client
+	enqueue func(ctx context.Context, deliveryID int) error
+}
+
+// NewDispatcher 创建 Dispatcher, enqueue 把投递放入后台任务队列, 需要能处理重复的 id
+func NewDispatcher(client *ent.Client, enqueue func(ctx context.Context, deliveryID int) error) *Dispatcher {
+	return &Dispatcher{client: client, enqueue: enqueue}
+}
+
+// Publish 实现 outbox.Broker
+func (d *Dispatcher) Publish(ctx context.Context, msg outbox.Message) error {
+	if _, ok := Events[msg.EventType]; !ok {
+		return nil
+	}
+	tenantID, err := d.tenant(ctx, msg)
+	if err != nil || tenantID == 0 {
+		return err
+	}
+	ctx = tenancy.NewContext(ctx, &tenancy.Tenant{ID: tenantID})
+
+	endpoints, err := d.client.WebhookEndpoint.Query().
+		Where(webhookendpoint.Enabled(true)).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	var body []byte
+	for _, endpoint := range endpoints {
+		if !Subscribed(endpoint.Events, msg.EventType) {
+			continue
+		}
+		if body == nil {
+			if body, err = json.Marshal(Envelope{
+				ID:        msg.ID,
+				Type:      msg.EventType,
+				CreatedAt: msg.CreatedAt,
+				Data:      msg.Payload,
+			}); err != nil {
+				return err
+			}
+		}
+		delivery, err := endpoint.QueryDeliveries().
+			Where(webhookdelivery.EventID(msg.ID), webhookdelivery.ReplayOf(0)).
+			Only(ctx)
+		if ent.IsNotFound(err) {
+			delivery, err = d.client.WebhookDelivery.Create().
+				SetEndpoint(endpoint).
+				SetEventID(msg.ID).
+				SetEventType(msg.EventType).
+				SetUserID(subject(msg)).
+				SetPayload(body).
+				Save(ctx)
+		}
+		if err != nil {
+			return err
+		}
+		// 上次放入队列失败的投递重新放入
+		if delivery.Status != webhookdelivery.StatusPending {
+			continue
+		}
+		if err := d.enqueue(ctx, delivery.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subject 事件涉及的用户 id, 不是用户事件时返回 0
+func subject(msg outbox.Message) int {
+	if msg.AggregateType != outbox.AggregateUser {
+		return 0
+	}
+	id, _ := strconv.Atoi(msg.AggregateID)
+	return id
+}
+
+// tenant 事件所属的租户, 聚合已不存在时返回 0, 已注销的用户仍然可以查到
+func (d *Dispatcher) tenant(ctx context.Context, msg outbox.Message) (int, error) {
+	id := subject(msg)
+	if id == 0 {
+		return 0, nil
+	}
+	u, err := d.client.User.Query().Where(user.ID(id)).Only(entx.IncludeDeleted(tenancy.System(ctx)))
+	if ent.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return u.TenantID, nil
+}
+
+// Close 实现 outbox.Broker
+func (d *Dispatcher) Close() error {
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package webhook 把领域事件以 HTTP POST 推送到注册的地址
+//
+// Dispatcher 作为 outbox 的消息中间件为订阅了事件的地址创建投递记录, 再由后台任务调用 Deliver 发送;
+// 请求体使用地址的密钥以 HMAC-SHA256 签名, 失败时按指数退避重试, 连续失败过多的地址自动停用
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 请求头
+const (
+	// HeaderEvent 事件类型
+	HeaderEvent = "X-Webhook-Event"
+	// HeaderEventID 事件 id, 重试与重放时不变, 接收方按它去重
+	HeaderEventID = "X-Webhook-Id"
+	// HeaderDelivery 投递 id, 可以用于查询投递记录
+	HeaderDelivery = "X-Webhook-Delivery"
+	// HeaderTimestamp 发送时的 unix 秒数, 参与签名
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature 签名, 格式为 sha256=<hex>
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Events 可以订阅的事件及说明
+var Events = map[string]string{
+	"user.registered":  "用户注册",
+	"user.activated":   "用户被激活",
+	"user.suspended":   "用户被封禁",
+	"user.deactivated": "用户被置为未激活",
+	"user.erased":      "用户注销, 应删除保存的个人数据",
+}
+
+// ErrInvalidSignature 签名不匹配或时间戳超出允许范围
+var ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+// Envelope 推送的请求体
+type Envelope struct {
+	ID        int             `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewSecret 生成签名密钥
+func NewSecret() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return "whsec_" + hex.EncodeToString(b)
+}
+
+// Sign 对 "时间戳.请求体" 签名, 时间戳参与签名使截获的请求不能在之后重放
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 接收方校验签名, 时间戳与 now 相差超过 tolerance 时视为重放
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > tolerance || d < -tolerance {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Subscribed events 中是否包含 event, events 以空格分隔
+func Subscribed(events, event string) bool {
+	for _, e := range strings.Fields(events) {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}